@@ -0,0 +1,107 @@
+package restclient
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	artifactDirPerm  = 0o755
+	artifactFilePerm = 0o644
+)
+
+// reUnsafeArtifactChars matches characters replaced when deriving artifact file names
+var reUnsafeArtifactChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// artifactExtensions maps response media types to artifact file extensions
+var artifactExtensions = map[string]string{
+	"application/json":         ".json",
+	"application/problem+json": ".json",
+	"application/xml":          ".xml",
+	"text/xml":                 ".xml",
+	"text/html":                ".html",
+	"text/plain":               ".txt",
+	"text/csv":                 ".csv",
+	"application/x-ndjson":     ".ndjson",
+	"application/pdf":          ".pdf",
+	"image/png":                ".png",
+	"image/jpeg":               ".jpg",
+}
+
+// SaveBody writes the raw response body to path, creating parent directories as needed.
+// An unread streamed body (see WithStreamedBodies) is copied to the file without buffering
+// and is consumed by the call.
+func (r *Response) SaveBody(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), artifactDirPerm); err != nil {
+		return fmt.Errorf("failed to create directory for response body %s: %w", path, err)
+	}
+	body, err := r.BodyReader()
+	if err != nil {
+		return fmt.Errorf("failed to save response body to %s: %w", path, err)
+	}
+	defer func() { _ = body.Close() }()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, artifactFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to save response body to %s: %w", path, err)
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to save response body to %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to save response body to %s: %w", path, err)
+	}
+	return nil
+}
+
+// saveResponseArtifact saves the body of a response produced by ExecuteFile into the
+// artifacts directory configured with WithArtifactsDir. It is a no-op when no directory is set.
+func (c *Client) saveResponseArtifact(requestFilePath string, index int, response *Response) error {
+	if c.artifactsDir == "" || response == nil {
+		return nil
+	}
+	// Buffer streamed bodies so the response stays readable after saving
+	if err := response.BufferBody(); err != nil {
+		return fmt.Errorf("failed to save response artifact: %w", err)
+	}
+	path := filepath.Join(c.artifactsDir, artifactRunDir(requestFilePath), artifactFileName(index, response))
+	return response.SaveBody(path)
+}
+
+// artifactRunDir derives the per-file artifacts directory from the request file name
+// (e.g. "users.http" -> "users").
+func artifactRunDir(requestFilePath string) string {
+	base := filepath.Base(requestFilePath)
+	return sanitizeArtifactName(strings.TrimSuffix(base, filepath.Ext(base)))
+}
+
+// artifactFileName builds "<index>-<request name or method>.<ext>", e.g. "001-login.json".
+// The index is 1-based to match request numbering in error messages.
+func artifactFileName(index int, response *Response) string {
+	label := "response"
+	if response.Request != nil {
+		label = response.Request.Name
+		if label == "" {
+			label = strings.ToLower(response.Request.Method)
+		}
+	}
+	ext, ok := artifactExtensions[response.ContentType()]
+	if !ok {
+		ext = ".body"
+	}
+	return fmt.Sprintf("%03d-%s%s", index+1, sanitizeArtifactName(label), ext)
+}
+
+// sanitizeArtifactName replaces characters that are unsafe in file names with '_'
+func sanitizeArtifactName(name string) string {
+	sanitized := strings.Trim(reUnsafeArtifactChars.ReplaceAllString(name, "_"), "_")
+	if sanitized == "" {
+		return "request"
+	}
+	return sanitized
+}
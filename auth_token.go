@@ -0,0 +1,44 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// authTokenPattern matches {{$auth.token("id")}} placeholders, capturing the directive and the id
+var authTokenPattern = regexp.MustCompile(`{{\s*(\$auth\.token\(\s*"([^"]*)"\s*\))\s*}}`)
+
+// resolveAuthTokens obtains the access tokens of the {{$auth.token("id")}} placeholders of req, the
+// JetBrains system variable for a token of the OAuth2 configuration id of the Security.Auth section of
+// the selected environment, and adds them to the request-scoped system variables. Tokens are kept in
+// the client's TokenStore like those of "# @auth oauth2", so a run obtains each one once and renews it
+// when it expires.
+func (c *Client) resolveAuthTokens(ctx context.Context, req *Request, systemVars map[string]string) error {
+	texts := []string{req.RawURLString, req.RawBody}
+	for _, values := range req.Headers {
+		texts = append(texts, values...)
+	}
+	for _, value := range req.ActiveVariables {
+		texts = append(texts, value) // e.g. "@token = {{$auth.token("api")}}"
+	}
+	for _, text := range texts {
+		for _, match := range authTokenPattern.FindAllStringSubmatch(text, -1) {
+			directive, id := match[1], match[2]
+			if _, ok := systemVars[directive]; ok {
+				continue
+			}
+			config, ok := req.authConfigs[id]
+			if !ok {
+				return fmt.Errorf("$auth.token: no OAuth2 configuration '%s' in the Security.Auth section "+
+					"of the selected environment", id)
+			}
+			token, err := c.oauth2Token(ctx, config, "")
+			if err != nil {
+				return fmt.Errorf("$auth.token(%q): %w", id, err)
+			}
+			systemVars[directive] = token.AccessToken
+		}
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package restclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStatus tells how a response of a client created with WithHTTPCache was obtained.
+type CacheStatus string
+
+const (
+	CacheMiss        CacheStatus = "MISS"        // fetched from the server (and stored if cacheable)
+	CacheHit         CacheStatus = "HIT"         // served from the cache without contacting the server
+	CacheRevalidated CacheStatus = "REVALIDATED" // stale entry confirmed by the server with 304 Not Modified
+)
+
+// WithHTTPCache enables a private, in-memory HTTP cache following RFC 9111. GET responses are stored
+// according to their Cache-Control, Expires and Vary headers and served without contacting the server
+// while fresh. Stale responses with an ETag or Last-Modified are revalidated with If-None-Match and
+// If-Modified-Since. Successful unsafe requests (POST, PUT, PATCH, DELETE) invalidate the entry of
+// their URL. Response.CacheStatus tells whether a response was a HIT, MISS or REVALIDATED. Requests
+// with their own conditional headers or "Cache-Control: no-store" bypass the cache.
+func WithHTTPCache() ClientOption {
+	return func(c *Client) error {
+		c.cache = &httpCache{entries: make(map[string]*cacheEntry)}
+		return nil
+	}
+}
+
+// cacheStatusKey is the context key under which the per-request *CacheStatus is stored
+type cacheStatusKey struct{}
+
+// withCacheStatus attaches a fresh CacheStatus to the request when the cache is enabled
+func (c *Client) withCacheStatus(httpReq *http.Request) (*http.Request, *CacheStatus) {
+	if c.cache == nil {
+		return httpReq, nil
+	}
+	status := new(CacheStatus)
+	return httpReq.WithContext(context.WithValue(httpReq.Context(), cacheStatusKey{}, status)), status
+}
+
+// applyCacheStatus copies the cache status recorded during the round trip onto the response
+func applyCacheStatus(resp *Response, status *CacheStatus) {
+	if status != nil {
+		resp.CacheStatus = *status
+	}
+}
+
+// httpCache stores GET responses by URL
+type httpCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry is a stored response along with what is needed to compute its age and match Vary
+type cacheEntry struct {
+	status       string
+	statusCode   int
+	proto        string
+	header       http.Header
+	body         []byte
+	varyValues   map[string]string // request header values selected by the Vary response header
+	requestTime  time.Time
+	responseTime time.Time
+}
+
+// heuristicallyCacheable lists the status codes cacheable without explicit freshness (RFC 9110 15.1)
+var heuristicallyCacheable = map[int]bool{
+	200: true, 203: true, 204: true, 206: true, 300: true, 301: true, 308: true,
+	404: true, 405: true, 410: true, 414: true, 501: true,
+}
+
+// cacheTransport wraps an http.RoundTripper with the client's cache. It sits below http.Client, so
+// each redirect hop is cached on its own.
+type cacheTransport struct {
+	base  http.RoundTripper
+	cache *httpCache
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	status, _ := req.Context().Value(cacheStatusKey{}).(*CacheStatus)
+	if status == nil {
+		status = new(CacheStatus)
+	}
+	key := req.URL.String()
+
+	if req.Method != http.MethodGet {
+		resp, err := base.RoundTrip(req)
+		if err == nil && isUnsafeMethod(req.Method) && resp.StatusCode < 400 {
+			t.cache.delete(key)
+		}
+		return resp, err
+	}
+
+	*status = CacheMiss
+	reqDirectives := parseCacheControl(req.Header.Get("Cache-Control"))
+	if _, noStore := reqDirectives["no-store"]; noStore || hasConditionalHeaders(req.Header) {
+		return base.RoundTrip(req)
+	}
+
+	entry := t.cache.lookup(key, req.Header)
+	if entry != nil && entry.isFresh(time.Now(), reqDirectives) {
+		*status = CacheHit
+		return entry.response(req, time.Now()), nil
+	}
+
+	outgoing := req
+	if entry != nil && entry.hasValidators() {
+		outgoing = req.Clone(req.Context())
+		if etag := entry.header.Get("ETag"); etag != "" {
+			outgoing.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.header.Get("Last-Modified"); lastModified != "" {
+			outgoing.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	requestTime := time.Now()
+	resp, err := base.RoundTrip(outgoing)
+	if err != nil {
+		return resp, err
+	}
+	if entry != nil && outgoing != req && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		entry = entry.freshened(resp.Header, requestTime, time.Now())
+		t.cache.put(key, entry)
+		*status = CacheRevalidated
+		return entry.response(req, time.Now()), nil
+	}
+	return t.cache.store(key, req, resp, requestTime), nil
+}
+
+// lookup returns the entry stored for key if it matches the Vary headers of the request
+func (c *httpCache) lookup(key string, reqHeader http.Header) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	for name, value := range entry.varyValues {
+		if reqHeader.Get(name) != value {
+			return nil
+		}
+	}
+	return entry
+}
+
+// put stores entry for key; entries are never modified once stored
+func (c *httpCache) put(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+}
+
+// delete removes the entry stored for key
+func (c *httpCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// store buffers the body of a GET response and stores it when cacheable, replacing any previous
+// entry for key; it returns the response with its body replaced by the buffered copy
+func (c *httpCache) store(key string, req *http.Request, resp *http.Response, requestTime time.Time) *http.Response {
+	if !isCacheable(resp) {
+		c.delete(key)
+		return resp
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		c.delete(key)
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+		return resp
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry := &cacheEntry{
+		status:       resp.Status,
+		statusCode:   resp.StatusCode,
+		proto:        resp.Proto,
+		header:       resp.Header.Clone(),
+		body:         body,
+		varyValues:   make(map[string]string),
+		requestTime:  requestTime,
+		responseTime: time.Now(),
+	}
+	for _, name := range headerListValues(resp.Header, "Vary") {
+		entry.varyValues[name] = req.Header.Get(name)
+	}
+	c.put(key, entry)
+	return resp
+}
+
+// errReader returns err on every read, replaying a body read error after the bytes read before it
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// isCacheable reports whether a GET response may be stored by a private cache (RFC 9111 3)
+func isCacheable(resp *http.Response) bool {
+	respDirectives := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if _, noStore := respDirectives["no-store"]; noStore {
+		return false
+	}
+	for _, name := range headerListValues(resp.Header, "Vary") {
+		if name == "*" {
+			return false
+		}
+	}
+	_, maxAge := respDirectives["max-age"]
+	return heuristicallyCacheable[resp.StatusCode] || maxAge || resp.Header.Get("Expires") != ""
+}
+
+// isFresh reports whether the entry can be served without revalidation (RFC 9111 4.2)
+func (e *cacheEntry) isFresh(now time.Time, reqDirectives map[string]string) bool {
+	if _, noCache := reqDirectives["no-cache"]; noCache {
+		return false
+	}
+	respDirectives := parseCacheControl(e.header.Get("Cache-Control"))
+	if _, noCache := respDirectives["no-cache"]; noCache {
+		return false
+	}
+	age := e.currentAge(now)
+	if maxAge, ok := parseDeltaSeconds(reqDirectives["max-age"]); ok && age > maxAge {
+		return false
+	}
+	return e.freshnessLifetime(respDirectives) > age
+}
+
+// freshnessLifetime computes how long the entry is fresh after it was generated (RFC 9111 4.2.1)
+func (e *cacheEntry) freshnessLifetime(respDirectives map[string]string) time.Duration {
+	if value, present := respDirectives["max-age"]; present {
+		maxAge, _ := parseDeltaSeconds(value)
+		return maxAge
+	}
+	date := e.dateValue()
+	if expires := e.header.Get("Expires"); expires != "" {
+		expiresTime, err := http.ParseTime(expires)
+		if err != nil {
+			return 0 // an invalid Expires means already expired
+		}
+		return expiresTime.Sub(date)
+	}
+	if _, mustRevalidate := respDirectives["must-revalidate"]; mustRevalidate {
+		return 0
+	}
+	if lastModified, err := http.ParseTime(e.header.Get("Last-Modified")); err == nil &&
+		heuristicallyCacheable[e.statusCode] && date.After(lastModified) {
+		return date.Sub(lastModified) / 10 // heuristic freshness (RFC 9111 4.2.2)
+	}
+	return 0
+}
+
+// currentAge computes the age of the entry (RFC 9111 4.2.3)
+func (e *cacheEntry) currentAge(now time.Time) time.Duration {
+	apparentAge := max(0, e.responseTime.Sub(e.dateValue()))
+	ageValue, _ := parseDeltaSeconds(e.header.Get("Age"))
+	correctedAge := ageValue + e.responseTime.Sub(e.requestTime)
+	return max(apparentAge, correctedAge) + now.Sub(e.responseTime)
+}
+
+// dateValue returns the Date header of the entry, or the time it was received if missing
+func (e *cacheEntry) dateValue() time.Time {
+	if date, err := http.ParseTime(e.header.Get("Date")); err == nil {
+		return date
+	}
+	return e.responseTime
+}
+
+// hasValidators reports whether the entry can be revalidated with a conditional request
+func (e *cacheEntry) hasValidators() bool {
+	return e.header.Get("ETag") != "" || e.header.Get("Last-Modified") != ""
+}
+
+// freshened returns a copy of the entry updated with the headers of a 304 Not Modified response
+// (RFC 9111 4.3.4)
+func (e *cacheEntry) freshened(header http.Header, requestTime, responseTime time.Time) *cacheEntry {
+	updated := *e
+	updated.header = e.header.Clone()
+	for name, values := range header {
+		if name != "Content-Length" {
+			updated.header[name] = append([]string(nil), values...)
+		}
+	}
+	updated.requestTime, updated.responseTime = requestTime, responseTime
+	return &updated
+}
+
+// response builds the response served from the entry for req, with an Age header
+func (e *cacheEntry) response(req *http.Request, now time.Time) *http.Response {
+	header := e.header.Clone()
+	header.Set("Age", strconv.FormatInt(int64(e.currentAge(now)/time.Second), 10))
+	protoMajor, protoMinor, _ := http.ParseHTTPVersion(e.proto)
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         e.proto,
+		ProtoMajor:    protoMajor,
+		ProtoMinor:    protoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// parseCacheControl parses a Cache-Control header into lowercased directive names and their values
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+	return directives
+}
+
+// parseDeltaSeconds parses a number of seconds, e.g. the value of max-age or the Age header
+func parseDeltaSeconds(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// headerListValues splits a comma-separated list header into canonical header names
+func headerListValues(header http.Header, name string) []string {
+	var values []string
+	for _, value := range header.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, http.CanonicalHeaderKey(item))
+			}
+		}
+	}
+	return values
+}
+
+// hasConditionalHeaders reports whether a request carries its own preconditions or a Range
+func hasConditionalHeaders(header http.Header) bool {
+	for _, name := range []string{"If-None-Match", "If-Modified-Since", "If-Match", "If-Unmodified-Since", "Range"} {
+		if header.Get(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnsafeMethod reports whether method may change server state (RFC 9110 9.2.1)
+func isUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return false
+	}
+	return true
+}
+
+// parseCacheStatus parses a cache status name, case-insensitively
+func parseCacheStatus(value string) (CacheStatus, error) {
+	switch status := CacheStatus(strings.ToUpper(value)); status {
+	case CacheHit, CacheMiss, CacheRevalidated:
+		return status, nil
+	}
+	return "", fmt.Errorf("expected HIT, MISS or REVALIDATED, got '%s'", value)
+}
@@ -0,0 +1,229 @@
+package restclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/joho/godotenv"
+)
+
+// capturedVarsFilePerm is the permission of files written by WithCapturedVarsFile; they often hold tokens
+const capturedVarsFilePerm = 0o600
+
+var (
+	// reCaptureName matches the variable name of a @capture directive
+	reCaptureName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	// reDotEnvAssignment matches the assignment of a .env line and captures its variable name
+	reDotEnvAssignment = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_.-]*)\s*[=:]`)
+)
+
+// Capture stores a value of a request's response in a variable (from "# @capture name = source"
+// directive), e.g. a token or the ID of a created resource
+type Capture struct {
+	Name   string // variable set for the requests that follow
+	Source string // status, body, header.<Name> or body.$<jsonpath>
+}
+
+// String renders the directive arguments, e.g. `token = body.$.access_token`
+func (c Capture) String() string {
+	return c.Name + " = " + c.Source
+}
+
+// parseCaptureDirective parses the arguments of `@capture token = body.$.access_token`
+func parseCaptureDirective(args string) (Capture, error) {
+	name, source, found := strings.Cut(args, "=")
+	capture := Capture{Name: strings.TrimSpace(name), Source: strings.TrimSpace(source)}
+	if !found || capture.Source == "" {
+		return Capture{}, errors.New("expected <name> = <source>")
+	}
+	if !reCaptureName.MatchString(capture.Name) {
+		return Capture{}, fmt.Errorf("invalid variable name '%s'", capture.Name)
+	}
+	switch {
+	case capture.Source == "status", capture.Source == "body":
+	case strings.HasPrefix(capture.Source, "header.") && len(capture.Source) > len("header."):
+	case strings.HasPrefix(capture.Source, "body.$"):
+		if _, err := parseJSONPath(strings.TrimPrefix(capture.Source, "body.")); err != nil {
+			return Capture{}, fmt.Errorf("invalid JSONPath in '%s': %w", capture.Source, err)
+		}
+	default:
+		return Capture{}, fmt.Errorf("source must be status, body, header.<Name> or body.$<jsonpath>, got '%s'",
+			capture.Source)
+	}
+	return capture, nil
+}
+
+// handleCaptureDirective processes "@capture <name> = <source>" directives; a request may have several
+func (p *requestParserState) handleCaptureDirective(commentContent string) bool {
+	if !strings.HasPrefix(commentContent, "@capture ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	capture, err := parseCaptureDirective(strings.TrimPrefix(commentContent, "@capture "))
+	if err != nil {
+		slog.Warn("Invalid @capture directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.Captures = append(p.currentRequest.Captures, capture)
+	return true
+}
+
+// value extracts the captured value from resp
+func (c Capture) value(resp *Response) (string, error) {
+	if err := resp.BufferBody(); err != nil {
+		return "", err
+	}
+	switch {
+	case c.Source == "status":
+		return strconv.Itoa(resp.StatusCode), nil
+	case c.Source == "body":
+		return resp.BodyString, nil
+	case strings.HasPrefix(c.Source, "header."):
+		name := strings.TrimPrefix(c.Source, "header.")
+		if _, ok := resp.Headers[http.CanonicalHeaderKey(name)]; !ok {
+			return "", fmt.Errorf("response has no header %s", name)
+		}
+		return resp.Header(name), nil
+	}
+	value, err := resp.JSONPath(strings.TrimPrefix(c.Source, "body."))
+	if err != nil {
+		return "", err
+	}
+	return pollConditionValue(value), nil
+}
+
+// captureVariables stores the values captured by the @capture directives of the response's request in
+// the global variables of parsedFile, where the following requests of the run find them
+func captureVariables(parsedFile *ParsedFile, resp *Response) error {
+	if resp == nil || resp.Error != nil || resp.Request == nil || len(resp.Request.Captures) == 0 {
+		return nil
+	}
+	var errs *multierror.Error
+	for _, capture := range resp.Request.Captures {
+		value, err := capture.value(resp)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("@capture %s: %w", capture, err))
+			continue
+		}
+		if parsedFile.GlobalVariables == nil {
+			parsedFile.GlobalVariables = make(map[string]string)
+		}
+		parsedFile.GlobalVariables[capture.Name] = value
+	}
+	return errs.ErrorOrNil()
+}
+
+// WithCapturedVarsFile writes the variables captured with "# @capture" directives back to a file at
+// the end of each ExecuteFile run, so later runs and IDE sessions reuse tokens and created IDs. A
+// path ending in .json is an environment file such as http-client.private.env.json: the values are
+// stored in the section of the environment selected with WithEnvironment. Any other path is a .env
+// file, in which the lines of captured variables are replaced and new ones appended. Other content
+// of the file is kept, and a missing file is created.
+func WithCapturedVarsFile(path string) ClientOption {
+	return func(c *Client) error {
+		if path == "" {
+			return errors.New("captured variables file path must not be empty")
+		}
+		c.capturedVarsFile = path
+		return nil
+	}
+}
+
+// persistCapturedVars writes the captured variables to the file of WithCapturedVarsFile, if any
+func (c *Client) persistCapturedVars(captured map[string]string) error {
+	if c.capturedVarsFile == "" || len(captured) == 0 {
+		return nil
+	}
+	var err error
+	if strings.EqualFold(filepath.Ext(c.capturedVarsFile), ".json") {
+		err = writeCapturedEnvJSON(c.capturedVarsFile, c.selectedEnvironmentName, captured)
+	} else {
+		err = writeCapturedDotEnv(c.capturedVarsFile, captured)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to persist captured variables to %s: %w", c.capturedVarsFile, err)
+	}
+	return nil
+}
+
+// writeCapturedEnvJSON stores captured in the environment env of the environment file at path
+func writeCapturedEnvJSON(path, env string, captured map[string]string) error {
+	if env == "" {
+		return errors.New("an environment must be selected with WithEnvironment")
+	}
+	environments := make(map[string]map[string]any)
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &environments); err != nil {
+			return fmt.Errorf("invalid environment file: %w", err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+	if environments[env] == nil {
+		environments[env] = make(map[string]any)
+	}
+	for name, value := range captured {
+		environments[env][name] = value
+	}
+	data, err = json.MarshalIndent(environments, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), capturedVarsFilePerm)
+}
+
+// writeCapturedDotEnv replaces the assignments of captured in the .env file at path and appends the
+// variables it does not assign yet
+func writeCapturedDotEnv(path string, captured map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	var lines []string
+	if content := strings.TrimRight(string(data), "\n"); content != "" {
+		lines = strings.Split(content, "\n")
+	}
+	written := make(map[string]bool, len(captured))
+	for i, line := range lines {
+		match := reDotEnvAssignment.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if value, ok := captured[match[1]]; ok {
+			if lines[i], err = dotEnvLine(match[1], value); err != nil {
+				return err
+			}
+			written[match[1]] = true
+		}
+	}
+	for _, name := range sortedKeys(captured) {
+		if written[name] {
+			continue
+		}
+		line, err := dotEnvLine(name, captured[name])
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line)
+	}
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), capturedVarsFilePerm)
+}
+
+// dotEnvLine formats the assignment of name in a .env file, quoted and escaped as godotenv reads it
+func dotEnvLine(name, value string) (string, error) {
+	return godotenv.Marshal(map[string]string{name: value})
+}
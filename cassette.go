@@ -0,0 +1,309 @@
+package restclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Permissions of the cassette directory and files written by WithRecording
+const (
+	cassetteDirPerm  = 0o755
+	cassetteFilePerm = 0o644
+)
+
+// ErrNoRecording marks the requests of a client with WithReplay that have no recorded response
+var ErrNoRecording = errors.New("no recorded response")
+
+// CassetteMatch selects the parts of a request that identify its recorded responses, combined with |
+type CassetteMatch uint8
+
+// Parts of a request matched by WithRecording and WithReplay
+const (
+	CassetteMatchMethod CassetteMatch = 1 << iota // the method
+	CassetteMatchURL                              // the URL, including the query
+	CassetteMatchBody                             // the body
+
+	// CassetteMatchDefault matches the method, URL and body
+	CassetteMatchDefault = CassetteMatchMethod | CassetteMatchURL | CassetteMatchBody
+)
+
+// WithRecording records the response of every HTTP request the client sends in a file of dir, so later
+// runs can be served from them offline with WithReplay. Requests are identified by the parts selected
+// by match, the method, URL and body by default, and a recording made with other parts is not found by
+// the replay. The responses of the same request, e.g. the attempts of a @poll request, are recorded in
+// order. Each run of the client replaces the recordings of the requests it sends. Request headers are
+// not recorded, but response headers are, including cookies; review recordings before committing them.
+func WithRecording(dir string, match ...CassetteMatch) ClientOption {
+	return withCassette(dir, true, match)
+}
+
+// WithReplay serves the HTTP requests of the client from the recordings of WithRecording in dir, without
+// network access, for deterministic offline runs. The responses recorded for the same request are served
+// in order, the last one again once all were served. Requests without a recording fail with an error
+// matching ErrNoRecording. match must select the parts used for the recording. GRPC requests are not
+// recorded or replayed.
+func WithReplay(dir string, match ...CassetteMatch) ClientOption {
+	return withCassette(dir, false, match)
+}
+
+// withCassette sets the recording or replay of a client
+func withCassette(dir string, record bool, match []CassetteMatch) ClientOption {
+	return func(c *Client) error {
+		if dir == "" {
+			return errors.New("cassette directory must not be empty")
+		}
+		if c.cassette != nil {
+			return errors.New("recording and replay cannot be combined")
+		}
+		var parts CassetteMatch
+		for _, m := range match {
+			parts |= m
+		}
+		if parts == 0 {
+			parts = CassetteMatchDefault
+		}
+		c.cassette = &cassette{dir: dir, record: record, match: parts, served: make(map[string]int)}
+		return nil
+	}
+}
+
+// cassette holds the recordings of a client with WithRecording or WithReplay
+type cassette struct {
+	dir    string
+	record bool
+	match  CassetteMatch
+
+	mu     sync.Mutex
+	served map[string]int // per recording file: responses recorded, or served by the replay
+}
+
+// cassetteFile is the content of a recording file: the request and its responses in order
+type cassetteFile struct {
+	Request   cassetteRequest    `json:"request"`
+	Responses []cassetteResponse `json:"responses"`
+}
+
+// cassetteRequest is a recorded request, kept to tell what a recording file is for
+type cassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// cassetteResponse is a recorded response. Bodies that are not UTF-8 text are encoded in base64.
+type cassetteResponse struct {
+	Status       string      `json:"status"`
+	StatusCode   int         `json:"statusCode"`
+	Proto        string      `json:"proto"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"` // "base64" for binary bodies
+}
+
+// cassetteTransport records the responses of base, or serves requests from the recordings of the
+// cassette
+type cassetteTransport struct {
+	base     http.RoundTripper
+	cassette *cassette
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := cassetteRequest{Method: req.Method, URL: req.URL.String(), Body: string(body)}
+	path := filepath.Join(t.cassette.dir, t.cassette.fileName(recorded))
+	if !t.cassette.record {
+		return t.cassette.replay(path, recorded, req)
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response for recording: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	if err := t.cassette.save(path, recorded, newCassetteResponse(resp, respBody)); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// readRequestBody reads and closes the body of a request, nil without one
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	return body, nil
+}
+
+// fileName names the recording file of a request after a hash of its matched parts, with the matched
+// method and path as a readable prefix, e.g. "get-users-42-1f3a...json"
+func (c *cassette) fileName(req cassetteRequest) string {
+	var key, prefix []string
+	if c.match&CassetteMatchMethod != 0 {
+		key = append(key, req.Method)
+		prefix = append(prefix, strings.ToLower(req.Method))
+	}
+	if c.match&CassetteMatchURL != 0 {
+		key = append(key, req.URL)
+		if index := strings.Index(req.URL, "://"); index >= 0 {
+			target := req.URL[index+3:]
+			if slash := strings.IndexByte(target, '/'); slash >= 0 {
+				prefix = append(prefix, target[slash+1:])
+			}
+		}
+	}
+	if c.match&CassetteMatchBody != 0 {
+		key = append(key, req.Body)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(key, "\n")))
+	readable := strings.Join(strings.FieldsFunc(strings.Join(prefix, "-"), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}), "-")
+	if len(readable) > 60 {
+		readable = readable[:60]
+	}
+	if readable != "" {
+		readable += "-"
+	}
+	return readable + hex.EncodeToString(sum[:8]) + ".json"
+}
+
+// save records a response of a request: the first of the run replaces the recording file, later ones
+// are appended to it
+func (c *cassette) save(path string, req cassetteRequest, resp cassetteResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	file := cassetteFile{Request: req}
+	if c.served[path] > 0 {
+		existing, err := readCassetteFile(path)
+		if err != nil {
+			return err
+		}
+		file.Responses = existing.Responses
+	}
+	file.Responses = append(file.Responses, resp)
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, cassetteDirPerm); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), cassetteFilePerm); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	c.served[path]++
+	return nil
+}
+
+// replay serves the next recorded response of a request
+func (c *cassette) replay(path string, recorded cassetteRequest, req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	file, err := readCassetteFile(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(file.Responses) == 0) {
+		return nil, fmt.Errorf("%w for %s %s in %s", ErrNoRecording, recorded.Method, recorded.URL, c.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := c.served[path]
+	if index >= len(file.Responses) {
+		index = len(file.Responses) - 1
+	}
+	c.served[path]++
+	return file.Responses[index].httpResponse(req)
+}
+
+// readCassetteFile reads a recording file
+func readCassetteFile(path string) (*cassetteFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// newCassetteResponse records a response with its body
+func newCassetteResponse(resp *http.Response, body []byte) cassetteResponse {
+	recorded := cassetteResponse{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Proto:      resp.Proto,
+		Headers:    resp.Header,
+		Body:       string(body),
+	}
+	if !utf8.Valid(body) {
+		recorded.Body = base64.StdEncoding.EncodeToString(body)
+		recorded.BodyEncoding = "base64"
+	}
+	return recorded
+}
+
+// httpResponse rebuilds the recorded response for req
+func (r cassetteResponse) httpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(r.Body)
+	if r.BodyEncoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recorded body: %w", err)
+		}
+		body = decoded
+	}
+	proto := r.Proto
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok {
+		proto, major, minor = "HTTP/1.1", 1, 1
+	}
+	header := r.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        r.Status,
+		StatusCode:    r.StatusCode,
+		Proto:         proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// lookupEncoding returns the text encoding for a charset or encoding name such as "latin1",
+// "windows-1252", "utf-16le" or any IANA charset name. UTF-8 (and its ASCII subset) yields
+// unicode.UTF8, for which no transcoding is needed.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "utf-8", "utf8", "ascii", "us-ascii":
+		// ASCII is a subset of UTF-8, so UTF-8 handling covers it
+		return unicode.UTF8, nil
+	case "latin1", "iso-8859-1":
+		return charmap.ISO8859_1, nil
+	case "cp1252", "windows-1252":
+		return charmap.Windows1252, nil
+	case "utf-16":
+		// RFC 2781: big endian unless a byte order mark says otherwise
+		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM), nil
+	case "utf-16le":
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil
+	case "utf-16be":
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), nil
+	}
+
+	enc, err := ianaindex.IANA.Encoding(name)
+	if err != nil || enc == nil {
+		return nil, fmt.Errorf("unsupported encoding: %s", name)
+	}
+	return enc, nil
+}
+
+// isUTF8Encoding reports whether enc needs no transcoding
+func isUTF8Encoding(enc encoding.Encoding) bool {
+	return enc == unicode.UTF8
+}
+
+// encodeRequestBody converts a UTF-8 request body into the charset declared by the request's
+// Content-Type header (e.g. "text/plain; charset=ISO-8859-1"), or with the BodyEncoder registered
+// for its media type. Bodies of static external files without a declared source encoding are sent
+// byte for byte and are not converted.
+func encodeRequestBody(req *Request, body string) (string, error) {
+	if body == "" || isRawExternalFileBody(req) {
+		return body, nil
+	}
+	contentType := req.Headers.Get("Content-Type")
+	mediaType, _ := parseContentType(contentType)
+	if encoder, ok := lookupBodyEncoder(mediaType); ok {
+		encoded, err := encoder.EncodeBody(body, contentType)
+		if err != nil {
+			return "", fmt.Errorf("cannot encode request body as %s: %w", mediaType, err)
+		}
+		return encoded, nil
+	}
+	charset := charsetFromContentType(req.Headers)
+	if charset == "" {
+		return body, nil
+	}
+	enc, err := lookupEncoding(charset)
+	if err != nil {
+		return "", fmt.Errorf("request Content-Type charset: %w", err)
+	}
+	if isUTF8Encoding(enc) {
+		return body, nil
+	}
+	encoded, err := enc.NewEncoder().String(body)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode request body as %s: %w", charset, err)
+	}
+	return encoded, nil
+}
+
+// isRawExternalFileBody reports whether the body comes unmodified from a "< ./file" reference
+func isRawExternalFileBody(req *Request) bool {
+	return req.ExternalFilePath != "" && req.ExternalFileEncoding == "" && !req.ExternalFileWithVariables
+}
+
+// charsetFromContentType extracts the charset parameter of a Content-Type header
+func charsetFromContentType(headers http.Header) string {
+	_, params := parseContentType(headers.Get("Content-Type"))
+	return params["charset"]
+}
+
+// Text returns the response body decoded to UTF-8 according to the charset of its Content-Type
+// header (e.g. ISO-8859-1 or UTF-16). Bodies without a charset, or with UTF-8, are returned as is.
+// Streamed bodies are buffered first.
+func (r *Response) Text() (string, error) {
+	if err := r.BufferBody(); err != nil {
+		return "", err
+	}
+	enc, err := lookupEncoding(r.Charset())
+	if err != nil {
+		return r.BodyString, fmt.Errorf("response Content-Type charset: %w", err)
+	}
+	if isUTF8Encoding(enc) {
+		return r.BodyString, nil
+	}
+	decoded, err := enc.NewDecoder().Bytes(r.Body)
+	if err != nil {
+		return r.BodyString, fmt.Errorf("failed to decode response body as %s: %w", r.Charset(), err)
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,113 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is the cause of the error of a request skipped by the circuit breaker of
+// WithCircuitBreaker; check for it with errors.Is(resp.Error, ErrCircuitOpen).
+var ErrCircuitOpen = errors.New("circuit open")
+
+// WithCircuitBreaker skips requests to a host that keeps failing. After threshold consecutive
+// failures (connection errors or 5xx responses) from a host, the circuit for the host opens and
+// further requests to it fail immediately with ErrCircuitOpen instead of waiting for timeouts.
+// After cooldown a single trial request is let through: success closes the circuit, failure opens
+// it for another cooldown.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) error {
+		if threshold < 1 {
+			return errors.New("circuit breaker threshold must be at least 1")
+		}
+		if cooldown <= 0 {
+			return errors.New("circuit breaker cooldown must be positive")
+		}
+		c.circuitBreaker = &circuitBreaker{
+			threshold: threshold,
+			cooldown:  cooldown,
+			hosts:     make(map[string]*hostCircuit),
+		}
+		return nil
+	}
+}
+
+// circuitBreaker tracks the failures of each host
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostCircuit
+}
+
+// hostCircuit is the circuit state of a single host
+type hostCircuit struct {
+	failures  int       // consecutive failures
+	openUntil time.Time // end of the cooldown of an open circuit
+	trial     bool      // a trial request after the cooldown is in flight
+}
+
+// allow reports ErrCircuitOpen when requests to host must be skipped
+func (b *circuitBreaker) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	circuit := b.hosts[host]
+	if circuit == nil || circuit.failures < b.threshold {
+		return nil
+	}
+	if wait := time.Until(circuit.openUntil); wait > 0 {
+		return fmt.Errorf("%w for host %s after %d consecutive failures, retrying in %s",
+			ErrCircuitOpen, host, circuit.failures, wait.Round(time.Millisecond))
+	}
+	if circuit.trial {
+		return fmt.Errorf("%w for host %s, waiting for the trial request", ErrCircuitOpen, host)
+	}
+	circuit.trial = true
+	return nil
+}
+
+// record counts the outcome of a request to host, opening its circuit at the threshold
+func (b *circuitBreaker) record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	circuit := b.hosts[host]
+	if circuit == nil {
+		circuit = &hostCircuit{}
+		b.hosts[host] = circuit
+	}
+	circuit.trial = false
+	if !failed {
+		circuit.failures = 0
+		return
+	}
+	circuit.failures++
+	if circuit.failures >= b.threshold {
+		circuit.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// circuitTransport skips requests to hosts whose circuit is open and records the outcome of the others
+type circuitTransport struct {
+	base    http.RoundTripper
+	breaker *circuitBreaker
+}
+
+func (t *circuitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	host := req.URL.Host
+	if err := t.breaker.allow(host); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := base.RoundTrip(req)
+	t.breaker.record(host, err != nil || resp.StatusCode >= http.StatusInternalServerError)
+	return resp, err
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -18,9 +19,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/joho/godotenv"
-	"golang.org/x/text/encoding"
-	"golang.org/x/text/encoding/charmap"
-	"golang.org/x/text/encoding/unicode"
 )
 
 
@@ -34,6 +32,47 @@ type Client struct {
 	currentDotEnvVars       map[string]string
 	programmaticVars        map[string]any
 	selectedEnvironmentName string // Added for T4
+	artifactsDir            string
+	wireCapture             bool
+	streamBodies            bool
+	strictMode              bool
+	urlVariableEncoding     *bool // nil: follow strictMode
+	inferContentType        bool
+	history                 HistoryRecorder
+	requestAssertions       map[string][]RequestAssertion
+	signers                 []SignerFunc
+	requestInterceptors     []RequestInterceptor  // see WithRequestInterceptor
+	responseInterceptors    []ResponseInterceptor // see WithResponseInterceptor
+	proxies                 *proxyTransports
+	proxyURL                *url.URL // see WithProxy
+	certTransports          *clientCertTransports
+	clientCert              *clientCertificate            // see WithClientCert
+	hostClientCerts         map[string]*clientCertificate // per host, see WithHostClientCert
+	cache                   *httpCache
+	eventualConsistency     *eventualConsistency
+	matchMode               MatchMode // see WithMatchMode
+	unorderedArrays         bool      // see WithUnorderedArrays
+	circuitBreaker          *circuitBreaker
+	networkShaping          *networkShaping
+	oauth1Hosts             map[string]*OAuth1Config // per host, see WithOAuth1
+	oauth2Hosts             map[string]*OAuth2Config // per host, see WithOAuth2
+	oauth2Tokens            *oauth2Tokens
+	deviceCodeHandler       DeviceCodeHandler
+	cookiesFile             *cookiesFileJar // see WithCookiesFile
+	varProviders            []VarProvider
+	nameFilter              *regexp.Regexp // see WithNameFilter
+	includeTags             []string       // see WithIncludeTags
+	excludeTags             []string       // see WithExcludeTags
+	urlRewrites             []urlRewrite
+	faultInjection          *faultInjector // see WithFaultInjection
+	capturedVarsFile        string         // see WithCapturedVarsFile
+	progress                ProgressFunc   // see WithProgress
+	scriptHandler           ScriptHandler  // see WithScriptHandler
+	sseHandler              SSEHandler     // see WithSSEHandler
+	grpc                    GRPCInvoker    // see WithGRPC
+	concurrency             int            // see WithConcurrency
+	hooks                   executionHooks // see WithBeforeRequestHook and the related options
+	cassette                *cassette      // see WithRecording and WithReplay
 }
 
 // NewClient creates a new instance of the REST client.
@@ -42,6 +81,9 @@ func NewClient(options ...ClientOption) (*Client, error) {
 	c := &Client{
 		httpClient:     &http.Client{},
 		DefaultHeaders: make(http.Header),
+		proxies:        &proxyTransports{},
+		certTransports: &clientCertTransports{},
+		oauth2Tokens:   &oauth2Tokens{},
 	}
 
 	for _, option := range options {
@@ -57,7 +99,8 @@ func NewClient(options ...ClientOption) (*Client, error) {
 
 // ExecuteFile parses a request file (.http, .rest), executes all requests found, and returns their responses.
 // It returns an error if the file cannot be parsed or no requests are found.
-// Individual request execution errors are stored within each Response object.
+// Individual request execution errors are stored within each Response object and collected in the
+// returned error, one RequestError per failed request (see RequestErrors).
 //
 // Variable Substitution Workflow:
 // 1. File Parsing (`parseRequestFile`):
@@ -73,7 +116,7 @@ func NewClient(options ...ClientOption) (*Client, error) {
 //     a. `resolveVariablesInText` is called. For {{variableName}} placeholders
 //        (where 'variableName' does not start with '$'),
 //     the precedence is: Client programmatic vars > file-scoped `@vars` (rcRequest.ActiveVariables) >
-//     Environment vars (parsedFile.EnvironmentVariables) > Global vars (parsedFile.GlobalVariables) >
+//     Global vars (parsedFile.GlobalVariables) > Environment vars (parsedFile.EnvironmentVariables) >
 //     OS env vars > .env vars > fallback.
 //     System variables like {{$uuid}} are resolved from the request-scoped map
 //     if the placeholder is {{$systemVarName}}.
@@ -82,10 +125,45 @@ func NewClient(options ...ClientOption) (*Client, error) {
 //     b. `substituteDynamicSystemVariables` is called: This handles system variables requiring arguments
 //     (e.g., `{{$dotenv NAME}}`, `{{$processEnv NAME}}`, `{{$randomInt MIN MAX}}`).
 //
-// Programmatic variables for substitution can be set on the Client using `WithVars()`.
-func (c *Client) ExecuteFile(ctx context.Context, requestFilePath string) ([]*Response, error) {
+// Programmatic variables for substitution can be set on the Client using `WithVars()`, or for a
+// single call by passing Vars, which take precedence over them (later Vars over earlier ones).
+func (c *Client) ExecuteFile(ctx context.Context, requestFilePath string, vars ...Vars) ([]*Response, error) {
+	opts := RunOptions{ExtraVars: mergeVars(vars)}
+	return c.withRunOptions(opts).executeFile(ctx, requestFilePath, opts)
+}
+
+// executeFile runs the requests of a file selected by opts, see ExecuteFileWithOptions
+func (c *Client) executeFile(ctx context.Context, requestFilePath string, opts RunOptions) ([]*Response, error) {
 	parsedFile, err := c.parseAndValidateFile(requestFilePath)
 	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	return c.executeParsedFileWithDefaults(ctx, requestFilePath, parsedFile, opts)
+}
+
+// executeParsedFileWithDefaults runs the requests of a parsed file selected by opts, with the workspace
+// defaults of its directory
+func (c *Client) executeParsedFileWithDefaults(ctx context.Context, requestFilePath string,
+	parsedFile *ParsedFile, opts RunOptions) ([]*Response, error) {
+	inherited, err := c.withWorkspaceDefaults(requestFilePath, parsedFile.Requests)
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	return inherited.executeParsedFile(ctx, requestFilePath, parsedFile, opts)
+}
+
+// executeParsedFile runs the requests of a parsed file selected by opts
+func (c *Client) executeParsedFile(ctx context.Context, requestFilePath string, parsedFile *ParsedFile,
+	opts RunOptions) ([]*Response, error) {
+	if opts.Parallelism == 0 {
+		opts.Parallelism = c.concurrency
+	}
+	selected, err := opts.selectRequests(parsedFile.Requests, c.requestFilter())
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+
+	if err := c.runBeforeFileHooks(ctx, requestFilePath); err != nil {
 		return nil, err
 	}
 
@@ -94,61 +172,93 @@ func (c *Client) ExecuteFile(ctx context.Context, requestFilePath string) ([]*Re
 	// Generate file-scoped system variables once for the entire file
 	c.resolveFileScopedSystemVariables(parsedFile)
 
+	run := c.newHistoryRun(requestFilePath)
 	var responses []*Response
 	var multiErr *multierror.Error
-	osEnvGetter := func(key string) (string, bool) { return os.LookupEnv(key) }
+	var executed runResponses
+	osEnvGetter := c.lookupVariable
+	send := func(i int, restClientReq *Request) (*Response, error) {
+		if err := executed.applyConditionalHeaders(restClientReq); err != nil {
+			return &Response{Request: restClientReq, Error: err}, err
+		}
+		return c.executeRequestWithVariables(ctx, restClientReq, parsedFile, osEnvGetter, i)
+	}
 
-	for i, restClientReq := range parsedFile.Requests {
-		response, err := c.executeRequestWithVariables(ctx, restClientReq, parsedFile, osEnvGetter, i)
-		response, shouldSkip := c.handleRequestExecutionError(response, err, restClientReq, i, &multiErr)
-		if shouldSkip {
-			continue
+	runSelectedRequests(parsedFile.Requests, selected, opts, send, func(sent sentRequest) bool {
+		i, restClientReq := sent.index, parsedFile.Requests[sent.index]
+		failuresBefore := len(multiErr.WrappedErrors())
+		response := c.handleRequestExecutionError(sent.response, sent.err, restClientReq, i, &multiErr)
+		responses = append(responses, response)
+		executed.record(response)
+		if captureErr := captureVariables(parsedFile, response); captureErr != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("request %d: %w", i+1, captureErr))
+		}
+		if scriptErr := c.runResponseHandler(i, response); scriptErr != nil {
+			multiErr = multierror.Append(multiErr, scriptErr)
 		}
-		if response != nil {
-			responses = append(responses, response)
+		if hookErr := c.runAfterResponseHooks(ctx, i, response); hookErr != nil {
+			multiErr = multierror.Append(multiErr, hookErr)
 		}
+		if assertErr := c.runRequestAssertions(i, response); assertErr != nil {
+			multiErr = multierror.Append(multiErr, assertErr)
+		}
+		if saveErr := c.saveResponseArtifact(requestFilePath, i, response); saveErr != nil {
+			multiErr = multierror.Append(multiErr, saveErr)
+		}
+		if recordErr := c.recordHistory(run, i, response); recordErr != nil {
+			multiErr = multierror.Append(multiErr, recordErr)
+		}
+		failures := multiErr.WrappedErrors()[failuresBefore:]
+		response.Failures = RequestErrors(&multierror.Error{Errors: failures})
+		return len(failures) > 0
+	})
+	if persistErr := c.persistCapturedVars(parsedFile.GlobalVariables); persistErr != nil {
+		multiErr = multierror.Append(multiErr, persistErr)
+	}
+	if hookErr := c.runAfterFileHooks(ctx, requestFilePath, responses); hookErr != nil {
+		multiErr = multierror.Append(multiErr, hookErr)
 	}
 
 	return responses, multiErr.ErrorOrNil()
 }
 
-// handleRequestExecutionError processes errors from request execution and manages error wrapping
-// Returns the processed response and a boolean indicating if the request should be skipped
+// handleRequestExecutionError records the failure of a request, if any, as one RequestError:
+// err is a substitution failure unless it is a RequestError already, e.g. of a pre-request script,
+// and a Response.Error without err a failure to send the request.
+// Returns the response of the request, which is never nil, so that every request sent has an entry
 func (c *Client) handleRequestExecutionError(
 	response *Response,
 	err error,
 	restClientReq *Request,
 	index int,
 	multiErr **multierror.Error,
-) (*Response, bool) {
+) *Response {
 	if err != nil {
-		*multiErr = multierror.Append(*multiErr, err)
-		if shouldSkipRequest(response, err) {
-			return nil, true
+		var reqErr *RequestError
+		if !errors.As(err, &reqErr) {
+			reqErr = newRequestError(restClientReq, index, PhaseSubstitute, err)
 		}
-		response = ensureResponseExists(response, restClientReq)
+		*multiErr = multierror.Append(*multiErr, reqErr)
+		return ensureResponseExists(response, restClientReq, err)
 	}
-	
-	c.wrapResponseError(response, restClientReq, index, multiErr)
-	return response, false
-}
 
-// shouldSkipRequest determines if a request should be skipped based on error type
-func shouldSkipRequest(response *Response, err error) bool {
-	return response != nil && response.Error != nil &&
-		(strings.Contains(err.Error(), "error processing body for request") ||
-			strings.Contains(err.Error(), "failed to read external file"))
+	response = ensureResponseExists(response, restClientReq, nil)
+	c.wrapResponseError(response, restClientReq, index, multiErr)
+	return response
 }
 
-// ensureResponseExists creates a response if none exists
-func ensureResponseExists(response *Response, restClientReq *Request) *Response {
-	if response == nil {
-		return &Response{Request: restClientReq, Error: errors.New("request processing failed")}
+// ensureResponseExists creates a response for restClientReq if none exists, failed with err
+func ensureResponseExists(response *Response, restClientReq *Request, err error) *Response {
+	if response != nil {
+		return response
 	}
-	return response
+	if err == nil {
+		err = errors.New("request processing failed")
+	}
+	return &Response{Request: restClientReq, Error: err}
 }
 
-// wrapResponseError wraps response errors for logging
+// wrapResponseError records a failure to send the request or read its response
 func (*Client) wrapResponseError(
 	response *Response,
 	restClientReq *Request,
@@ -156,14 +266,7 @@ func (*Client) wrapResponseError(
 	multiErr **multierror.Error,
 ) {
 	if response != nil && response.Error != nil {
-		urlForError := restClientReq.RawURLString
-		if restClientReq.URL != nil {
-			urlForError = restClientReq.URL.String()
-		}
-		wrappedErr := fmt.Errorf(
-			"request %d (%s %s) processing resulted in error: %w",
-			index+1, restClientReq.Method, urlForError, response.Error)
-		*multiErr = multierror.Append(*multiErr, wrappedErr)
+		*multiErr = multierror.Append(*multiErr, newRequestError(restClientReq, index, PhaseSend, response.Error))
 	}
 }
 
@@ -376,31 +479,81 @@ func (c *Client) executeRequest(ctx context.Context, rcRequest *Request) (*Respo
 	if rcRequest == nil {
 		return nil, errors.New("cannot execute a nil request")
 	}
-
-	clientResponse := &Response{Request: rcRequest}
-
 	if err := c.prepareRequestURL(rcRequest); err != nil {
 		return nil, err
 	}
+	if err := c.runRequestInterceptors(ctx, rcRequest); err != nil {
+		return &Response{Request: rcRequest, Error: err}, nil
+	}
+	clientResponse := c.dispatchRequest(ctx, rcRequest)
+	c.runResponseInterceptors(ctx, clientResponse)
+	return clientResponse, nil
+}
 
+// sendRequest sends a prepared HTTP request; failures are captured in Response.Error
+func (c *Client) sendRequest(ctx context.Context, rcRequest *Request) *Response {
+	clientResponse := &Response{Request: rcRequest}
 	httpReq, err := c.createHTTPRequest(ctx, rcRequest)
+	if err == nil {
+		err = authenticateRequest(httpReq, rcRequest)
+	}
+	if err == nil {
+		err = c.authorizeOAuth2(ctx, httpReq, rcRequest)
+	}
+	if err == nil {
+		err = c.signOAuth1(httpReq, rcRequest)
+	}
+	if err == nil {
+		err = c.signRequest(httpReq)
+	}
 	if err != nil {
 		clientResponse.Error = err
-		return clientResponse, nil
+		return clientResponse
 	}
 
-	httpResp, duration, doErr := c.executeHTTPRequest(httpReq, rcRequest)
+	httpReq, capture := c.withWireCapture(httpReq)
+	httpReq, cacheStatus := c.withCacheStatus(httpReq)
+	httpReq, informational := withInformationalCapture(httpReq)
+	httpReq, timings := withTimings(httpReq)
+	progress := c.trackUpload(httpReq, rcRequest)
+	clientResponse.BytesSent = requestWireSize(httpReq)
+	clientResponse.StartTime = time.Now()
+	httpResp, duration, doErr := c.executeHTTPRequest(httpReq, rcRequest, &clientResponse.Redirects)
 	clientResponse.Duration = duration
+	clientResponse.Timings = timings.result(duration)
+	applyWireCapture(clientResponse, capture)
+	applyCacheStatus(clientResponse, cacheStatus)
+	clientResponse.Informational = informational.responses
 
 	if doErr != nil {
-		return c.handleHTTPError(clientResponse, httpResp, doErr, httpReq), nil
+		return c.handleHTTPError(clientResponse, httpResp, doErr, httpReq)
+	}
+	progress.trackDownload(httpResp)
+
+	if rcRequest.OutputFile != "" {
+		c._populateResponseDetails(clientResponse, httpResp, nil, nil)
+		writeResponseBody(clientResponse, httpResp)
+		return clientResponse
+	}
+	if isEventStreamContentType(httpResp.Header.Get("Content-Type")) {
+		c.readEventStream(clientResponse, httpResp)
+		return clientResponse
+	}
+
+	if c.streamBodies {
+		c._populateResponseDetails(clientResponse, httpResp, nil, nil)
+		clientResponse.BytesReceived = responseWireSize(httpResp, 0)
+		streamResponseBody(clientResponse, httpResp)
+		return clientResponse
 	}
 
 	defer func() { _ = httpResp.Body.Close() }()
 	bodyBytes, readErr := io.ReadAll(httpResp.Body)
 	c._populateResponseDetails(clientResponse, httpResp, bodyBytes, readErr)
+	clientResponse.BytesReceived = responseWireSize(httpResp, len(bodyBytes))
+	clientResponse.Trailers = receivedTrailers(httpResp)
 
-	return clientResponse, nil
+	return clientResponse
 }
 
 // prepareRequestURL handles URL preparation and variable substitution
@@ -410,10 +563,11 @@ func (c *Client) prepareRequestURL(rcRequest *Request) error {
 			rcRequest,
 			nil, // parsedFile - no file context for direct executeRequest
 			c.generateRequestScopedSystemVariables(),
-			os.LookupEnv,
+			c.lookupVariable,
 			c.programmaticVars,
 			nil,       // currentDotEnvVars - no specific .env file for direct call
 			c.BaseURL, // Pass client's BaseURL for consistency
+			c.encodesURLVariables(),
 		)
 		if subsErr != nil {
 			return fmt.Errorf("variable substitution failed for request '%s': %w", rcRequest.Name, subsErr)
@@ -428,10 +582,18 @@ func (c *Client) prepareRequestURL(rcRequest *Request) error {
 
 // createHTTPRequest creates an HTTP request with headers
 func (c *Client) createHTTPRequest(ctx context.Context, rcRequest *Request) (*http.Request, error) {
-	httpReq, err := http.NewRequestWithContext(ctx, rcRequest.Method, rcRequest.URL.String(), rcRequest.Body)
+	target, err := c.rewriteURL(rcRequest.URL)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, rcRequest.Method, target.String(), rcRequest.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http request: %w", err)
 	}
+	if rcRequest.bodyLength > 0 {
+		httpReq.ContentLength = rcRequest.bodyLength
+		httpReq.GetBody = rcRequest.GetBody
+	}
 
 	c.setRequestHeaders(httpReq, rcRequest)
 	return httpReq, nil
@@ -457,7 +619,7 @@ func (c *Client) addDefaultHeaders(httpReq *http.Request) {
 func (*Client) addRequestHeaders(httpReq *http.Request, rcRequest *Request) {
 	for key, values := range rcRequest.Headers {
 		httpReq.Header.Del(key)
-		for _, value := range values {
+		for _, value := range sentHeaderValues(key, values) {
 			httpReq.Header.Add(key, value)
 		}
 	}
@@ -470,20 +632,62 @@ func (*Client) setHostHeader(httpReq *http.Request) {
 	}
 }
 
-// executeHTTPRequest executes the HTTP request and returns response, duration, and error
-func (c *Client) executeHTTPRequest(httpReq *http.Request, rcRequest *Request) (*http.Response, time.Duration, error) {
-	startTime := time.Now()
-	var httpResp *http.Response
-	var doErr error
-
-	if rcRequest.NoCookieJar {
-		tempClient := *c.httpClient
-		tempClient.Jar = nil
-		httpResp, doErr = tempClient.Do(httpReq)
-	} else {
-		httpResp, doErr = c.httpClient.Do(httpReq)
+// executeHTTPRequest executes the HTTP request and returns response, duration, and error.
+// Followed redirects are appended to redirects.
+func (c *Client) executeHTTPRequest(
+	httpReq *http.Request,
+	rcRequest *Request,
+	redirects *[]RedirectHop,
+) (*http.Response, time.Duration, error) {
+	// Per-request copy of the configured client, so request settings never leak between requests
+	tempClient := *c.httpClient
+	if c.cookiesFile != nil {
+		tempClient.Jar = c.cookiesFile
+	}
+	for _, setting := range requestClientSettings(rcRequest) {
+		setting.apply(&tempClient)
+	}
+	tempClient.CheckRedirect = recordingCheckRedirect(tempClient.CheckRedirect, redirects)
+	proxyTransport, err := c.requestProxyTransport(rcRequest)
+	if err != nil {
+		return nil, 0, err
+	}
+	if proxyTransport != nil {
+		tempClient.Transport = proxyTransport
+	}
+	if cert := c.clientCertificateFor(httpReq, rcRequest); cert != nil {
+		transport, err := c.clientCertTransport(tempClient.Transport, cert)
+		if err != nil {
+			return nil, 0, err
+		}
+		tempClient.Transport = transport
+	}
+	if c.cassette != nil {
+		// Innermost, in place of the network when replaying
+		tempClient.Transport = &cassetteTransport{base: tempClient.Transport, cassette: c.cassette}
+	}
+	if c.faultInjection != nil {
+		tempClient.Transport = &faultTransport{base: tempClient.Transport, injector: c.faultInjection}
+	}
+	if c.networkShaping != nil {
+		tempClient.Transport = &shapedTransport{base: tempClient.Transport, shaping: c.networkShaping}
+	}
+	if c.wireCapture {
+		tempClient.Transport = &wireCaptureTransport{base: tempClient.Transport}
+	}
+	if config, _ := c.oauth2ConfigFor(httpReq, rcRequest); config != nil {
+		tempClient.Transport = &oauth2Transport{base: tempClient.Transport, client: c, config: config}
+	}
+	if c.circuitBreaker != nil {
+		tempClient.Transport = &circuitTransport{base: tempClient.Transport, breaker: c.circuitBreaker}
+	}
+	if c.cache != nil {
+		// Outermost, so wire capture only records what is actually sent
+		tempClient.Transport = &cacheTransport{base: tempClient.Transport, cache: c.cache}
 	}
 
+	startTime := time.Now()
+	httpResp, doErr := tempClient.Do(httpReq)
 	duration := time.Since(startTime)
 	return httpResp, duration, doErr
 }
@@ -525,6 +729,9 @@ func populateBasicResponseData(resp *Response, httpResp *http.Response) {
 	resp.Proto = httpResp.Proto
 	resp.Headers = httpResp.Header
 	resp.Size = httpResp.ContentLength
+	if httpResp.Request != nil && httpResp.Request.URL != nil {
+		resp.FinalURL = httpResp.Request.URL.String()
+	}
 }
 
 // populateBodyData handles body data and errors
@@ -547,6 +754,7 @@ func populateTLSData(resp *Response, httpResp *http.Response) {
 		resp.IsTLS = true
 		resp.TLSVersion = getTLSVersionString(httpResp.TLS.Version)
 		resp.TLSCipherSuite = tls.CipherSuiteName(httpResp.TLS.CipherSuite)
+		resp.TLS = newTLSInfo(httpResp.TLS)
 	}
 }
 
@@ -566,102 +774,23 @@ func getTLSVersionString(version uint16) string {
 	}
 }
 
-// processExternalFile reads and processes external file references with optional variable substitution and encoding
-func (c *Client) processExternalFile(
-	restClientReq *Request,
-	parsedFile *ParsedFile,
-	requestScopedSystemVars map[string]string,
-	osEnvGetter func(string) (string, bool),
-) (string, error) {
-	// Resolve the file path relative to the request's file directory
-	requestDir := filepath.Dir(restClientReq.FilePath)
-	fullPath := restClientReq.ExternalFilePath
-	if !filepath.IsAbs(fullPath) {
-		fullPath = filepath.Join(requestDir, restClientReq.ExternalFilePath)
-	}
-
-	// Read the file with appropriate encoding
-	content, err := c.readFileWithEncoding(fullPath, restClientReq.ExternalFileEncoding)
-	if err != nil {
-		return "", fmt.Errorf("failed to read external file %s: %w", restClientReq.ExternalFilePath, err)
-	}
-
-	// Apply variable substitution if requested
-	if restClientReq.ExternalFileWithVariables {
-		resolvedContent := resolveVariablesInText(
-			content,
-			c.programmaticVars,
-			restClientReq.ActiveVariables,
-			parsedFile.EnvironmentVariables,
-			parsedFile.GlobalVariables,
-			requestScopedSystemVars,
-			osEnvGetter,
-			c.currentDotEnvVars,
-		)
-		content = substituteDynamicSystemVariables(
-			resolvedContent,
-			c.currentDotEnvVars,
-			c.programmaticVars,
-		)
-	}
-
-	return content, nil
-}
-
-// readFileWithEncoding reads a file with the specified encoding, defaulting to UTF-8
-func (c *Client) readFileWithEncoding(filePath, encodingName string) (string, error) {
-	// Read the file as bytes
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return "", err
-	}
-
-	// If no encoding specified or UTF-8, return as-is
-	if encodingName == "" || strings.ToLower(encodingName) == "utf-8" || strings.ToLower(encodingName) == "utf8" {
-		return string(data), nil
-	}
-
-	// Get the decoder for the specified encoding
-	decoder, err := c.getEncodingDecoder(encodingName)
-	if err != nil {
-		return "", fmt.Errorf("unsupported encoding %s: %w", encodingName, err)
-	}
-
-	// Decode the content
-	decodedContent, err := decoder.Bytes(data)
-	if err != nil {
-		return "", fmt.Errorf("failed to decode content with encoding %s: %w", encodingName, err)
-	}
-
-	return string(decodedContent), nil
-}
-
-// getEncodingDecoder returns the appropriate decoder for the given encoding name
-func (*Client) getEncodingDecoder(encodingName string) (*encoding.Decoder, error) {
-	encodingName = strings.ToLower(encodingName)
-
-	switch encodingName {
-	case "latin1", "iso-8859-1":
-		return charmap.ISO8859_1.NewDecoder(), nil
-	case "cp1252", "windows-1252":
-		return charmap.Windows1252.NewDecoder(), nil
-	case "ascii":
-		// ASCII is a subset of UTF-8, so we can use UTF-8 decoder
-		return unicode.UTF8.NewDecoder(), nil
-	default:
-		return nil, fmt.Errorf("unsupported encoding: %s", encodingName)
-	}
-}
-
 // parseAndValidateFile parses the request file and validates it has requests
 func (c *Client) parseAndValidateFile(requestFilePath string) (*ParsedFile, error) {
 	parsedFile, err := parseRequestFile(requestFilePath, c, make([]string, 0))
+	return c.validateParsedFile(requestFilePath, parsedFile, err)
+}
+
+// validateParsedFile checks that a parsed request file has requests and no variable cycles
+func (c *Client) validateParsedFile(requestFilePath string, parsedFile *ParsedFile, err error) (*ParsedFile, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse request file %s: %w", requestFilePath, err)
 	}
 	if len(parsedFile.Requests) == 0 {
 		return nil, fmt.Errorf("no requests found in file %s", requestFilePath)
 	}
+	if err := detectVariableCycles(parsedFile, c.programmaticVars); err != nil {
+		return nil, fmt.Errorf("failed to parse request file %s: %w", requestFilePath, err)
+	}
 	return parsedFile, nil
 }
 
@@ -685,7 +814,16 @@ func (c *Client) executeRequestWithVariables(
 	osEnvGetter func(string) (string, bool),
 	index int,
 ) (*Response, error) {
+	if err := c.runBeforeRequestHooks(ctx, index, restClientReq); err != nil {
+		return &Response{Request: restClientReq, Error: err}, err
+	}
+	if err := c.runScript(restClientReq.PreRequestScript, restClientReq, nil); err != nil {
+		return &Response{Request: restClientReq, Error: err}, newRequestError(restClientReq, index, PhaseScript, err)
+	}
 	requestScopedSystemVars := c.generateRequestScopedSystemVariables()
+	if err := c.resolveAuthTokens(ctx, restClientReq, requestScopedSystemVars); err != nil {
+		return &Response{Request: restClientReq, Error: err}, newRequestError(restClientReq, index, PhaseSubstitute, err)
+	}
 
 	// Substitute variables for URL and Headers
 	err := c.substituteRequestURLAndHeaders(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
@@ -703,11 +841,26 @@ func (c *Client) executeRequestWithVariables(
 			restClientReq.Name, index, err)
 	}
 
+	if restClientReq.WaitFor != nil {
+		if err := c.waitUntilReady(ctx, restClientReq.WaitFor); err != nil {
+			return &Response{Request: restClientReq, Error: err}, nil
+		}
+	}
+
 	// Execute the HTTP request
 	resp, execErr := c.executeRequest(ctx, restClientReq)
+	if restClientReq.Retry != nil {
+		resp, execErr = c.retryFailed(ctx, restClientReq, resp, execErr)
+	}
 	if execErr != nil {
 		return &Response{Request: restClientReq, Error: execErr}, nil
 	}
+	if restClientReq.Poll != nil {
+		resp = c.pollUntil(ctx, restClientReq, resp)
+	}
+	if restClientReq.Pagination != nil {
+		return c.followPages(ctx, restClientReq, resp), nil
+	}
 	return resp, nil
 }
 
@@ -726,12 +879,13 @@ func (c *Client) substituteRequestURLAndHeaders(
 		c.programmaticVars,
 		c.currentDotEnvVars,
 		c.BaseURL,
+		c.encodesURLVariables(),
 	)
 	if subsErr != nil {
 		return subsErr
 	}
 	restClientReq.URL = finalParsedURL
-	return nil
+	return c.substituteFileReferencesInHeaders(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
 }
 
 // substituteRequestBody handles body variable substitution including external files
@@ -741,11 +895,23 @@ func (c *Client) substituteRequestBody(
 	requestScopedSystemVars map[string]string,
 	osEnvGetter func(string) (string, bool),
 ) error {
+	if restClientReq.ExternalFilePath == "" && restClientReq.RawBody != "" && isMultipartForm(restClientReq) {
+		return c.substituteMultipartBody(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+	}
 	finalSubstitutedBody, err := c.resolveRequestBody(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
 	if err != nil {
 		return err
 	}
+	if restClientReq.SOAP != nil {
+		finalSubstitutedBody = restClientReq.SOAP.wrap(finalSubstitutedBody)
+		restClientReq.SOAP.applyHeaders(restClientReq)
+	}
+	finalSubstitutedBody, err = encodeRequestBody(restClientReq, finalSubstitutedBody)
+	if err != nil {
+		return err
+	}
 
+	c.applyContentHeaders(restClientReq, finalSubstitutedBody)
 	c.setRequestBody(restClientReq, finalSubstitutedBody)
 	return nil
 }
@@ -765,11 +931,13 @@ func (c *Client) resolveRequestBody(
 		return "", nil
 	}
 
-	if c.isMultipartFormWithFileReferences(restClientReq) {
-		return c.processMultipartFormWithFiles(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+	var body string
+	if isNDJSONContentType(restClientReq.Headers.Get("Content-Type")) {
+		body = c.processNDJSONBody(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+	} else {
+		body = c.processRegularBody(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
 	}
-
-	return c.processRegularBody(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter), nil
+	return c.substituteFileReferenceVariables(body, restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
 }
 
 // processRegularBody handles regular body processing (non-multipart, non-external)
@@ -807,6 +975,5 @@ func (*Client) setRequestBody(restClientReq *Request, finalSubstitutedBody strin
 }
 
 // TODO: Add other public methods as needed, e.g.:
-// - Execute(ctx context.Context, request *Request, options ...RequestOption) (*Response, error)
 // - A method to validate a single response if users construct ExpectedResponse manually.
 //
@@ -0,0 +1,235 @@
+package restclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ClientCertificate is a TLS client certificate presented to servers that request one (mutual TLS)
+type ClientCertificate struct {
+	CertFile string // PEM certificate chain, or PEM certificate and key when KeyFile is empty
+	KeyFile  string // PEM private key, unencrypted
+}
+
+// clientCertificate is a loaded ClientCertificate with the settings of an SSLConfiguration
+type clientCertificate struct {
+	key        string          // identifies the transport of the certificate
+	cert       tls.Certificate // empty for an SSLConfiguration only turning off verification
+	skipVerify bool            // "verifyHostCertificate": false
+}
+
+// clientCertTransports caches the transports presenting client certificates, shared by the copies of a
+// client
+type clientCertTransports struct {
+	mu         sync.Mutex
+	transports map[clientCertTransportKey]*http.Transport
+}
+
+// clientCertTransportKey identifies a transport: the transport it was cloned from and the certificate
+type clientCertTransportKey struct {
+	base *http.Transport
+	cert string
+}
+
+// WithClientCert presents the certificate of certFile and keyFile to servers that ask for a TLS client
+// certificate. With an empty keyFile, certFile holds both the certificate and the key. An
+// SSLConfiguration of the selected environment takes precedence.
+func WithClientCert(certFile, keyFile string) ClientOption {
+	return func(c *Client) error {
+		cert, err := loadClientCertificate(ClientCertificate{CertFile: certFile, KeyFile: keyFile}, "PEM", "PEM")
+		if err != nil {
+			return err
+		}
+		c.clientCert = cert
+		return nil
+	}
+}
+
+// WithHostClientCert presents cert to host only (a host name, or host:port to match a port too), e.g.
+// for internal APIs requiring mutual TLS next to public ones. It takes precedence over WithClientCert.
+func WithHostClientCert(host string, cert ClientCertificate) ClientOption {
+	return func(c *Client) error {
+		if host == "" {
+			return errors.New("client certificate host must not be empty")
+		}
+		loaded, err := loadClientCertificate(cert, "PEM", "PEM")
+		if err != nil {
+			return err
+		}
+		if c.hostClientCerts == nil {
+			c.hostClientCerts = make(map[string]*clientCertificate)
+		}
+		c.hostClientCerts[strings.ToLower(host)] = loaded
+		return nil
+	}
+}
+
+// clientCertificateFor returns the client certificate of a request: the SSLConfiguration of the
+// selected environment, the one of WithHostClientCert for its host, or the one of WithClientCert
+func (c *Client) clientCertificateFor(httpReq *http.Request, rcRequest *Request) *clientCertificate {
+	if rcRequest.clientCert != nil {
+		return rcRequest.clientCert
+	}
+	if cert, ok := c.hostClientCerts[strings.ToLower(httpReq.URL.Host)]; ok {
+		return cert
+	}
+	if cert, ok := c.hostClientCerts[strings.ToLower(httpReq.URL.Hostname())]; ok {
+		return cert
+	}
+	return c.clientCert
+}
+
+// clientCertTransport returns a clone of base presenting cert, cached per base transport and
+// certificate so requests with the same certificate share connections
+func (c *Client) clientCertTransport(base http.RoundTripper, cert *clientCertificate) (http.RoundTripper, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	baseTransport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("client certificates require the HTTP client to use an *http.Transport, got %T",
+			base)
+	}
+	c.certTransports.mu.Lock()
+	defer c.certTransports.mu.Unlock()
+	key := clientCertTransportKey{base: baseTransport, cert: cert.key}
+	if transport, ok := c.certTransports.transports[key]; ok {
+		return transport, nil
+	}
+	transport := baseTransport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if len(cert.cert.Certificate) > 0 {
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert.cert}
+	}
+	if cert.skipVerify {
+		transport.TLSClientConfig.InsecureSkipVerify = true // "verifyHostCertificate": false
+	}
+	if c.certTransports.transports == nil {
+		c.certTransports.transports = make(map[clientCertTransportKey]*http.Transport)
+	}
+	c.certTransports.transports[key] = transport
+	return transport, nil
+}
+
+// envSSLConfiguration is the SSLConfiguration section of an environment in the JetBrains format. The
+// certificate and key are file paths, or objects with a "path" and a "format" of "PEM" or "DER".
+type envSSLConfiguration struct {
+	ClientCertificate        json.RawMessage `json:"clientCertificate"`
+	ClientCertificateKey     json.RawMessage `json:"clientCertificateKey"`
+	HasCertificatePassphrase bool            `json:"hasCertificatePassphrase"`
+	VerifyHostCertificate    *bool           `json:"verifyHostCertificate"`
+}
+
+// envCertificateFile is a certificate or key file of an SSLConfiguration
+type envCertificateFile struct {
+	Path   string `json:"path"`
+	Format string `json:"format"`
+}
+
+// parseEnvCertificateFile reads a file path or a {"path", "format"} object; paths are relative to fileDir
+func parseEnvCertificateFile(raw json.RawMessage, fileDir string) (envCertificateFile, error) {
+	var file envCertificateFile
+	if len(raw) == 0 {
+		return file, nil
+	}
+	if err := json.Unmarshal(raw, &file.Path); err != nil {
+		if err := json.Unmarshal(raw, &file); err != nil {
+			return file, errors.New("expected a file path or an object with a path and a format")
+		}
+	}
+	if file.Path != "" && !filepath.IsAbs(file.Path) {
+		file.Path = filepath.Join(fileDir, file.Path)
+	}
+	return file, nil
+}
+
+// config loads the client certificate of the section; nil when the section configures nothing.
+// Turning off host certificate verification does not need a client certificate.
+func (e envSSLConfiguration) config(fileDir string) (*clientCertificate, error) {
+	if e.HasCertificatePassphrase {
+		return nil, errors.New("client certificates protected by a passphrase are not supported")
+	}
+	certFile, err := parseEnvCertificateFile(e.ClientCertificate, fileDir)
+	if err != nil {
+		return nil, fmt.Errorf("clientCertificate: %w", err)
+	}
+	keyFile, err := parseEnvCertificateFile(e.ClientCertificateKey, fileDir)
+	if err != nil {
+		return nil, fmt.Errorf("clientCertificateKey: %w", err)
+	}
+	cert := &clientCertificate{}
+	if certFile.Path != "" {
+		files := ClientCertificate{CertFile: certFile.Path, KeyFile: keyFile.Path}
+		if cert, err = loadClientCertificate(files, certFile.Format, keyFile.Format); err != nil {
+			return nil, err
+		}
+	}
+	if e.VerifyHostCertificate != nil && !*e.VerifyHostCertificate {
+		cert.skipVerify = true
+		cert.key += " insecure"
+	}
+	if cert.key == "" {
+		return nil, nil
+	}
+	return cert, nil
+}
+
+// loadClientCertificate reads a certificate and its private key in the PEM or DER format
+func loadClientCertificate(files ClientCertificate, certFormat, keyFormat string) (*clientCertificate, error) {
+	if files.CertFile == "" {
+		return nil, errors.New("client certificate file must not be empty")
+	}
+	certData, err := os.ReadFile(files.CertFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	keyData := certData
+	if files.KeyFile != "" {
+		if keyData, err = os.ReadFile(files.KeyFile); err != nil {
+			return nil, fmt.Errorf("failed to read client certificate key: %w", err)
+		}
+	}
+
+	if strings.EqualFold(certFormat, "DER") {
+		certData = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certData})
+	}
+	if strings.EqualFold(keyFormat, "DER") {
+		if keyData, err = derPrivateKeyToPEM(keyData); err != nil {
+			return nil, fmt.Errorf("invalid client certificate key %s: %w", files.KeyFile, err)
+		}
+	}
+	cert, err := tls.X509KeyPair(certData, keyData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate %s: %w", files.CertFile, err)
+	}
+	return &clientCertificate{key: files.CertFile + " " + files.KeyFile, cert: cert}, nil
+}
+
+// derPrivateKeyToPEM converts a DER encoded PKCS #8, PKCS #1 or SEC 1 private key into a PEM PKCS #8 key
+func derPrivateKeyToPEM(der []byte) ([]byte, error) {
+	var key any
+	var err error
+	if key, err = x509.ParsePKCS8PrivateKey(der); err != nil {
+		if key, err = x509.ParsePKCS1PrivateKey(der); err != nil {
+			if key, err = x509.ParseECPrivateKey(der); err != nil {
+				return nil, errors.New("unsupported private key, expected PKCS #8, PKCS #1 or SEC 1")
+			}
+		}
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), nil
+}
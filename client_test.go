@@ -24,6 +24,10 @@ func TestRedirectHandling(t *testing.T) {
 	test.RunRedirectHandling(t)
 }
 
+func TestRedirectHistory(t *testing.T) {
+	test.RunRedirectHistory(t)
+}
+
 // Core execution tests
 func TestExecuteFile_SingleRequest(t *testing.T) {
 	test.RunExecuteFile_SingleRequest(t)
@@ -82,6 +86,10 @@ func TestExecuteFile_MultipartFileUploads(t *testing.T) {
 }
 
 // Edge case tests
+func TestExecuteFile_LineEndingStyles(t *testing.T) {
+	test.RunExecuteFile_LineEndingStyles(t)
+}
+
 func TestExecuteFile_InvalidMethodInFile(t *testing.T) {
 	test.RunExecuteFile_InvalidMethodInFile(t)
 }
@@ -119,6 +127,70 @@ func TestExecuteFile_ExternalFileNotFound(t *testing.T) {
 	test.RunExecuteFile_ExternalFileNotFound(t)
 }
 
+func TestExecuteFile_CharsetAwareBodies(t *testing.T) {
+	test.RunExecuteFile_CharsetAwareBodies(t)
+}
+
+func TestExecuteFile_URLVariableEncoding(t *testing.T) {
+	test.RunExecuteFile_URLVariableEncoding(t)
+}
+
+func TestExecuteFile_InternationalizedDomainNames(t *testing.T) {
+	test.RunExecuteFile_InternationalizedDomainNames(t)
+}
+
+func TestExecuteFile_NonASCIIPathAndQuery(t *testing.T) {
+	test.RunExecuteFile_NonASCIIPathAndQuery(t)
+}
+
+func TestExecuteFile_ContentHeadersAfterSubstitution(t *testing.T) {
+	test.RunExecuteFile_ContentHeadersAfterSubstitution(t)
+}
+
+func TestExecuteFile_ContentTypeInferenceOffByDefault(t *testing.T) {
+	test.RunExecuteFile_ContentTypeInferenceOffByDefault(t)
+}
+
+func TestExecuteFile_MultiValueAndFoldedHeaders(t *testing.T) {
+	test.RunExecuteFile_MultiValueAndFoldedHeaders(t)
+}
+
+func TestExecuteFile_StructuredRequestErrors(t *testing.T) {
+	test.RunExecuteFile_StructuredRequestErrors(t)
+}
+
+func TestExecuteFile_ParseErrorPhase(t *testing.T) {
+	test.RunExecuteFile_ParseErrorPhase(t)
+}
+
+func TestExecuteFile_FileContentVariable(t *testing.T) {
+	test.RunExecuteFile_FileContentVariable(t)
+}
+
+func TestExecuteFile_FileContentVariableMissingFile(t *testing.T) {
+	test.RunExecuteFile_FileContentVariableMissingFile(t)
+}
+
+func TestExecuteFile_RandomFromFileVariable(t *testing.T) {
+	test.RunExecuteFile_RandomFromFileVariable(t)
+}
+
+func TestExecuteFile_SavesResponseArtifacts(t *testing.T) {
+	test.RunExecuteFile_SavesResponseArtifacts(t)
+}
+
+func TestExecuteFile_WireCapture(t *testing.T) {
+	test.RunExecuteFile_WireCapture(t)
+}
+
+func TestExecuteFile_TLSConnectionDetails(t *testing.T) {
+	test.RunExecuteFile_TLSConnectionDetails(t)
+}
+
+func TestExecuteFile_ResponseSizeAndBudgets(t *testing.T) {
+	test.RunExecuteFile_ResponseSizeAndBudgets(t)
+}
+
 // Variable handling tests
 func TestExecuteFile_WithCustomVariables(t *testing.T) {
 	test.RunExecuteFile_WithCustomVariables(t)
@@ -132,6 +204,14 @@ func TestExecuteFile_WithDotEnvSystemVariable(t *testing.T) {
 	test.RunExecuteFile_WithDotEnvSystemVariable(t)
 }
 
+func TestExecuteFile_WithEnvironmentLookupDefaults(t *testing.T) {
+	test.RunExecuteFile_WithEnvironmentLookupDefaults(t)
+}
+
+func TestExecuteFile_WithComputedEnvironmentVariables(t *testing.T) {
+	test.RunExecuteFile_WithComputedEnvironmentVariables(t)
+}
+
 func TestExecuteFile_WithProgrammaticVariables(t *testing.T) {
 	test.RunExecuteFile_WithProgrammaticVariables(t)
 }
@@ -160,6 +240,18 @@ func TestExecuteFile_WithContactAndInternetFakerData(t *testing.T) {
 	test.RunExecuteFile_WithContactAndInternetFakerData(t)
 }
 
+func TestExecuteFile_WithIdentifierGenerators(t *testing.T) {
+	test.RunExecuteFile_WithIdentifierGenerators(t)
+}
+
+func TestExecuteFile_WithLoremFakerData(t *testing.T) {
+	test.RunExecuteFile_WithLoremFakerData(t)
+}
+
+func TestExecuteFile_WithGeoFakerData(t *testing.T) {
+	test.RunExecuteFile_WithGeoFakerData(t)
+}
+
 func TestExecuteFile_WithIndirectEnvironmentVariables(t *testing.T) {
 	test.RunExecuteFile_WithIndirectEnvironmentVariables(t)
 }
@@ -262,6 +354,10 @@ func TestExecuteFile_InPlace_VariableDefinedByRandomInt(t *testing.T) {
 	test.RunExecuteFile_InPlace_VariableDefinedByRandomInt(t)
 }
 
+func TestExecuteFile_InPlace_CircularReferenceDetected(t *testing.T) {
+	test.RunExecuteFile_InPlace_CircularReferenceDetected(t)
+}
+
 // GraphQL tests
 func TestExecuteFile_GraphQLBasicQuery(t *testing.T) {
 	test.RunExecuteFile_GraphQLBasicQuery(t)
@@ -294,4 +390,297 @@ func TestExecuteFile_GraphQLBatchQueries(t *testing.T) {
 // Test helper tests
 func TestCreateTestFileFromTemplate_DebugOutput(t *testing.T) {
 	test.RunCreateTestFileFromTemplate_DebugOutput(t)
-}
\ No newline at end of file
+}
+
+// Request rendering tests
+func TestRenderResolved_RendersSubstitutedRequests(t *testing.T) {
+	test.RunRenderResolved_RendersSubstitutedRequests(t)
+}
+
+func TestRequest_StringRendersAuthoredRequest(t *testing.T) {
+	test.RunRequest_StringRendersAuthoredRequest(t)
+}
+
+// Executor interface tests
+func TestExecutor_FakeImplementation(t *testing.T) {
+	test.RunExecutor_FakeImplementation(t)
+}
+
+// Editor integration tests
+func TestToolkit_DocumentSymbols(t *testing.T) {
+	test.RunToolkit_DocumentSymbols(t)
+}
+
+func TestToolkit_Hover(t *testing.T) {
+	test.RunToolkit_Hover(t)
+}
+
+// Execution history tests
+func TestExecuteFile_RecordsHistory(t *testing.T) {
+	test.RunExecuteFile_RecordsHistory(t)
+}
+
+func TestReport_DiffRuns(t *testing.T) {
+	test.RunReport_DiffRuns(t)
+}
+
+// Replay tests
+func TestReplay_RecordedRunWithOverrides(t *testing.T) {
+	test.RunReplay_RecordedRunWithOverrides(t)
+}
+
+func TestReplay_ReportsRequestErrors(t *testing.T) {
+	test.RunReplay_ReportsRequestErrors(t)
+}
+
+func TestReplay_RedactsCredentials(t *testing.T) {
+	test.RunReplay_RedactsCredentials(t)
+}
+
+// Request assertion tests
+func TestExecuteFile_RequestAssertions(t *testing.T) {
+	test.RunExecuteFile_RequestAssertions(t)
+}
+
+// Plugin registry tests
+func TestExecuteFile_Plugins(t *testing.T) {
+	test.RunExecuteFile_Plugins(t)
+}
+
+func TestValidateResponses_BodyValidatorPlugin(t *testing.T) {
+	test.RunValidateResponses_BodyValidatorPlugin(t)
+}
+
+// Placeholder expression tests
+func TestExecuteFile_PlaceholderExpressions(t *testing.T) {
+	test.RunExecuteFile_PlaceholderExpressions(t)
+}
+
+// Request signing tests
+func TestExecuteFile_WithRequestSigner(t *testing.T) {
+	test.RunExecuteFile_WithRequestSigner(t)
+}
+
+func TestExecuteFile_WithProxyDirective(t *testing.T) {
+	test.RunExecuteFile_WithProxyDirective(t)
+}
+
+func TestExecuteFile_WithManyProxies(t *testing.T) {
+	test.RunExecuteFile_WithManyProxies(t)
+}
+
+func TestExecuteFile_WithHTTPCache(t *testing.T) {
+	test.RunExecuteFile_WithHTTPCache(t)
+}
+
+func TestExecuteFile_WithConditionalDirectives(t *testing.T) {
+	test.RunExecuteFile_WithConditionalDirectives(t)
+}
+
+func TestExecuteFile_WithPagination(t *testing.T) {
+	test.RunExecuteFile_WithPagination(t)
+}
+
+func TestExecuteFile_PaginationCrossHost(t *testing.T) {
+	test.RunExecuteFile_PaginationCrossHost(t)
+}
+
+func TestExecuteFile_WithPollDirective(t *testing.T) {
+	test.RunExecuteFile_WithPollDirective(t)
+}
+
+func TestExecuteFile_WithCircuitBreaker(t *testing.T) {
+	test.RunExecuteFile_WithCircuitBreaker(t)
+}
+
+func TestExecuteFile_WithNetworkShaping(t *testing.T) {
+	test.RunExecuteFile_WithNetworkShaping(t)
+}
+
+func TestExecuteFile_WithNDJSON(t *testing.T) {
+	test.RunExecuteFile_WithNDJSON(t)
+}
+
+func TestExecuteFile_WithSOAPDirective(t *testing.T) {
+	test.RunExecuteFile_WithSOAPDirective(t)
+}
+
+func TestExecuteFile_WithQueryParameterBlock(t *testing.T) {
+	test.RunExecuteFile_WithQueryParameterBlock(t)
+}
+
+func TestExecuteFile_WithOAuth1Signing(t *testing.T) {
+	test.RunExecuteFile_WithOAuth1Signing(t)
+}
+
+func TestExecuteFile_WithOAuth2DeviceFlow(t *testing.T) {
+	test.RunExecuteFile_WithOAuth2DeviceFlow(t)
+}
+
+func TestExecuteFile_WithOAuth2TokenRefresh(t *testing.T) {
+	test.RunExecuteFile_WithOAuth2TokenRefresh(t)
+}
+
+func TestExecuteFile_WithCookiesFile(t *testing.T) {
+	test.RunExecuteFile_WithCookiesFile(t)
+}
+
+func TestExecuteFileWithOptions(t *testing.T) {
+	test.RunExecuteFileWithOptions(t)
+}
+
+func TestExecuteFileWithOptions_FailFast(t *testing.T) {
+	test.RunExecuteFileWithOptions_FailFast(t)
+}
+
+func TestGenerateGo(t *testing.T) {
+	test.RunGenerateGo(t)
+}
+
+func TestFromHTTPRequest(t *testing.T) {
+	test.RunFromHTTPRequest(t)
+}
+
+func TestExecuteFile_WithVarProvider(t *testing.T) {
+	test.RunExecuteFile_WithVarProvider(t)
+}
+
+func TestExecuteFile_WithCallVars(t *testing.T) {
+	test.RunExecuteFile_WithCallVars(t)
+}
+
+func TestExecuteFile_WithNameFilter(t *testing.T) {
+	test.RunExecuteFile_WithNameFilter(t)
+}
+
+func TestExecuteFile_WithWorkspaceDefaults(t *testing.T) {
+	test.RunExecuteFile_WithWorkspaceDefaults(t)
+}
+
+func TestExecuteFile_WithURLRewrite(t *testing.T) {
+	test.RunExecuteFile_WithURLRewrite(t)
+}
+
+func TestExecuteFile_WithFaultInjection(t *testing.T) {
+	test.RunExecuteFile_WithFaultInjection(t)
+}
+
+func TestValidateResponses_MaxSize(t *testing.T) {
+	test.RunValidateResponses_MaxSize(t)
+}
+
+func TestExecuteFile_CaptureAndPersistVars(t *testing.T) {
+	test.RunExecuteFile_CaptureAndPersistVars(t)
+}
+
+func TestCompareEnvironments(t *testing.T) {
+	test.RunCompareEnvironments(t)
+}
+
+func TestExecuteFiles(t *testing.T) {
+	test.RunExecuteFiles(t)
+}
+
+func TestExecuteFile_WaitFor(t *testing.T) {
+	test.RunExecuteFile_WaitFor(t)
+}
+
+func TestExecuteFile_TrailersAndInformational(t *testing.T) {
+	test.RunExecuteFile_TrailersAndInformational(t)
+}
+
+func TestExecuteFile_Progress(t *testing.T) {
+	test.RunExecuteFile_Progress(t)
+}
+
+func TestExecuteFile_ProgressAfterRedirect(t *testing.T) {
+	test.RunExecuteFile_ProgressAfterRedirect(t)
+}
+
+func TestExecuteFileRequest_ByName(t *testing.T) {
+	test.RunExecuteFileRequest_ByName(t)
+}
+
+func TestExecuteFile_ScriptBlocks(t *testing.T) {
+	test.RunExecuteFile_ScriptBlocks(t)
+}
+
+func TestExecuteFile_WithTimeoutAndRetryDirectives(t *testing.T) {
+	test.RunExecuteFile_WithTimeoutAndRetryDirectives(t)
+}
+
+func TestExecuteFile_WithConcurrency(t *testing.T) {
+	test.RunExecuteFile_WithConcurrency(t)
+}
+
+func TestExecuteFile_MultipartFormBodies(t *testing.T) {
+	test.RunExecuteFile_MultipartFormBodies(t)
+}
+
+func TestExecuteFile_OutputDirective(t *testing.T) {
+	test.RunExecuteFile_OutputDirective(t)
+}
+
+func TestExecuteFile_ServerSentEvents(t *testing.T) {
+	test.RunExecuteFile_ServerSentEvents(t)
+}
+
+func TestExecuteFile_GRPCRequests(t *testing.T) {
+	test.RunExecuteFile_GRPCRequests(t)
+}
+
+func TestExecuteFile_GRPCWithDescriptors(t *testing.T) {
+	test.RunExecuteFile_GRPCWithDescriptors(t)
+}
+
+func TestExecuteFile_WithOAuth2ClientCredentials(t *testing.T) {
+	test.RunExecuteFile_WithOAuth2ClientCredentials(t)
+}
+
+func TestExecuteFile_WithClientCertificates(t *testing.T) {
+	test.RunExecuteFile_WithClientCertificates(t)
+}
+
+func TestExecuteFile_WithClientProxy(t *testing.T) {
+	test.RunExecuteFile_WithClientProxy(t)
+}
+
+func TestExecuteFile_WithInterceptors(t *testing.T) {
+	test.RunExecuteFile_WithInterceptors(t)
+}
+
+func TestExecuteFile_ResponseTimings(t *testing.T) {
+	test.RunExecuteFile_ResponseTimings(t)
+}
+
+func TestExecuteFile_CurlImport(t *testing.T) {
+	test.RunExecuteFile_CurlImport(t)
+}
+
+func TestGenerateRequestsFromOpenAPI(t *testing.T) {
+	test.RunGenerateRequestsFromOpenAPI(t)
+}
+
+func TestRequestBuilder_Execute(t *testing.T) {
+	test.RunRequestBuilder_Execute(t)
+}
+
+func TestRequestBuilder_NoRedirect(t *testing.T) {
+	test.RunRequestBuilder_NoRedirect(t)
+}
+
+func TestExecuteSource_AndReader(t *testing.T) {
+	test.RunExecuteSource_AndReader(t)
+}
+
+func TestExecuteFile_WithTagFilters(t *testing.T) {
+	test.RunExecuteFile_WithTagFilters(t)
+}
+
+func TestExecuteFile_WithHooks(t *testing.T) {
+	test.RunExecuteFile_WithHooks(t)
+}
+
+func TestExecuteFile_RecordAndReplay(t *testing.T) {
+	test.RunExecuteFile_RecordAndReplay(t)
+}
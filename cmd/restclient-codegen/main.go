@@ -0,0 +1,60 @@
+// Command restclient-codegen converts the requests of a .http or .rest file into Go net/http code.
+//
+// Usage:
+//
+//	restclient-codegen [-env name] [-package name] [-o file.go] requests.http
+//
+// Requests are resolved like go-restclient executes them, with the variables of the selected
+// environment, .env files and the OS environment, without being sent.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+const outputFilePerm = 0o644
+
+func main() {
+	environment := flag.String("env", "", "environment of http-client.env.json to resolve variables with")
+	packageName := flag.String("package", "client", "package of the generated code")
+	output := flag.String("o", "", "file to write the generated code to; standard output by default")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] requests.http\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *environment, *packageName, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run generates the code of requestFile and writes it to output, or standard output when empty
+func run(requestFile, environment, packageName, output string) error {
+	var options []rc.ClientOption
+	if environment != "" {
+		options = append(options, rc.WithEnvironment(environment))
+	}
+	client, err := rc.NewClient(options...)
+	if err != nil {
+		return err
+	}
+	code, err := client.GenerateGo(requestFile, packageName)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = fmt.Print(code)
+		return err
+	}
+	return os.WriteFile(output, []byte(code), outputFilePerm)
+}
@@ -0,0 +1,229 @@
+package restclient
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GenerateGo resolves the requests of a file like RenderResolved and converts them into Go source
+// code of package packageName using net/http, so prototypes written as .http files can graduate into
+// production client code. Each request becomes a function
+//
+//	func GetUser(ctx context.Context, client *http.Client) (*http.Response, error)
+//
+// named after its @name, or its method and path. The @no-redirect, @no-cookie-jar and @timeout
+// directives are reproduced; directives that need the runtime of this package, such as @auth, @poll,
+// @retry or @paginate, are listed in a comment of the function instead. Secrets resolved from
+// variables end up in the generated code, so review it before committing. Files with GRPC requests
+// are rejected.
+func (c *Client) GenerateGo(requestFilePath, packageName string) (string, error) {
+	if !token.IsIdentifier(packageName) {
+		return "", fmt.Errorf("invalid Go package name %q", packageName)
+	}
+	requests, err := c.resolveFileRequests(requestFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	var functions strings.Builder
+	usesStrings := false
+	names := make(map[string]bool)
+	for i, req := range requests {
+		if isGRPCRequest(req) {
+			return "", fmt.Errorf("request %d: GRPC requests cannot be generated as net/http code", i+1)
+		}
+		name := uniqueGoName(goFunctionName(req, i), names)
+		writeGoFunction(&functions, name, req)
+		usesStrings = usesStrings || req.RawBody != ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// Code generated by go-restclient from %s. DO NOT EDIT.\n\n", filepath.Base(requestFilePath))
+	fmt.Fprintf(&sb, "package %s\n\n", packageName)
+	sb.WriteString("import (\n\t\"context\"\n\t\"net/http\"\n")
+	if usesStrings {
+		sb.WriteString("\t\"strings\"\n")
+	}
+	if usesTimeout(requests) {
+		sb.WriteString("\t\"time\"\n")
+	}
+	sb.WriteString(")\n")
+	sb.WriteString(functions.String())
+
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return "", fmt.Errorf("generated code does not compile: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// writeGoFunction writes the function sending one resolved request
+func writeGoFunction(sb *strings.Builder, name string, req *Request) {
+	fmt.Fprintf(sb, "\n// %s sends %s %s.\n", name, req.Method, req.URL)
+	if unsupported := unsupportedGoDirectives(req); len(unsupported) > 0 {
+		fmt.Fprintf(sb, "// The %s directives of the request are not reproduced.\n", strings.Join(unsupported, ", "))
+	}
+	fmt.Fprintf(sb, "func %s(ctx context.Context, client *http.Client) (*http.Response, error) {\n", name)
+	body := "nil"
+	if req.RawBody != "" {
+		body = "strings.NewReader(" + goStringLiteral(req.RawBody) + ")"
+	}
+	fmt.Fprintf(sb, "\treq, err := http.NewRequestWithContext(ctx, %s, %s, %s)\n",
+		goMethod(req.Method), strconv.Quote(req.URL.String()), body)
+	sb.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	for _, key := range sortedKeys(req.Headers) {
+		for _, value := range req.Headers[key] {
+			if strings.EqualFold(key, "Host") {
+				fmt.Fprintf(sb, "\treq.Host = %s\n", strconv.Quote(value))
+				continue
+			}
+			fmt.Fprintf(sb, "\treq.Header.Add(%s, %s)\n", strconv.Quote(key), strconv.Quote(value))
+		}
+	}
+	settings := requestClientSettings(req)
+	if len(settings) == 0 {
+		sb.WriteString("\treturn client.Do(req)\n}\n")
+		return
+	}
+	sb.WriteString("\tcustom := *client\n")
+	for _, setting := range settings {
+		sb.WriteString(setting.code)
+	}
+	sb.WriteString("\treturn custom.Do(req)\n}\n")
+}
+
+// unsupportedGoDirectives lists the directives of a request the generated code does not reproduce
+func unsupportedGoDirectives(req *Request) []string {
+	var directives []string
+	if req.AuthProvider != "" {
+		directives = append(directives, "@auth")
+	}
+	if req.Proxy != "" {
+		directives = append(directives, "@proxy")
+	}
+	if req.NoProxy {
+		directives = append(directives, "@no-proxy")
+	}
+	if len(req.ConditionalHeaders) > 0 {
+		directives = append(directives, "conditional")
+	}
+	if req.Pagination != nil {
+		directives = append(directives, "@paginate")
+	}
+	if req.Poll != nil {
+		directives = append(directives, "@poll")
+	}
+	if req.Retry != nil {
+		directives = append(directives, "@retry")
+	}
+	if req.WaitFor != nil {
+		directives = append(directives, "@wait-for")
+	}
+	if len(req.Captures) > 0 {
+		directives = append(directives, "@capture")
+	}
+	if req.OutputFile != "" {
+		directives = append(directives, "@output")
+	}
+	if req.SSETimeout > 0 {
+		directives = append(directives, "@sse-timeout")
+	}
+	if req.PreRequestScript != nil || req.ResponseHandlerScript != nil {
+		directives = append(directives, "script")
+	}
+	return directives
+}
+
+// usesTimeout reports whether a generated function sets a timeout
+func usesTimeout(requests []*Request) bool {
+	for _, req := range requests {
+		if req.Timeout > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// goMethodConstants are the net/http constants of the standard methods
+var goMethodConstants = map[string]string{
+	http.MethodGet:     "http.MethodGet",
+	http.MethodHead:    "http.MethodHead",
+	http.MethodPost:    "http.MethodPost",
+	http.MethodPut:     "http.MethodPut",
+	http.MethodPatch:   "http.MethodPatch",
+	http.MethodDelete:  "http.MethodDelete",
+	http.MethodConnect: "http.MethodConnect",
+	http.MethodOptions: "http.MethodOptions",
+	http.MethodTrace:   "http.MethodTrace",
+}
+
+// goMethod renders a method as a net/http constant, or as a string literal for other methods
+func goMethod(method string) string {
+	if constant, ok := goMethodConstants[method]; ok {
+		return constant
+	}
+	return strconv.Quote(method)
+}
+
+// goStringLiteral renders a body as a raw string literal when that keeps it readable, otherwise as
+// an interpreted string literal
+func goStringLiteral(s string) string {
+	if utf8.ValidString(s) && !strings.ContainsAny(s, "`\r") {
+		readable := true
+		for _, r := range s {
+			if r != '\n' && r != '\t' && !unicode.IsPrint(r) {
+				readable = false
+				break
+			}
+		}
+		if readable {
+			return "`" + s + "`"
+		}
+	}
+	return strconv.Quote(s)
+}
+
+// goFunctionName derives an exported function name from the request's @name, or else from its method
+// and path, e.g. "GetUsersOrders"
+func goFunctionName(req *Request, index int) string {
+	source := req.Name
+	if source == "" {
+		source = strings.ToLower(req.Method)
+		if req.URL != nil {
+			source += " " + req.URL.Path
+		}
+	}
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(source, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		first, size := utf8.DecodeRuneInString(word)
+		sb.WriteRune(unicode.ToUpper(first))
+		sb.WriteString(word[size:])
+	}
+	name := sb.String()
+	if name == "" {
+		return fmt.Sprintf("Request%d", index+1)
+	}
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsLetter(first) || !token.IsIdentifier(name) {
+		return "Request" + name
+	}
+	return name
+}
+
+// uniqueGoName appends a number to name when it is already taken
+func uniqueGoName(name string, taken map[string]bool) string {
+	unique := name
+	for n := 2; taken[unique]; n++ {
+		unique = fmt.Sprintf("%s%d", name, n)
+	}
+	taken[unique] = true
+	return unique
+}
@@ -0,0 +1,107 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// conditionalValidators maps the headers filled by the conditional request directives
+// (@if-match, @if-none-match, @if-modified-since and @if-unmodified-since) to the response header
+// their value is taken from
+var conditionalValidators = map[string]string{
+	"If-Match":            "ETag",
+	"If-None-Match":       "ETag",
+	"If-Modified-Since":   "Last-Modified",
+	"If-Unmodified-Since": "Last-Modified",
+}
+
+// handleConditionalDirective processes "@if-match [requestName]" and the other conditional directives
+func (p *requestParserState) handleConditionalDirective(commentContent string) bool {
+	header, source, ok := parseConditionalDirective(commentContent)
+	if !ok {
+		return false
+	}
+	p.ensureCurrentRequest()
+	if p.currentRequest.ConditionalHeaders == nil {
+		p.currentRequest.ConditionalHeaders = make(map[string]string)
+	}
+	p.currentRequest.ConditionalHeaders[header] = source
+	return true
+}
+
+// parseConditionalDirective parses "@if-match [requestName]" and the other conditional request
+// directives, returning the header to fill and the @name of the request whose response provides
+// the value ("" for the preceding request)
+func parseConditionalDirective(commentContent string) (header, source string, ok bool) {
+	fields := strings.Fields(commentContent)
+	if len(fields) == 0 || len(fields) > 2 || !strings.HasPrefix(fields[0], "@if-") {
+		return "", "", false
+	}
+	header = http.CanonicalHeaderKey(strings.TrimPrefix(fields[0], "@"))
+	if _, known := conditionalValidators[header]; !known {
+		return "", "", false
+	}
+	if len(fields) == 2 {
+		source = fields[1]
+	}
+	return header, source, true
+}
+
+// conditionalDirectives renders the conditional request directives of a request, sorted by header
+func conditionalDirectives(conditionalHeaders map[string]string) []string {
+	directives := make([]string, 0, len(conditionalHeaders))
+	for _, header := range sortedKeys(conditionalHeaders) {
+		directive := "@" + strings.ToLower(header)
+		if source := conditionalHeaders[header]; source != "" {
+			directive += " " + source
+		}
+		directives = append(directives, directive)
+	}
+	return directives
+}
+
+// runResponses tracks the responses of an ExecuteFile run that conditional headers are taken from
+type runResponses struct {
+	previous *Response
+	named    map[string]*Response
+}
+
+// record remembers resp as the latest response and under its request's @name
+func (r *runResponses) record(resp *Response) {
+	if resp == nil {
+		return
+	}
+	r.previous = resp
+	if resp.Request != nil && resp.Request.Name != "" {
+		if r.named == nil {
+			r.named = make(map[string]*Response)
+		}
+		r.named[resp.Request.Name] = resp
+	}
+}
+
+// applyConditionalHeaders sets the headers requested by the conditional directives of req from the
+// ETag or Last-Modified of earlier responses in the run
+func (r *runResponses) applyConditionalHeaders(req *Request) error {
+	for _, header := range sortedKeys(req.ConditionalHeaders) {
+		source := req.ConditionalHeaders[header]
+		resp, description := r.previous, "the preceding request"
+		if source != "" {
+			resp, description = r.named[source], fmt.Sprintf("request '%s'", source)
+		}
+		if resp == nil {
+			return fmt.Errorf("@%s: no response from %s", strings.ToLower(header), description)
+		}
+		validator := conditionalValidators[header]
+		value := resp.Header(validator)
+		if value == "" {
+			return fmt.Errorf("@%s: response from %s has no %s header", strings.ToLower(header), description, validator)
+		}
+		if req.Headers == nil {
+			req.Headers = make(http.Header)
+		}
+		req.Headers.Set(header, value)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package restclient
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Content types assigned by inferContentType
+const (
+	inferredJSONContentType = "application/json"
+	inferredXMLContentType  = "application/xml"
+	inferredFormContentType = "application/x-www-form-urlencoded"
+)
+
+// formBodyPattern matches a single-line urlencoded form body such as "name=John&age=42"
+var formBodyPattern = regexp.MustCompile(`^[^=&\s]+=[^&\s]*(?:&[^=&\s]+=[^&\s]*)*$`)
+
+// applyContentHeaders fixes the content headers of req for its final, substituted body: an authored
+// Content-Length is rewritten to the body size, and with inference enabled a missing Content-Type is
+// derived from the body unless the request opts out with @no-infer-content-type.
+func (c *Client) applyContentHeaders(req *Request, body string) {
+	if req.Headers.Get("Content-Length") != "" {
+		req.Headers.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	if !c.inferContentType || req.NoInferContentType || body == "" {
+		return
+	}
+	if req.Headers.Get("Content-Type") != "" || c.DefaultHeaders.Get("Content-Type") != "" {
+		return
+	}
+	contentType := inferContentType(body)
+	if contentType == "" {
+		return
+	}
+	if req.Headers == nil {
+		req.Headers = make(http.Header)
+	}
+	req.Headers.Set("Content-Type", contentType)
+}
+
+// inferContentType returns the content type of a JSON, XML or urlencoded form body, or "" when the
+// body is none of these
+func inferContentType(body string) string {
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		if json.Valid([]byte(trimmed)) {
+			return inferredJSONContentType
+		}
+	case strings.HasPrefix(trimmed, "<"):
+		if isWellFormedXML(trimmed) {
+			return inferredXMLContentType
+		}
+	case formBodyPattern.MatchString(trimmed):
+		return inferredFormContentType
+	}
+	return ""
+}
+
+// isWellFormedXML reports whether text is a well-formed XML document
+func isWellFormedXML(text string) bool {
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package restclient
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cookiesFileDirPerm  = 0o755
+	cookiesFileFilePerm = 0o600 // cookies often hold session identifiers
+
+	cookiesFileHeader  = "# domain\tpath\tname\tvalue\tdate"
+	cookiesFileSession = "-1" // date of a cookie without expiry
+)
+
+// WithCookiesFile persists cookies in a file of the JetBrains HTTP Client format
+// (.idea/httpRequests/http-client.cookies in IDE projects), so sessions established in the IDE are
+// reused by the client and the other way around. The file is read when the option is applied and
+// rewritten whenever a response sets cookies. It replaces the cookie jar of the HTTP client;
+// requests with @no-cookie-jar neither send nor store cookies. A missing file starts empty.
+func WithCookiesFile(path string) ClientOption {
+	return func(c *Client) error {
+		if path == "" {
+			return errors.New("cookies file path must not be empty")
+		}
+		jar, err := newCookiesFileJar(path)
+		if err != nil {
+			return err
+		}
+		c.cookiesFile = jar
+		return nil
+	}
+}
+
+// storedCookie is a line of a cookies file
+type storedCookie struct {
+	domain  string
+	path    string
+	name    string
+	value   string
+	expires time.Time // zero for session cookies
+}
+
+// key identifies a cookie the way browsers do: by domain, path and name
+func (s storedCookie) key() string {
+	return s.domain + "\t" + s.path + "\t" + s.name
+}
+
+// cookiesFileJar is an http.CookieJar that mirrors the cookies it stores into a cookies file
+type cookiesFileJar struct {
+	mu      sync.Mutex
+	path    string
+	jar     *cookiejar.Jar
+	cookies map[string]storedCookie
+}
+
+// newCookiesFileJar reads a cookies file into a new jar; expired cookies are dropped
+func newCookiesFileJar(path string) (*cookiesFileJar, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	fileJar := &cookiesFileJar{path: path, jar: jar, cookies: make(map[string]storedCookie)}
+	stored, err := readCookiesFile(path)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, cookie := range stored {
+		if !cookie.expires.IsZero() && !cookie.expires.After(now) {
+			continue
+		}
+		fileJar.cookies[cookie.key()] = cookie
+		jar.SetCookies(&url.URL{Scheme: "http", Host: cookie.domain, Path: cookie.path}, []*http.Cookie{{
+			Name: cookie.name, Value: cookie.value, Path: cookie.path, Expires: cookie.expires,
+		}})
+	}
+	return fileJar, nil
+}
+
+// Cookies implements http.CookieJar
+func (j *cookiesFileJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// SetCookies implements http.CookieJar, rewriting the cookies file. A failure to write the file is
+// logged and does not fail the request.
+func (j *cookiesFileJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	for _, cookie := range cookies {
+		stored := storedCookie{
+			domain:  strings.TrimPrefix(strings.ToLower(cookie.Domain), "."),
+			path:    cookie.Path,
+			name:    cookie.Name,
+			value:   cookie.Value,
+			expires: cookie.Expires,
+		}
+		if stored.domain == "" {
+			stored.domain = strings.ToLower(u.Hostname())
+		}
+		if stored.path == "" || !strings.HasPrefix(stored.path, "/") {
+			stored.path = defaultCookiePath(u.Path)
+		}
+		switch {
+		case cookie.MaxAge < 0:
+			stored.expires = now
+		case cookie.MaxAge > 0:
+			stored.expires = now.Add(time.Duration(cookie.MaxAge) * time.Second)
+		}
+		if !stored.expires.IsZero() && !stored.expires.After(now) {
+			delete(j.cookies, stored.key())
+			continue
+		}
+		j.cookies[stored.key()] = stored
+	}
+	if err := writeCookiesFile(j.path, j.cookies); err != nil {
+		slog.Warn("Failed to write cookies file", "error", err, "file", j.path)
+	}
+}
+
+// defaultCookiePath is the default path of a cookie set by a response to requestPath (RFC 6265 5.1.4)
+func defaultCookiePath(requestPath string) string {
+	if requestPath == "" || requestPath[0] != '/' {
+		return "/"
+	}
+	if i := strings.LastIndex(requestPath, "/"); i > 0 {
+		return requestPath[:i]
+	}
+	return "/"
+}
+
+// readCookiesFile parses the tab separated lines of a cookies file; comment lines start with "#"
+func readCookiesFile(path string) ([]storedCookie, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cookies file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var cookies []storedCookie
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("cookies file %s line %d: expected domain, path, name, value and date "+
+				"separated by tabs", path, lineNumber)
+		}
+		cookie := storedCookie{domain: fields[0], path: fields[1], name: fields[2], value: fields[3]}
+		if len(fields) > 4 && fields[4] != "" && fields[4] != cookiesFileSession {
+			if cookie.expires, err = http.ParseTime(fields[4]); err != nil {
+				return nil, fmt.Errorf("cookies file %s line %d: invalid date '%s'", path, lineNumber, fields[4])
+			}
+		}
+		cookies = append(cookies, cookie)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cookies file: %w", err)
+	}
+	return cookies, nil
+}
+
+// writeCookiesFile replaces the cookies file, sorted by domain, path and name
+func writeCookiesFile(path string, cookies map[string]storedCookie) error {
+	keys := sortedKeys(cookies)
+	var sb strings.Builder
+	sb.WriteString(cookiesFileHeader + "\n")
+	for _, key := range keys {
+		cookie := cookies[key]
+		date := cookiesFileSession
+		if !cookie.expires.IsZero() {
+			date = cookie.expires.UTC().Format(http.TimeFormat)
+		}
+		fmt.Fprintf(&sb, "%s\t%s\t%s\t%s\t%s\n", cookie.domain, cookie.path, cookie.name, cookie.value, date)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), cookiesFileDirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sb.String()), cookiesFileFilePerm)
+}
@@ -0,0 +1,452 @@
+package restclient
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// curlPrefix starts a request written as a curl command instead of a request line
+const curlPrefix = "curl "
+
+// curlFormBoundary separates the parts of the multipart/form-data body of -F options
+const curlFormBoundary = "CurlFormBoundary"
+
+// errCurlIncomplete reports a curl command with an open quote or a trailing backslash, which continues
+// on the next line
+var errCurlIncomplete = errors.New("unterminated curl command")
+
+// curlIgnoredFlags are the curl options without a value that do not change the request sent
+var curlIgnoredFlags = map[string]bool{
+	"-s": true, "--silent": true, "-S": true, "--show-error": true, "-v": true, "--verbose": true,
+	"-i": true, "--include": true, "-L": true, "--location": true, "-k": true, "--insecure": true,
+	"-f": true, "--fail": true, "-N": true, "--no-buffer": true, "-g": true, "--globoff": true,
+	"--compressed": true,
+}
+
+// curlIgnoredOptions are the curl options with a value that do not change the request sent
+var curlIgnoredOptions = map[string]bool{
+	"-o": true, "--output": true, "-w": true, "--write-out": true, "-c": true, "--cookie-jar": true,
+	"--connect-timeout": true, "--retry": true,
+}
+
+// curlValueOptions are the short curl options taking a value, which may be attached to them ("-XPOST")
+const curlValueOptions = "XHdFuAebmxowc"
+
+// curlCommand is the request described by the arguments of a curl command
+type curlCommand struct {
+	method   string
+	url      string
+	headers  http.Header
+	data     []string // values of the -d options, sent joined with "&"
+	dataFile string   // file of a "-d @file" option, sent as the body
+	form     []string // values of the -F options
+	get      bool     // -G: the data is sent in the query string
+	head     bool     // -I: HEAD request
+	timeout  time.Duration
+	proxy    string
+}
+
+// handleCurlLine parses requests written as curl commands, e.g. copied from the developer tools of a
+// browser, in place of the request line. The command may continue over several lines, with trailing
+// backslashes or quotes spanning lines.
+func (p *requestParserState) handleCurlLine(line string) (bool, error) {
+	if p.curlLines == nil {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, curlPrefix) || (p.currentRequest != nil && p.currentRequest.Method != "") {
+			return false, nil
+		}
+		line = trimmed
+	}
+	p.curlLines = append(p.curlLines, line)
+	args, err := splitCurlArgs(strings.Join(p.curlLines, "\n"))
+	if errors.Is(err, errCurlIncomplete) {
+		return true, nil
+	}
+	p.curlLines = nil
+	if err == nil {
+		err = p.applyCurlCommand(args)
+	}
+	if err != nil {
+		return true, fmt.Errorf("line %d: invalid curl command: %w", p.lineNumber, err)
+	}
+	return true, nil
+}
+
+// applyCurlCommand sets the method, URL, headers and body of the current request from a curl command
+func (p *requestParserState) applyCurlCommand(args []string) error {
+	cmd, err := parseCurlCommand(args)
+	if err != nil {
+		return err
+	}
+	p.ensureCurrentRequest()
+	req := p.currentRequest
+	req.Method = cmd.requestMethod()
+	p.parseURLAndVersion([]string{req.Method, cmd.requestURL()})
+	for name, values := range cmd.headers {
+		for _, value := range values {
+			req.Headers.Add(name, value)
+		}
+	}
+	req.Timeout, req.Proxy = cmd.timeout, cmd.proxy
+
+	switch {
+	case len(cmd.form) > 0:
+		body, err := curlFormBody(cmd.form)
+		if err != nil {
+			return err
+		}
+		req.Headers.Set("Content-Type", "multipart/form-data; boundary="+curlFormBoundary)
+		p.bodyLines = strings.Split(body, "\n")
+	case cmd.get:
+		// The data is sent in the query string
+	case cmd.dataFile != "":
+		req.Headers.Set("Content-Type", cmd.contentType())
+		p.handleExternalFileReference("< " + cmd.dataFile)
+	case len(cmd.data) > 0:
+		req.Headers.Set("Content-Type", cmd.contentType())
+		p.bodyLines = strings.Split(strings.Join(cmd.data, "&"), "\n")
+	}
+	return nil
+}
+
+// parseCurlCommand reads the request described by the arguments of a curl command, the first being "curl"
+func parseCurlCommand(args []string) (*curlCommand, error) {
+	cmd := &curlCommand{headers: make(http.Header)}
+	args = expandCurlShortOptions(args[1:])
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			if cmd.url != "" {
+				return nil, fmt.Errorf("unexpected argument %q", arg)
+			}
+			cmd.url = arg
+			continue
+		}
+		if cmd.applyFlag(arg) {
+			continue
+		}
+		if i+1 == len(args) {
+			return nil, fmt.Errorf("option %s requires a value", arg)
+		}
+		i++
+		if err := cmd.applyOption(arg, args[i]); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.url == "" {
+		return nil, errors.New("missing URL")
+	}
+	if cmd.dataFile != "" && len(cmd.data) > 0 {
+		return nil, errors.New("a data file cannot be combined with other data")
+	}
+	if len(cmd.form) > 0 && (len(cmd.data) > 0 || cmd.dataFile != "") {
+		return nil, errors.New("form fields cannot be combined with data")
+	}
+	return cmd, nil
+}
+
+// expandCurlShortOptions splits combined short flags ("-sSL") and short options with their value
+// attached ("-XPOST") into separate arguments
+func expandCurlShortOptions(args []string) []string {
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args {
+		if len(arg) <= 2 || arg[0] != '-' || arg[1] == '-' {
+			expanded = append(expanded, arg)
+			continue
+		}
+		for i := 1; i < len(arg); i++ {
+			expanded = append(expanded, "-"+arg[i:i+1])
+			if strings.IndexByte(curlValueOptions, arg[i]) >= 0 {
+				if i+1 < len(arg) {
+					expanded = append(expanded, arg[i+1:])
+				}
+				break
+			}
+		}
+	}
+	return expanded
+}
+
+// applyFlag applies an option without a value, reporting whether arg is one
+func (cmd *curlCommand) applyFlag(arg string) bool {
+	switch arg {
+	case "-G", "--get":
+		cmd.get = true
+	case "-I", "--head":
+		cmd.head = true
+	default:
+		return curlIgnoredFlags[arg]
+	}
+	return true
+}
+
+// applyOption applies an option with its value
+func (cmd *curlCommand) applyOption(name, value string) error {
+	switch name {
+	case "-X", "--request":
+		cmd.method = strings.ToUpper(value)
+	case "-H", "--header":
+		key, headerValue, ok := strings.Cut(value, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid header %q", value)
+		}
+		cmd.headers.Add(strings.TrimSpace(key), strings.TrimSpace(headerValue))
+	case "-d", "--data", "--data-ascii", "--data-binary":
+		return cmd.addData(value)
+	case "--data-raw":
+		cmd.data = append(cmd.data, value)
+	case "--data-urlencode":
+		cmd.data = append(cmd.data, curlURLEncode(value))
+	case "--json":
+		cmd.setDefaultHeader("Content-Type", "application/json")
+		cmd.setDefaultHeader("Accept", "application/json")
+		return cmd.addData(value)
+	case "-F", "--form":
+		cmd.form = append(cmd.form, value)
+	case "-u", "--user":
+		cmd.headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(value)))
+	case "-A", "--user-agent":
+		cmd.headers.Set("User-Agent", value)
+	case "-e", "--referer":
+		cmd.headers.Set("Referer", value)
+	case "-b", "--cookie":
+		if !strings.Contains(value, "=") {
+			return fmt.Errorf("cookie file %q is not supported", value)
+		}
+		cmd.headers.Add("Cookie", value)
+	default:
+		return cmd.applyTransportOption(name, value)
+	}
+	return nil
+}
+
+// applyTransportOption applies the options of the URL, timeout and proxy, ignoring the options that do
+// not change the request sent
+func (cmd *curlCommand) applyTransportOption(name, value string) error {
+	switch name {
+	case "--url":
+		if cmd.url != "" {
+			return fmt.Errorf("unexpected URL %q", value)
+		}
+		cmd.url = value
+	case "-m", "--max-time":
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds <= 0 {
+			return fmt.Errorf("invalid %s value %q", name, value)
+		}
+		cmd.timeout = time.Duration(seconds * float64(time.Second))
+	case "-x", "--proxy":
+		cmd.proxy = value
+	default:
+		if !curlIgnoredOptions[name] {
+			return fmt.Errorf("unsupported option %s", name)
+		}
+	}
+	return nil
+}
+
+// addData adds the value of a -d option, sending the file of "@file" values as the body
+func (cmd *curlCommand) addData(value string) error {
+	if path, ok := strings.CutPrefix(value, "@"); ok {
+		if cmd.dataFile != "" {
+			return errors.New("only one data file is supported")
+		}
+		cmd.dataFile = path
+		return nil
+	}
+	cmd.data = append(cmd.data, value)
+	return nil
+}
+
+// setDefaultHeader sets a header unless the command sets it
+func (cmd *curlCommand) setDefaultHeader(name, value string) {
+	if cmd.headers.Get(name) == "" {
+		cmd.headers.Set(name, value)
+	}
+}
+
+// contentType returns the Content-Type of the data: like curl, a form unless the command sets one
+func (cmd *curlCommand) contentType() string {
+	if contentType := cmd.headers.Get("Content-Type"); contentType != "" {
+		return contentType
+	}
+	return "application/x-www-form-urlencoded"
+}
+
+// requestMethod returns the method of -X, else HEAD for -I, POST for data without -G, and GET
+func (cmd *curlCommand) requestMethod() string {
+	switch {
+	case cmd.method != "":
+		return cmd.method
+	case cmd.head:
+		return http.MethodHead
+	case !cmd.get && (len(cmd.data) > 0 || cmd.dataFile != "" || len(cmd.form) > 0):
+		return http.MethodPost
+	}
+	return http.MethodGet
+}
+
+// requestURL returns the URL of the command, with a scheme, and with the data in its query string for -G
+func (cmd *curlCommand) requestURL() string {
+	requestURL := cmd.url
+	if !strings.Contains(requestURL, "://") && !strings.HasPrefix(requestURL, "{{") {
+		requestURL = "http://" + requestURL
+	}
+	if !cmd.get || len(cmd.data) == 0 {
+		return requestURL
+	}
+	separator := "?"
+	if strings.Contains(requestURL, "?") {
+		separator = "&"
+	}
+	return requestURL + separator + strings.Join(cmd.data, "&")
+}
+
+// curlURLEncode encodes the value of a --data-urlencode option: "content", "=content" or "name=content"
+func curlURLEncode(value string) string {
+	name, content, ok := strings.Cut(value, "=")
+	if !ok {
+		return url.QueryEscape(value)
+	}
+	if name == "" {
+		return url.QueryEscape(content)
+	}
+	return name + "=" + url.QueryEscape(content)
+}
+
+// curlFormBody writes the -F fields as a multipart/form-data body: "name=value" fields, and
+// "name=@path" files with optional ";type=" and ";filename=" attributes, sent as `< path` parts
+func curlFormBody(fields []string) (string, error) {
+	var body strings.Builder
+	for _, field := range fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return "", fmt.Errorf("invalid form field %q", field)
+		}
+		body.WriteString("--" + curlFormBoundary + "\n")
+		path, isFile := strings.CutPrefix(value, "@")
+		if !isFile {
+			if strings.HasPrefix(value, "<") {
+				return "", fmt.Errorf("form field %q: field values read from files are not supported", name)
+			}
+			fmt.Fprintf(&body, "Content-Disposition: form-data; name=%q\n\n%s\n", name, value)
+			continue
+		}
+		attributes := strings.Split(path, ";")
+		disposition := fmt.Sprintf("form-data; name=%q", name)
+		var contentType string
+		for _, attribute := range attributes[1:] {
+			key, attributeValue, _ := strings.Cut(attribute, "=")
+			switch strings.TrimSpace(key) {
+			case "type":
+				contentType = attributeValue
+			case "filename":
+				disposition += fmt.Sprintf("; filename=%q", attributeValue)
+			}
+		}
+		body.WriteString("Content-Disposition: " + disposition + "\n")
+		if contentType != "" {
+			body.WriteString("Content-Type: " + contentType + "\n")
+		}
+		body.WriteString("\n< " + attributes[0] + "\n")
+	}
+	body.WriteString("--" + curlFormBoundary + "--")
+	return body.String(), nil
+}
+
+// splitCurlArgs splits a curl command into its arguments like a POSIX shell: with single, double and
+// $'...' quotes, backslash escapes and backslash-newline line continuations
+func splitCurlArgs(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	for i := 0; i < len(command); i++ {
+		switch c := command[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+			continue
+		case c == '\\':
+			if i+1 == len(command) {
+				return nil, errCurlIncomplete
+			}
+			i++
+			if command[i] == '\n' {
+				continue
+			}
+			arg.WriteByte(command[i])
+		case c == '\'':
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end < 0 {
+				return nil, errCurlIncomplete
+			}
+			arg.WriteString(command[i+1 : i+1+end])
+			i += end + 1
+		case c == '"' || (c == '$' && strings.HasPrefix(command[i+1:], "'")):
+			n, err := readCurlQuoted(command[i:], &arg)
+			if err != nil {
+				return nil, err
+			}
+			i += n - 1
+		default:
+			arg.WriteByte(c)
+		}
+		inArg = true
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// curlANSIEscapes are the escapes of $'...' quotes
+var curlANSIEscapes = map[byte]byte{'n': '\n', 't': '\t', 'r': '\r', '\\': '\\', '\'': '\'', '"': '"'}
+
+// readCurlQuoted writes the content of the double-quoted or $'...' quoted string s starts with to arg,
+// returning the length of the quoted string
+func readCurlQuoted(s string, arg *strings.Builder) (int, error) {
+	quote, start := byte('"'), 1
+	if s[0] == '$' {
+		quote, start = '\'', 2
+	}
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		if c == quote {
+			return i + 1, nil
+		}
+		if c != '\\' || i+1 == len(s) {
+			arg.WriteByte(c)
+			continue
+		}
+		next := s[i+1]
+		switch {
+		case quote == '"' && strings.IndexByte("\"\\$`", next) >= 0:
+			arg.WriteByte(next)
+		case quote == '"' && next == '\n':
+		case quote == '\'' && curlANSIEscapes[next] != 0:
+			arg.WriteByte(curlANSIEscapes[next])
+		default:
+			arg.WriteByte(c)
+			continue
+		}
+		i++
+	}
+	return 0, errCurlIncomplete
+}
+
+// warnUnterminatedCurl reports a curl command still open at the end of the file, which is not sent
+func (p *requestParserState) warnUnterminatedCurl() {
+	if p.curlLines != nil {
+		slog.Warn("Unterminated curl command: missing closing quote or continued last line", "filePath", p.filePath)
+	}
+}
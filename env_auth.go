@@ -0,0 +1,112 @@
+package restclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// environmentAuth holds the configurations of the Security.Auth section of an environment, by id, and
+// the client certificate of its SSLConfiguration section
+type environmentAuth struct {
+	oauth1     map[string]*OAuth1Config
+	oauth2     map[string]*OAuth2Config
+	clientCert *clientCertificate
+}
+
+// loadEnvironmentAuth reads the Security.Auth and SSLConfiguration sections of the selected environment
+// from http-client.env.json and http-client.private.env.json; entries of the private file replace those
+// of the public one. Entries of unknown types are ignored.
+func loadEnvironmentAuth(fileDir, selectedEnvName string) (*environmentAuth, error) {
+	auth := &environmentAuth{
+		oauth1: make(map[string]*OAuth1Config),
+		oauth2: make(map[string]*OAuth2Config),
+	}
+	for _, name := range []string{"http-client.env.json", "http-client.private.env.json"} {
+		content, err := os.ReadFile(filepath.Join(fileDir, name))
+		if err != nil {
+			continue
+		}
+		var environments map[string]struct {
+			Security struct {
+				Auth map[string]json.RawMessage `json:"Auth"`
+			} `json:"Security"`
+			SSLConfiguration *envSSLConfiguration `json:"SSLConfiguration"`
+		}
+		if err := json.Unmarshal(normalizeLineEndings(content), &environments); err != nil {
+			continue
+		}
+		environment := environments[selectedEnvName]
+		for id, raw := range environment.Security.Auth {
+			if err := auth.add(id, raw, fileDir); err != nil {
+				return nil, fmt.Errorf("%s: Security.Auth '%s': %w", name, id, err)
+			}
+		}
+		if environment.SSLConfiguration != nil {
+			if auth.clientCert, err = environment.SSLConfiguration.config(fileDir); err != nil {
+				return nil, fmt.Errorf("%s: SSLConfiguration: %w", name, err)
+			}
+		}
+	}
+	return auth, nil
+}
+
+// add parses an entry of the Security.Auth section according to its Type
+func (a *environmentAuth) add(id string, raw json.RawMessage, fileDir string) error {
+	var header struct {
+		Type string `json:"Type"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil
+	}
+	switch strings.ToLower(header.Type) {
+	case OAuth1AuthProvider:
+		var entry envOAuth1Config
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		config, err := entry.config(fileDir)
+		if err != nil {
+			return err
+		}
+		delete(a.oauth2, id)
+		a.oauth1[id] = config
+	case OAuth2AuthProvider:
+		var entry envOAuth2Config
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		config, err := entry.config()
+		if err != nil {
+			return err
+		}
+		delete(a.oauth1, id)
+		a.oauth2[id] = config
+	}
+	return nil
+}
+
+// apply attaches the configurations to the requests selecting them with "# @auth oauth1 <id>" or
+// "# @auth oauth2 <id>", the OAuth2 ones to every request for {{$auth.token("id")}} placeholders, and
+// the client certificate to every request
+func (a *environmentAuth) apply(requests []*Request) {
+	for _, req := range requests {
+		req.authConfigs = a.oauth2
+		req.clientCert = a.clientCert
+		if len(req.AuthArgs) != 1 {
+			continue
+		}
+		switch req.AuthProvider {
+		case OAuth1AuthProvider:
+			if config, ok := a.oauth1[req.AuthArgs[0]]; ok {
+				req.OAuth1 = config
+			}
+		case OAuth2AuthProvider:
+			if config, ok := a.oauth2[req.AuthArgs[0]]; ok {
+				req.OAuth2 = config
+			}
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// defaultComparedHeadersIgnored change between any two responses and are not compared by
+// CompareEnvironments
+var defaultComparedHeadersIgnored = []string{"Date", "Age", "Expires", "Last-Modified", "Content-Length"}
+
+// DifferenceKind names the aspect of a response that differs between environments
+type DifferenceKind string
+
+// Difference kinds reported by CompareEnvironments
+const (
+	DifferenceStatus  DifferenceKind = "status"
+	DifferenceError   DifferenceKind = "error"
+	DifferenceHeader  DifferenceKind = "header"
+	DifferenceBody    DifferenceKind = "body"
+	DifferenceMissing DifferenceKind = "missing" // the request has a response in one environment only
+)
+
+// Difference is a single difference between the responses of a request in two environments.
+// First and Second hold the values of each environment; for DifferenceMissing, the status of the
+// response received, or "no status" if it could not be sent, and "" for the environment without one.
+type Difference struct {
+	Kind   DifferenceKind
+	Header string // canonical header name, for DifferenceHeader
+	First  string
+	Second string
+	Diff   string // unified diff of the normalized bodies, for DifferenceBody
+}
+
+// RequestComparison compares the responses of one request of the file in both environments
+type RequestComparison struct {
+	Name        string
+	Method      string
+	URL         string    // URL as written in the file, before variable substitution
+	First       *Response // nil if the request has no response in the first environment
+	Second      *Response // nil if the request has no response in the second environment
+	Differences []Difference
+}
+
+// EnvironmentComparison is the result of CompareEnvironments, with one RequestComparison per request
+// in the order of the file
+type EnvironmentComparison struct {
+	First    string // name of the first environment
+	Second   string // name of the second environment
+	Requests []RequestComparison
+}
+
+// Differing returns the requests whose responses differ.
+func (c *EnvironmentComparison) Differing() []RequestComparison {
+	var differing []RequestComparison
+	for _, req := range c.Requests {
+		if len(req.Differences) > 0 {
+			differing = append(differing, req)
+		}
+	}
+	return differing
+}
+
+// Equal reports whether every request got equivalent responses in both environments.
+func (c *EnvironmentComparison) Equal() bool {
+	return len(c.Differing()) == 0
+}
+
+// compareConfig holds the settings applied by CompareOptions
+type compareConfig struct {
+	ignoredHeaders map[string]bool
+}
+
+// CompareOption is a functional option for configuring CompareEnvironments.
+type CompareOption func(*compareConfig)
+
+// IgnoreHeaders excludes headers from CompareEnvironments, in addition to the defaults (Date,
+// Age, Expires, Last-Modified and Content-Length), e.g. request IDs or Set-Cookie.
+func IgnoreHeaders(names ...string) CompareOption {
+	return func(cfg *compareConfig) {
+		for _, name := range names {
+			cfg.ignoredHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// CompareEnvironments executes the file at requestFilePath once in the environment first and once in
+// second, one after the other, and compares the responses of each request, e.g. for parity checks of
+// staging and production before a release. Statuses, failures to send, headers and bodies are
+// compared; JSON bodies are normalized, so formatting and key order do not count as differences.
+//
+// Requests that fail are reported as differences rather than errors; an error is returned only when
+// the file cannot be run at all, e.g. it does not parse. As with WithEnvironment, an environment
+// missing from the http-client.env.json files has no variables.
+func (c *Client) CompareEnvironments(ctx context.Context, requestFilePath, first, second string,
+	options ...CompareOption) (*EnvironmentComparison, error) {
+	if first == "" || second == "" {
+		return nil, errors.New("both environments to compare must be named")
+	}
+	cfg := &compareConfig{ignoredHeaders: make(map[string]bool)}
+	IgnoreHeaders(defaultComparedHeadersIgnored...)(cfg)
+	for _, option := range options {
+		option(cfg)
+	}
+
+	firstResponses, err := c.executeInEnvironment(ctx, requestFilePath, first)
+	if err != nil {
+		return nil, err
+	}
+	secondResponses, err := c.executeInEnvironment(ctx, requestFilePath, second)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &EnvironmentComparison{First: first, Second: second}
+	secondByRequest := make(map[string]*Response, len(secondResponses))
+	for _, resp := range secondResponses {
+		secondByRequest[requestPosition(resp)] = resp
+	}
+	for _, resp := range firstResponses {
+		position := requestPosition(resp)
+		comparison.Requests = append(comparison.Requests, cfg.compareRequest(resp, secondByRequest[position]))
+		delete(secondByRequest, position)
+	}
+	for _, resp := range secondResponses {
+		if _, unmatched := secondByRequest[requestPosition(resp)]; unmatched {
+			comparison.Requests = append(comparison.Requests, cfg.compareRequest(nil, resp))
+		}
+	}
+	return comparison, nil
+}
+
+// executeInEnvironment runs the file in env, keeping the responses of failed requests
+func (c *Client) executeInEnvironment(ctx context.Context, requestFilePath, env string) ([]*Response, error) {
+	opts := RunOptions{Environment: env}
+	responses, err := c.withRunOptions(opts).executeFile(ctx, requestFilePath, opts)
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) && reqErr.Phase == PhaseParse {
+		return nil, fmt.Errorf("environment '%s': %w", env, err)
+	}
+	return responses, nil
+}
+
+// requestPosition identifies the request of a response across runs of the same file by its location
+func requestPosition(resp *Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+	return resp.Request.FilePath + ":" + strconv.Itoa(resp.Request.LineNumber)
+}
+
+// compareRequest compares the responses of a request in both environments; either may be nil
+func (cfg *compareConfig) compareRequest(first, second *Response) RequestComparison {
+	described := first
+	if described == nil {
+		described = second
+	}
+	comparison := RequestComparison{First: first, Second: second}
+	if req := described.Request; req != nil {
+		comparison.Name, comparison.Method, comparison.URL = req.Name, req.Method, req.RawURLString
+	}
+
+	if first == nil || second == nil {
+		missing := Difference{Kind: DifferenceMissing}
+		if first != nil {
+			missing.First = receivedStatus(first)
+		} else {
+			missing.Second = receivedStatus(second)
+		}
+		comparison.Differences = []Difference{missing}
+		return comparison
+	}
+	if first.StatusCode != second.StatusCode {
+		comparison.Differences = append(comparison.Differences, Difference{
+			Kind: DifferenceStatus, First: strconv.Itoa(first.StatusCode), Second: strconv.Itoa(second.StatusCode),
+		})
+	}
+	if firstErr, secondErr := errorText(first.Error), errorText(second.Error); firstErr != secondErr {
+		comparison.Differences = append(comparison.Differences,
+			Difference{Kind: DifferenceError, First: firstErr, Second: secondErr})
+	}
+	comparison.Differences = append(comparison.Differences, cfg.compareHeaders(first.Headers, second.Headers)...)
+	comparison.Differences = append(comparison.Differences, compareResponseBodies(first, second)...)
+	return comparison
+}
+
+// receivedStatus returns the status of resp, or "no status" when it could not be sent
+func receivedStatus(resp *Response) string {
+	if resp.Status == "" {
+		return "no status"
+	}
+	return resp.Status
+}
+
+// errorText returns the message of err, or "" for nil
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// compareHeaders reports each header whose values differ, except the ignored ones
+func (cfg *compareConfig) compareHeaders(first, second http.Header) []Difference {
+	names := make(map[string]bool)
+	for _, headers := range []http.Header{first, second} {
+		for name := range headers {
+			if canonical := http.CanonicalHeaderKey(name); !cfg.ignoredHeaders[canonical] {
+				names[canonical] = true
+			}
+		}
+	}
+	var differences []Difference
+	for _, name := range sortedKeys(names) {
+		firstValue := strings.Join(first.Values(name), ", ")
+		secondValue := strings.Join(second.Values(name), ", ")
+		if firstValue != secondValue {
+			differences = append(differences,
+				Difference{Kind: DifferenceHeader, Header: name, First: firstValue, Second: secondValue})
+		}
+	}
+	return differences
+}
+
+// compareResponseBodies reports different bodies with a unified diff of the normalized bodies
+func compareResponseBodies(first, second *Response) []Difference {
+	firstBody, secondBody := normalizeComparedBody(first.BodyString), normalizeComparedBody(second.BodyString)
+	if firstBody == secondBody {
+		return nil
+	}
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(firstBody + "\n"),
+		B:        difflib.SplitLines(secondBody + "\n"),
+		FromFile: "first",
+		ToFile:   "second",
+		Context:  3,
+	})
+	return []Difference{{Kind: DifferenceBody, First: first.BodyString, Second: second.BodyString, Diff: diff}}
+}
+
+// normalizeComparedBody pretty-prints JSON bodies with sorted keys; other bodies are compared with
+// line endings and surrounding space normalized
+func normalizeComparedBody(body string) string {
+	var data any
+	if err := json.Unmarshal([]byte(body), &data); err == nil {
+		if normalized, err := json.MarshalIndent(data, "", "  "); err == nil {
+			return string(normalized)
+		}
+	}
+	return strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+}
+
+// String renders the comparison as text, one block per differing request.
+func (c *EnvironmentComparison) String() string {
+	var sb strings.Builder
+	differing := c.Differing()
+	fmt.Fprintf(&sb, "%s vs %s: %d of %d requests differ\n", c.First, c.Second, len(differing), len(c.Requests))
+	for _, req := range differing {
+		label := req.Name
+		if label == "" {
+			label = req.Method + " " + req.URL
+		}
+		fmt.Fprintf(&sb, "%s\n", label)
+		for _, difference := range req.Differences {
+			fmt.Fprintf(&sb, "  %s\n", difference)
+		}
+	}
+	return sb.String()
+}
+
+// String renders a single difference on one line, followed by the body diff if any.
+func (d Difference) String() string {
+	switch d.Kind {
+	case DifferenceMissing:
+		if d.First == "" {
+			return "no response in the first environment"
+		}
+		return "no response in the second environment"
+	case DifferenceHeader:
+		return fmt.Sprintf("header %s: %q vs %q", d.Header, d.First, d.Second)
+	case DifferenceBody:
+		lines := strings.Split(strings.TrimRight(d.Diff, "\n"), "\n")
+		return "body differs:\n    " + strings.Join(lines, "\n    ")
+	}
+	return fmt.Sprintf("%s: %q vs %q", d.Kind, d.First, d.Second)
+}
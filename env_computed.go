@@ -0,0 +1,116 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// rePlaceholder matches any {{...}} placeholder, capturing its trimmed content
+var rePlaceholder = regexp.MustCompile(`{{\s*(.*?)\s*}}`)
+
+// resolveComputedEnvironmentVariables resolves references between the keys of a loaded environment,
+// e.g. "baseUrl": "{{scheme}}://{{host}}:{{port}}" or "nextPort": "{{ port + 1 }}", so that
+// environments can be defined in terms of their own keys. Placeholders naming anything else (system
+// variables, file or programmatic variables) are kept for substitution at request time.
+// Keys referencing each other in a cycle are reported as an error.
+func resolveComputedEnvironmentVariables(env map[string]string) (map[string]string, error) {
+	graph := make(map[string][]string, len(env))
+	names := make([]string, 0, len(env))
+	for name, value := range env {
+		names = append(names, name)
+		for _, match := range rePlaceholder.FindAllStringSubmatch(value, -1) {
+			graph[name] = append(graph[name], environmentPlaceholderRefs(match[1], env)...)
+		}
+	}
+	sort.Strings(names) // deterministic error messages
+
+	finder := &cycleFinder{graph: graph, state: make(map[string]int)}
+	for _, name := range names {
+		if cycle := finder.visit(name, nil); cycle != nil {
+			return nil, fmt.Errorf("circular environment variable reference: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	resolver := &environmentResolver{env: env, resolved: make(map[string]string, len(env))}
+	for _, name := range names {
+		resolver.resolve(name)
+	}
+	return resolver.resolved, nil
+}
+
+// environmentPlaceholderRefs returns the environment keys referenced by the content of a placeholder,
+// either as its variable name or as operands of an expression
+func environmentPlaceholderRefs(directive string, env map[string]string) []string {
+	if strings.HasPrefix(directive, "$") {
+		return nil
+	}
+	if _, defined := env[directive]; defined {
+		return []string{directive}
+	}
+	tokens, ok := tokenizeExpression(directive)
+	if !ok {
+		varName, _, _ := parseVariableDirective(directive)
+		tokens = []exprToken{{kind: exprIdent, value: varName}}
+	}
+	var refs []string
+	for _, token := range tokens {
+		if _, defined := env[token.value]; defined && token.kind == exprIdent {
+			refs = append(refs, token.value)
+		}
+	}
+	return refs
+}
+
+// environmentResolver substitutes environment keys into each other; the keys must be free of cycles
+type environmentResolver struct {
+	env      map[string]string
+	resolved map[string]string
+}
+
+// resolve returns the value of name with references to other environment keys substituted
+func (r *environmentResolver) resolve(name string) string {
+	if value, done := r.resolved[name]; done {
+		return value
+	}
+	value := rePlaceholder.ReplaceAllStringFunc(r.env[name], r.resolvePlaceholder)
+	r.resolved[name] = value
+	return value
+}
+
+// resolvePlaceholder substitutes a placeholder that only references environment keys, keeping others
+func (r *environmentResolver) resolvePlaceholder(match string) string {
+	directive := strings.TrimSpace(match[2 : len(match)-2])
+	if strings.HasPrefix(directive, "$") {
+		return match
+	}
+	if _, defined := r.env[directive]; defined {
+		return r.resolve(directive)
+	}
+	complete := true
+	value, err := evaluateExpression(directive, func(name string) string {
+		if _, defined := r.env[name]; !defined {
+			complete = false
+			return ""
+		}
+		return r.resolve(name)
+	})
+	switch {
+	case errors.Is(err, errNotExpression):
+	case !complete:
+		return match // references variables only known at request time
+	case err != nil:
+		slog.Warn("Cannot evaluate environment variable expression", "placeholder", match, "error", err)
+		return match
+	default:
+		return value
+	}
+	varName, _, _ := parseVariableDirective(directive)
+	if _, defined := r.env[varName]; defined {
+		return r.resolve(varName)
+	}
+	return match
+}
@@ -0,0 +1,32 @@
+package restclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveComputedEnvironmentVariables(t *testing.T) {
+	// Given
+	env := map[string]string{
+		"port":    "80",
+		"next":    "{{ port + 1 }}",
+		"baseUrl": "http://localhost:{{port}}",
+		"token":   "{{userToken | anonymous}}",
+		"id":      "{{$uuid}}",
+	}
+
+	// When
+	resolved, err := resolveComputedEnvironmentVariables(env)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"port":    "80",
+		"next":    "81",
+		"baseUrl": "http://localhost:80",
+		"token":   "{{userToken | anonymous}}",
+		"id":      "{{$uuid}}",
+	}, resolved)
+}
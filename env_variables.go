@@ -0,0 +1,188 @@
+package restclient
+
+import (
+	"log/slog"
+	"os"
+	"regexp"
+)
+
+// envDefaultPattern matches an optional inline default after an environment variable name:
+// either a double-quoted string (group 1) or the remaining bare text (group 2).
+const envDefaultPattern = `(?:\s+(?:"([^"]*)"|([^"}\s][^}]*?)))?\s*}}`
+
+var (
+	reSystemEnvVar       = regexp.MustCompile(`{{\$env\.([A-Za-z_][A-Za-z0-9_]*)` + envDefaultPattern)
+	reDotEnv             = regexp.MustCompile(`{{\s*\$dotenv\s+([a-zA-Z_][a-zA-Z0-9_]*)` + envDefaultPattern)
+	reProcessEnv         = regexp.MustCompile(`{{\s*\$processEnv\s+([a-zA-Z_][a-zA-Z0-9_]*)` + envDefaultPattern)
+	reProcessEnvIndirect = regexp.MustCompile(`{{\s*\$processEnv\s+%([a-zA-Z_][a-zA-Z0-9_]*)` + envDefaultPattern)
+)
+
+// envLookupParts holds the variable name and optional inline default of an environment placeholder
+type envLookupParts struct {
+	name         string
+	defaultValue string
+	hasDefault   bool
+}
+
+// parseEnvLookup extracts the name and inline default from a match of one of the env regexes
+func parseEnvLookup(re *regexp.Regexp, match string) (envLookupParts, bool) {
+	idx := re.FindStringSubmatchIndex(match)
+	if len(idx) != 8 {
+		return envLookupParts{}, false
+	}
+	lookup := envLookupParts{name: match[idx[2]:idx[3]]}
+	switch {
+	case idx[4] >= 0: // quoted default, possibly empty
+		lookup.defaultValue, lookup.hasDefault = match[idx[4]:idx[5]], true
+	case idx[6] >= 0:
+		lookup.defaultValue, lookup.hasDefault = match[idx[6]:idx[7]], true
+	}
+	return lookup, true
+}
+
+// substituteSystemEnvVariables handles {{$env.VAR_NAME}} and {{$env.VAR_NAME default}} placeholders
+func substituteSystemEnvVariables(text string) string {
+	return reSystemEnvVar.ReplaceAllStringFunc(text, func(match string) string {
+		lookup, ok := parseEnvLookup(reSystemEnvVar, match)
+		if !ok {
+			slog.Warn("Failed to parse $env.VAR_NAME, returning original match", "match", match)
+			return match
+		}
+		if val, found := os.LookupEnv(lookup.name); found {
+			return val
+		}
+		return lookup.defaultValue
+	})
+}
+
+// substituteDotEnvVariables handles {{$dotenv VAR}} and {{$dotenv VAR default}} placeholders
+func substituteDotEnvVariables(text string, activeDotEnvVars map[string]string) string {
+	text = reDotEnv.ReplaceAllStringFunc(text, dotEnvReplacer(activeDotEnvVars))
+	text = substituteDotEnvEncoded(text, activeDotEnvVars)
+	return text
+}
+
+// dotEnvReplacer returns a replacement function for dotenv variables
+func dotEnvReplacer(activeDotEnvVars map[string]string) func(string) string {
+	return func(match string) string {
+		lookup, ok := parseEnvLookup(reDotEnv, match)
+		if !ok {
+			slog.Warn("Failed to parse $dotenv, returning original match", "match", match)
+			return match
+		}
+		if val, found := activeDotEnvVars[lookup.name]; found {
+			return val
+		}
+		return lookup.defaultValue
+	}
+}
+
+// substituteDotEnvEncoded handles URL-encoded dotenv variables
+func substituteDotEnvEncoded(text string, activeDotEnvVars map[string]string) string {
+	reDotEnvEncoded := regexp.MustCompile(`%7B%7B\$dotenv\s+([a-zA-Z_][a-zA-Z0-9_]*)%7D%7D`)
+	return reDotEnvEncoded.ReplaceAllStringFunc(text, func(match string) string {
+		parts := reDotEnvEncoded.FindStringSubmatch(match)
+		if len(parts) == 2 {
+			varName := parts[1]
+			if val, ok := activeDotEnvVars[varName]; ok {
+				return val
+			}
+			return ""
+		}
+		slog.Warn("Failed to parse URL-encoded $dotenv, returning original match",
+			"match", match, "parts_len", len(parts))
+		return match
+	})
+}
+
+// substituteProcessEnvVariables handles {{$processEnv VAR}} and {{$processEnv VAR default}} placeholders
+func substituteProcessEnvVariables(text string) string {
+	text = reProcessEnv.ReplaceAllStringFunc(text, processEnvReplacer())
+	text = substituteProcessEnvEncoded(text)
+	return text
+}
+
+// substituteProcessEnvIndirect handles {{$processEnv %VAR}} placeholders
+func substituteProcessEnvIndirect(text string, programmaticVars map[string]any) string {
+	return reProcessEnvIndirect.ReplaceAllStringFunc(text, func(match string) string {
+		return processIndirectEnvMatch(match, programmaticVars)
+	})
+}
+
+// processIndirectEnvMatch processes a single indirect environment variable match
+func processIndirectEnvMatch(match string, programmaticVars map[string]any) string {
+	lookup, ok := parseEnvLookup(reProcessEnvIndirect, match)
+	if !ok {
+		slog.Warn("Failed to parse $processEnv indirect, returning original match", "match", match)
+		return match
+	}
+
+	envVarName := resolveIndirectVarName(lookup.name, programmaticVars)
+	if envVarName == "" {
+		if lookup.hasDefault {
+			return lookup.defaultValue
+		}
+		return match // Variable not found, return original match
+	}
+
+	if envVal, found := os.LookupEnv(envVarName); found {
+		return envVal
+	}
+	return lookup.defaultValue // Environment variable doesn't exist, use default (empty if none)
+}
+
+// resolveIndirectVarName resolves the variable name from programmaticVars
+func resolveIndirectVarName(varName string, programmaticVars map[string]any) string {
+	if programmaticVars == nil {
+		return ""
+	}
+
+	val, ok := programmaticVars[varName]
+	if !ok {
+		return ""
+	}
+
+	envVarName, ok := val.(string)
+	if !ok {
+		return ""
+	}
+
+	return envVarName
+}
+
+// processEnvReplacer returns a replacement function for process env variables.
+// Unset variables without a default are left as placeholders.
+func processEnvReplacer() func(string) string {
+	return func(match string) string {
+		lookup, ok := parseEnvLookup(reProcessEnv, match)
+		if !ok {
+			slog.Warn("Failed to parse $processEnv, returning original match", "match", match)
+			return match
+		}
+		if val, found := os.LookupEnv(lookup.name); found {
+			return val
+		}
+		if lookup.hasDefault {
+			return lookup.defaultValue
+		}
+		return match
+	}
+}
+
+// substituteProcessEnvEncoded handles URL-encoded process env variables
+func substituteProcessEnvEncoded(text string) string {
+	reProcessEnvEncoded := regexp.MustCompile(`%7B%7B\$processEnv\s+([A-Za-z_][A-Za-z0-9_]*)%7D%7D`)
+	return reProcessEnvEncoded.ReplaceAllStringFunc(text, func(match string) string {
+		parts := reProcessEnvEncoded.FindStringSubmatch(match)
+		if len(parts) == 2 {
+			varName := parts[1]
+			if val, ok := os.LookupEnv(varName); ok {
+				return val
+			}
+			return match
+		}
+		slog.Warn("Failed to parse URL-encoded $processEnv, returning original match",
+			"match", match, "parts_len", len(parts))
+		return match
+	})
+}
@@ -0,0 +1,75 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// eventualConsistency holds the retry window configured with WithEventualConsistency
+type eventualConsistency struct {
+	maxWait  time.Duration
+	interval time.Duration
+}
+
+// WithEventualConsistency makes ValidateResponses retry responses that do not match their expectation,
+// for eventually-consistent endpoints: the request is sent again after interval, with the delay doubling
+// after each attempt, until the response matches or maxWait has passed since validation started.
+// Validation fails only if no matching response arrives within maxWait. A retried response replaces
+// the one passed to ValidateResponses in place, so callers see the latest one.
+func WithEventualConsistency(maxWait, interval time.Duration) ClientOption {
+	return func(c *Client) error {
+		if maxWait <= 0 || interval <= 0 {
+			return errors.New("eventual consistency maxWait and interval must be positive")
+		}
+		c.eventualConsistency = &eventualConsistency{maxWait: maxWait, interval: interval}
+		return nil
+	}
+}
+
+// validateEventually validates actual against expected. With WithEventualConsistency, a mismatch is
+// retried by sending actual's request again until it matches or the window has passed.
+func (c *Client) validateEventually(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse) *multierror.Error {
+	errs := c.validateSingleResponse(responseFilePath, responseIndex, actual, expected, nil)
+	if errs == nil || c.eventualConsistency == nil || actual.Request == nil || actual.Request.URL == nil {
+		return errs
+	}
+
+	deadline := time.Now().Add(c.eventualConsistency.maxWait)
+	delay := c.eventualConsistency.interval
+	for attempt := 2; time.Now().Before(deadline); attempt++ {
+		time.Sleep(min(delay, time.Until(deadline)))
+		delay *= 2
+
+		*actual = *c.resendRequest(context.Background(), actual.Request)
+		if errs = c.validateSingleResponse(responseFilePath, responseIndex, actual, expected, nil); errs == nil {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return multierror.Append(errs, fmt.Errorf(
+				"validation for response #%d ('%s'): no matching response after %d attempts within %s",
+				responseIndex, responseFilePath, attempt, c.eventualConsistency.maxWait))
+		}
+	}
+	return errs
+}
+
+// resendRequest sends an already substituted request again, rewinding its body
+func (c *Client) resendRequest(ctx context.Context, rcRequest *Request) *Response {
+	if rcRequest.GetBody != nil {
+		body, err := rcRequest.GetBody()
+		if err != nil {
+			return &Response{Request: rcRequest, Error: fmt.Errorf("failed to rewind request body: %w", err)}
+		}
+		rcRequest.Body = body
+	}
+	resp, err := c.executeRequest(ctx, rcRequest)
+	if err != nil {
+		return &Response{Request: rcRequest, Error: err}
+	}
+	return resp
+}
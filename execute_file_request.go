@@ -0,0 +1,73 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ExecuteFileRequest runs the single request of a file named name, by a "# @name" directive or its
+// "### name" separator, instead of the whole file, e.g. one block of a large .http file shared by a
+// test suite. Other requests are not sent, so their responses cannot be referenced and their @capture
+// variables are not set. The name must belong to exactly one request of the file.
+//
+// Failures are reported like those of ExecuteFile: the response carries them and the error is an
+// aggregate whose entries are RequestErrors.
+func (c *Client) ExecuteFileRequest(ctx context.Context, requestFilePath, name string) (*Response, error) {
+	responses, err := c.executeFileRequests(ctx, requestFilePath, []string{name}, true)
+	if len(responses) == 0 {
+		return nil, err
+	}
+	return responses[0], err
+}
+
+// ExecuteFileRequests runs only the requests of a file with one of the given names, in the order of
+// the file, like ExecuteFileRequest. Every name must belong to at least one request; requests sharing
+// a name are all sent.
+func (c *Client) ExecuteFileRequests(ctx context.Context, requestFilePath string,
+	names ...string) ([]*Response, error) {
+	return c.executeFileRequests(ctx, requestFilePath, names, false)
+}
+
+// executeFileRequests runs the requests named names, failing before any request is sent when a name
+// is not found or, with unique, is shared by several requests
+func (c *Client) executeFileRequests(ctx context.Context, requestFilePath string, names []string,
+	unique bool) ([]*Response, error) {
+	if len(names) == 0 {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: errors.New("no request names given")}
+	}
+	parsedFile, err := c.parseAndValidateFile(requestFilePath)
+	if err == nil {
+		err = checkRequestNames(parsedFile.Requests, names, unique)
+	}
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	inherited, err := c.withWorkspaceDefaults(requestFilePath, parsedFile.Requests)
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	return inherited.executeParsedFile(ctx, requestFilePath, parsedFile, RunOptions{Names: names})
+}
+
+// checkRequestNames verifies that every name belongs to a request, and with unique to only one
+func checkRequestNames(requests []*Request, names []string, unique bool) error {
+	counts := make(map[string]int, len(requests))
+	for _, req := range requests {
+		counts[req.Name]++
+	}
+	var errs *multierror.Error
+	for _, name := range names {
+		switch {
+		case name == "":
+			errs = multierror.Append(errs, errors.New("request name must not be empty"))
+		case counts[name] == 0:
+			errs = multierror.Append(errs, fmt.Errorf("no request named %q", name))
+		case unique && counts[name] > 1:
+			errs = multierror.Append(errs, fmt.Errorf("request name %q is used by %d requests", name, counts[name]))
+		}
+	}
+	return errs.ErrorOrNil()
+}
@@ -0,0 +1,75 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http/cookiejar"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// FileResult holds the outcome of one file of ExecuteFiles
+type FileResult struct {
+	Path      string
+	Responses []*Response
+	Err       error // the error ExecuteFile would return for the file; see RequestErrors
+}
+
+// ExecuteFiles runs the request files at paths in order as one scenario, e.g. provision, test and
+// cleanup, and returns their results in the same order. The files share the state a run builds up:
+// variables captured with "# @capture" in one file are available in the files that follow, cookies
+// set by a response are sent by later files (in a cookie jar of the scenario when the HTTP client has
+// none), and OAuth2 tokens are reused as in any run of the client.
+//
+// A failing file does not stop the scenario, so cleanup files still run. The returned error lists the
+// errors of all files, each prefixed with its path; FileResult.Err holds them per file.
+func (c *Client) ExecuteFiles(ctx context.Context, paths ...string) ([]FileResult, error) {
+	scenario := c.withScenarioCookieJar()
+	var globals map[string]string
+	var multiErr *multierror.Error
+	results := make([]FileResult, 0, len(paths))
+	for _, path := range paths {
+		responses, err := scenario.executeFileWithGlobals(ctx, path, &globals)
+		results = append(results, FileResult{Path: path, Responses: responses, Err: err})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return results, multiErr.ErrorOrNil()
+}
+
+// withScenarioCookieJar returns a copy of the client whose HTTP client has a fresh cookie jar, unless
+// the client already keeps cookies
+func (c *Client) withScenarioCookieJar() *Client {
+	if c.httpClient.Jar != nil || c.cookiesFile != nil {
+		return c
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return c
+	}
+	scenario := *c
+	httpClient := *c.httpClient
+	httpClient.Jar = jar
+	scenario.httpClient = &httpClient
+	return &scenario
+}
+
+// executeFileWithGlobals runs a file like ExecuteFile, starting with the global variables captured by
+// earlier files and replacing them with those known at its end
+func (c *Client) executeFileWithGlobals(ctx context.Context, requestFilePath string,
+	globals *map[string]string) ([]*Response, error) {
+	parsedFile, err := c.parseAndValidateFile(requestFilePath)
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	inherited, err := c.withWorkspaceDefaults(requestFilePath, parsedFile.Requests)
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	parsedFile.GlobalVariables = maps.Clone(*globals)
+	responses, err := inherited.executeParsedFile(ctx, requestFilePath, parsedFile, RunOptions{})
+	*globals = parsedFile.GlobalVariables
+	return responses, err
+}
@@ -0,0 +1,60 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+)
+
+// ExecuteRequest sends a single request built in code or restored from a recorded run, instead of
+// one parsed from a file by ExecuteFile. When req.URL is nil, RawURLString and the header values are
+// substituted like those of a file: programmatic variables (see WithVars) take precedence over
+// req.ActiveVariables, then come the selected environment (see WithEnvironment) of the
+// http-client.env.json files next to req.FilePath, OS environment variables and system variables.
+// The URL is then resolved against the BaseURL. The body (req.RawBody) is sent verbatim.
+//
+// As with ExecuteFile, failures to send the request are returned in Response.Error and as the error,
+// wrapped in a RequestError; the request is not recorded by WithHistory or WithArtifactsDir.
+func (c *Client) ExecuteRequest(ctx context.Context, req *Request) (*Response, error) {
+	if req == nil {
+		return nil, errors.New("cannot execute a nil request")
+	}
+	parsedFile := &ParsedFile{FilePath: req.FilePath}
+	if req.FilePath != "" {
+		c.loadDotEnvVars(req.FilePath)
+		if c.selectedEnvironmentName != "" {
+			fileDir := filepath.Dir(req.FilePath)
+			envVars, err := loadEnvironmentFiles(fileDir, c.selectedEnvironmentName)
+			if err != nil {
+				return &Response{Request: req, Error: err}, newRequestError(req, 0, PhaseSubstitute, err)
+			}
+			parsedFile.EnvironmentVariables = envVars
+			auth, err := loadEnvironmentAuth(fileDir, c.selectedEnvironmentName)
+			if err != nil {
+				return &Response{Request: req, Error: err}, newRequestError(req, 0, PhaseSubstitute, err)
+			}
+			auth.apply([]*Request{req})
+		}
+	}
+
+	osEnvGetter := c.lookupVariable
+	if req.URL == nil {
+		systemVars := c.generateRequestScopedSystemVariables()
+		if err := c.resolveAuthTokens(ctx, req, systemVars); err != nil {
+			return &Response{Request: req, Error: err}, newRequestError(req, 0, PhaseSubstitute, err)
+		}
+		if err := c.substituteRequestURLAndHeaders(req, parsedFile, systemVars, osEnvGetter); err != nil {
+			return &Response{Request: req, Error: err}, newRequestError(req, 0, PhaseSubstitute, err)
+		}
+	}
+	c.setRequestBody(req, req.RawBody)
+
+	response, err := c.executeRequest(ctx, req)
+	if err != nil {
+		return &Response{Request: req, Error: err}, newRequestError(req, 0, PhaseSend, err)
+	}
+	if response.Error != nil {
+		return response, newRequestError(req, 0, PhaseSend, response.Error)
+	}
+	return response, nil
+}
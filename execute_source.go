@@ -0,0 +1,51 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// sourceFileName names the requests of ExecuteSource and ExecuteReader in errors, history and artifacts,
+// as a file of the directory their relative paths are resolved against
+const sourceFileName = "source.http"
+
+// ExecuteSource runs requests written in .http syntax held in a string, e.g. a Go literal or a file
+// embedded with go:embed, like ExecuteFile runs those of a file. Relative paths, such as imports, external
+// bodies and the http-client.env.json and .env files, are resolved against the working directory.
+func (c *Client) ExecuteSource(ctx context.Context, source string, vars ...Vars) ([]*Response, error) {
+	return c.ExecuteReader(ctx, strings.NewReader(source), "", vars...)
+}
+
+// ExecuteReader runs the requests in .http syntax read from r, like ExecuteFile runs those of a file in
+// baseDir: relative paths, such as imports, external bodies and the http-client.env.json, .env and
+// workspace defaults files, are resolved against baseDir, the working directory when empty. Errors,
+// history and artifacts name the requests after a "source.http" file of baseDir.
+func (c *Client) ExecuteReader(ctx context.Context, r io.Reader, baseDir string, vars ...Vars) ([]*Response, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: fmt.Errorf("failed to read requests: %w", err)}
+	}
+	if baseDir == "" {
+		baseDir = "."
+	}
+	sourcePath := filepath.Join(baseDir, sourceFileName)
+	opts := RunOptions{ExtraVars: mergeVars(vars)}
+	return c.withRunOptions(opts).executeSource(ctx, sourcePath, content, opts)
+}
+
+// executeSource parses the requests of content as the file at sourcePath and runs them
+func (c *Client) executeSource(ctx context.Context, sourcePath string, content []byte,
+	opts RunOptions) ([]*Response, error) {
+	absSourcePath, importStack, err := prepareParsingContext(sourcePath, nil)
+	if err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	parsedFile, err := parseRequestContent(sourcePath, absSourcePath, content, c, importStack)
+	if parsedFile, err = c.validateParsedFile(sourcePath, parsedFile, err); err != nil {
+		return nil, &RequestError{Index: -1, Phase: PhaseParse, Err: err}
+	}
+	return c.executeParsedFileWithDefaults(ctx, sourcePath, parsedFile, opts)
+}
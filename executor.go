@@ -0,0 +1,14 @@
+package restclient
+
+import "context"
+
+// Executor is the subset of Client used to run request files and validate their responses.
+// Applications embedding the library can depend on Executor instead of *Client and substitute
+// a fake (see package restclienttest) in their own unit tests.
+type Executor interface {
+	ExecuteFile(ctx context.Context, requestFilePath string, vars ...Vars) ([]*Response, error)
+	ValidateResponses(responseFilePath string, actualResponses ...*Response) error
+}
+
+// Client implements Executor.
+var _ Executor = (*Client)(nil)
@@ -0,0 +1,353 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Placeholder expressions, e.g. {{ count + 1 }} or {{ env == "prod" ? prodKey : devKey }}.
+//
+// Operands are variables (resolved like {{name}}), numbers, "double" or 'single' quoted strings and
+// true/false. Operators, from lowest to highest precedence: ?:, ||, &&, == !=, < <= > >=, + -, * / %,
+// and the unary ! and -. Values are strings: arithmetic and ordering treat operands as numbers when
+// both parse as numbers, '+' concatenates otherwise; "", "false" and "0" are false, anything else true.
+// A '-' between letters belongs to the name (api-key), so write subtraction with spaces (a - b). A
+// placeholder naming a defined variable is never an expression, so {{foo-1}} reads the variable foo-1.
+
+// exprTokenKind classifies the tokens of a placeholder expression
+type exprTokenKind int
+
+const (
+	exprIdent exprTokenKind = iota
+	exprNumber
+	exprString
+	exprOperator
+)
+
+// exprToken is a single token of a placeholder expression
+type exprToken struct {
+	kind  exprTokenKind
+	value string
+}
+
+// exprOperators lists the operators, two-character ones first so they win over their prefixes
+var exprOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")",
+}
+
+// errNotExpression reports a directive that is not an expression and is resolved as a variable name
+var errNotExpression = errors.New("not an expression")
+
+// evaluatePlaceholderExpression evaluates directive, the content of a {{...}} placeholder, when it is an
+// expression: it tokenizes, contains at least one operator and parses completely. Otherwise it returns
+// errNotExpression, so plain names, fallbacks ({{name | default}}) and system variables keep their meaning.
+func evaluatePlaceholderExpression(directive string, lookup func(name string) string) (string, error) {
+	if lookup(directive) != "" {
+		return "", errNotExpression
+	}
+	return evaluateExpression(directive, lookup)
+}
+
+// evaluateExpression evaluates directive like evaluatePlaceholderExpression, for callers that already
+// checked that directive is not itself the name of a variable
+func evaluateExpression(directive string, lookup func(name string) string) (string, error) {
+	tokens, ok := tokenizeExpression(directive)
+	if !ok || !hasOperatorToken(tokens) {
+		return "", errNotExpression
+	}
+	parser := &exprParser{tokens: tokens, lookup: lookup}
+	value, err := parser.parseTernary()
+	if err != nil {
+		return "", err
+	}
+	if parser.pos != len(tokens) {
+		return "", errNotExpression
+	}
+	return value, nil
+}
+
+// tokenizeExpression splits an expression into tokens; ok is false for text that is not an expression
+func tokenizeExpression(text string) (tokens []exprToken, ok bool) {
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(text[i+1:], c)
+			if end < 0 {
+				return nil, false
+			}
+			tokens = append(tokens, exprToken{kind: exprString, value: text[i+1 : i+1+end]})
+			i += end + 2
+		case isExprDigit(c):
+			start := i
+			for i < len(text) && (isExprDigit(text[i]) || text[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: exprNumber, value: text[start:i]})
+		case isExprIdentStart(c):
+			start := i
+			for i < len(text) && (isExprIdentPart(text[i]) ||
+				(text[i] == '-' && i+1 < len(text) && isExprIdentStart(text[i+1]))) {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: exprIdent, value: text[start:i]})
+		default:
+			op := matchExprOperator(text[i:])
+			if op == "" {
+				return nil, false
+			}
+			tokens = append(tokens, exprToken{kind: exprOperator, value: op})
+			i += len(op)
+		}
+	}
+	return tokens, len(tokens) > 0
+}
+
+// matchExprOperator returns the operator at the start of text, or ""
+func matchExprOperator(text string) string {
+	for _, op := range exprOperators {
+		if strings.HasPrefix(text, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isExprDigit(c byte) bool      { return c >= '0' && c <= '9' }
+func isExprIdentStart(c byte) bool { return c == '_' || (c|0x20 >= 'a' && c|0x20 <= 'z') }
+func isExprIdentPart(c byte) bool  { return isExprIdentStart(c) || isExprDigit(c) || c == '.' }
+
+// hasOperatorToken reports whether tokens contain an operator
+func hasOperatorToken(tokens []exprToken) bool {
+	for _, token := range tokens {
+		if token.kind == exprOperator {
+			return true
+		}
+	}
+	return false
+}
+
+// exprParser evaluates an expression by recursive descent while parsing it
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+	lookup func(name string) string
+}
+
+// acceptOperator consumes the next token if it is one of ops and returns it, or ""
+func (p *exprParser) acceptOperator(ops ...string) string {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != exprOperator {
+		return ""
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].value == op {
+			p.pos++
+			return op
+		}
+	}
+	return ""
+}
+
+// parseTernary parses "cond ? a : b"
+func (p *exprParser) parseTernary() (string, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil || p.acceptOperator("?") == "" {
+		return cond, err
+	}
+	whenTrue, err := p.parseTernary()
+	if err != nil {
+		return "", err
+	}
+	if p.acceptOperator(":") == "" {
+		return "", errors.New("expected ':' in conditional expression")
+	}
+	whenFalse, err := p.parseTernary()
+	if err != nil {
+		return "", err
+	}
+	if exprTruthy(cond) {
+		return whenTrue, nil
+	}
+	return whenFalse, nil
+}
+
+// exprBinaryLevels lists the binary operators by increasing precedence
+var exprBinaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// parseBinary parses the left-associative binary operators of a precedence level and above
+func (p *exprParser) parseBinary(level int) (string, error) {
+	if level == len(exprBinaryLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return "", err
+	}
+	for {
+		op := p.acceptOperator(exprBinaryLevels[level]...)
+		if op == "" {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return "", err
+		}
+		if left, err = applyExprOperator(op, left, right); err != nil {
+			return "", err
+		}
+	}
+}
+
+// parseUnary parses "!x", "-x" and primary operands
+func (p *exprParser) parseUnary() (string, error) {
+	switch p.acceptOperator("!", "-") {
+	case "!":
+		operand, err := p.parseUnary()
+		return strconv.FormatBool(!exprTruthy(operand)), err
+	case "-":
+		operand, err := p.parseUnary()
+		if err != nil {
+			return "", err
+		}
+		return applyExprOperator("-", "0", operand)
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses literals, variables and parenthesized expressions
+func (p *exprParser) parsePrimary() (string, error) {
+	if p.acceptOperator("(") != "" {
+		value, err := p.parseTernary()
+		if err != nil {
+			return "", err
+		}
+		if p.acceptOperator(")") == "" {
+			return "", errors.New("expected ')'")
+		}
+		return value, nil
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind == exprOperator {
+		return "", errNotExpression
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case token.kind == exprIdent && (token.value == "true" || token.value == "false"):
+		return token.value, nil
+	case token.kind == exprIdent:
+		return p.lookup(token.value), nil
+	default:
+		return token.value, nil
+	}
+}
+
+// applyExprOperator applies a binary operator to two values
+func applyExprOperator(op, left, right string) (string, error) {
+	switch op {
+	case "||":
+		return strconv.FormatBool(exprTruthy(left) || exprTruthy(right)), nil
+	case "&&":
+		return strconv.FormatBool(exprTruthy(left) && exprTruthy(right)), nil
+	case "==", "!=":
+		return strconv.FormatBool(exprEqual(left, right) == (op == "==")), nil
+	case "<", "<=", ">", ">=":
+		return strconv.FormatBool(exprCompare(op, left, right)), nil
+	}
+
+	l, lErr := strconv.ParseFloat(left, 64)
+	r, rErr := strconv.ParseFloat(right, 64)
+	if lErr != nil || rErr != nil {
+		if op == "+" {
+			return left + right, nil
+		}
+		return "", fmt.Errorf("operator '%s' needs numbers, got %q and %q", op, left, right)
+	}
+	switch op {
+	case "+":
+		return formatExprNumber(l + r), nil
+	case "-":
+		return formatExprNumber(l - r), nil
+	case "*":
+		return formatExprNumber(l * r), nil
+	}
+	if r == 0 {
+		return "", errors.New("division by zero")
+	}
+	if op == "/" {
+		return formatExprNumber(l / r), nil
+	}
+	return formatExprNumber(math.Mod(l, r)), nil
+}
+
+// exprEqual compares numerically when both values are numbers, as strings otherwise
+func exprEqual(left, right string) bool {
+	l, lErr := strconv.ParseFloat(left, 64)
+	r, rErr := strconv.ParseFloat(right, 64)
+	if lErr == nil && rErr == nil {
+		return l == r
+	}
+	return left == right
+}
+
+// exprCompare orders numerically when both values are numbers, lexically otherwise
+func exprCompare(op, left, right string) bool {
+	cmp := strings.Compare(left, right)
+	l, lErr := strconv.ParseFloat(left, 64)
+	r, rErr := strconv.ParseFloat(right, 64)
+	if lErr == nil && rErr == nil {
+		cmp = 0
+		if l < r {
+			cmp = -1
+		} else if l > r {
+			cmp = 1
+		}
+	}
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// exprTruthy reports whether a value counts as true in conditions
+func exprTruthy(value string) bool {
+	return value != "" && value != "false" && value != "0"
+}
+
+// formatExprNumber formats a number without exponent or trailing zeros, e.g. 3 or 2.5
+func formatExprNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// resolveExpressionPlaceholder evaluates the placeholder match with content directive if it is an
+// expression. An expression that fails to evaluate (e.g. division by zero) is logged and kept as is.
+func resolveExpressionPlaceholder(match, directive string, lookup func(name string) string) (string, bool) {
+	value, err := evaluatePlaceholderExpression(directive, lookup)
+	if errors.Is(err, errNotExpression) {
+		return "", false
+	}
+	if err != nil {
+		slog.Warn("Cannot evaluate placeholder expression", "placeholder", match, "error", err)
+		return match, true
+	}
+	return value, true
+}
@@ -92,6 +92,8 @@ var userAgents = []string{
 func substituteFakerVariables(text string) string {
 	text = substituteVSCodeStyleFakers(text)
 	text = substituteJetBrainsStyleFakers(text)
+	text = substituteLoremVariables(text)
+	text = substituteGeoVariables(text)
 	return text
 }
 
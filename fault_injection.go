@@ -0,0 +1,144 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrFaultInjected marks the connection resets injected by WithFaultInjection. The errors also match
+// syscall.ECONNRESET, like real resets.
+var ErrFaultInjected = errors.New("injected fault")
+
+// FaultInjectedHeader is set on the responses synthesized by WithFaultInjection
+const FaultInjectedHeader = "X-Fault-Injected"
+
+// defaultFaultStatus is the status of synthesized responses without FaultInjection.ErrorStatus
+const defaultFaultStatus = http.StatusServiceUnavailable
+
+// FaultInjection configures WithFaultInjection. Rates are the share of requests, between 0 and 1,
+// affected by each fault.
+type FaultInjection struct {
+	DelayRate   float64       // share of requests delayed by Delay before they are sent
+	Delay       time.Duration // delay of the requests selected by DelayRate
+	ResetRate   float64       // share of requests failing with a connection reset instead of being sent
+	ErrorRate   float64       // share of requests answered with ErrorStatus instead of being sent
+	ErrorStatus int           // status of synthesized responses, 4xx or 5xx; 503 by default
+	Seed        int64         // seed of the random sequence, so a run can be repeated
+}
+
+// WithFaultInjection makes the client misbehave like an unreliable network or server, to verify retry
+// and validation handling and the resilience of services under test from the same suite: a random
+// share of requests is delayed, fails with a connection reset, or gets a synthesized error response
+// with the FaultInjectedHeader set. Resets and error responses exclude each other, so ResetRate plus
+// ErrorRate must not exceed 1; a delayed request may still fail afterwards. The random sequence is
+// seeded with Seed, so the same suite injects the same faults in every run, as long as it sends its
+// requests in the same order.
+func WithFaultInjection(faults FaultInjection) ClientOption {
+	return func(c *Client) error {
+		if err := faults.validate(); err != nil {
+			return err
+		}
+		if faults.ErrorStatus == 0 {
+			faults.ErrorStatus = defaultFaultStatus
+		}
+		c.faultInjection = &faultInjector{faults: faults, random: rand.New(rand.NewSource(faults.Seed))}
+		return nil
+	}
+}
+
+// validate checks the rates, delay and status of the fault injection settings
+func (f FaultInjection) validate() error {
+	for _, rate := range []float64{f.DelayRate, f.ResetRate, f.ErrorRate} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("fault injection rates must be between 0 and 1, got %g", rate)
+		}
+	}
+	if f.ResetRate+f.ErrorRate > 1 {
+		return errors.New("fault injection reset and error rates must not add up to more than 1")
+	}
+	if f.Delay < 0 {
+		return errors.New("fault injection delay must not be negative")
+	}
+	if f.ErrorStatus != 0 && (f.ErrorStatus < http.StatusBadRequest || f.ErrorStatus > 599) {
+		return fmt.Errorf("fault injection error status must be 4xx or 5xx, got %d", f.ErrorStatus)
+	}
+	return nil
+}
+
+// faultInjector draws the faults of the requests of a client with WithFaultInjection
+type faultInjector struct {
+	faults FaultInjection
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// draw decides the faults of the next request: whether it is delayed, and whether it fails with a
+// reset or an error response
+func (f *faultInjector) draw() (delayed, reset, errorResponse bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delayed = f.random.Float64() < f.faults.DelayRate
+	failure := f.random.Float64()
+	reset = failure < f.faults.ResetRate
+	errorResponse = !reset && failure < f.faults.ResetRate+f.faults.ErrorRate
+	return delayed, reset, errorResponse
+}
+
+// faultTransport injects the faults of a client with WithFaultInjection into the requests sent
+// through base
+type faultTransport struct {
+	base     http.RoundTripper
+	injector *faultInjector
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	delayed, reset, errorResponse := t.injector.draw()
+	if delayed {
+		if err := sleepContext(req.Context(), t.injector.faults.Delay); err != nil {
+			closeRequestBody(req)
+			return nil, err
+		}
+	}
+	switch {
+	case reset:
+		closeRequestBody(req)
+		return nil, fmt.Errorf("%w: %w", ErrFaultInjected, syscall.ECONNRESET)
+	case errorResponse:
+		closeRequestBody(req)
+		return faultResponse(req, t.injector.faults.ErrorStatus), nil
+	}
+	return base.RoundTrip(req)
+}
+
+// faultResponse synthesizes an empty response with status for req
+func faultResponse(req *http.Request, status int) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{FaultInjectedHeader: []string{"error"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+}
+
+// closeRequestBody closes the body of a request that is not sent, as RoundTrip must
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
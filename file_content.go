@@ -0,0 +1,207 @@
+package restclient
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+)
+
+// reFileContent matches {{$fileContent ./path}} and {{$fileContent @./path}}.
+// The optional '@' marker mirrors the '<@' body syntax and requests variable substitution
+// inside the inlined file content.
+var reFileContent = regexp.MustCompile(`{{\s*\$fileContent\s+(@)?\s*([^}\s]+)\s*}}`)
+
+// substituteFileContentVariables replaces {{$fileContent path}} placeholders with the content
+// of the referenced file. Relative paths are resolved against the directory of the request file.
+// With the '@' marker ({{$fileContent @./fragment.json}}), the inlined content is itself
+// resolved using the same variable sources as the request.
+// It returns an error naming the first file that could not be read.
+func (c *Client) substituteFileContentVariables(
+	text string,
+	restClientReq *Request,
+	parsedFile *ParsedFile,
+	requestScopedSystemVars map[string]string,
+	osEnvGetter func(string) (string, bool),
+) (string, error) {
+	if !reFileContent.MatchString(text) {
+		return text, nil
+	}
+
+	var firstErr error
+	result := reFileContent.ReplaceAllStringFunc(text, func(match string) string {
+		parts := reFileContent.FindStringSubmatch(match)
+		content, err := c.readFileContentVariable(parts[2], restClientReq.FilePath)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return match
+		}
+		if parts[1] == "" {
+			return content
+		}
+		return c.resolveFileContentVariables(content, restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+	})
+
+	return result, firstErr
+}
+
+// readFileContentVariable reads the file referenced by a {{$fileContent}} placeholder
+func (c *Client) readFileContentVariable(path, requestFilePath string) (string, error) {
+	fullPath := path
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(filepath.Dir(requestFilePath), path)
+	}
+
+	content, err := c.readFileWithEncoding(fullPath, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to read file for $fileContent %s: %w", path, err)
+	}
+	return content, nil
+}
+
+// resolveFileContentVariables applies request variable substitution to inlined file content
+func (c *Client) resolveFileContentVariables(
+	content string,
+	restClientReq *Request,
+	parsedFile *ParsedFile,
+	requestScopedSystemVars map[string]string,
+	osEnvGetter func(string) (string, bool),
+) string {
+	var envVars, globalVars map[string]string
+	if parsedFile != nil {
+		envVars = parsedFile.EnvironmentVariables
+		globalVars = parsedFile.GlobalVariables
+	}
+
+	resolved := resolveVariablesInText(
+		content,
+		c.programmaticVars,
+		restClientReq.ActiveVariables,
+		envVars,
+		globalVars,
+		requestScopedSystemVars,
+		osEnvGetter,
+		c.currentDotEnvVars,
+	)
+	return substituteDynamicSystemVariables(resolved, c.currentDotEnvVars, c.programmaticVars)
+}
+
+// substituteFileReferenceVariables applies the placeholders that read files relative to the
+// request file: {{$fileContent}} and {{$randomFromFile}}.
+func (c *Client) substituteFileReferenceVariables(
+	text string,
+	restClientReq *Request,
+	parsedFile *ParsedFile,
+	requestScopedSystemVars map[string]string,
+	osEnvGetter func(string) (string, bool),
+) (string, error) {
+	text, err := c.substituteFileContentVariables(text, restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+	if err != nil {
+		return text, err
+	}
+	return c.substituteRandomFromFileVariables(text, restClientReq.FilePath)
+}
+
+// substituteFileReferencesInHeaders applies file reference substitution to all request header values
+func (c *Client) substituteFileReferencesInHeaders(
+	restClientReq *Request,
+	parsedFile *ParsedFile,
+	requestScopedSystemVars map[string]string,
+	osEnvGetter func(string) (string, bool),
+) error {
+	for key, values := range restClientReq.Headers {
+		for i, value := range values {
+			substituted, err := c.substituteFileReferenceVariables(
+				value, restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+			if err != nil {
+				return fmt.Errorf("header %s: %w", key, err)
+			}
+			values[i] = substituted
+		}
+	}
+	return nil
+}
+
+// processExternalFile reads and processes external file references with optional variable substitution and encoding
+func (c *Client) processExternalFile(
+	restClientReq *Request,
+	parsedFile *ParsedFile,
+	requestScopedSystemVars map[string]string,
+	osEnvGetter func(string) (string, bool),
+) (string, error) {
+	// Resolve the file path relative to the request's file directory
+	requestDir := filepath.Dir(restClientReq.FilePath)
+	fullPath := restClientReq.ExternalFilePath
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(requestDir, restClientReq.ExternalFilePath)
+	}
+
+	// Read the file with appropriate encoding
+	content, err := c.readFileWithEncoding(fullPath, restClientReq.ExternalFileEncoding)
+	if err != nil {
+		return "", fmt.Errorf("failed to read external file %s: %w", restClientReq.ExternalFilePath, err)
+	}
+
+	// Apply variable substitution if requested
+	if restClientReq.ExternalFileWithVariables {
+		resolvedContent := resolveVariablesInText(
+			content,
+			c.programmaticVars,
+			restClientReq.ActiveVariables,
+			parsedFile.EnvironmentVariables,
+			parsedFile.GlobalVariables,
+			requestScopedSystemVars,
+			osEnvGetter,
+			c.currentDotEnvVars,
+		)
+		content = substituteDynamicSystemVariables(
+			resolvedContent,
+			c.currentDotEnvVars,
+			c.programmaticVars,
+		)
+	}
+
+	return content, nil
+}
+
+// readFileWithEncoding reads a file with the specified encoding, defaulting to UTF-8
+func (c *Client) readFileWithEncoding(filePath, encodingName string) (string, error) {
+	// Read the file as bytes
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	// If no encoding specified or UTF-8, return as-is
+	if encodingName == "" || strings.ToLower(encodingName) == "utf-8" || strings.ToLower(encodingName) == "utf8" {
+		return string(data), nil
+	}
+
+	// Get the decoder for the specified encoding
+	decoder, err := c.getEncodingDecoder(encodingName)
+	if err != nil {
+		return "", fmt.Errorf("unsupported encoding %s: %w", encodingName, err)
+	}
+
+	// Decode the content
+	decodedContent, err := decoder.Bytes(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode content with encoding %s: %w", encodingName, err)
+	}
+
+	return string(decodedContent), nil
+}
+
+// getEncodingDecoder returns the appropriate decoder for the given encoding name
+func (*Client) getEncodingDecoder(encodingName string) (*encoding.Decoder, error) {
+	enc, err := lookupEncoding(encodingName)
+	if err != nil {
+		return nil, err
+	}
+	return enc.NewDecoder(), nil
+}
@@ -0,0 +1,106 @@
+package restclient
+
+import (
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	minLatitude     = -90.0
+	maxLatitude     = 90.0
+	minLongitude    = -180.0
+	maxLongitude    = 180.0
+	geoPrecision    = 6
+	bboxCoordinates = 4
+)
+
+var (
+	reRandomLatitude  = regexp.MustCompile(`{{\s*\$randomLatitude\s*}}`)
+	reRandomLongitude = regexp.MustCompile(`{{\s*\$randomLongitude\s*}}`)
+	reRandomGeoPoint  = regexp.MustCompile(`{{\s*\$randomGeoPoint(?:\s+"([^"]*)")?\s*}}`)
+)
+
+// geoRegexes lists the geo coordinate faker patterns so that file-scoped variables
+// holding them are evaluated dynamically.
+var geoRegexes = []*regexp.Regexp{reRandomLatitude, reRandomLongitude, reRandomGeoPoint}
+
+// geoBoundingBox is a region given as "minLon,minLat,maxLon,maxLat" (GeoJSON bbox order)
+type geoBoundingBox struct {
+	minLon, minLat, maxLon, maxLat float64
+}
+
+// worldBoundingBox covers every valid coordinate
+var worldBoundingBox = geoBoundingBox{
+	minLon: minLongitude, minLat: minLatitude, maxLon: maxLongitude, maxLat: maxLatitude,
+}
+
+// substituteGeoVariables handles geo coordinate faker variables:
+// {{$randomLatitude}}, {{$randomLongitude}} and {{$randomGeoPoint "minLon,minLat,maxLon,maxLat"}}.
+// {{$randomGeoPoint}} renders as "lat,lon"; a malformed bounding box leaves the placeholder untouched.
+func substituteGeoVariables(text string) string {
+	text = reRandomLatitude.ReplaceAllStringFunc(text, getRandomLatitude)
+	text = reRandomLongitude.ReplaceAllStringFunc(text, getRandomLongitude)
+	text = reRandomGeoPoint.ReplaceAllStringFunc(text, substituteRandomGeoPoint)
+	return text
+}
+
+// getRandomLatitude returns a random latitude in [-90, 90]
+func getRandomLatitude(_ string) string {
+	return formatCoordinate(randomInRange(minLatitude, maxLatitude))
+}
+
+// getRandomLongitude returns a random longitude in [-180, 180]
+func getRandomLongitude(_ string) string {
+	return formatCoordinate(randomInRange(minLongitude, maxLongitude))
+}
+
+// substituteRandomGeoPoint generates a "lat,lon" pair for a {{$randomGeoPoint "bbox"}} match
+func substituteRandomGeoPoint(match string) string {
+	bbox := worldBoundingBox
+	parts := reRandomGeoPoint.FindStringSubmatch(match)
+	if len(parts) > 1 && parts[1] != "" {
+		parsed, ok := parseGeoBoundingBox(parts[1])
+		if !ok {
+			return match
+		}
+		bbox = parsed
+	}
+	lat := randomInRange(bbox.minLat, bbox.maxLat)
+	lon := randomInRange(bbox.minLon, bbox.maxLon)
+	return formatCoordinate(lat) + "," + formatCoordinate(lon)
+}
+
+// parseGeoBoundingBox parses "minLon,minLat,maxLon,maxLat" and validates the ranges
+func parseGeoBoundingBox(value string) (geoBoundingBox, bool) {
+	fields := strings.Split(value, ",")
+	if len(fields) != bboxCoordinates {
+		return geoBoundingBox{}, false
+	}
+	coords := make([]float64, bboxCoordinates)
+	for i, field := range fields {
+		coord, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return geoBoundingBox{}, false
+		}
+		coords[i] = coord
+	}
+	bbox := geoBoundingBox{minLon: coords[0], minLat: coords[1], maxLon: coords[2], maxLat: coords[3]}
+	if bbox.minLon > bbox.maxLon || bbox.minLat > bbox.maxLat ||
+		bbox.minLon < minLongitude || bbox.maxLon > maxLongitude ||
+		bbox.minLat < minLatitude || bbox.maxLat > maxLatitude {
+		return geoBoundingBox{}, false
+	}
+	return bbox, true
+}
+
+// randomInRange returns a random float in [minVal, maxVal]
+func randomInRange(minVal, maxVal float64) float64 {
+	return minVal + rand.Float64()*(maxVal-minVal)
+}
+
+// formatCoordinate renders a coordinate with fixed precision
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', geoPrecision, 64)
+}
@@ -0,0 +1,198 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// grpcMethod is the method of JetBrains-style gRPC requests: `GRPC localhost:50051/package.Service/Method`
+const grpcMethod = "GRPC"
+
+// GRPCCall is the unary gRPC call of a GRPC request, after variable substitution.
+type GRPCCall struct {
+	Target   string      // host:port of the server
+	TLS      bool        // true for grpcs:// and https:// targets
+	Method   string      // full method name, e.g. "routeguide.RouteGuide/GetFeature"
+	Metadata http.Header // the default and request headers, sent as request metadata
+	Message  string      // the request message as JSON; "" for an empty message
+}
+
+// GRPCResult is the outcome of a GRPCCall that reached the server.
+type GRPCResult struct {
+	Code    int         // gRPC status code, 0 (OK) on success
+	Message string      // gRPC status message
+	Header  http.Header // response header metadata
+	Trailer http.Header // response trailer metadata
+	Body    string      // the response message as JSON; "" when the call failed
+}
+
+// GRPCInvoker performs the gRPC calls of GRPC requests. It returns an error only when the call could
+// not be made at all, e.g. for an unknown method; failed calls are reported by GRPCResult.Code.
+// Package grpcclient provides an implementation with grpc-go, using server reflection or supplied
+// descriptors.
+type GRPCInvoker interface {
+	InvokeGRPC(ctx context.Context, call *GRPCCall) (*GRPCResult, error)
+}
+
+// WithGRPC executes the GRPC requests of .http files with invoker, e.g. a grpcclient.Invoker:
+//
+//	GRPC localhost:50051/helloworld.Greeter/SayHello
+//
+//	{"name": "{{user}}"}
+//
+// Their responses go through the same pipeline as HTTP ones: the gRPC status is mapped to an HTTP
+// status code (OK to 200, NotFound to 404, and so on) and also reported in the Grpc-Status and
+// Grpc-Message headers, metadata becomes Headers and Trailers, and the response message is the JSON
+// Body. Without an invoker, GRPC requests fail.
+func WithGRPC(invoker GRPCInvoker) ClientOption {
+	return func(c *Client) error {
+		if invoker == nil {
+			return errors.New("gRPC invoker must not be nil")
+		}
+		c.grpc = invoker
+		return nil
+	}
+}
+
+// isGRPCRequest reports whether req is a GRPC request
+func isGRPCRequest(req *Request) bool {
+	return strings.EqualFold(req.Method, grpcMethod)
+}
+
+// grpcRequestURL returns the target of a GRPC request line as a URL: targets without a scheme, such
+// as localhost:50051/package.Service/Method, are given the grpc:// scheme
+func grpcRequestURL(method, rawURL string) string {
+	if !strings.EqualFold(method, grpcMethod) || strings.Contains(rawURL, "://") {
+		return rawURL
+	}
+	return "grpc://" + rawURL
+}
+
+// newGRPCCall builds the call of a substituted GRPC request
+func (c *Client) newGRPCCall(req *Request) (*GRPCCall, error) {
+	if req.URL == nil || req.URL.Host == "" {
+		return nil, errors.New("GRPC request needs a target like localhost:50051/package.Service/Method")
+	}
+	var useTLS bool
+	switch strings.ToLower(req.URL.Scheme) {
+	case "grpc", "http":
+	case "grpcs", "https":
+		useTLS = true
+	default:
+		return nil, fmt.Errorf("unsupported GRPC target scheme %q", req.URL.Scheme)
+	}
+	method := strings.Trim(req.URL.Path, "/")
+	service, name, ok := strings.Cut(method, "/")
+	if !ok || service == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("GRPC method must be package.Service/Method, got %q", method)
+	}
+	metadata := c.DefaultHeaders.Clone()
+	if metadata == nil {
+		metadata = make(http.Header)
+	}
+	for key, values := range req.Headers {
+		metadata[key] = values
+	}
+	return &GRPCCall{
+		Target:   req.URL.Host,
+		TLS:      useTLS,
+		Method:   method,
+		Metadata: metadata,
+		Message:  strings.TrimSpace(req.RawBody),
+	}, nil
+}
+
+// dispatchRequest sends a prepared request with the client's gRPC invoker for GRPC requests and over
+// HTTP otherwise; failures are captured in Response.Error
+func (c *Client) dispatchRequest(ctx context.Context, req *Request) *Response {
+	if isGRPCRequest(req) {
+		return c.executeGRPCRequest(ctx, req)
+	}
+	return c.sendRequest(ctx, req)
+}
+
+// executeGRPCRequest performs the call of a GRPC request with the client's invoker and converts the
+// result into a Response
+func (c *Client) executeGRPCRequest(ctx context.Context, req *Request) *Response {
+	resp := &Response{Request: req, Proto: "HTTP/2.0", StartTime: time.Now()}
+	if c.grpc == nil {
+		resp.Error = errors.New("GRPC requests need a client created with WithGRPC")
+		return resp
+	}
+	call, err := c.newGRPCCall(req)
+	if err != nil {
+		resp.Error = err
+		return resp
+	}
+	resp.FinalURL = req.URL.String()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+	result, err := c.grpc.InvokeGRPC(ctx, call)
+	resp.Duration = time.Since(resp.StartTime)
+	if err != nil {
+		resp.Error = fmt.Errorf("failed to execute gRPC request: %w", err)
+		return resp
+	}
+
+	resp.StatusCode = grpcHTTPStatus(result.Code)
+	resp.Status = fmt.Sprintf("%d %s", resp.StatusCode, grpcStatusText(resp.StatusCode))
+	resp.Headers = result.Header.Clone()
+	if resp.Headers == nil {
+		resp.Headers = make(http.Header)
+	}
+	resp.Headers.Set("Grpc-Status", strconv.Itoa(result.Code))
+	if result.Message != "" {
+		resp.Headers.Set("Grpc-Message", result.Message)
+	}
+	resp.Trailers = result.Trailer
+	resp.Body = []byte(result.Body)
+	resp.BodyString = result.Body
+	resp.Size = int64(len(result.Body))
+	return resp
+}
+
+// grpcHTTPStatuses maps gRPC status codes to the HTTP status codes of the gRPC-HTTP mapping of
+// google.rpc.Code
+var grpcHTTPStatuses = []int{
+	http.StatusOK,                  // OK
+	499,                            // CANCELLED
+	http.StatusInternalServerError, // UNKNOWN
+	http.StatusBadRequest,          // INVALID_ARGUMENT
+	http.StatusGatewayTimeout,      // DEADLINE_EXCEEDED
+	http.StatusNotFound,            // NOT_FOUND
+	http.StatusConflict,            // ALREADY_EXISTS
+	http.StatusForbidden,           // PERMISSION_DENIED
+	http.StatusTooManyRequests,     // RESOURCE_EXHAUSTED
+	http.StatusBadRequest,          // FAILED_PRECONDITION
+	http.StatusConflict,            // ABORTED
+	http.StatusBadRequest,          // OUT_OF_RANGE
+	http.StatusNotImplemented,      // UNIMPLEMENTED
+	http.StatusInternalServerError, // INTERNAL
+	http.StatusServiceUnavailable,  // UNAVAILABLE
+	http.StatusInternalServerError, // DATA_LOSS
+	http.StatusUnauthorized,        // UNAUTHENTICATED
+}
+
+// grpcHTTPStatus returns the HTTP status code of a gRPC status code; 500 for unknown codes
+func grpcHTTPStatus(code int) int {
+	if code < 0 || code >= len(grpcHTTPStatuses) {
+		return http.StatusInternalServerError
+	}
+	return grpcHTTPStatuses[code]
+}
+
+// grpcStatusText returns the text of an HTTP status code of grpcHTTPStatus
+func grpcStatusText(code int) string {
+	if code == 499 {
+		return "Client Closed Request"
+	}
+	return http.StatusText(code)
+}
@@ -0,0 +1,270 @@
+// Package grpcclient executes the GRPC requests of .http files with grpc-go. Request and response
+// messages are converted from and to JSON with the descriptors of the called method, which are fetched
+// with server reflection unless supplied. Register an Invoker with restclient.WithGRPC:
+//
+//	invoker, err := grpcclient.New()
+//	defer invoker.Close()
+//	client, err := restclient.NewClient(restclient.WithGRPC(invoker))
+//	responses, err := client.ExecuteFile(ctx, "greeter.http")
+package grpcclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+// Invoker performs unary gRPC calls for restclient.WithGRPC. Connections are kept per target until
+// Close. It is safe for concurrent use.
+type Invoker struct {
+	files       *protoregistry.Files // supplied descriptors; nil: server reflection
+	dialOptions []grpc.DialOption
+
+	mu        sync.Mutex
+	conns     map[string]*grpc.ClientConn
+	reflected map[string]*protoregistry.Files // by target and service
+}
+
+// Option is a functional option for configuring an Invoker.
+type Option func(*Invoker) error
+
+// WithDescriptors resolves methods and messages from files instead of server reflection, for servers
+// that do not expose the reflection service.
+func WithDescriptors(files *protoregistry.Files) Option {
+	return func(i *Invoker) error {
+		if files == nil {
+			return errors.New("descriptors must not be nil")
+		}
+		i.files = files
+		return nil
+	}
+}
+
+// WithDescriptorSetFile resolves methods and messages from a file descriptor set instead of server
+// reflection, as written by `protoc --include_imports --descriptor_set_out=<path>`.
+func WithDescriptorSetFile(path string) Option {
+	return func(i *Invoker) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read descriptor set: %w", err)
+		}
+		var set descriptorpb.FileDescriptorSet
+		if err := proto.Unmarshal(data, &set); err != nil {
+			return fmt.Errorf("failed to parse descriptor set %s: %w", path, err)
+		}
+		files, err := protodesc.NewFiles(&set)
+		if err != nil {
+			return fmt.Errorf("invalid descriptor set %s: %w", path, err)
+		}
+		i.files = files
+		return nil
+	}
+}
+
+// WithDialOptions adds options to the connections of the Invoker, applied after the transport
+// credentials chosen by the target scheme, e.g. to trust a private CA with grpc.WithTransportCredentials.
+func WithDialOptions(options ...grpc.DialOption) Option {
+	return func(i *Invoker) error {
+		i.dialOptions = append(i.dialOptions, options...)
+		return nil
+	}
+}
+
+// New creates an Invoker. Without WithDescriptors or WithDescriptorSetFile, the descriptors of a
+// service are fetched with server reflection on its first call.
+func New(options ...Option) (*Invoker, error) {
+	i := &Invoker{
+		conns:     make(map[string]*grpc.ClientConn),
+		reflected: make(map[string]*protoregistry.Files),
+	}
+	for _, option := range options {
+		if err := option(i); err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// InvokeGRPC performs call as a unary RPC. Streaming methods are not supported.
+func (i *Invoker) InvokeGRPC(ctx context.Context, call *rc.GRPCCall) (*rc.GRPCResult, error) {
+	conn, err := i.conn(call)
+	if err != nil {
+		return nil, err
+	}
+	service, name, _ := strings.Cut(call.Method, "/")
+	files, err := i.descriptors(ctx, conn, call.Target, service)
+	if err != nil {
+		return nil, err
+	}
+	method, err := findMethod(files, service, name)
+	if err != nil {
+		return nil, err
+	}
+	types := dynamicpb.NewTypes(files)
+
+	in := dynamicpb.NewMessage(method.Input())
+	if call.Message != "" {
+		if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal([]byte(call.Message), in); err != nil {
+			return nil, fmt.Errorf("invalid %s message: %w", method.Input().FullName(), err)
+		}
+	}
+	out := dynamicpb.NewMessage(method.Output())
+	var header, trailer metadata.MD
+	ctx = metadata.NewOutgoingContext(ctx, outgoingMetadata(call.Metadata))
+	callErr := conn.Invoke(ctx, "/"+call.Method, in, out, grpc.Header(&header), grpc.Trailer(&trailer))
+
+	st := status.Convert(callErr)
+	result := &rc.GRPCResult{
+		Code:    int(st.Code()),
+		Message: st.Message(),
+		Header:  httpHeader(header),
+		Trailer: httpHeader(trailer),
+	}
+	if callErr == nil {
+		body, err := marshalMessage(out, types)
+		if err != nil {
+			return nil, err
+		}
+		result.Body = body
+	}
+	return result, nil
+}
+
+// Close closes the connections of the Invoker.
+func (i *Invoker) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var errs *multierror.Error
+	for target, conn := range i.conns {
+		if err := conn.Close(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		delete(i.conns, target)
+	}
+	return errs.ErrorOrNil()
+}
+
+// conn returns the connection to the target of call, creating it on first use
+func (i *Invoker) conn(call *rc.GRPCCall) (*grpc.ClientConn, error) {
+	key := call.Target
+	if call.TLS {
+		key = "tls:" + key
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if conn, ok := i.conns[key]; ok {
+		return conn, nil
+	}
+	creds := insecure.NewCredentials()
+	if call.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	options := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, i.dialOptions...)
+	conn, err := grpc.NewClient(call.Target, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", call.Target, err)
+	}
+	i.conns[key] = conn
+	return conn, nil
+}
+
+// descriptors returns the descriptors to resolve the methods of service with: the supplied ones, or
+// those fetched from the server at target
+func (i *Invoker) descriptors(
+	ctx context.Context, conn *grpc.ClientConn, target, service string,
+) (*protoregistry.Files, error) {
+	if i.files != nil {
+		return i.files, nil
+	}
+	key := target + "/" + service
+	i.mu.Lock()
+	files, ok := i.reflected[key]
+	i.mu.Unlock()
+	if ok {
+		return files, nil
+	}
+	files, err := reflectService(ctx, conn, service)
+	if err != nil {
+		return nil, fmt.Errorf("server reflection for %s failed: %w", service, err)
+	}
+	i.mu.Lock()
+	i.reflected[key] = files
+	i.mu.Unlock()
+	return files, nil
+}
+
+// findMethod returns the unary method name of service
+func findMethod(files *protoregistry.Files, service, name string) (protoreflect.MethodDescriptor, error) {
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("unknown gRPC service %s: %w", service, err)
+	}
+	serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a gRPC service", service)
+	}
+	method := serviceDescriptor.Methods().ByName(protoreflect.Name(name))
+	if method == nil {
+		return nil, fmt.Errorf("unknown method %s of gRPC service %s", name, service)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("streaming method %s/%s is not supported", service, name)
+	}
+	return method, nil
+}
+
+// outgoingMetadata converts request headers into gRPC metadata
+func outgoingMetadata(headers http.Header) metadata.MD {
+	md := make(metadata.MD, len(headers))
+	for key, values := range headers {
+		md.Append(key, values...)
+	}
+	return md
+}
+
+// httpHeader converts gRPC metadata into canonical HTTP headers
+func httpHeader(md metadata.MD) http.Header {
+	header := make(http.Header, len(md))
+	for key, values := range md {
+		canonical := http.CanonicalHeaderKey(key)
+		header[canonical] = append(header[canonical], values...)
+	}
+	return header
+}
+
+// marshalMessage returns msg as indented JSON. protojson output is deliberately unstable in its
+// whitespace, so it is reformatted for stable bodies.
+func marshalMessage(msg proto.Message, types *dynamicpb.Types) (string, error) {
+	data, err := (protojson.MarshalOptions{Resolver: types}).Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert response message to JSON: %w", err)
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return "", fmt.Errorf("failed to format response message: %w", err)
+	}
+	return indented.String(), nil
+}
@@ -0,0 +1,148 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// reflectionQuery asks the reflection service for the file defining symbol, or the file named filename
+type reflectionQuery struct {
+	symbol   string
+	filename string
+}
+
+// reflectService fetches the file defining service and its dependencies with server reflection.
+// Dependencies the server does not return are looked up in the files linked into the binary, which
+// covers the well-known types.
+func reflectService(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	fetched := make(map[string]*descriptorpb.FileDescriptorProto)
+	if err := fetchFiles(ctx, conn, reflectionQuery{symbol: service}, fetched); err != nil {
+		return nil, err
+	}
+	for missing := missingDependencies(fetched); len(missing) > 0; missing = missingDependencies(fetched) {
+		for _, name := range missing {
+			if linked, err := protoregistry.GlobalFiles.FindFileByPath(name); err == nil {
+				fetched[name] = protodesc.ToFileDescriptorProto(linked)
+				continue
+			}
+			if err := fetchFiles(ctx, conn, reflectionQuery{filename: name}, fetched); err != nil {
+				return nil, err
+			}
+			if fetched[name] == nil {
+				return nil, fmt.Errorf("server did not return dependency %s", name)
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, file := range fetched {
+		set.File = append(set.File, file)
+	}
+	return protodesc.NewFiles(set)
+}
+
+// missingDependencies returns the dependencies of the fetched files that were not fetched yet
+func missingDependencies(fetched map[string]*descriptorpb.FileDescriptorProto) []string {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, file := range fetched {
+		for _, dependency := range file.GetDependency() {
+			if fetched[dependency] == nil && !seen[dependency] {
+				seen[dependency] = true
+				missing = append(missing, dependency)
+			}
+		}
+	}
+	return missing
+}
+
+// fetchFiles adds the file descriptors answering query to fetched, using the v1 reflection service
+// and falling back to v1alpha for servers that predate it
+func fetchFiles(
+	ctx context.Context, conn *grpc.ClientConn, query reflectionQuery,
+	fetched map[string]*descriptorpb.FileDescriptorProto,
+) error {
+	files, err := queryReflectionV1(ctx, conn, query)
+	if status.Code(err) == codes.Unimplemented {
+		files, err = queryReflectionV1Alpha(ctx, conn, query)
+	}
+	if err != nil {
+		return err
+	}
+	for _, data := range files {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(data, file); err != nil {
+			return fmt.Errorf("invalid file descriptor from server: %w", err)
+		}
+		fetched[file.GetName()] = file
+	}
+	return nil
+}
+
+// queryReflectionV1 answers query with the grpc.reflection.v1 service
+func queryReflectionV1(ctx context.Context, conn *grpc.ClientConn, query reflectionQuery) ([][]byte, error) {
+	stream, err := reflectionv1.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.CloseSend() }()
+	req := &reflectionv1.ServerReflectionRequest{}
+	if query.symbol != "" {
+		req.MessageRequest = &reflectionv1.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: query.symbol,
+		}
+	} else {
+		req.MessageRequest = &reflectionv1.ServerReflectionRequest_FileByFilename{FileByFilename: query.filename}
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
+
+// queryReflectionV1Alpha answers query with the grpc.reflection.v1alpha service
+func queryReflectionV1Alpha(ctx context.Context, conn *grpc.ClientConn, query reflectionQuery) ([][]byte, error) {
+	stream, err := reflectionv1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.CloseSend() }()
+	req := &reflectionv1alpha.ServerReflectionRequest{}
+	if query.symbol != "" {
+		req.MessageRequest = &reflectionv1alpha.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: query.symbol,
+		}
+	} else {
+		req.MessageRequest = &reflectionv1alpha.ServerReflectionRequest_FileByFilename{
+			FileByFilename: query.filename,
+		}
+	}
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
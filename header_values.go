@@ -0,0 +1,102 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// isFoldedHeaderLine reports whether line continues the header on the previous line (obs-fold, RFC 7230
+// section 3.2.4): it starts with a space or tab and directly follows a header line.
+func isFoldedHeaderLine(line string, lineNumber, lastHeaderLine int) bool {
+	return lastHeaderLine > 0 && lineNumber == lastHeaderLine+1 &&
+		(strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && strings.TrimSpace(line) != ""
+}
+
+// appendFoldedHeaderValue joins a folded continuation onto the last value of the named header
+// with a single space, as a recipient is required to do when unfolding
+func appendFoldedHeaderValue(headers http.Header, name, continuation string) {
+	values := headers.Values(name)
+	if len(values) == 0 {
+		return
+	}
+	values[len(values)-1] += " " + strings.TrimSpace(continuation)
+}
+
+// handleFoldedHeaderLine appends a folded continuation line to the previous request header
+func (p *requestParserState) handleFoldedHeaderLine(trimmedLine string) {
+	appendFoldedHeaderValue(p.currentRequest.Headers, p.lastHeaderName, trimmedLine)
+	p.lastHeaderLine = p.lineNumber
+}
+
+// handleFoldedHeaderLine appends a folded continuation line to the previous expected header
+func (s *responseParserState) handleFoldedHeaderLine(trimmedLine string) {
+	appendFoldedHeaderValue(s.currentExpectedResponse.Headers, s.lastHeaderName, trimmedLine)
+	s.lastHeaderLine = s.lineNumber
+}
+
+// sentHeaderValues returns the values of a request header as sent on the wire. Repeated Cookie lines
+// are combined into one "a=1; b=2" header (RFC 6265 section 5.4), so that cookies added by the
+// client's cookie jar extend rather than replace them; other headers keep one line per value.
+func sentHeaderValues(key string, values []string) []string {
+	if http.CanonicalHeaderKey(key) == "Cookie" && len(values) > 1 {
+		return []string{strings.Join(values, "; ")}
+	}
+	return values
+}
+
+// listHeaders are the headers whose values are comma-separated lists by definition (RFC 9110 and
+// others), so a comma in them separates elements rather than being part of a value such as a date
+var listHeaders = map[string]bool{
+	"Accept": true, "Accept-Charset": true, "Accept-Encoding": true, "Accept-Language": true,
+	"Accept-Patch": true, "Accept-Ranges": true, "Access-Control-Allow-Headers": true,
+	"Access-Control-Allow-Methods": true, "Access-Control-Expose-Headers": true,
+	"Access-Control-Request-Headers": true, "Allow": true, "Cache-Control": true, "Connection": true,
+	"Content-Encoding": true, "Content-Language": true, "If-Match": true, "If-None-Match": true,
+	"Link": true, "Pragma": true, "Prefer": true, "Preference-Applied": true, "Trailer": true,
+	"Transfer-Encoding": true, "Upgrade": true, "Vary": true, "Via": true, "Warning": true,
+}
+
+// headerValueElements returns the actual values of a response header together with, for the list
+// headers in listHeaders, the elements of comma-separated values, so each element can be asserted on
+// its own. Values of other headers, e.g. Date, Set-Cookie or custom ones, are not split.
+func headerValueElements(key string, values []string) []string {
+	elements := append([]string{}, values...)
+	if !listHeaders[http.CanonicalHeaderKey(key)] {
+		return elements
+	}
+	for _, value := range values {
+		if !strings.Contains(value, ",") {
+			continue
+		}
+		for _, element := range strings.Split(value, ",") {
+			elements = append(elements, strings.TrimSpace(element))
+		}
+	}
+	if len(values) > 1 {
+		elements = append(elements, strings.Join(values, ", "))
+	}
+	return elements
+}
+
+// isHeaderValuePresent checks if an expected header value is present in the actual values (including the
+// elements of comma-separated values, see headerValueElements). Expected values with placeholders match
+// like expected bodies, e.g. "{{$regexp application/json.*}}", or "{{$any}}" for a header that only has to
+// be present; an invalid pattern is an error.
+func isHeaderValuePresent(expectedValue string, actualValues []string, ref dateReference) (bool, error) {
+	if !strings.Contains(expectedValue, "{{$") {
+		return containsString(actualValues, expectedValue), nil
+	}
+	pattern, dateChecks := buildRegexFromExpectedBody(expectedValue)
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern in expected value '%s': %w", expectedValue, err)
+	}
+	for _, actualValue := range actualValues {
+		if matcher.MatchString(actualValue) && verifyDateChecks(matcher, actualValue, dateChecks, ref) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
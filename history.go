@@ -0,0 +1,55 @@
+package restclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HistoryRun identifies a single ExecuteFile call, so recorded responses can be grouped by run.
+type HistoryRun struct {
+	ID          string    // unique per ExecuteFile call
+	RequestFile string    // the request file path as passed to ExecuteFile
+	StartTime   time.Time // when ExecuteFile started
+}
+
+// HistoryRecorder receives every response produced by ExecuteFile, including responses of
+// failed requests, once the request has been executed. Register it with WithHistory;
+// package history provides a JSON-lines implementation.
+type HistoryRecorder interface {
+	RecordResponse(run HistoryRun, index int, response *Response) error
+}
+
+// WithHistory records every response produced by ExecuteFile into recorder, e.g. a history.Store,
+// so past runs can be inspected or validated again. Streamed bodies (see WithStreamedBodies) are
+// buffered before recording.
+func WithHistory(recorder HistoryRecorder) ClientOption {
+	return func(c *Client) error {
+		c.history = recorder
+		return nil
+	}
+}
+
+// newHistoryRun starts a run for an ExecuteFile call; the zero value when no recorder is set
+func (c *Client) newHistoryRun(requestFilePath string) HistoryRun {
+	if c.history == nil {
+		return HistoryRun{}
+	}
+	return HistoryRun{ID: uuid.NewString(), RequestFile: requestFilePath, StartTime: time.Now()}
+}
+
+// recordHistory passes a response produced by ExecuteFile to the recorder configured with
+// WithHistory. It is a no-op when no recorder is set and for requests marked with @no-log.
+func (c *Client) recordHistory(run HistoryRun, index int, response *Response) error {
+	if c.history == nil || response == nil || (response.Request != nil && response.Request.NoLog) {
+		return nil
+	}
+	if err := response.BufferBody(); err != nil {
+		return fmt.Errorf("failed to record history for request %d: %w", index+1, err)
+	}
+	if err := c.history.RecordResponse(run, index, response); err != nil {
+		return fmt.Errorf("failed to record history for request %d: %w", index+1, err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package history
+
+import (
+	"errors"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+// Redacted replaces the values of credential headers in recorded entries.
+const Redacted = "[REDACTED]"
+
+// sensitiveHeaders are the request and response headers carrying credentials, which are not recorded
+var sensitiveHeaders = []string{
+	"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token",
+}
+
+// NewEntry converts a response produced by ExecuteFile into an entry. Its body must be
+// buffered; an unread streamed body is recorded as empty. Credential headers such as
+// Authorization, Cookie and Set-Cookie are recorded as Redacted, and file variables whose
+// values they contain, e.g. the token of "Authorization: Bearer {{token}}", are not recorded.
+func NewEntry(run rc.HistoryRun, index int, response *rc.Response) Entry {
+	entry := Entry{
+		RunID:       run.ID,
+		RunStart:    run.StartTime,
+		RequestFile: run.RequestFile,
+		Index:       index,
+		Response: ResponseRecord{
+			Status:        response.Status,
+			StatusCode:    response.StatusCode,
+			Proto:         response.Proto,
+			Headers:       redactHeaders(response.Headers),
+			Body:          response.BodyString,
+			FinalURL:      response.FinalURL,
+			StartTime:     response.StartTime,
+			Duration:      response.Duration,
+			BytesSent:     response.BytesSent,
+			BytesReceived: response.BytesReceived,
+		},
+	}
+	if response.Error != nil {
+		entry.Error = response.Error.Error()
+	}
+
+	if req := response.Request; req != nil {
+		entry.Name = req.Name
+		entry.Variables = redactVariables(req.ActiveVariables, req.Headers, response.Headers)
+		entry.Request = RequestRecord{
+			Method:  req.Method,
+			URL:     req.RawURLString,
+			RawURL:  req.RawURLString,
+			Headers: redactHeaders(req.Headers),
+			Body:    req.RawBody,
+		}
+		if req.URL != nil {
+			entry.Request.URL = req.URL.String()
+		}
+	}
+	return entry
+}
+
+// ToResponse rebuilds the recorded response, e.g. to validate it again with ValidateResponses.
+func (e Entry) ToResponse() *rc.Response {
+	response := &rc.Response{
+		Request:       e.ToRequest(),
+		Status:        e.Response.Status,
+		StatusCode:    e.Response.StatusCode,
+		Proto:         e.Response.Proto,
+		Headers:       e.Response.Headers.Clone(),
+		Body:          []byte(e.Response.Body),
+		BodyString:    e.Response.Body,
+		Size:          int64(len(e.Response.Body)),
+		FinalURL:      e.Response.FinalURL,
+		StartTime:     e.Response.StartTime,
+		Duration:      e.Response.Duration,
+		BytesSent:     e.Response.BytesSent,
+		BytesReceived: e.Response.BytesReceived,
+	}
+	if e.Error != "" {
+		response.Error = errors.New(e.Error)
+	}
+	return response
+}
+
+// ToRequest rebuilds the recorded request, as sent. Its URL is the recorded, substituted URL;
+// RawURLString is the URL as written in the file.
+func (e Entry) ToRequest() *rc.Request {
+	req := &rc.Request{
+		Name:            e.Name,
+		Method:          e.Request.Method,
+		RawURLString:    e.Request.RawURL,
+		Headers:         e.Request.Headers.Clone(),
+		RawBody:         e.Request.Body,
+		ActiveVariables: maps.Clone(e.Variables),
+		FilePath:        e.RequestFile,
+	}
+	if parsed, err := url.Parse(e.Request.URL); err == nil {
+		req.URL = parsed
+	}
+	if req.RawURLString == "" {
+		req.RawURLString = e.Request.URL
+	}
+	return req
+}
+
+// redactHeaders returns a copy of headers with the values of sensitive headers replaced by Redacted
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, name := range sensitiveHeaders {
+		if values := redacted.Values(name); len(values) > 0 {
+			redacted[http.CanonicalHeaderKey(name)] = []string{Redacted}
+		}
+	}
+	return redacted
+}
+
+// redactVariables returns a copy of variables without those whose values appear in the values of
+// sensitive headers
+func redactVariables(variables map[string]string, headers ...http.Header) map[string]string {
+	redacted := maps.Clone(variables)
+	for name, value := range redacted {
+		if value != "" && inSensitiveHeader(value, headers) {
+			delete(redacted, name)
+		}
+	}
+	return redacted
+}
+
+// inSensitiveHeader reports whether a value of a sensitive header contains value
+func inSensitiveHeader(value string, headers []http.Header) bool {
+	for _, header := range headers {
+		for _, name := range sensitiveHeaders {
+			for _, headerValue := range header.Values(name) {
+				if strings.Contains(headerValue, value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
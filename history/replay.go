@@ -0,0 +1,58 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/go-multierror"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+// Replay executes recorded entries again with client, in order, e.g. a sample of yesterday's production
+// run against staging. Bodies and headers are sent verbatim as recorded, except for the credential
+// headers recorded as Redacted, which are left out: give the replaying client its own credentials, e.g.
+// with WithDefaultHeader or WithOAuth2. URLs are substituted again from the URL as written in the file,
+// so configure the client to override values: WithEnvironment selects another environment of the
+// request file's http-client.env.json, WithVars overrides selected variables (taking precedence over the
+// recorded file variables) and WithBaseURL applies to relative URLs. Entries recorded without the
+// authored URL are sent to their recorded URL.
+//
+// Like ExecuteFile, Replay returns one response per entry and an aggregate of RequestErrors indexed by
+// the entries' request index. Use NewEntry to record the responses, e.g. for report.DiffRuns.
+func Replay(ctx context.Context, client *rc.Client, entries []Entry) ([]*rc.Response, error) {
+	var responses []*rc.Response
+	var multiErr *multierror.Error
+	for _, entry := range entries {
+		req := entry.ToRequest()
+		dropRedactedHeaders(req.Headers)
+		if entry.Request.RawURL != "" {
+			req.URL = nil
+		}
+
+		response, err := client.ExecuteRequest(ctx, req)
+		if err != nil {
+			var reqErr *rc.RequestError
+			if errors.As(err, &reqErr) {
+				indexed := *reqErr
+				indexed.Index = entry.Index
+				err = &indexed
+			}
+			multiErr = multierror.Append(multiErr, err)
+		}
+		if response != nil {
+			responses = append(responses, response)
+		}
+	}
+	return responses, multiErr.ErrorOrNil()
+}
+
+// dropRedactedHeaders removes the headers whose values were not recorded
+func dropRedactedHeaders(headers http.Header) {
+	for name, values := range headers {
+		if len(values) == 1 && values[0] == Redacted {
+			delete(headers, name)
+		}
+	}
+}
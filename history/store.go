@@ -0,0 +1,222 @@
+// Package history persists executed requests and their responses as JSON lines, one entry per
+// request, so past runs can be inspected or validated again. Register a Store with
+// restclient.WithHistory:
+//
+//	store, err := history.Open("restclient-history.jsonl")
+//	client, err := restclient.NewClient(restclient.WithHistory(store))
+//	...
+//	entry, err := store.Last("createUser")
+//	err = client.ValidateResponses("createUser.hresp", entry.ToResponse())
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+const (
+	historyDirPerm  = 0o755
+	historyFilePerm = 0o644
+
+	// maxEntrySize bounds a single JSON line; entries hold whole bodies
+	maxEntrySize = 64 << 20
+)
+
+// ErrNotFound is returned by queries matching no recorded entry.
+var ErrNotFound = errors.New("history entry not found")
+
+// Entry is one executed request of a run: the request as sent, after variable substitution,
+// and a summary of its response. Bodies are stored as text, so bytes that are not valid UTF-8
+// are not preserved.
+type Entry struct {
+	RunID       string            `json:"runId"`
+	RunStart    time.Time         `json:"runStart"`
+	RequestFile string            `json:"requestFile"`
+	Index       int               `json:"index"` // zero-based position of the request in the file
+	Name        string            `json:"name,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"` // resolved file variables, keyed by "@name"; see NewEntry
+	Request     RequestRecord     `json:"request"`
+	Response    ResponseRecord    `json:"response"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// RequestRecord is the request as sent, excluding the client's default headers.
+type RequestRecord struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	RawURL  string      `json:"rawUrl,omitempty"` // as written in the file, before substitution
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// ResponseRecord summarizes a response and its timing.
+type ResponseRecord struct {
+	Status        string        `json:"status,omitempty"`
+	StatusCode    int           `json:"statusCode,omitempty"`
+	Proto         string        `json:"proto,omitempty"`
+	Headers       http.Header   `json:"headers,omitempty"`
+	Body          string        `json:"body,omitempty"`
+	FinalURL      string        `json:"finalUrl,omitempty"`
+	StartTime     time.Time     `json:"startTime"`
+	Duration      time.Duration `json:"duration"`
+	BytesSent     int64         `json:"bytesSent,omitempty"`
+	BytesReceived int64         `json:"bytesReceived,omitempty"`
+}
+
+// Store appends entries to a JSON-lines file and queries them. It is safe for concurrent use
+// within a process; entries written by other processes are seen by subsequent queries.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Store implements restclient.HistoryRecorder.
+var _ rc.HistoryRecorder = (*Store)(nil)
+
+// Open returns a store backed by the JSON-lines file at path, creating the file and its parent
+// directories if needed. Existing entries are kept.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), historyDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create history directory for %s: %w", path, err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, historyFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	return &Store{path: path}, nil
+}
+
+// Path returns the file backing the store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// RecordResponse appends the entry for a response produced by ExecuteFile.
+func (s *Store) RecordResponse(run rc.HistoryRun, index int, response *rc.Response) error {
+	return s.Append(NewEntry(run, index, response))
+}
+
+// Append writes entry as a new line of the history file.
+func (s *Store) Append(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode history entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, historyFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to open history %s: %w", s.path, err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write history %s: %w", s.path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write history %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Entries returns every recorded entry, oldest first.
+func (s *Store) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", s.path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode history %s line %d: %w", s.path, lineNumber, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history %s: %w", s.path, err)
+	}
+	return entries, nil
+}
+
+// Last returns the most recent entry of the request with the given @name.
+func (s *Store) Last(name string) (Entry, error) {
+	entries, err := s.Entries()
+	if err != nil {
+		return Entry{}, err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Name == name {
+			return entries[i], nil
+		}
+	}
+	return Entry{}, fmt.Errorf("request %q: %w", name, ErrNotFound)
+}
+
+// RunIDs returns the IDs of the recorded runs, oldest first.
+func (s *Store) RunIDs() ([]string, error) {
+	entries, err := s.Entries()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if !seen[entry.RunID] {
+			seen[entry.RunID] = true
+			ids = append(ids, entry.RunID)
+		}
+	}
+	return ids, nil
+}
+
+// Run returns the entries of the run with the given ID, in request order.
+func (s *Store) Run(id string) ([]Entry, error) {
+	entries, err := s.Entries()
+	if err != nil {
+		return nil, err
+	}
+	var run []Entry
+	for _, entry := range entries {
+		if entry.RunID == id {
+			run = append(run, entry)
+		}
+	}
+	if len(run) == 0 {
+		return nil, fmt.Errorf("run %q: %w", id, ErrNotFound)
+	}
+	return run, nil
+}
+
+// LastRun returns the entries of the most recent run, in request order.
+func (s *Store) LastRun() ([]Entry, error) {
+	ids, err := s.RunIDs()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no runs: %w", ErrNotFound)
+	}
+	return s.Run(ids[len(ids)-1])
+}
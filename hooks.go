@@ -0,0 +1,126 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BeforeRequestHook runs before a request of a file is substituted and sent, e.g. to seed a database
+// the request relies on. It may provide variables for the request with req.SetVariable.
+type BeforeRequestHook func(ctx context.Context, req *Request) error
+
+// AfterResponseHook runs once the response of a request of a file was received, in the order of the
+// file, e.g. to assert the side effects of the request. It also runs for requests that could not be
+// sent, whose resp.Error is set.
+type AfterResponseHook func(ctx context.Context, resp *Response) error
+
+// BeforeFileHook runs before the requests of a file are sent, with the path of the file
+type BeforeFileHook func(ctx context.Context, filePath string) error
+
+// AfterFileHook runs once the requests of a file ran, with the path of the file and the responses
+type AfterFileHook func(ctx context.Context, filePath string, responses []*Response) error
+
+// executionHooks are the hooks of the client, in the order they were added
+type executionHooks struct {
+	beforeRequest []BeforeRequestHook
+	afterResponse []AfterResponseHook
+	beforeFile    []BeforeFileHook
+	afterFile     []AfterFileHook
+}
+
+// WithBeforeRequestHook runs hook before each request of ExecuteFile and the related methods is
+// substituted and sent, after the requests before it in the file completed. Unlike request
+// interceptors (see WithRequestInterceptor), which see each substituted request as it is sent, hooks
+// run once per request of the file, and their variables are substituted. An error fails the request
+// with a RequestError of phase PhaseHook, without sending it. With a concurrency above 1, hooks of
+// different requests may run at once.
+func WithBeforeRequestHook(hook BeforeRequestHook) ClientOption {
+	return func(c *Client) error {
+		if hook == nil {
+			return errors.New("before request hook must not be nil")
+		}
+		c.hooks.beforeRequest = append(c.hooks.beforeRequest, hook)
+		return nil
+	}
+}
+
+// WithAfterResponseHook runs hook on the response of each request of ExecuteFile and the related
+// methods, in the order of the file, after the response handler script and before the assertions of
+// WithRequestAssertion. An error is reported as a failure of the request with phase PhaseHook.
+func WithAfterResponseHook(hook AfterResponseHook) ClientOption {
+	return func(c *Client) error {
+		if hook == nil {
+			return errors.New("after response hook must not be nil")
+		}
+		c.hooks.afterResponse = append(c.hooks.afterResponse, hook)
+		return nil
+	}
+}
+
+// WithBeforeFileHook runs hook before the requests of each file executed with ExecuteFile and the
+// related methods are sent. An error fails the run without sending any request.
+func WithBeforeFileHook(hook BeforeFileHook) ClientOption {
+	return func(c *Client) error {
+		if hook == nil {
+			return errors.New("before file hook must not be nil")
+		}
+		c.hooks.beforeFile = append(c.hooks.beforeFile, hook)
+		return nil
+	}
+}
+
+// WithAfterFileHook runs hook once the requests of each file executed with ExecuteFile and the related
+// methods ran, also when some failed, e.g. to clean up what a BeforeFileHook seeded. An error is added
+// to the errors of the run.
+func WithAfterFileHook(hook AfterFileHook) ClientOption {
+	return func(c *Client) error {
+		if hook == nil {
+			return errors.New("after file hook must not be nil")
+		}
+		c.hooks.afterFile = append(c.hooks.afterFile, hook)
+		return nil
+	}
+}
+
+// runBeforeRequestHooks runs the before request hooks in order, stopping at the first error
+func (c *Client) runBeforeRequestHooks(ctx context.Context, index int, req *Request) error {
+	for _, hook := range c.hooks.beforeRequest {
+		if err := hook(ctx, req); err != nil {
+			return newRequestError(req, index, PhaseHook, fmt.Errorf("before request hook: %w", err))
+		}
+	}
+	return nil
+}
+
+// runAfterResponseHooks runs the after response hooks in order, stopping at the first error
+func (c *Client) runAfterResponseHooks(ctx context.Context, index int, resp *Response) error {
+	for _, hook := range c.hooks.afterResponse {
+		if err := hook(ctx, resp); err != nil {
+			return newRequestError(resp.Request, index, PhaseHook, fmt.Errorf("after response hook: %w", err))
+		}
+	}
+	return nil
+}
+
+// runBeforeFileHooks runs the before file hooks in order, stopping at the first error
+func (c *Client) runBeforeFileHooks(ctx context.Context, filePath string) error {
+	for _, hook := range c.hooks.beforeFile {
+		if err := hook(ctx, filePath); err != nil {
+			return &RequestError{Index: -1, FilePath: filePath, Phase: PhaseHook,
+				Err: fmt.Errorf("before file hook: %w", err)}
+		}
+	}
+	return nil
+}
+
+// runAfterFileHooks runs the after file hooks in order, stopping at the first error
+func (c *Client) runAfterFileHooks(ctx context.Context, filePath string, responses []*Response) error {
+	for _, hook := range c.hooks.afterFile {
+		if err := hook(ctx, filePath, responses); err != nil {
+			return &RequestError{Index: -1, FilePath: filePath, Phase: PhaseHook,
+				Err: fmt.Errorf("after file hook: %w", err)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package restclient
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// bodyFilePrefix starts the body of an expected response read from a file: "< ./user.json"
+const bodyFilePrefix = "< "
+
+// processBodyFileLine handles the "< path" line that replaces the body of an expected response, which
+// must be its only body line. Bodies read from files keep lines starting with '#', '@' or "###", which
+// the .hresp syntax would take for comments, variables and separators.
+func (s *responseParserState) processBodyFileLine(trimmedLine string) (bool, error) {
+	if s.currentExpectedResponse.BodyFile != "" {
+		if trimmedLine == "" {
+			return true, nil
+		}
+		return true, fmt.Errorf("line %d: unexpected body line after '%s%s'",
+			s.lineNumber, bodyFilePrefix, s.currentExpectedResponse.BodyFile)
+	}
+	if len(s.bodyLines) > 0 || !strings.HasPrefix(trimmedLine, bodyFilePrefix) {
+		return false, nil
+	}
+	s.currentExpectedResponse.BodyFile = strings.TrimSpace(strings.TrimPrefix(trimmedLine, bodyFilePrefix))
+	return true, nil
+}
+
+// loadExpectedBodyFiles reads the bodies of expected responses given as "< path", relative to the
+// directory of the .hresp file. Their content is used as is, without variable substitution.
+func loadExpectedBodyFiles(responseFilePath string, expectedResponses []*ExpectedResponse) error {
+	for _, expected := range expectedResponses {
+		if expected.BodyFile == "" {
+			continue
+		}
+		path := expected.BodyFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(responseFilePath), path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read expected body file of %s: %w", responseFilePath, err)
+		}
+		body := string(normalizeLineEndings(content))
+		expected.Body = &body
+	}
+	return nil
+}
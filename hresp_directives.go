@@ -0,0 +1,226 @@
+package restclient
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// hrespDirectiveParser parses the value of an .hresp assertion directive into the expected response
+type hrespDirectiveParser func(value string, resp *ExpectedResponse) error
+
+// hrespDirectives lists the comment directives that add assertions to an expected response,
+// e.g. "# redirects 2" or "# max-size: 64KB". They are recognized in the status/header section only;
+// other comments are ignored as before.
+var hrespDirectives = map[string]hrespDirectiveParser{
+	"final-url":     parseFinalURLDirective,
+	"redirects":     parseRedirectsDirective,
+	"max-bytes":     parseMaxBytesDirective,
+	"max-size":      parseMaxSizeDirective,
+	"max-duration":  parseMaxDurationDirective,
+	"validate":      parseValidateDirective,
+	"cache-status":  parseCacheStatusDirective,
+	"ndjson-lines":  parseNDJSONLinesDirective,
+	"xmlns":         parseXMLNamespaceDirective,
+	"trailer":       parseTrailerDirective,
+	"informational": parseInformationalDirective,
+	"match-mode":    parseMatchModeDirective,
+	"unordered":     parseUnorderedDirective,
+}
+
+// processDirectiveLine handles a comment line that is an assertion directive.
+// It reports whether the line was consumed.
+func (s *responseParserState) processDirectiveLine(trimmedLine string) (bool, error) {
+	if s.parsingBody || !strings.HasPrefix(trimmedLine, commentPrefix) {
+		return false, nil
+	}
+	fields := strings.Fields(strings.TrimLeft(trimmedLine, commentPrefix))
+	if len(fields) == 0 {
+		return false, nil
+	}
+	name := strings.TrimSuffix(fields[0], ":")
+	parse, ok := hrespDirectives[name]
+	if !ok {
+		return false, nil
+	}
+	s.processedAnyLine = true
+	value := strings.Join(fields[1:], " ")
+	if err := parse(value, s.currentExpectedResponse); err != nil {
+		return true, fmt.Errorf("line %d: invalid '# %s' directive: %w", s.lineNumber, name, err)
+	}
+	return true, nil
+}
+
+// parseFinalURLDirective parses "# final-url <url or /path>"
+func parseFinalURLDirective(value string, resp *ExpectedResponse) error {
+	if value == "" {
+		return fmt.Errorf("missing URL")
+	}
+	resp.FinalURL = &value
+	return nil
+}
+
+// parseRedirectsDirective parses "# redirects <count>"
+func parseRedirectsDirective(value string, resp *ExpectedResponse) error {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return fmt.Errorf("expected a non-negative number of redirects, got '%s'", value)
+	}
+	resp.RedirectCount = &count
+	return nil
+}
+
+// parseMaxBytesDirective parses "# max-bytes <bytes>"
+func parseMaxBytesDirective(value string, resp *ExpectedResponse) error {
+	maxBytes, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || maxBytes < 0 {
+		return fmt.Errorf("expected a non-negative number of bytes, got '%s'", value)
+	}
+	resp.MaxBytes = &maxBytes
+	return nil
+}
+
+// reByteSize matches the sizes of "# max-size", e.g. "64KB", "1.5 MB" or "512"
+var reByteSize = regexp.MustCompile(`^(?i)(\d+(?:\.\d+)?)\s*([KMG]?B)?$`)
+
+// byteSizeUnits are the units of "# max-size", in powers of 1024
+var byteSizeUnits = map[string]float64{"": 1, "B": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30}
+
+// parseMaxSizeDirective parses "# max-size <size>", e.g. "64KB", "1.5 MB" or "512" (bytes); a
+// kilobyte is 1024 bytes
+func parseMaxSizeDirective(value string, resp *ExpectedResponse) error {
+	match := reByteSize.FindStringSubmatch(value)
+	if match == nil {
+		return fmt.Errorf("expected a size like 64KB, 1MB or 512B, got '%s'", value)
+	}
+	size, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return fmt.Errorf("expected a size like 64KB, 1MB or 512B, got '%s'", value)
+	}
+	maxBytes := int64(size * byteSizeUnits[strings.ToUpper(match[2])])
+	resp.MaxBytes = &maxBytes
+	return nil
+}
+
+// parseMaxDurationDirective parses "# max-duration <duration>", e.g. "500ms" or "2s"
+func parseMaxDurationDirective(value string, resp *ExpectedResponse) error {
+	maxDuration, err := time.ParseDuration(value)
+	if err != nil || maxDuration <= 0 {
+		return fmt.Errorf("expected a positive duration like 500ms or 2s, got '%s'", value)
+	}
+	resp.MaxDuration = &maxDuration
+	return nil
+}
+
+// parseValidateDirective parses "# validate <name> [args...]" naming a registered ResponseValidator
+func parseValidateDirective(value string, resp *ExpectedResponse) error {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return fmt.Errorf("missing validator name")
+	}
+	if _, ok := lookupValidator(fields[0]); !ok {
+		return fmt.Errorf("unknown validator '%s'", fields[0])
+	}
+	resp.Validators = append(resp.Validators, ValidatorCall{Name: fields[0], Args: fields[1:]})
+	return nil
+}
+
+// parseCacheStatusDirective parses "# cache-status <HIT|MISS|REVALIDATED>"
+func parseCacheStatusDirective(value string, resp *ExpectedResponse) error {
+	status, err := parseCacheStatus(value)
+	if err != nil {
+		return err
+	}
+	resp.CacheStatus = &status
+	return nil
+}
+
+// validatePlugins applies the registered validators named by "# validate" directives
+func (*Client) validatePlugins(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	for _, call := range expected.Validators {
+		validator, ok := lookupValidator(call.Name)
+		if !ok {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"validation for response #%d ('%s'): unknown validator '%s'", responseIndex, responseFilePath, call.Name))
+			continue
+		}
+		if err := validator.ValidateResponse(actual, call.Args); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"validation for response #%d ('%s'): validator '%s' failed: %w",
+				responseIndex, responseFilePath, call.Name, err))
+		}
+	}
+	return errs
+}
+
+// validateBudgets checks the "# max-bytes" and "# max-duration" assertions
+func (*Client) validateBudgets(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if expected.MaxBytes != nil && int64(len(actual.Body)) > *expected.MaxBytes {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): body size %d bytes exceeds max-bytes %d",
+			responseIndex, responseFilePath, len(actual.Body), *expected.MaxBytes))
+	}
+	if expected.MaxDuration != nil && actual.Duration > *expected.MaxDuration {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): duration %s exceeds max-duration %s",
+			responseIndex, responseFilePath, actual.Duration, *expected.MaxDuration))
+	}
+	return errs
+}
+
+// validateRedirects checks the "# final-url" and "# redirects" assertions
+func (*Client) validateRedirects(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if expected.FinalURL != nil && !finalURLMatches(*expected.FinalURL, actual.FinalURL) {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): final URL mismatch: expected '%s', got '%s'",
+			responseIndex, responseFilePath, *expected.FinalURL, actual.FinalURL))
+	}
+	if expected.RedirectCount != nil && len(actual.Redirects) != *expected.RedirectCount {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): redirect count mismatch: expected %d, got %d %v",
+			responseIndex, responseFilePath, *expected.RedirectCount, len(actual.Redirects),
+			redirectHopURLs(actual.Redirects)))
+	}
+	return errs
+}
+
+// validateCacheStatus checks the "# cache-status" assertion
+func (*Client) validateCacheStatus(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if expected.CacheStatus != nil && actual.CacheStatus != *expected.CacheStatus {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): cache status mismatch: expected '%s', got '%s'",
+			responseIndex, responseFilePath, *expected.CacheStatus, actual.CacheStatus))
+	}
+	return errs
+}
+
+// finalURLMatches compares an expected final URL with the actual one. An expected value starting
+// with '/' is compared with the actual path and query only, independent of scheme and host.
+func finalURLMatches(expected, actual string) bool {
+	if !strings.HasPrefix(expected, "/") {
+		return expected == actual
+	}
+	parsed, err := url.Parse(actual)
+	if err != nil {
+		return false
+	}
+	return expected == parsed.RequestURI()
+}
+
+// redirectHopURLs lists the hop URLs for error messages
+func redirectHopURLs(hops []RedirectHop) []string {
+	urls := make([]string, 0, len(hops))
+	for _, hop := range hops {
+		urls = append(urls, hop.URL)
+	}
+	return urls
+}
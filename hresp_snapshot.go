@@ -0,0 +1,186 @@
+package restclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// UpdateExpectedEnv is the environment variable that makes ValidateResponses write the .hresp file from the
+// actual responses instead of comparing them, when set to a true value such as "1" or "true"
+const UpdateExpectedEnv = "UPDATE_HRESP"
+
+// Permissions of the directories and .hresp files written in snapshot update mode
+const (
+	snapshotDirPerm  = 0o755
+	snapshotFilePerm = 0o644
+)
+
+// snapshotSkippedHeaders are the response headers left out of written .hresp files, as their values
+// change between runs or describe the connection rather than the response
+var snapshotSkippedHeaders = map[string]bool{
+	"Age":               true,
+	"Connection":        true,
+	"Content-Length":    true,
+	"Date":              true,
+	"Etag":              true,
+	"Keep-Alive":        true,
+	"Set-Cookie":        true,
+	"Transfer-Encoding": true,
+}
+
+// snapshotPlaceholders replace the dynamic values of written .hresp files, in order
+var snapshotPlaceholders = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(guidRegexPattern), "{{$anyGuid}}"},
+	{regexp.MustCompile(iso8601RegexPattern), "{{$anyDatetime iso8601}}"},
+	{regexp.MustCompile(rfc1123RegexPattern), "{{$anyDatetime rfc1123}}"},
+}
+
+// WriteExpectedResponses writes responses to the .hresp file at path, replacing it, so they can be
+// validated later with ValidateResponses. Dynamic values of header values and bodies are replaced by
+// placeholders: GUIDs by {{$anyGuid}}, ISO 8601 and RFC 1123 dates by {{$anyDatetime}}. Headers that
+// change between runs, e.g. Date and Content-Length, and Set-Cookie are left out, and JSON bodies are
+// indented. Bodies with lines the .hresp syntax would not read back as body, such as Markdown headings
+// starting with '#', are written to a file next to it, e.g. "users.2.body", referenced with "< file".
+// Responses that were not received (their Error is set) cannot be written.
+func (c *Client) WriteExpectedResponses(path string, responses ...*Response) error {
+	var content strings.Builder
+	bodyFiles := make(map[string]string)
+	for i, resp := range responses {
+		if resp == nil {
+			return fmt.Errorf("cannot write expected response %d: response is nil", i+1)
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("cannot write expected response %d: %w", i+1, resp.Error)
+		}
+		if i > 0 {
+			content.WriteString("\n###\n\n")
+		}
+		writeExpectedResponse(&content, resp)
+		body, inline := expectedResponseBody(resp)
+		switch {
+		case body == "":
+		case inline:
+			content.WriteString("\n" + body)
+		default:
+			name := fmt.Sprintf("%s.%d.body", strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), i+1)
+			bodyFiles[name] = body
+			content.WriteString("\n" + bodyFilePrefix + "./" + name + "\n")
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), snapshotDirPerm); err != nil {
+		return fmt.Errorf("failed to create directory of %s: %w", path, err)
+	}
+	for name, body := range bodyFiles {
+		if err := os.WriteFile(filepath.Join(filepath.Dir(path), name), []byte(body), snapshotFilePerm); err != nil {
+			return fmt.Errorf("failed to write expected body: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(content.String()), snapshotFilePerm); err != nil {
+		return fmt.Errorf("failed to write expected responses: %w", err)
+	}
+	return nil
+}
+
+// ExecuteAndWriteExpected executes the requests of requestFilePath like ExecuteFile and writes their
+// responses with WriteExpectedResponses to expectedDir, in a .hresp file named after the request file,
+// e.g. "users.hresp" for "users.http". The file is not written when a request could not be sent.
+func (c *Client) ExecuteAndWriteExpected(
+	ctx context.Context, requestFilePath, expectedDir string,
+) ([]*Response, error) {
+	responses, err := c.ExecuteFile(ctx, requestFilePath)
+	for _, resp := range responses {
+		if resp == nil || resp.Error != nil {
+			return responses, err
+		}
+	}
+	name := strings.TrimSuffix(filepath.Base(requestFilePath), filepath.Ext(requestFilePath)) + ".hresp"
+	if writeErr := c.WriteExpectedResponses(filepath.Join(expectedDir, name), responses...); writeErr != nil {
+		return responses, multierror.Append(err, writeErr).ErrorOrNil()
+	}
+	return responses, err
+}
+
+// updateExpectedEnabled tells whether UpdateExpectedEnv asks ValidateResponses to write .hresp files
+func updateExpectedEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(UpdateExpectedEnv))
+	return err == nil && enabled
+}
+
+// writeExpectedResponse writes the status line and headers of a response in .hresp syntax
+func writeExpectedResponse(content *strings.Builder, resp *Response) {
+	proto := resp.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	status := resp.Status
+	code := strconv.Itoa(resp.StatusCode)
+	if !strings.HasPrefix(status, code) {
+		status = strings.TrimSpace(code + " " + http.StatusText(resp.StatusCode))
+	}
+	fmt.Fprintf(content, "%s %s\n", proto, status)
+
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		if !snapshotSkippedHeaders[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Headers[name] {
+			fmt.Fprintf(content, "%s: %s\n", name, replaceDynamicValues(value))
+		}
+	}
+}
+
+// expectedResponseBody returns the body of a response as written to an .hresp file, ending with a line
+// break, and whether it can be written inline: not when a line would be read back as a comment, variable,
+// separator or body file reference, or when it contains "{{", which would be substituted as a variable
+func expectedResponseBody(resp *Response) (string, bool) {
+	body := resp.BodyString
+	if body == "" && len(resp.Body) > 0 {
+		body = string(resp.Body)
+	}
+	if body == "" {
+		return "", true
+	}
+	inline := !strings.Contains(body, "{{")
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, commentPrefix) || strings.HasPrefix(trimmed, "@") ||
+			strings.HasPrefix(trimmed, bodyFilePrefix) {
+			inline = false
+		}
+	}
+	var indented bytes.Buffer
+	if json.Valid([]byte(body)) && json.Indent(&indented, []byte(body), "", "  ") == nil {
+		body = indented.String()
+	}
+	body = replaceDynamicValues(body)
+	if !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+	return body, inline
+}
+
+// replaceDynamicValues replaces the GUIDs and dates of text by placeholders matching them
+func replaceDynamicValues(text string) string {
+	for _, p := range snapshotPlaceholders {
+		text = p.pattern.ReplaceAllLiteralString(text, p.placeholder)
+	}
+	return text
+}
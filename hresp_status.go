@@ -0,0 +1,46 @@
+package restclient
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reStatusAlternative matches an acceptable status of an .hresp status line: a code like 201, or a
+// status class like 2xx
+var reStatusAlternative = regexp.MustCompile(`^(?i)[1-5](?:\d\d|xx)$`)
+
+// isStatusAlternatives reports whether the status of an .hresp status line lists alternatives, e.g.
+// "200|201", or is a status class, e.g. "2xx"
+func isStatusAlternatives(status string) bool {
+	return strings.Contains(status, "|") || strings.HasSuffix(strings.ToLower(status), "xx")
+}
+
+// parseStatusAlternatives parses the status of a status line like "HTTP/1.1 200|201|204" or
+// "HTTP/1.1 2xx|304"; a status text following it is ignored
+func parseStatusAlternatives(status string, lineNumber int, resp *ExpectedResponse) error {
+	alternatives := strings.Split(strings.ToLower(status), "|")
+	for _, alternative := range alternatives {
+		if !reStatusAlternative.MatchString(alternative) {
+			return fmt.Errorf("line %d: invalid status '%s': expected codes like 200 or classes like 2xx, "+
+				"separated by |", lineNumber, alternative)
+		}
+	}
+	resp.StatusCodes = alternatives
+	resp.Status = &status
+	return nil
+}
+
+// statusCodeAccepted reports whether code is one of the acceptable codes or in one of the classes
+func statusCodeAccepted(code int, accepted []string) bool {
+	for _, alternative := range accepted {
+		switch {
+		case strings.HasSuffix(alternative, "xx") && strconv.Itoa(code/100) == alternative[:1]:
+			return true
+		case strconv.Itoa(code) == alternative:
+			return true
+		}
+	}
+	return false
+}
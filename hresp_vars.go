@@ -3,9 +3,11 @@ package restclient
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/joho/godotenv"
 )
 
 // extractHrespDefines parses raw .hresp content to find @name=value definitions at the beginning of lines.
@@ -70,12 +72,13 @@ func processDefineLineToMaps(trimmedLine string, defines map[string]string) {
 //     if the placeholder is a direct system variable like `{{$uuid}}`).
 //     These are generated once per call by `client.generateRequestScopedSystemVariables()` if a `client` is provided.
 //  2. Client Programmatic variables (from `client.programmaticVars`, map[string]any)
-//  3. `fileVars` (variables defined with `@name=value` in the .hresp file itself, map[string]string)
-//  4. OS Environment variables (looked up by `variableName`)
+//  3. `fileVars` (variables defined with `@name=value` in the .hresp file itself, then the variables of the
+//     selected environment, see loadHrespEnvironment)
+//  4. Variable providers (see WithVarProvider), then OS Environment variables (looked up by `variableName`)
 //  5. `fallbackValue` (if provided in the placeholder like `{{variableName | fallbackValue}}`)
 //
 // After the above substitutions, a final pass is made using
-// `client.substituteDynamicSystemVariables` if a `client` is provided.
+// `substituteDynamicSystemVariables` with `dotEnvVars` if a `client` is provided.
 // This second pass handles system variables that require argument parsing
 // from their placeholder (e.g., `{{$dotenv VAR}}`, `{{$randomInt MIN MAX}}`),
 // and also resolves simple system variables (like `{{$uuid}}`) that might have been
@@ -85,14 +88,14 @@ func processDefineLineToMaps(trimmedLine string, defines map[string]string) {
 // If a variable is not found in any source and no fallback is specified, the placeholder remains unchanged.
 // The `client` parameter is optional; if nil, client-side programmatic variable substitution and all system
 // variable substitutions will not occur.
-func resolveAndSubstitute(content string, fileVars map[string]string, client *Client) string {
+func resolveAndSubstitute(content string, fileVars, dotEnvVars map[string]string, client *Client) string {
 	re := regexp.MustCompile(`{{\s*(.*?)\s*}}`)
 	requestScopedSystemVars := getRequestScopedSystemVars(client)
 
 	resolvedContent := re.ReplaceAllStringFunc(content, 
 		createFirstPassReplacer(requestScopedSystemVars, fileVars, client))
 	resolvedContent = performSecondPass(re, resolvedContent, requestScopedSystemVars)
-	resolvedContent = performFinalPass(resolvedContent, client)
+	resolvedContent = performFinalPass(resolvedContent, dotEnvVars, client)
 
 	return resolvedContent
 }
@@ -113,6 +116,11 @@ func createFirstPassReplacer(
 ) func(string) string {
 	return func(match string) string {
 		directive := strings.TrimSpace(match[2 : len(match)-2])
+		if value, isExpression := resolveExpressionPlaceholder(match, directive, func(name string) string {
+			return resolveVariable(name, client, fileVars)
+		}); isExpression {
+			return value
+		}
 
 		if handleSystemVariable(directive, requestScopedSystemVars, match) != match {
 			return handleSystemVariable(directive, requestScopedSystemVars, match)
@@ -166,7 +174,7 @@ func resolveVariable(varName string, client *Client, fileVars map[string]string)
 	if val := tryFileVars(varName, fileVars); val != "" {
 		return val
 	}
-	if val := tryEnvironmentVars(varName); val != "" {
+	if val := tryEnvironmentVars(varName, client); val != "" {
 		return val
 	}
 	return "" // Not found
@@ -192,9 +200,9 @@ func tryFileVars(varName string, fileVars map[string]string) string {
 	return ""
 }
 
-// tryEnvironmentVars checks OS environment variables
-func tryEnvironmentVars(varName string) string {
-	if envVal, ok := os.LookupEnv(varName); ok {
+// tryEnvironmentVars checks the client's variable providers and OS environment variables
+func tryEnvironmentVars(varName string, client *Client) string {
+	if envVal, ok := client.lookupVariable(varName); ok {
 		return envVal
 	}
 	return ""
@@ -218,10 +226,28 @@ func performSecondPass(re *regexp.Regexp, content string, requestScopedSystemVar
 }
 
 // performFinalPass handles dynamic system variables
-func performFinalPass(content string, client *Client) string {
+func performFinalPass(content string, dotEnvVars map[string]string, client *Client) string {
 	if client != nil {
-		return substituteDynamicSystemVariables(
-			content, client.currentDotEnvVars, client.programmaticVars)
+		return substituteDynamicSystemVariables(content, dotEnvVars, client.programmaticVars)
 	}
 	return content
 }
+
+// loadHrespEnvironment returns the variables of the selected environment (see WithEnvironment) in the
+// http-client.env.json files next to an .hresp file, and the variables of the .env file next to it for
+// {{$dotenv}}, or those of the last executed .http file when there is none
+func (c *Client) loadHrespEnvironment(responseFilePath string) (map[string]string, map[string]string, error) {
+	fileDir := filepath.Dir(responseFilePath)
+	envVars := make(map[string]string)
+	if c.selectedEnvironmentName != "" {
+		var err error
+		if envVars, err = loadEnvironmentFiles(fileDir, c.selectedEnvironmentName); err != nil {
+			return nil, nil, err
+		}
+	}
+	dotEnvVars, err := godotenv.Read(filepath.Join(fileDir, ".env"))
+	if err != nil {
+		dotEnvVars = c.currentDotEnvVars
+	}
+	return envVars, dotEnvVars, nil
+}
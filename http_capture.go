@@ -0,0 +1,81 @@
+package restclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// FromHTTPRequest converts a live *http.Request into a Request, so middleware can capture incoming
+// or outgoing requests and persist them as .http fixtures with FormatRequests. Server-side requests,
+// whose URL holds only the path, get the scheme and host they were received on. The body is read
+// and then restored, so the request can still be handled or sent; it must be UTF-8 text, as .http
+// files cannot hold binary bodies. The Content-Length header is dropped, being derived from the body.
+func FromHTTPRequest(httpReq *http.Request) (*Request, error) {
+	if httpReq == nil {
+		return nil, errors.New("cannot convert a nil http.Request")
+	}
+	body, err := captureHTTPRequestBody(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	target := *httpReq.URL
+	if target.Host == "" {
+		target.Host = httpReq.Host
+		target.Scheme = "http"
+		if httpReq.TLS != nil {
+			target.Scheme = "https"
+		}
+	}
+	headers := httpReq.Header.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	headers.Del("Content-Length")
+	if httpReq.Host != "" && httpReq.Host != target.Host {
+		headers.Set("Host", httpReq.Host)
+	}
+	method := httpReq.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return &Request{
+		Method:       method,
+		RawURLString: target.String(),
+		URL:          &target,
+		Headers:      headers,
+		RawBody:      body,
+	}, nil
+}
+
+// captureHTTPRequestBody reads the body of a request and replaces it with an unread copy
+func captureHTTPRequestBody(httpReq *http.Request) (string, error) {
+	if httpReq.Body == nil || httpReq.Body == http.NoBody {
+		return "", nil
+	}
+	body, err := io.ReadAll(httpReq.Body)
+	_ = httpReq.Body.Close()
+	httpReq.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	if !utf8.Valid(body) {
+		return "", errors.New("request body is not UTF-8 text and cannot be written in .http syntax")
+	}
+	return string(body), nil
+}
+
+// FormatRequests renders requests in .http syntax (see Request.String), separated by "###", so they
+// can be written to a .http file and executed with ExecuteFile.
+func FormatRequests(requests ...*Request) string {
+	rendered := make([]string, 0, len(requests))
+	for _, req := range requests {
+		rendered = append(rendered, req.String())
+	}
+	return strings.Join(rendered, "\n###\n")
+}
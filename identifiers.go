@@ -0,0 +1,98 @@
+package restclient
+
+import (
+	"math/big"
+	"math/rand"
+	"regexp"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// charsetBase58 is the Bitcoin base58 alphabet (no 0, O, I or l)
+	charsetBase58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// charsetSlug contains the characters allowed in generated URL slugs
+	charsetSlug           = "abcdefghijklmnopqrstuvwxyz0123456789"
+	defaultRandomSlugLen  = 8
+	maxRandomSlugLen      = 256
+	shortUUIDMinLength    = 22
+	randomSlugSeparator   = '-'
+	randomSlugSegmentSize = 4
+)
+
+var (
+	reShortUuid  = regexp.MustCompile(`{{\s*\$shortUuid\s*}}`)
+	reRandomSlug = regexp.MustCompile(`{{\s*\$randomSlug(?:\s+(\d+))?\s*}}`)
+)
+
+// identifierRegexes lists the compact identifier generator patterns so that file-scoped
+// variables holding them are evaluated dynamically.
+var identifierRegexes = []*regexp.Regexp{reShortUuid, reRandomSlug}
+
+// substituteIdentifierVariables handles compact identifier generators:
+// {{$shortUuid}} (base58-encoded UUID v4) and {{$randomSlug N}} (URL-safe slug of length N).
+// Each occurrence produces a new value.
+func substituteIdentifierVariables(text string) string {
+	text = reShortUuid.ReplaceAllStringFunc(text, getShortUUID)
+	text = reRandomSlug.ReplaceAllStringFunc(text, substituteRandomSlug)
+	return text
+}
+
+// getShortUUID returns a new UUID v4 encoded in base58, left-padded to 22 characters
+func getShortUUID(_ string) string {
+	id := uuid.New()
+	return encodeBase58(id[:], shortUUIDMinLength)
+}
+
+// encodeBase58 encodes data using the base58 alphabet, padding with the zero digit to minLength
+func encodeBase58(data []byte, minLength int) string {
+	num := new(big.Int).SetBytes(data)
+	base := big.NewInt(int64(len(charsetBase58)))
+	mod := new(big.Int)
+
+	var encoded []byte
+	for num.Sign() > 0 {
+		num.DivMod(num, base, mod)
+		encoded = append(encoded, charsetBase58[mod.Int64()])
+	}
+	for len(encoded) < minLength {
+		encoded = append(encoded, charsetBase58[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// substituteRandomSlug generates a slug for a {{$randomSlug N}} match.
+// Malformed or out-of-range lengths leave the placeholder untouched.
+func substituteRandomSlug(match string) string {
+	length := defaultRandomSlugLen
+	parts := reRandomSlug.FindStringSubmatch(match)
+	if len(parts) > 1 && parts[1] != "" {
+		parsed, err := strconv.Atoi(parts[1])
+		if err != nil || parsed <= 0 || parsed > maxRandomSlugLen {
+			return match
+		}
+		length = parsed
+	}
+	return generateRandomSlug(length)
+}
+
+// generateRandomSlug builds a lowercase alphanumeric slug of exactly length characters.
+// Slugs longer than one segment are split into hyphen-separated groups of four characters;
+// a hyphen is never placed at the start or end, nor next to another hyphen.
+func generateRandomSlug(length int) string {
+	slug := make([]byte, length)
+	for i := range slug {
+		isSeparatorPos := (i+1)%(randomSlugSegmentSize+1) == 0
+		if isSeparatorPos && i != length-1 {
+			slug[i] = randomSlugSeparator
+			continue
+		}
+		slug[i] = charsetSlug[rand.Intn(len(charsetSlug))]
+	}
+	return string(slug)
+}
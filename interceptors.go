@@ -0,0 +1,69 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// RequestInterceptor is called before a request is sent, with its variables substituted and its URL
+// resolved, e.g. to add tracing headers with req.Headers.Set or to log the payload in req.RawBody.
+// Returning an error fails the request without sending it.
+type RequestInterceptor func(ctx context.Context, req *Request) error
+
+// ResponseInterceptor is called with the response of a request, also when sending it failed (see
+// Response.Error), e.g. to record metrics. Returning an error fails the request.
+type ResponseInterceptor func(ctx context.Context, resp *Response) error
+
+// WithRequestInterceptor adds an interceptor called before every request the client sends, including
+// each attempt of a @retry or @poll request and each page of a @paginate request. Interceptors run in
+// the order they were added; the first error stops the chain and fails the request.
+func WithRequestInterceptor(interceptor RequestInterceptor) ClientOption {
+	return func(c *Client) error {
+		if interceptor == nil {
+			return errors.New("request interceptor must not be nil")
+		}
+		c.requestInterceptors = append(c.requestInterceptors, interceptor)
+		return nil
+	}
+}
+
+// WithResponseInterceptor adds an interceptor called with every response the client receives, before
+// captures, response handler scripts and assertions see it. Interceptors run in the order they were
+// added; the first error stops the chain and fails the request, unless it already failed.
+func WithResponseInterceptor(interceptor ResponseInterceptor) ClientOption {
+	return func(c *Client) error {
+		if interceptor == nil {
+			return errors.New("response interceptor must not be nil")
+		}
+		c.responseInterceptors = append(c.responseInterceptors, interceptor)
+		return nil
+	}
+}
+
+// runRequestInterceptors calls the request interceptors in order until one fails
+func (c *Client) runRequestInterceptors(ctx context.Context, req *Request) error {
+	if len(c.requestInterceptors) > 0 && req.Headers == nil {
+		req.Headers = make(http.Header) // interceptors may set headers of requests built in code
+	}
+	for _, interceptor := range c.requestInterceptors {
+		if err := interceptor(ctx, req); err != nil {
+			return fmt.Errorf("request interceptor: %w", err)
+		}
+	}
+	return nil
+}
+
+// runResponseInterceptors calls the response interceptors in order until one fails, recording the
+// failure in resp.Error when the request succeeded so far
+func (c *Client) runResponseInterceptors(ctx context.Context, resp *Response) {
+	for _, interceptor := range c.responseInterceptors {
+		if err := interceptor(ctx, resp); err != nil {
+			if resp.Error == nil {
+				resp.Error = fmt.Errorf("response interceptor: %w", err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package restclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is a single step of a JSONPath expression: an object key or an array index
+type jsonPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// String renders the segment in path notation for error messages
+func (s jsonPathSegment) String() string {
+	if s.isIndex {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return "." + s.key
+}
+
+// evaluateJSONPath navigates decoded JSON data (as produced by encoding/json into `any`)
+// using a subset of JSONPath: `$` root, `.key`, `['key']` / `["key"]` and `[index]`
+// (negative indexes count from the end), e.g. `$.data.items[0].id`.
+func evaluateJSONPath(data any, path string) (any, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := data
+	walked := "$"
+	for _, segment := range segments {
+		current, err = applyJSONPathSegment(current, segment)
+		if err != nil {
+			return nil, fmt.Errorf("JSONPath %s: at %s: %w", path, walked, err)
+		}
+		walked += segment.String()
+	}
+	return current, nil
+}
+
+// applyJSONPathSegment applies one segment to the current value
+func applyJSONPathSegment(current any, segment jsonPathSegment) (any, error) {
+	if segment.isIndex {
+		arr, ok := current.([]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot index %T with [%d]", current, segment.index)
+		}
+		idx := segment.index
+		if idx < 0 {
+			idx += len(arr)
+		}
+		if idx < 0 || idx >= len(arr) {
+			return nil, fmt.Errorf("index %d out of range (length %d)", segment.index, len(arr))
+		}
+		return arr[idx], nil
+	}
+
+	obj, ok := current.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot read key %q from %T", segment.key, current)
+	}
+	value, ok := obj[segment.key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found", segment.key)
+	}
+	return value, nil
+}
+
+// parseJSONPath splits a JSONPath expression into segments
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest := strings.TrimSpace(path)
+	rest = strings.TrimPrefix(rest, "$")
+
+	var segments []jsonPathSegment
+	for rest != "" {
+		var segment jsonPathSegment
+		var err error
+		switch rest[0] {
+		case '.':
+			segment, rest, err = parseJSONPathKey(rest[1:])
+		case '[':
+			segment, rest, err = parseJSONPathBracket(rest[1:])
+		default:
+			// Allow a bare leading key, e.g. "data.id"
+			if len(segments) > 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", path, rest[0])
+			}
+			segment, rest, err = parseJSONPathKey(rest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSONPath %q: %w", path, err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// parseJSONPathKey parses a dot-notation key, which ends at the next '.' or '['
+func parseJSONPathKey(rest string) (jsonPathSegment, string, error) {
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		end = len(rest)
+	}
+	if end == 0 {
+		return jsonPathSegment{}, "", fmt.Errorf("empty key")
+	}
+	return jsonPathSegment{key: rest[:end]}, rest[end:], nil
+}
+
+// parseJSONPathBracket parses `[index]`, `['key']` or `["key"]` (the opening bracket already consumed)
+func parseJSONPathBracket(rest string) (jsonPathSegment, string, error) {
+	if rest != "" && (rest[0] == '\'' || rest[0] == '"') {
+		quote := rest[0]
+		end := strings.IndexByte(rest[1:], quote)
+		if end < 0 || len(rest) < end+3 || rest[end+2] != ']' {
+			return jsonPathSegment{}, "", fmt.Errorf("unterminated quoted key")
+		}
+		return jsonPathSegment{key: rest[1 : end+1]}, rest[end+3:], nil
+	}
+
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return jsonPathSegment{}, "", fmt.Errorf("missing ']'")
+	}
+	index, err := strconv.Atoi(strings.TrimSpace(rest[:end]))
+	if err != nil {
+		return jsonPathSegment{}, "", fmt.Errorf("invalid array index %q", rest[:end])
+	}
+	return jsonPathSegment{index: index, isIndex: true}, rest[end+1:], nil
+}
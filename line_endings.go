@@ -0,0 +1,18 @@
+package restclient
+
+import "bytes"
+
+// utf8BOM is the byte order mark some Windows editors prepend to UTF-8 files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// normalizeLineEndings prepares authored text files (.http, .hresp, environment files) for
+// line-based parsing: it strips a leading UTF-8 BOM and converts CRLF and lone CR line endings
+// to LF, so files saved on Windows or with mixed endings parse like LF-only files.
+func normalizeLineEndings(content []byte) []byte {
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if bytes.IndexByte(content, '\r') < 0 {
+		return content
+	}
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+}
@@ -0,0 +1,113 @@
+package restclient
+
+import (
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLoremSentenceWords = 8
+	defaultLoremParagraphs    = 1
+	maxLoremSentenceWords     = 1000
+	maxLoremParagraphs        = 100
+	minLoremParagraphSentence = 3
+	maxLoremParagraphSentence = 6
+	minLoremSentenceWords     = 6
+	maxLoremWordsVariance     = 7
+)
+
+var (
+	reRandomLoremWord       = regexp.MustCompile(`{{\s*\$randomLoremWord\s*}}`)
+	reRandomLoremSentence   = regexp.MustCompile(`{{\s*\$randomLoremSentence(?:\s+(\d+))?\s*}}`)
+	reRandomLoremParagraphs = regexp.MustCompile(`{{\s*\$randomLoremParagraphs(?:\s+(\d+))?\s*}}`)
+)
+
+// loremRegexes lists the lorem ipsum faker patterns so that file-scoped variables
+// holding them are evaluated dynamically.
+var loremRegexes = []*regexp.Regexp{reRandomLoremWord, reRandomLoremSentence, reRandomLoremParagraphs}
+
+// Lorem ipsum word list for text generation
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+	"ex", "ea", "commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "eu", "fugiat", "nulla", "pariatur", "excepteur", "sint", "occaecat",
+	"cupidatat", "non", "proident", "sunt", "culpa", "qui", "officia", "deserunt", "mollit", "anim",
+	"id", "est", "laborum",
+}
+
+// substituteLoremVariables handles lorem ipsum faker variables:
+// {{$randomLoremWord}}, {{$randomLoremSentence N}} (N words) and
+// {{$randomLoremParagraphs N}} (N paragraphs separated by a blank line).
+func substituteLoremVariables(text string) string {
+	text = reRandomLoremWord.ReplaceAllStringFunc(text, getRandomLoremWord)
+	text = reRandomLoremSentence.ReplaceAllStringFunc(text, substituteLoremSentence)
+	text = reRandomLoremParagraphs.ReplaceAllStringFunc(text, substituteLoremParagraphs)
+	return text
+}
+
+// getRandomLoremWord returns a random lorem ipsum word
+func getRandomLoremWord(_ string) string {
+	return loremWords[rand.Intn(len(loremWords))]
+}
+
+// substituteLoremSentence generates a sentence for a {{$randomLoremSentence N}} match.
+// Malformed or out-of-range word counts leave the placeholder untouched.
+func substituteLoremSentence(match string) string {
+	count, ok := parseLoremCount(match, reRandomLoremSentence, defaultLoremSentenceWords, maxLoremSentenceWords)
+	if !ok {
+		return match
+	}
+	return generateLoremSentence(count)
+}
+
+// substituteLoremParagraphs generates paragraphs for a {{$randomLoremParagraphs N}} match.
+// Malformed or out-of-range paragraph counts leave the placeholder untouched.
+func substituteLoremParagraphs(match string) string {
+	count, ok := parseLoremCount(match, reRandomLoremParagraphs, defaultLoremParagraphs, maxLoremParagraphs)
+	if !ok {
+		return match
+	}
+	paragraphs := make([]string, count)
+	for i := range paragraphs {
+		paragraphs[i] = generateLoremParagraph()
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// parseLoremCount extracts the optional count argument, enforcing 1..maxCount
+func parseLoremCount(match string, re *regexp.Regexp, defaultCount, maxCount int) (int, bool) {
+	parts := re.FindStringSubmatch(match)
+	if len(parts) < 2 || parts[1] == "" {
+		return defaultCount, true
+	}
+	count, err := strconv.Atoi(parts[1])
+	if err != nil || count <= 0 || count > maxCount {
+		return 0, false
+	}
+	return count, true
+}
+
+// generateLoremSentence builds a capitalised sentence of exactly wordCount words ending with a period
+func generateLoremSentence(wordCount int) string {
+	words := make([]string, wordCount)
+	for i := range words {
+		words[i] = getRandomLoremWord("")
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	return strings.Join(words, " ") + "."
+}
+
+// generateLoremParagraph builds a paragraph of a few sentences of varying length
+func generateLoremParagraph() string {
+	sentenceCount := minLoremParagraphSentence +
+		rand.Intn(maxLoremParagraphSentence-minLoremParagraphSentence+1)
+	sentences := make([]string, sentenceCount)
+	for i := range sentences {
+		sentences[i] = generateLoremSentence(minLoremSentenceWords + rand.Intn(maxLoremWordsVariance))
+	}
+	return strings.Join(sentences, " ")
+}
@@ -5,15 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/textproto"
 	"os"
 	"path/filepath"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
-
 // multipartPart represents a parsed multipart form part
 type multipartPart struct {
 	Name            string
@@ -21,165 +21,289 @@ type multipartPart struct {
 	ContentType     string
 	Content         string
 	IsFileReference bool
+	// Headers are the part headers other than Content-Disposition and Content-Type, sent as written
+	Headers textproto.MIMEHeader
 }
 
-// isMultipartFormWithFileReferences checks if the request is a multipart form containing file references
-func (*Client) isMultipartFormWithFileReferences(restClientReq *Request) bool {
-	contentType := restClientReq.Headers.Get("Content-Type")
-	if !strings.Contains(strings.ToLower(contentType), "multipart/form-data") {
-		return false
-	}
-	
-	// Check if the body contains file reference syntax (< filename)
-	return strings.Contains(restClientReq.RawBody, "< ")
+// isMultipartForm reports whether the request has a multipart/form-data body, which is rebuilt from its
+// parts instead of being sent as written
+func isMultipartForm(restClientReq *Request) bool {
+	mediaType, _ := parseContentType(restClientReq.Headers.Get("Content-Type"))
+	return mediaType == "multipart/form-data"
 }
 
-// processMultipartFormWithFiles processes multipart form data and replaces file references with actual file content
-func (c *Client) processMultipartFormWithFiles(
+// keepsEmptyBodyLines reports whether the empty lines of the body being parsed are part of it: in
+// multipart bodies, they separate the headers of a part from its value
+func (p *requestParserState) keepsEmptyBodyLines() bool {
+	return len(p.bodyLines) > 0 && p.currentRequest != nil && isMultipartForm(p.currentRequest)
+}
+
+// substituteMultipartBody substitutes the variables of a multipart/form-data body and sets the request
+// body to the form rebuilt from its parts: with CRLF line breaks, the boundary of the Content-Type header
+// and the files of `< ./path` parts, which are streamed while sending rather than read beforehand. RawBody
+// keeps the substituted form with its file references.
+func (c *Client) substituteMultipartBody(
 	restClientReq *Request,
 	parsedFile *ParsedFile,
 	requestScopedSystemVars map[string]string,
 	osEnvGetter func(string) (string, bool),
-) (string, error) {
-	// First apply variable substitution to the raw body
-	resolvedBody := resolveVariablesInText(
-		restClientReq.RawBody,
-		c.programmaticVars,
-		restClientReq.ActiveVariables,
-		parsedFile.EnvironmentVariables,
-		parsedFile.GlobalVariables,
-		requestScopedSystemVars,
-		osEnvGetter,
-		c.currentDotEnvVars,
-	)
-	
-	processedBody := substituteDynamicSystemVariables(
-		resolvedBody,
-		c.currentDotEnvVars,
-		c.programmaticVars,
-	)
-	
-	// Parse and reconstruct the multipart form with file substitution
-	result, err := c.reconstructMultipartFormWithFiles(processedBody, restClientReq)
+) error {
+	resolvedBody := c.processRegularBody(restClientReq, parsedFile, requestScopedSystemVars, osEnvGetter)
+	form, err := c.buildMultipartBody(resolvedBody, restClientReq)
 	if err != nil {
-		return "", err
+		return err
+	}
+	if restClientReq.Headers.Get("Content-Length") != "" {
+		restClientReq.Headers.Set("Content-Length", strconv.FormatInt(form.length, 10))
+	}
+	restClientReq.RawBody = resolvedBody
+	restClientReq.Body = form.open()
+	restClientReq.GetBody = func() (io.ReadCloser, error) { return form.open(), nil }
+	restClientReq.bodyLength = form.length
+	return nil
+}
+
+// multipartBody is a rebuilt multipart form: the boundaries, part headers and field values written
+// ahead of time, and the files of file parts by path
+type multipartBody struct {
+	segments []multipartSegment
+	length   int64
+}
+
+// multipartSegment is either written data or, with a path, the content of a file of the given size
+type multipartSegment struct {
+	data []byte
+	path string
+	size int64
+}
+
+// addData appends a copy of data to the form
+func (b *multipartBody) addData(data []byte) {
+	if len(data) == 0 {
+		return
 	}
-	return result, nil
+	b.segments = append(b.segments, multipartSegment{data: bytes.Clone(data)})
+	b.length += int64(len(data))
 }
 
-// reconstructMultipartFormWithFiles parses multipart form data and reconstructs it with file content substitution
-func (c *Client) reconstructMultipartFormWithFiles(body string, restClientReq *Request) (string, error) {
-	boundary, formParts, err := c.parseMultipartFormData(body, restClientReq)
+// addFile appends the content of the file at path to the form
+func (b *multipartBody) addFile(path string) error {
+	info, err := os.Stat(path)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to read file %s: %w", path, err)
 	}
-	
-	return c.buildMultipartForm(boundary, formParts, restClientReq.FilePath)
+	if info.IsDir() {
+		return fmt.Errorf("failed to read file %s: is a directory", path)
+	}
+	b.segments = append(b.segments, multipartSegment{path: path, size: info.Size()})
+	b.length += info.Size()
+	return nil
 }
 
-// parseMultipartFormData extracts boundary and parses form parts
-func (c *Client) parseMultipartFormData(body string, restClientReq *Request) (string, []multipartPart, error) {
-	contentType := restClientReq.Headers.Get("Content-Type")
-	boundary := c.extractBoundaryFromContentType(contentType)
-	if boundary == "" {
-		return "", nil, fmt.Errorf("no boundary found in Content-Type header: %s", contentType)
+// open returns a reader of the whole form, which opens the files of file parts one at a time as they are
+// reached
+func (b *multipartBody) open() io.ReadCloser {
+	return &multipartReader{segments: b.segments}
+}
+
+// multipartReader reads the segments of a multipart body in order
+type multipartReader struct {
+	segments []multipartSegment
+	current  io.ReadCloser
+}
+
+// Read implements io.Reader
+func (r *multipartReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.segments) == 0 {
+				return 0, io.EOF
+			}
+			if err := r.next(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			_ = r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
 	}
-	
-	formParts, err := c.parseMultipartBody(body, boundary)
+}
+
+// next opens the first remaining segment
+func (r *multipartReader) next() error {
+	segment := r.segments[0]
+	r.segments = r.segments[1:]
+	if segment.path == "" {
+		r.current = io.NopCloser(bytes.NewReader(segment.data))
+		return nil
+	}
+	file, err := os.Open(segment.path)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to parse multipart body: %w", err)
+		return fmt.Errorf("failed to read file %s: %w", segment.path, err)
 	}
-	
-	return boundary, formParts, nil
+	r.current = file
+	return nil
+}
+
+// Close implements io.Closer, closing the file being read
+func (r *multipartReader) Close() error {
+	r.segments = nil
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
 }
 
-// buildMultipartForm creates a new multipart form with file substitution
-func (c *Client) buildMultipartForm(boundary string, formParts []multipartPart, filePath string) (string, error) {
+// buildMultipartBody parses multipart form data and rebuilds it, with the files of file parts
+func (c *Client) buildMultipartBody(body string, restClientReq *Request) (*multipartBody, error) {
+	boundary, formParts, err := c.parseMultipartFormData(body, restClientReq)
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
-	
 	if err := writer.SetBoundary(boundary); err != nil {
-		return "", fmt.Errorf("failed to set multipart boundary: %w", err)
+		return nil, fmt.Errorf("failed to set multipart boundary: %w", err)
 	}
-	
+
+	form := &multipartBody{}
 	for _, part := range formParts {
-		if err := c.writePartToMultipart(writer, part, filePath); err != nil {
-			return "", err
+		if err := c.writePart(form, writer, &buf, part, restClientReq.FilePath); err != nil {
+			return nil, err
 		}
 	}
-	
 	if err := writer.Close(); err != nil {
-		return "", fmt.Errorf("failed to close multipart writer: %w", err)
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
 	}
-	
-	return buf.String(), nil
+	form.addData(buf.Bytes())
+	return form, nil
 }
 
-// writePartToMultipart writes a single part to the multipart writer
-func (c *Client) writePartToMultipart(writer *multipart.Writer, part multipartPart, filePath string) error {
-	if part.IsFileReference {
-		if err := c.writeFilePartToMultipart(writer, part, filePath); err != nil {
-			return fmt.Errorf("failed to write file part: %w", err)
+// writePart writes the headers of a part and its value to writer, or, for a file part, moves what was
+// written so far to the form and appends the file
+func (c *Client) writePart(form *multipartBody, writer *multipart.Writer, buf *bytes.Buffer,
+	part multipartPart, requestFilePath string) error {
+	partWriter, err := writer.CreatePart(part.header())
+	if err != nil {
+		return fmt.Errorf("failed to create form part %q: %w", part.Name, err)
+	}
+	if !part.IsFileReference {
+		if _, err := io.WriteString(partWriter, part.Content); err != nil {
+			return fmt.Errorf("failed to write form field %q: %w", part.Name, err)
 		}
-	} else {
-		if err := c.writeFieldPartToMultipart(writer, part); err != nil {
-			return fmt.Errorf("failed to write field part: %w", err)
+		return nil
+	}
+	form.addData(buf.Bytes())
+	buf.Reset()
+	return form.addFile(c.resolveFilePath(part.Content, requestFilePath))
+}
+
+// parseMultipartFormData extracts boundary and parses form parts
+func (c *Client) parseMultipartFormData(body string, restClientReq *Request) (string, []multipartPart, error) {
+	contentType := restClientReq.Headers.Get("Content-Type")
+	_, params := parseContentType(contentType)
+	boundary := params["boundary"]
+	if boundary == "" {
+		return "", nil, fmt.Errorf("no boundary found in Content-Type header: %s", contentType)
+	}
+
+	formParts, err := c.parseMultipartBody(body, boundary)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse multipart body: %w", err)
+	}
+
+	return boundary, formParts, nil
+}
+
+// quoteEscaper escapes the quoted names and filenames of Content-Disposition headers
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// header returns the headers of the part. File parts without a filename take the name of the file, and
+// without a Content-Type the type of its extension.
+func (p multipartPart) header() textproto.MIMEHeader {
+	header := make(textproto.MIMEHeader, len(p.Headers)+2)
+	for key, values := range p.Headers {
+		header[key] = values
+	}
+	filename, contentType := p.Filename, p.ContentType
+	if p.IsFileReference {
+		if filename == "" {
+			filename = filepath.Base(p.Content)
+		}
+		if contentType == "" {
+			contentType = fileContentType(p.Content)
 		}
 	}
-	return nil
+	disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(p.Name))
+	if filename != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(filename))
+	}
+	header.Set("Content-Disposition", disposition)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return header
 }
 
-// extractBoundaryFromContentType extracts the boundary from a Content-Type header
-func (*Client) extractBoundaryFromContentType(contentType string) string {
-	// Look for boundary= in Content-Type
-	re := regexp.MustCompile(`boundary=([^;]+)`)
-	matches := re.FindStringSubmatch(contentType)
-	if len(matches) >= 2 {
-		return strings.TrimSpace(matches[1])
+// fileContentType returns the media type of a file by its extension, application/octet-stream when
+// unknown
+func fileContentType(path string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+		return contentType
 	}
-	return ""
+	return "application/octet-stream"
 }
 
 // parseMultipartBody parses a multipart body into individual parts
 func (c *Client) parseMultipartBody(body, boundary string) ([]multipartPart, error) {
 	var parts []multipartPart
-	
+
 	// Split by boundary
 	boundaryDelimiter := "--" + boundary
 	sections := strings.Split(body, boundaryDelimiter)
-	
+
 	for _, section := range sections {
 		section = strings.TrimSpace(section)
 		if section == "" || section == "--" {
 			continue
 		}
-		
+
 		part, err := c.parseMultipartSection(section)
 		if err != nil {
 			continue // Skip malformed sections
 		}
-		
+
 		parts = append(parts, part)
 	}
-	
+
 	if len(parts) == 0 {
 		return nil, errors.New("no valid multipart sections found in body")
 	}
-	
+
 	return parts, nil
 }
 
 // parseMultipartSection parses a single multipart section
 func (c *Client) parseMultipartSection(section string) (multipartPart, error) {
 	var part multipartPart
-	
+
 	// Trim the section to remove leading/trailing whitespace
 	section = strings.TrimSpace(section)
-	
+
 	headerLines, contentLines := c.splitSectionIntoHeadersAndContent(section)
-	c.parseMultipartHeaders(&part, headerLines)
+	parseMultipartHeaders(&part, headerLines)
 	c.parseMultipartContent(&part, contentLines)
-	
+
 	if part.Name == "" {
 		return part, errors.New("no name found in multipart section")
 	}
@@ -189,7 +313,7 @@ func (c *Client) parseMultipartSection(section string) (multipartPart, error) {
 // splitSectionIntoHeadersAndContent splits a multipart section into headers and content
 func (c *Client) splitSectionIntoHeadersAndContent(section string) (headerLines []string, contentLines []string) {
 	lines := strings.Split(section, "\n")
-	
+
 	contentStartIndex := c.findContentStartIndex(lines)
 	return c.splitLinesAtIndex(lines, contentStartIndex)
 }
@@ -200,7 +324,7 @@ func (*Client) findContentStartIndex(lines []string) int {
 	if emptyLineIndex := findEmptyLineIndex(lines); emptyLineIndex != -1 {
 		return emptyLineIndex + 1
 	}
-	
+
 	// Use heuristic to separate headers from content
 	return findContentStartByHeuristic(lines)
 }
@@ -210,7 +334,7 @@ func (*Client) splitLinesAtIndex(lines []string, contentStartIndex int) (headerL
 	if contentStartIndex == -1 {
 		return lines, nil // All lines are headers
 	}
-	
+
 	headerLines = lines[:contentStartIndex]
 	if contentStartIndex < len(lines) {
 		contentLines = lines[contentStartIndex:]
@@ -243,7 +367,7 @@ func isMultipartHeaderLine(line string) bool {
 	if !strings.Contains(line, ":") {
 		return false
 	}
-	
+
 	trimmedLine := strings.TrimSpace(line)
 	return strings.HasPrefix(trimmedLine, "Content-Disposition:") ||
 		strings.HasPrefix(trimmedLine, "Content-Type:") ||
@@ -252,13 +376,28 @@ func isMultipartHeaderLine(line string) bool {
 }
 
 // parseMultipartHeaders parses the headers of a multipart section
-func (c *Client) parseMultipartHeaders(part *multipartPart, headerLines []string) {
+func parseMultipartHeaders(part *multipartPart, headerLines []string) {
 	for _, headerLine := range headerLines {
-		if strings.Contains(headerLine, "Content-Disposition:") {
-			part.Name = c.extractFormFieldName(headerLine)
-			part.Filename = c.extractFilename(headerLine)
-		} else if strings.Contains(headerLine, "Content-Type:") {
-			part.ContentType = c.extractContentType(headerLine)
+		key, value, ok := strings.Cut(headerLine, ":")
+		if !ok {
+			continue
+		}
+		key, value = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "Content-Disposition":
+			_, params, err := mime.ParseMediaType(value)
+			if err == nil {
+				part.Name, part.Filename = params["name"], params["filename"]
+			}
+		case "Content-Type":
+			part.ContentType = value
+		case "Content-Length":
+			// Dropped: the length of the value written may differ from the part sent
+		default:
+			if part.Headers == nil {
+				part.Headers = make(textproto.MIMEHeader)
+			}
+			part.Headers.Add(key, value)
 		}
 	}
 }
@@ -267,7 +406,7 @@ func (c *Client) parseMultipartHeaders(part *multipartPart, headerLines []string
 func (*Client) parseMultipartContent(part *multipartPart, contentLines []string) {
 	content := strings.Join(contentLines, "\n")
 	content = strings.TrimSpace(content)
-	
+
 	if strings.HasPrefix(content, "< ") {
 		part.IsFileReference = true
 		part.Content = strings.TrimSpace(content[2:]) // Remove "< "
@@ -277,71 +416,21 @@ func (*Client) parseMultipartContent(part *multipartPart, contentLines []string)
 	}
 }
 
-// extractFormFieldName extracts the name from Content-Disposition header
-func (*Client) extractFormFieldName(header string) string {
-	re := regexp.MustCompile(`name="([^"]+)"`)
-	matches := re.FindStringSubmatch(header)
-	if len(matches) >= 2 {
-		return matches[1]
-	}
-	return ""
-}
-
-// extractFilename extracts the filename from Content-Disposition header
-func (*Client) extractFilename(header string) string {
-	re := regexp.MustCompile(`filename="([^"]+)"`)
-	matches := re.FindStringSubmatch(header)
-	if len(matches) >= 2 {
-		return matches[1]
-	}
-	return ""
-}
-
-// extractContentType extracts the content type from Content-Type header
-func (*Client) extractContentType(header string) string {
-	parts := strings.SplitN(header, ":", 2)
-	if len(parts) == 2 {
-		return strings.TrimSpace(parts[1])
-	}
-	return ""
-}
-
-// writeFilePartToMultipart writes a file part to the multipart writer
-func (c *Client) writeFilePartToMultipart(writer *multipart.Writer, part multipartPart, requestFilePath string) error {
-	filePath := c.resolveFilePath(part.Content, requestFilePath)
-	
-	fileContent, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to read file %s: %w", filePath, err)
-	}
-	
-	formWriter, err := c.createMultipartFormWriter(writer, part)
-	if err != nil {
-		return fmt.Errorf("failed to create form field: %w", err)
-	}
-	
-	if _, err = formWriter.Write(fileContent); err != nil {
-		return fmt.Errorf("failed to write file content: %w", err)
-	}
-	
-	return nil
-}
-
 // resolveFilePath resolves a file path relative to request file or working directory
 func (*Client) resolveFilePath(contentPath, requestFilePath string) string {
 	if filepath.IsAbs(contentPath) {
 		return contentPath
 	}
-	
+
 	requestDir := filepath.Dir(requestFilePath)
-	
+
 	// If the request file is in a temporary directory, try resolving relative to cwd first
 	if strings.Contains(requestDir, os.TempDir()) {
 		if resolvedPath := tryResolveFromCwd(contentPath); resolvedPath != "" {
 			return resolvedPath
 		}
 	}
-	
+
 	// Fallback to request directory
 	return filepath.Join(requestDir, contentPath)
 }
@@ -352,58 +441,11 @@ func tryResolveFromCwd(contentPath string) string {
 	if err != nil {
 		return ""
 	}
-	
+
 	cwdPath := filepath.Join(cwd, contentPath)
 	if _, err := os.Stat(cwdPath); err == nil {
 		return cwdPath
 	}
-	
-	return ""
-}
-
-// createMultipartFormWriter creates the appropriate form writer based on part characteristics
-func (*Client) createMultipartFormWriter(writer *multipart.Writer, part multipartPart) (io.Writer, error) {
-	if part.Filename != "" {
-		return createFilePartWithFilename(writer, part)
-	}
-	
-	if part.ContentType != "" {
-		return createFilePartWithoutFilename(writer, part)
-	}
-	
-	return writer.CreateFormField(part.Name)
-}
-
-// createFilePartWithFilename creates a multipart file part with explicit filename
-func createFilePartWithFilename(writer *multipart.Writer, part multipartPart) (io.Writer, error) {
-	header := make(textproto.MIMEHeader)
-	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, part.Name, part.Filename))
-	if part.ContentType != "" {
-		header.Set("Content-Type", part.ContentType)
-	}
-	return writer.CreatePart(header)
-}
 
-// createFilePartWithoutFilename creates a multipart file part with inferred filename
-func createFilePartWithoutFilename(writer *multipart.Writer, part multipartPart) (io.Writer, error) {
-	filename := filepath.Base(part.Content)
-	header := make(textproto.MIMEHeader)
-	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, part.Name, filename))
-	header.Set("Content-Type", part.ContentType)
-	return writer.CreatePart(header)
+	return ""
 }
-
-// writeFieldPartToMultipart writes a regular form field to the multipart writer
-func (*Client) writeFieldPartToMultipart(writer *multipart.Writer, part multipartPart) error {
-	formWriter, err := writer.CreateFormField(part.Name)
-	if err != nil {
-		return fmt.Errorf("failed to create form field: %w", err)
-	}
-	
-	_, err = formWriter.Write([]byte(part.Content))
-	if err != nil {
-		return fmt.Errorf("failed to write field content: %w", err)
-	}
-	
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,104 @@
+package restclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// isNDJSONContentType reports whether a Content-Type header denotes newline-delimited JSON
+func isNDJSONContentType(header string) bool {
+	mediaType, _ := parseContentType(header)
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return true
+	}
+	return false
+}
+
+// ndjsonLines splits an NDJSON body into its records, skipping blank lines
+func ndjsonLines(body string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// processNDJSONBody substitutes the variables of an NDJSON body line by line. Each line gets its own
+// request-scoped system variables, so e.g. {{$uuid}} differs between records; the first line shares
+// them with the URL and headers. Blank lines are dropped and every record ends with a newline.
+func (c *Client) processNDJSONBody(
+	restClientReq *Request,
+	parsedFile *ParsedFile,
+	requestScopedSystemVars map[string]string,
+	osEnvGetter func(string) (string, bool),
+) string {
+	var sb strings.Builder
+	for i, line := range ndjsonLines(restClientReq.RawBody) {
+		systemVars := requestScopedSystemVars
+		if i > 0 {
+			systemVars = c.generateRequestScopedSystemVariables()
+		}
+		resolvedLine := resolveVariablesInText(
+			line,
+			c.programmaticVars,
+			restClientReq.ActiveVariables,
+			parsedFile.EnvironmentVariables,
+			parsedFile.GlobalVariables,
+			systemVars,
+			osEnvGetter,
+			c.currentDotEnvVars,
+		)
+		sb.WriteString(substituteDynamicSystemVariables(resolvedLine, c.currentDotEnvVars, c.programmaticVars))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// parseNDJSONLinesDirective parses "# ndjson-lines <count>"
+func parseNDJSONLinesDirective(value string, resp *ExpectedResponse) error {
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return fmt.Errorf("expected a non-negative number of lines, got '%s'", value)
+	}
+	resp.NDJSONLines = &count
+	return nil
+}
+
+// validateNDJSONLines checks the number of records of an NDJSON response against "# ndjson-lines"
+func (*Client) validateNDJSONLines(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if expected.NDJSONLines == nil {
+		return errs
+	}
+	if count := len(ndjsonLines(actual.BodyString)); count != *expected.NDJSONLines {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): NDJSON line count mismatch: expected %d, got %d",
+			responseIndex, responseFilePath, *expected.NDJSONLines, count))
+	}
+	return errs
+}
+
+// compareNDJSONBodies compares an NDJSON body record by record, each line like a JSON body with
+// placeholders. Both bodies must have the same number of records.
+func compareNDJSONBodies(responseFilePath string, responseIndex int, expectedBody, actualBody string,
+	ref dateReference) *multierror.Error {
+	expectedLines, actualLines := ndjsonLines(expectedBody), ndjsonLines(actualBody)
+	var errs *multierror.Error
+	if len(expectedLines) != len(actualLines) {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): NDJSON line count mismatch: expected %d, got %d",
+			responseIndex, responseFilePath, len(expectedLines), len(actualLines)))
+	}
+	for i := 0; i < min(len(expectedLines), len(actualLines)); i++ {
+		if err := compareBodies(responseFilePath, responseIndex, expectedLines[i], actualLines[i], ref); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("NDJSON line %d: %w", i+1, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,128 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WithNetworkShaping simulates a slow network, e.g. to test timeouts and client-side deadlines.
+// Each request is delayed by latency plus a random offset within ±jitter before it is sent, and
+// request and response bodies are transferred at no more than bandwidth bytes per second. Zero
+// disables the respective setting. The jitter sequence is seeded per client, so runs are repeatable.
+// Delays honour the request context, so a timeout fires during them like on a real network.
+func WithNetworkShaping(latency time.Duration, bandwidth int64, jitter time.Duration) ClientOption {
+	return func(c *Client) error {
+		if latency < 0 || bandwidth < 0 || jitter < 0 {
+			return errors.New("network shaping latency, bandwidth and jitter must not be negative")
+		}
+		c.networkShaping = &networkShaping{
+			latency:   latency,
+			bandwidth: bandwidth,
+			jitter:    jitter,
+			random:    rand.New(rand.NewSource(1)),
+		}
+		return nil
+	}
+}
+
+// networkShaping holds the settings of WithNetworkShaping
+type networkShaping struct {
+	latency   time.Duration
+	bandwidth int64 // bytes per second
+	jitter    time.Duration
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// delay returns the latency of the next request
+func (s *networkShaping) delay() time.Duration {
+	if s.jitter == 0 {
+		return s.latency
+	}
+	s.mu.Lock()
+	offset := time.Duration(s.random.Int63n(int64(2*s.jitter)+1)) - s.jitter
+	s.mu.Unlock()
+	return max(s.latency+offset, 0)
+}
+
+// shapedTransport applies network shaping to the requests sent through base
+type shapedTransport struct {
+	base    http.RoundTripper
+	shaping *networkShaping
+}
+
+func (t *shapedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := req.Context()
+	if err := sleepContext(ctx, t.shaping.delay()); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	if t.shaping.bandwidth == 0 {
+		return base.RoundTrip(req)
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(ctx)
+		req.Body = newThrottledBody(ctx, req.Body, t.shaping.bandwidth)
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = newThrottledBody(ctx, resp.Body, t.shaping.bandwidth)
+	return resp, nil
+}
+
+// throttledBody limits reads from a body to a number of bytes per second
+type throttledBody struct {
+	io.ReadCloser
+	ctx       context.Context
+	bandwidth int64
+	start     time.Time
+	read      int64
+}
+
+func newThrottledBody(ctx context.Context, body io.ReadCloser, bandwidth int64) *throttledBody {
+	return &throttledBody{ReadCloser: body, ctx: ctx, bandwidth: bandwidth, start: time.Now()}
+}
+
+func (b *throttledBody) Read(p []byte) (int, error) {
+	// Reads of about a tenth of a second keep the transfer smooth
+	if chunk := max(b.bandwidth/10, 1); int64(len(p)) > chunk {
+		p = p[:chunk]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	due := time.Duration(float64(b.read) / float64(b.bandwidth) * float64(time.Second))
+	if sleepErr := sleepContext(b.ctx, due-time.Since(b.start)); sleepErr != nil {
+		return n, sleepErr
+	}
+	return n, err
+}
+
+// sleepContext waits for d, or returns the context's error when it is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,301 @@
+package restclient
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OAuth1AuthProvider is the "# @auth oauth1 <id>" provider name selecting an OAuth1 configuration
+// from the Security.Auth section of the selected environment
+const OAuth1AuthProvider = "oauth1"
+
+// OAuth 1.0a signature methods and parameter placements
+const (
+	OAuth1HMACSHA1 = "HMAC-SHA1"
+	OAuth1RSASHA1  = "RSA-SHA1"
+
+	OAuth1Header = "header" // oauth_* parameters in the Authorization header
+	OAuth1Query  = "query"  // oauth_* parameters appended to the query string
+)
+
+// OAuth1Config signs requests according to OAuth 1.0a (RFC 5849), for legacy APIs that still
+// require it. The signature covers the method, URL, query parameters and urlencoded form bodies.
+type OAuth1Config struct {
+	ConsumerKey     string
+	ConsumerSecret  string          // HMAC-SHA1 only
+	Token           string          // access token; omitted from the request when empty
+	TokenSecret     string          // HMAC-SHA1 only
+	SignatureMethod string          // OAuth1HMACSHA1 (default) or OAuth1RSASHA1
+	PrivateKey      *rsa.PrivateKey // RSA-SHA1 only
+	Placement       string          // OAuth1Header (default) or OAuth1Query
+	Realm           string          // optional realm of the Authorization header
+}
+
+// WithOAuth1 signs every request to host (a host name, or host:port to match a port too) with
+// OAuth 1.0a. A request with "# @auth oauth1 <id>" is signed with that configuration of the
+// environment instead.
+func WithOAuth1(host string, config OAuth1Config) ClientOption {
+	return func(c *Client) error {
+		if host == "" {
+			return errors.New("OAuth1 host must not be empty")
+		}
+		if err := config.validate(); err != nil {
+			return err
+		}
+		if c.oauth1Hosts == nil {
+			c.oauth1Hosts = make(map[string]*OAuth1Config)
+		}
+		c.oauth1Hosts[strings.ToLower(host)] = &config
+		return nil
+	}
+}
+
+// validate checks that the configuration can sign requests
+func (o *OAuth1Config) validate() error {
+	if o.ConsumerKey == "" {
+		return errors.New("OAuth1 consumer key must not be empty")
+	}
+	switch o.SignatureMethod {
+	case "", OAuth1HMACSHA1:
+	case OAuth1RSASHA1:
+		if o.PrivateKey == nil {
+			return errors.New("OAuth1 RSA-SHA1 needs a private key")
+		}
+	default:
+		return fmt.Errorf("unsupported OAuth1 signature method '%s'", o.SignatureMethod)
+	}
+	if o.Placement != "" && o.Placement != OAuth1Header && o.Placement != OAuth1Query {
+		return fmt.Errorf("unsupported OAuth1 placement '%s', expected header or query", o.Placement)
+	}
+	return nil
+}
+
+// Sign adds the OAuth 1.0a protocol parameters and signature to req. It has the signature of a
+// SignerFunc; body is only read for application/x-www-form-urlencoded requests.
+func (o *OAuth1Config) Sign(req *http.Request, body []byte) error {
+	method := o.SignatureMethod
+	if method == "" {
+		method = OAuth1HMACSHA1
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate OAuth1 nonce: %w", err)
+	}
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     o.ConsumerKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": method,
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if o.Token != "" {
+		oauthParams["oauth_token"] = o.Token
+	}
+
+	signature, err := o.signature(method, oauth1BaseString(req, body, oauthParams))
+	if err != nil {
+		return err
+	}
+	oauthParams["oauth_signature"] = signature
+
+	keys := sortedKeys(oauthParams)
+	if o.Placement == OAuth1Query {
+		pairs := make([]string, 0, len(keys)+1)
+		if req.URL.RawQuery != "" {
+			pairs = append(pairs, req.URL.RawQuery)
+		}
+		for _, key := range keys {
+			pairs = append(pairs, oauth1Escape(key)+"="+oauth1Escape(oauthParams[key]))
+		}
+		req.URL.RawQuery = strings.Join(pairs, "&")
+		return nil
+	}
+	parts := make([]string, 0, len(keys)+1)
+	if o.Realm != "" {
+		parts = append(parts, fmt.Sprintf("realm=%q", o.Realm))
+	}
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, oauth1Escape(key), oauth1Escape(oauthParams[key])))
+	}
+	req.Header.Set("Authorization", "OAuth "+strings.Join(parts, ", "))
+	return nil
+}
+
+// signature signs the signature base string with the configured method
+func (o *OAuth1Config) signature(method, baseString string) (string, error) {
+	if method == OAuth1RSASHA1 {
+		digest := sha1.Sum([]byte(baseString))
+		signed, err := rsa.SignPKCS1v15(rand.Reader, o.PrivateKey, crypto.SHA1, digest[:])
+		if err != nil {
+			return "", fmt.Errorf("failed to compute OAuth1 RSA-SHA1 signature: %w", err)
+		}
+		return base64.StdEncoding.EncodeToString(signed), nil
+	}
+	mac := hmac.New(sha1.New, []byte(oauth1Escape(o.ConsumerSecret)+"&"+oauth1Escape(o.TokenSecret)))
+	mac.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// oauth1BaseString builds the signature base string of RFC 5849 section 3.4.1 from the method, the
+// base URL and the normalized protocol, query and form body parameters
+func oauth1BaseString(req *http.Request, body []byte, oauthParams map[string]string) string {
+	var pairs []string
+	add := func(key, value string) {
+		pairs = append(pairs, oauth1Escape(key)+"="+oauth1Escape(value))
+	}
+	for key, value := range oauthParams {
+		add(key, value)
+	}
+	for key, values := range req.URL.Query() {
+		for _, value := range values {
+			add(key, value)
+		}
+	}
+	if mediaType, _ := parseContentType(req.Header.Get("Content-Type")); mediaType == inferredFormContentType {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for key, values := range form {
+				for _, value := range values {
+					add(key, value)
+				}
+			}
+		}
+	}
+	sort.Strings(pairs)
+
+	host := strings.ToLower(req.URL.Host)
+	scheme := strings.ToLower(req.URL.Scheme)
+	if (scheme == "http" && strings.HasSuffix(host, ":80")) || (scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	baseURL := scheme + "://" + host + req.URL.EscapedPath()
+	return strings.ToUpper(req.Method) + "&" + oauth1Escape(baseURL) + "&" + oauth1Escape(strings.Join(pairs, "&"))
+}
+
+// oauth1Escape percent-encodes everything but the unreserved characters of RFC 3986
+func oauth1Escape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
+
+// oauth1ConfigFor returns the OAuth1 configuration a request is signed with: the environment's
+// configuration selected with @auth oauth1, or the one of WithOAuth1 for the request's host
+func (c *Client) oauth1ConfigFor(httpReq *http.Request, rcRequest *Request) (*OAuth1Config, error) {
+	if rcRequest.AuthProvider == OAuth1AuthProvider {
+		if rcRequest.OAuth1 == nil {
+			id := strings.Join(rcRequest.AuthArgs, " ")
+			return nil, fmt.Errorf("@auth oauth1: no OAuth1 configuration '%s' in the Security.Auth section "+
+				"of the selected environment", id)
+		}
+		return rcRequest.OAuth1, nil
+	}
+	if config, ok := c.oauth1Hosts[strings.ToLower(httpReq.URL.Host)]; ok {
+		return config, nil
+	}
+	return c.oauth1Hosts[strings.ToLower(httpReq.URL.Hostname())], nil
+}
+
+// signOAuth1 signs the request with OAuth 1.0a when a configuration applies to it
+func (c *Client) signOAuth1(httpReq *http.Request, rcRequest *Request) error {
+	config, err := c.oauth1ConfigFor(httpReq, rcRequest)
+	if err != nil || config == nil {
+		return err
+	}
+	body, err := bufferRequestBody(httpReq)
+	if err != nil {
+		return err
+	}
+	if err := config.Sign(httpReq, body); err != nil {
+		return fmt.Errorf("failed to sign request with OAuth1: %w", err)
+	}
+	return nil
+}
+
+// envOAuth1Config is an entry of Type "OAuth1" of the Security.Auth section of an environment
+type envOAuth1Config struct {
+	ConsumerKey     string `json:"Consumer Key"`
+	ConsumerSecret  string `json:"Consumer Secret"`
+	Token           string `json:"Token"`
+	TokenSecret     string `json:"Token Secret"`
+	SignatureMethod string `json:"Signature Method"`
+	PrivateKeyFile  string `json:"Private Key File"`
+	Placement       string `json:"Placement"`
+	Realm           string `json:"Realm"`
+}
+
+// config converts an environment entry, reading the private key file relative to fileDir
+func (e envOAuth1Config) config(fileDir string) (*OAuth1Config, error) {
+	config := &OAuth1Config{
+		ConsumerKey:     e.ConsumerKey,
+		ConsumerSecret:  e.ConsumerSecret,
+		Token:           e.Token,
+		TokenSecret:     e.TokenSecret,
+		SignatureMethod: strings.ToUpper(e.SignatureMethod),
+		Placement:       strings.ToLower(e.Placement),
+		Realm:           e.Realm,
+	}
+	if e.PrivateKeyFile != "" {
+		keyPath := e.PrivateKeyFile
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(fileDir, keyPath)
+		}
+		key, err := loadRSAPrivateKey(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		config.PrivateKey = key
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// loadRSAPrivateKey reads a PEM encoded PKCS #1 or PKCS #8 RSA private key
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("private key file %s is not PEM encoded", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key %s is not an RSA key", path)
+	}
+	return key, nil
+}
@@ -0,0 +1,481 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2AuthProvider is the "# @auth oauth2 <id>" provider name selecting an OAuth2 configuration
+// from the Security.Auth section of the selected environment
+const OAuth2AuthProvider = "oauth2"
+
+// OAuth2DeviceAuthorization is the device authorization grant (RFC 8628), for headless environments
+// where the redirects of the authorization code grant are not possible
+const OAuth2DeviceAuthorization = "Device Authorization"
+
+// OAuth2ClientCredentials is the client credentials grant (RFC 6749 section 4.4), for service
+// accounts that obtain tokens with their own credentials, without a user
+const OAuth2ClientCredentials = "Client Credentials"
+
+// deviceCodeGrantType is the grant_type of the device access token request
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// OAuth2Config obtains access tokens sent as "Authorization: Bearer <token>". Tokens are kept in the
+// client's TokenStore per token URL, client ID and scope, so the user authorizes once per run, and are
+// renewed with their refresh token when they expire or the server rejects them with 401 Unauthorized.
+type OAuth2Config struct {
+	GrantType     string // OAuth2DeviceAuthorization, the default, or OAuth2ClientCredentials
+	DeviceAuthURL string // the device authorization endpoint, for OAuth2DeviceAuthorization
+	TokenURL      string // the token endpoint
+	ClientID      string
+	ClientSecret  string // required for OAuth2ClientCredentials, optional for confidential device clients
+	Scope         string // optional, space separated scopes
+}
+
+// DeviceAuthorization is what the user needs to authorize a device: the code to enter at the
+// verification URI, before the code expires
+type DeviceAuthorization struct {
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string // optional, the verification URI with the user code included
+	ExpiresIn               time.Duration
+}
+
+// DeviceCodeHandler shows a device authorization to the user, e.g. by printing it or opening a
+// browser. An error aborts the flow and fails the request.
+type DeviceCodeHandler func(ctx context.Context, authorization DeviceAuthorization) error
+
+// OAuth2Token is an access token obtained by an OAuth2 flow
+type OAuth2Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`    // "Bearer" when the token endpoint does not say otherwise
+	RefreshToken string    `json:"refresh_token,omitempty"` // optional
+	Expiry       time.Time `json:"expiry"`                  // zero when the token does not expire
+}
+
+// oauth2ExpiryDelta renews tokens slightly before they expire, so they do not expire in transit
+const oauth2ExpiryDelta = 10 * time.Second
+
+// valid reports whether the token can still be sent
+func (t *OAuth2Token) valid() bool {
+	return t != nil && t.AccessToken != "" && (t.Expiry.IsZero() || time.Now().Add(oauth2ExpiryDelta).Before(t.Expiry))
+}
+
+// WithOAuth2 authorizes every request to host (a host name, or host:port to match a port too) with
+// an access token obtained by the OAuth2 flow of config. A request with "# @auth oauth2 <id>" uses
+// that configuration of the environment instead.
+func WithOAuth2(host string, config OAuth2Config) ClientOption {
+	return func(c *Client) error {
+		if host == "" {
+			return errors.New("OAuth2 host must not be empty")
+		}
+		if err := config.validate(); err != nil {
+			return err
+		}
+		if c.oauth2Hosts == nil {
+			c.oauth2Hosts = make(map[string]*OAuth2Config)
+		}
+		c.oauth2Hosts[strings.ToLower(host)] = &config
+		return nil
+	}
+}
+
+// WithDeviceCodeHandler sets how the user code and verification URI of the device authorization
+// grant are shown; by default they are logged with slog.
+func WithDeviceCodeHandler(handler DeviceCodeHandler) ClientOption {
+	return func(c *Client) error {
+		if handler == nil {
+			return errors.New("device code handler must not be nil")
+		}
+		c.deviceCodeHandler = handler
+		return nil
+	}
+}
+
+// validate checks that the configuration can obtain tokens
+func (o *OAuth2Config) validate() error {
+	if o.ClientID == "" {
+		return errors.New("OAuth2 client ID must not be empty")
+	}
+	switch {
+	case o.GrantType == "" || strings.EqualFold(o.GrantType, OAuth2DeviceAuthorization):
+		if o.DeviceAuthURL == "" || o.TokenURL == "" {
+			return errors.New("OAuth2 device authorization needs a device auth URL and a token URL")
+		}
+	case strings.EqualFold(o.GrantType, OAuth2ClientCredentials):
+		if o.TokenURL == "" || o.ClientSecret == "" {
+			return errors.New("OAuth2 client credentials need a token URL and a client secret")
+		}
+	default:
+		return fmt.Errorf("unsupported OAuth2 grant type '%s'", o.GrantType)
+	}
+	return nil
+}
+
+// cacheKey identifies the tokens the configuration obtains
+func (o *OAuth2Config) cacheKey() string {
+	return o.TokenURL + " " + o.ClientID + " " + o.Scope
+}
+
+// oauth2Tokens holds the token store of a client, running one flow at a time so parallel requests do
+// not ask the user to authorize twice
+type oauth2Tokens struct {
+	mu    sync.Mutex
+	store TokenStore // NewMemoryTokenStore when nil
+}
+
+// oauth2ConfigFor returns the OAuth2 configuration a request is authorized with: the environment's
+// configuration selected with @auth oauth2, or the one of WithOAuth2 for the request's host
+func (c *Client) oauth2ConfigFor(httpReq *http.Request, rcRequest *Request) (*OAuth2Config, error) {
+	if rcRequest.AuthProvider == OAuth2AuthProvider {
+		if rcRequest.OAuth2 == nil {
+			id := strings.Join(rcRequest.AuthArgs, " ")
+			return nil, fmt.Errorf("@auth oauth2: no OAuth2 configuration '%s' in the Security.Auth section "+
+				"of the selected environment", id)
+		}
+		return rcRequest.OAuth2, nil
+	}
+	if config, ok := c.oauth2Hosts[strings.ToLower(httpReq.URL.Host)]; ok {
+		return config, nil
+	}
+	return c.oauth2Hosts[strings.ToLower(httpReq.URL.Hostname())], nil
+}
+
+// authorizeOAuth2 sets the Authorization header of the request when an OAuth2 configuration applies
+// to it, running the flow when no valid token is cached
+func (c *Client) authorizeOAuth2(ctx context.Context, httpReq *http.Request, rcRequest *Request) error {
+	config, err := c.oauth2ConfigFor(httpReq, rcRequest)
+	if err != nil || config == nil {
+		return err
+	}
+	token, err := c.oauth2Token(ctx, config, "")
+	if err != nil {
+		return fmt.Errorf("OAuth2: %w", err)
+	}
+	httpReq.Header.Set("Authorization", token.authorization())
+	return nil
+}
+
+// authorization renders the Authorization header value of the token
+func (t *OAuth2Token) authorization() string {
+	tokenType := t.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	return tokenType + " " + t.AccessToken
+}
+
+// oauth2Token returns the stored token of the configuration while it is valid and not the rejected
+// access token, or obtains a new one: with the refresh token when there is one, otherwise, or when
+// the refresh fails, by running the flow again
+func (c *Client) oauth2Token(ctx context.Context, config *OAuth2Config, rejected string) (*OAuth2Token, error) {
+	c.oauth2Tokens.mu.Lock()
+	defer c.oauth2Tokens.mu.Unlock()
+	if c.oauth2Tokens.store == nil {
+		c.oauth2Tokens.store = NewMemoryTokenStore()
+	}
+	store, key := c.oauth2Tokens.store, config.cacheKey()
+	stored, err := store.Load(key)
+	if err != nil {
+		return nil, err
+	}
+	if stored.valid() && stored.AccessToken != rejected {
+		return stored, nil
+	}
+
+	var token *OAuth2Token
+	if stored != nil && stored.RefreshToken != "" {
+		if token, err = c.refreshOAuth2Token(ctx, config, stored.RefreshToken); err != nil {
+			slog.Warn("OAuth2 token refresh failed, authorizing again", "error", err, "token_url", config.TokenURL)
+		}
+	}
+	if token == nil {
+		if err := store.Delete(key); err != nil {
+			return nil, err
+		}
+		if token, err = c.authorizeOAuth2Grant(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+	if err := store.Save(key, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// refreshOAuth2Token obtains a new token with the refresh token grant; the refresh token is kept
+// when the token endpoint does not issue a new one
+func (c *Client) refreshOAuth2Token(ctx context.Context, config *OAuth2Config,
+	refreshToken string) (*OAuth2Token, error) {
+	params := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {config.ClientID},
+	}
+	if config.ClientSecret != "" {
+		params.Set("client_secret", config.ClientSecret)
+	}
+	var response tokenResponse
+	if err := c.postOAuth2Form(ctx, config.TokenURL, params, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, errors.New(response.describeError())
+	}
+	token, err := response.oauth2Token()
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// oauth2Transport retries a request once with a renewed token when the server rejects the token it
+// was sent with, e.g. because it was revoked or expired earlier than announced
+type oauth2Transport struct {
+	base   http.RoundTripper
+	client *Client
+	config *OAuth2Config
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	sent := req.Header.Get("Authorization")
+	_, rejected, found := strings.Cut(sent, " ")
+	if !found || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, nil // not authorized by us, e.g. after a redirect to another host, or not replayable
+	}
+	token, tokenErr := t.client.oauth2Token(req.Context(), t.config, rejected)
+	if tokenErr != nil || token.authorization() == sent {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	retry.Header.Set("Authorization", token.authorization())
+	return base.RoundTrip(retry)
+}
+
+// authorizeOAuth2Grant obtains a new token with the grant type of the configuration
+func (c *Client) authorizeOAuth2Grant(ctx context.Context, config *OAuth2Config) (*OAuth2Token, error) {
+	if strings.EqualFold(config.GrantType, OAuth2ClientCredentials) {
+		return c.clientCredentials(ctx, config)
+	}
+	return c.deviceAuthorization(ctx, config)
+}
+
+// clientCredentials runs the client credentials grant: the client authenticates with its ID and
+// secret at the token endpoint
+func (c *Client) clientCredentials(ctx context.Context, config *OAuth2Config) (*OAuth2Token, error) {
+	params := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {config.ClientID},
+		"client_secret": {config.ClientSecret},
+	}
+	if config.Scope != "" {
+		params.Set("scope", config.Scope)
+	}
+	var response tokenResponse
+	if err := c.postOAuth2Form(ctx, config.TokenURL, params, &response); err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("token request: %s", response.describeError())
+	}
+	return response.oauth2Token()
+}
+
+// deviceAuthorizationResponse is the response of the device authorization endpoint
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                *int   `json:"interval"`
+}
+
+// tokenResponse is a successful or error response of the token endpoint
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// deviceAuthorization runs the device authorization grant: it requests a device code, shows the user
+// code with the device code handler, and polls the token endpoint until the user has authorized the
+// device, denied it, or the code expired
+func (c *Client) deviceAuthorization(ctx context.Context, config *OAuth2Config) (*OAuth2Token, error) {
+	params := url.Values{"client_id": {config.ClientID}}
+	if config.Scope != "" {
+		params.Set("scope", config.Scope)
+	}
+	var device deviceAuthorizationResponse
+	if err := c.postOAuth2Form(ctx, config.DeviceAuthURL, params, &device); err != nil {
+		return nil, fmt.Errorf("device authorization request: %w", err)
+	}
+	if device.DeviceCode == "" || device.UserCode == "" || device.VerificationURI == "" {
+		return nil, errors.New("device authorization response lacks device_code, user_code or verification_uri")
+	}
+
+	authorization := DeviceAuthorization{
+		UserCode:                device.UserCode,
+		VerificationURI:         device.VerificationURI,
+		VerificationURIComplete: device.VerificationURIComplete,
+		ExpiresIn:               time.Duration(device.ExpiresIn) * time.Second,
+	}
+	handler := c.deviceCodeHandler
+	if handler == nil {
+		handler = logDeviceAuthorization
+	}
+	if err := handler(ctx, authorization); err != nil {
+		return nil, fmt.Errorf("device code handler: %w", err)
+	}
+
+	interval := 5 * time.Second
+	if device.Interval != nil {
+		interval = time.Duration(*device.Interval) * time.Second
+	}
+	if authorization.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, authorization.ExpiresIn)
+		defer cancel()
+	}
+	params = url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {device.DeviceCode},
+		"client_id":   {config.ClientID},
+	}
+	if config.ClientSecret != "" {
+		params.Set("client_secret", config.ClientSecret)
+	}
+	for {
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, fmt.Errorf("device code expired before the user authorized the device: %w", err)
+		}
+		var token tokenResponse
+		if err := c.postOAuth2Form(ctx, config.TokenURL, params, &token); err != nil {
+			return nil, fmt.Errorf("token request: %w", err)
+		}
+		switch token.Error {
+		case "":
+			return token.oauth2Token()
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, fmt.Errorf("token request: %s", token.describeError())
+		}
+	}
+}
+
+// oauth2Token converts a successful token response
+func (r *tokenResponse) oauth2Token() (*OAuth2Token, error) {
+	if r.AccessToken == "" {
+		return nil, errors.New("token response lacks access_token")
+	}
+	token := &OAuth2Token{AccessToken: r.AccessToken, TokenType: r.TokenType, RefreshToken: r.RefreshToken}
+	if r.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// describeError renders an OAuth2 error response
+func (r *tokenResponse) describeError() string {
+	if r.ErrorDescription != "" {
+		return r.Error + ": " + r.ErrorDescription
+	}
+	return r.Error
+}
+
+// postOAuth2Form posts form parameters to an OAuth2 endpoint and decodes its JSON response. Error
+// responses with an OAuth2 error body are decoded too, so the caller can act on the error code.
+func (c *Client) postOAuth2Form(ctx context.Context, endpoint string, params url.Values, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", inferredFormContentType)
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		if oauthErr, ok := target.(*tokenResponse); ok && oauthErr.Error != "" {
+			return nil
+		}
+		return fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// logDeviceAuthorization is the default DeviceCodeHandler
+func logDeviceAuthorization(_ context.Context, authorization DeviceAuthorization) error {
+	slog.Info("OAuth2 device authorization: visit the verification URI and enter the user code",
+		"verification_uri", authorization.VerificationURI, "user_code", authorization.UserCode,
+		"verification_uri_complete", authorization.VerificationURIComplete)
+	return nil
+}
+
+// envOAuth2Config is an entry of Type "OAuth2" of the Security.Auth section of an environment
+type envOAuth2Config struct {
+	GrantType     string `json:"Grant Type"`
+	DeviceAuthURL string `json:"Device Auth URL"`
+	TokenURL      string `json:"Token URL"`
+	ClientID      string `json:"Client ID"`
+	ClientSecret  string `json:"Client Secret"`
+	Scope         string `json:"Scope"`
+}
+
+// config converts an environment entry
+func (e envOAuth2Config) config() (*OAuth2Config, error) {
+	config := &OAuth2Config{
+		GrantType:     e.GrantType,
+		DeviceAuthURL: e.DeviceAuthURL,
+		TokenURL:      e.TokenURL,
+		ClientID:      e.ClientID,
+		ClientSecret:  e.ClientSecret,
+		Scope:         e.Scope,
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,392 @@
+package restclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIBaseURLVariable is the variable of the base URL of generated requests
+const openAPIBaseURLVariable = "baseUrl"
+
+// openAPIMethods are the operations of a path item, in the order requests are generated
+var openAPIMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+	http.MethodHead, http.MethodOptions, http.MethodTrace,
+}
+
+// OpenAPIOptions adjusts GenerateRequestsFromOpenAPI. Zero values generate a request for every
+// operation, sent to the first server of the spec.
+type OpenAPIOptions struct {
+	BaseURL string   // value of the {{baseUrl}} variable, instead of the first server of the spec
+	Tags    []string // only operations with at least one of these tags
+}
+
+// openAPISpec is the part of an OpenAPI 3 document requests are generated from
+type openAPISpec struct {
+	OpenAPI string `yaml:"openapi"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*openAPISchema      `yaml:"schemas"`
+		Parameters    map[string]*openAPIParameter   `yaml:"parameters"`
+		RequestBodies map[string]*openAPIRequestBody `yaml:"requestBodies"`
+	} `yaml:"components"`
+}
+
+// openAPIOperation is an operation of a path
+type openAPIOperation struct {
+	OperationID string              `yaml:"operationId"`
+	Tags        []string            `yaml:"tags"`
+	Parameters  []*openAPIParameter `yaml:"parameters"`
+	RequestBody *openAPIRequestBody `yaml:"requestBody"`
+}
+
+// openAPIParameter is a path, query, header or cookie parameter
+type openAPIParameter struct {
+	Ref     string         `yaml:"$ref"`
+	Name    string         `yaml:"name"`
+	In      string         `yaml:"in"`
+	Example any            `yaml:"example"`
+	Schema  *openAPISchema `yaml:"schema"`
+}
+
+// openAPIRequestBody is the request body of an operation, per media type
+type openAPIRequestBody struct {
+	Ref     string                      `yaml:"$ref"`
+	Content map[string]openAPIMediaType `yaml:"content"`
+}
+
+// openAPIMediaType is the example and schema of a body of one media type
+type openAPIMediaType struct {
+	Example  any `yaml:"example"`
+	Examples map[string]struct {
+		Value any `yaml:"value"`
+	} `yaml:"examples"`
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+// GenerateRequestsFromOpenAPI generates a request for each operation of the OpenAPI 3 spec at specPath,
+// in YAML or JSON, to bootstrap the requests and validation suites of a service. Requests are named
+// after the operationId, tagged with the tags of the operation and sorted by path. Their URL starts
+// with {{baseUrl}}, and path, query and header parameters are templated as variables named after them
+// (e.g. {{userId}}), set on each request (see Request.SetVariable) to the example of the parameter or
+// of its schema. JSON bodies are the example of the media type, or are built from the examples, enums,
+// defaults and types of its schema.
+//
+// The requests can be sent with ExecuteRequest, or written as an .http file with GenerateHTTPFromOpenAPI.
+func GenerateRequestsFromOpenAPI(specPath string, opts OpenAPIOptions) ([]*Request, error) {
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec %s: %w", specPath, err)
+	}
+	var spec openAPISpec
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec %s: %w", specPath, err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q in %s, expected 3.x", spec.OpenAPI, specPath)
+	}
+	baseURL := opts.BaseURL
+	if baseURL == "" && len(spec.Servers) > 0 {
+		baseURL = strings.TrimSuffix(spec.Servers[0].URL, "/")
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var requests []*Request
+	names := make(map[string]bool)
+	for _, path := range paths {
+		pathRequests, err := spec.pathRequests(path, opts.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("OpenAPI spec %s: %w", specPath, err)
+		}
+		for _, req := range pathRequests {
+			req.Name = uniqueOpenAPIName(req.Name, names)
+			req.SetVariable(openAPIBaseURLVariable, baseURL)
+			requests = append(requests, req)
+		}
+	}
+	return requests, nil
+}
+
+// GenerateHTTPFromOpenAPI generates the requests of GenerateRequestsFromOpenAPI as the content of an
+// .http file: the definitions of their variables, with the first example found for each, followed by
+// the requests separated by "###".
+func GenerateHTTPFromOpenAPI(specPath string, opts OpenAPIOptions) (string, error) {
+	requests, err := GenerateRequestsFromOpenAPI(specPath, opts)
+	if err != nil {
+		return "", err
+	}
+	values := map[string]string{}
+	var names []string
+	for _, req := range requests {
+		for name, value := range req.ActiveVariables {
+			if _, ok := values[name]; !ok {
+				values[name] = value
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "@"+openAPIBaseURLVariable) != (names[j] == "@"+openAPIBaseURLVariable) {
+			return names[i] == "@"+openAPIBaseURLVariable
+		}
+		return names[i] < names[j]
+	})
+
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s = %s\n", name, values[name])
+	}
+	if len(requests) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(FormatRequests(requests...))
+	}
+	return sb.String(), nil
+}
+
+// pathRequests generates the requests of the operations of a path
+func (s *openAPISpec) pathRequests(path string, tags []string) ([]*Request, error) {
+	item := s.Paths[path]
+	var shared []*openAPIParameter
+	if node, ok := item["parameters"]; ok {
+		if err := node.Decode(&shared); err != nil {
+			return nil, fmt.Errorf("path %s: invalid parameters: %w", path, err)
+		}
+	}
+	var requests []*Request
+	for _, method := range openAPIMethods {
+		node, ok := item[strings.ToLower(method)]
+		if !ok {
+			continue
+		}
+		var op openAPIOperation
+		if err := node.Decode(&op); err != nil {
+			return nil, fmt.Errorf("%s %s: invalid operation: %w", method, path, err)
+		}
+		if len(tags) > 0 && !hasAnyTag(op.Tags, tags) {
+			continue
+		}
+		req, err := s.operationRequest(method, path, &op, shared)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", method, path, err)
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// operationRequest generates the request of an operation
+func (s *openAPISpec) operationRequest(method, path string, op *openAPIOperation,
+	shared []*openAPIParameter) (*Request, error) {
+	req := &Request{Method: method, Name: op.OperationID, Headers: make(http.Header)}
+	if req.Name == "" {
+		req.Name = openAPIOperationName(method, path)
+	}
+	for _, tag := range op.Tags {
+		req.Tags = append(req.Tags, strings.Join(strings.Fields(tag), "-"))
+	}
+
+	parameters, err := s.operationParameters(shared, op.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	target := "{{" + openAPIBaseURLVariable + "}}" + path
+	var query []string
+	for _, param := range parameters {
+		variable := openAPIVariableName(param.Name)
+		switch param.In {
+		case "path":
+			target = strings.ReplaceAll(target, "{"+param.Name+"}", "{{"+variable+"}}")
+		case "query":
+			query = append(query, url.QueryEscape(param.Name)+"={{"+variable+"}}")
+		case "header":
+			req.Headers.Set(param.Name, "{{"+variable+"}}")
+		default:
+			continue // cookie parameters are left to the cookie jar
+		}
+		req.SetVariable(variable, openAPIValueString(s.parameterExample(param)))
+	}
+	if len(query) > 0 {
+		target += "?" + strings.Join(query, "&")
+	}
+	req.RawURLString = target
+
+	if err := s.setRequestBody(req, op.RequestBody); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// operationParameters resolves the parameters of the path and of the operation, the latter replacing
+// the former of the same name and location
+func (s *openAPISpec) operationParameters(shared, own []*openAPIParameter) ([]*openAPIParameter, error) {
+	var parameters []*openAPIParameter
+	index := make(map[string]int)
+	for _, param := range append(append([]*openAPIParameter{}, shared...), own...) {
+		resolved, err := s.resolveParameter(param)
+		if err != nil {
+			return nil, err
+		}
+		key := resolved.In + " " + resolved.Name
+		if i, ok := index[key]; ok {
+			parameters[i] = resolved
+			continue
+		}
+		index[key] = len(parameters)
+		parameters = append(parameters, resolved)
+	}
+	return parameters, nil
+}
+
+// resolveParameter follows the $ref of a parameter to the components of the spec
+func (s *openAPISpec) resolveParameter(param *openAPIParameter) (*openAPIParameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+	name, ok := strings.CutPrefix(param.Ref, "#/components/parameters/")
+	if resolved := s.Components.Parameters[name]; ok && resolved != nil {
+		return s.resolveParameter(resolved)
+	}
+	return nil, fmt.Errorf("unresolved parameter reference %q", param.Ref)
+}
+
+// parameterExample returns the example of a parameter, or one built from its schema
+func (s *openAPISpec) parameterExample(param *openAPIParameter) any {
+	if param.Example != nil {
+		return param.Example
+	}
+	return s.schemaExample(param.Schema, nil)
+}
+
+// setRequestBody sets the body and Content-Type of a request to the example of its JSON media type,
+// or else of the first media type by name
+func (s *openAPISpec) setRequestBody(req *Request, body *openAPIRequestBody) error {
+	if body == nil {
+		return nil
+	}
+	if body.Ref != "" {
+		name, ok := strings.CutPrefix(body.Ref, "#/components/requestBodies/")
+		resolved := s.Components.RequestBodies[name]
+		if !ok || resolved == nil {
+			return fmt.Errorf("unresolved request body reference %q", body.Ref)
+		}
+		return s.setRequestBody(req, resolved)
+	}
+	if len(body.Content) == 0 {
+		return nil
+	}
+	mediaTypes := make([]string, 0, len(body.Content))
+	for mediaType := range body.Content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+	contentType := mediaTypes[0]
+	if _, ok := body.Content["application/json"]; ok {
+		contentType = "application/json"
+	}
+	media := body.Content[contentType]
+	rendered, err := renderOpenAPIBody(contentType, s.mediaExample(media))
+	if err != nil {
+		return err
+	}
+	req.Headers.Set("Content-Type", contentType)
+	req.RawBody = rendered
+	return nil
+}
+
+// mediaExample returns the example of a media type: its example, its first example by name, or one
+// built from its schema
+func (s *openAPISpec) mediaExample(media openAPIMediaType) any {
+	if media.Example != nil {
+		return media.Example
+	}
+	if len(media.Examples) > 0 {
+		names := make([]string, 0, len(media.Examples))
+		for name := range media.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return media.Examples[names[0]].Value
+	}
+	return s.schemaExample(media.Schema, nil)
+}
+
+// renderOpenAPIBody renders an example body: strings as they are, form media types as a form, and
+// other values as indented JSON
+func renderOpenAPIBody(contentType string, example any) (string, error) {
+	if text, ok := example.(string); ok {
+		return text, nil
+	}
+	if fields, ok := example.(map[string]any); ok && contentType == "application/x-www-form-urlencoded" {
+		form := url.Values{}
+		for name, value := range fields {
+			form.Set(name, openAPIValueString(value))
+		}
+		return form.Encode(), nil
+	}
+	rendered, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to render %s example: %w", contentType, err)
+	}
+	return string(rendered), nil
+}
+
+// openAPIValueString renders the example of a parameter or form field: strings as they are, other
+// values as JSON
+func openAPIValueString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	rendered, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(rendered)
+}
+
+// openAPIVariableName derives the variable of a parameter from its name, replacing the characters
+// variable names cannot hold with "_", e.g. "X-Request-ID" becomes "X_Request_ID"
+func openAPIVariableName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// openAPIOperationName names an operation without operationId after its method and path, e.g.
+// "get-users-id" for GET /users/{id}
+func openAPIOperationName(method, path string) string {
+	words := strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(append([]string{strings.ToLower(method)}, words...), "-")
+}
+
+// uniqueOpenAPIName appends a number to the name of a request when it is already taken
+func uniqueOpenAPIName(name string, taken map[string]bool) string {
+	unique := name
+	for n := 2; taken[unique]; n++ {
+		unique = fmt.Sprintf("%s-%d", name, n)
+	}
+	taken[unique] = true
+	return unique
+}
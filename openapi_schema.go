@@ -0,0 +1,128 @@
+package restclient
+
+import "strings"
+
+// openAPISchema is the part of a schema example values are built from
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       any                       `yaml:"type"` // a type, or a list of types in OpenAPI 3.1
+	Format     string                    `yaml:"format"`
+	Example    any                       `yaml:"example"`
+	Examples   []any                     `yaml:"examples"`
+	Default    any                       `yaml:"default"`
+	Enum       []any                     `yaml:"enum"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Items      *openAPISchema            `yaml:"items"`
+	AllOf      []*openAPISchema          `yaml:"allOf"`
+	OneOf      []*openAPISchema          `yaml:"oneOf"`
+	AnyOf      []*openAPISchema          `yaml:"anyOf"`
+}
+
+// openAPIFormatExamples are the example strings of string formats
+var openAPIFormatExamples = map[string]string{
+	"date":      "2024-01-01",
+	"date-time": "2024-01-01T00:00:00Z",
+	"email":     "user@example.com",
+	"uuid":      "00000000-0000-0000-0000-000000000000",
+	"uri":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+}
+
+// schemaExample builds an example value of a schema: its example, first example, default or first enum
+// value, else a value of its type, with the properties of objects and one item for arrays. visiting
+// holds the component schemas being built, so recursive schemas end with nil.
+func (s *openAPISpec) schemaExample(schema *openAPISchema, visiting map[string]bool) any {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		return s.refExample(schema.Ref, visiting)
+	}
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case len(schema.Examples) > 0:
+		return schema.Examples[0]
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		merged := map[string]any{}
+		for _, part := range schema.AllOf {
+			if fields, ok := s.schemaExample(part, visiting).(map[string]any); ok {
+				for name, value := range fields {
+					merged[name] = value
+				}
+			}
+		}
+		return merged
+	case len(schema.OneOf) > 0:
+		return s.schemaExample(schema.OneOf[0], visiting)
+	case len(schema.AnyOf) > 0:
+		return s.schemaExample(schema.AnyOf[0], visiting)
+	}
+	return s.typeExample(schema, visiting)
+}
+
+// refExample builds the example of a component schema reference
+func (s *openAPISpec) refExample(ref string, visiting map[string]bool) any {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok || visiting[name] {
+		return nil
+	}
+	if visiting == nil {
+		visiting = make(map[string]bool)
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+	return s.schemaExample(s.Components.Schemas[name], visiting)
+}
+
+// typeExample builds a value of the type of a schema
+func (s *openAPISpec) typeExample(schema *openAPISchema, visiting map[string]bool) any {
+	switch schemaType(schema) {
+	case "object":
+		fields := map[string]any{}
+		for name, property := range schema.Properties {
+			fields[name] = s.schemaExample(property, visiting)
+		}
+		return fields
+	case "array":
+		item := s.schemaExample(schema.Items, visiting)
+		if item == nil {
+			return []any{}
+		}
+		return []any{item}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "string":
+		if example, ok := openAPIFormatExamples[schema.Format]; ok {
+			return example
+		}
+		return "string"
+	}
+	return nil
+}
+
+// schemaType returns the type of a schema: the first non-null type of an OpenAPI 3.1 list, and
+// "object" for schemas with properties but no type
+func schemaType(schema *openAPISchema) string {
+	switch t := schema.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, candidate := range t {
+			if name, ok := candidate.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if len(schema.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
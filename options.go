@@ -77,4 +77,69 @@ func WithEnvironment(name string) ClientOption {
 		c.selectedEnvironmentName = name
 		return nil
 	}
-}
\ No newline at end of file
+}
+
+// WithArtifactsDir enables saving every response body produced by ExecuteFile into dir,
+// one sub-directory per request file and one file per request named by its index and
+// @name (or method), e.g. "artifacts/users/002-createUser.json". Useful for inspecting
+// CI failures after the fact. Existing files from earlier runs are overwritten.
+func WithArtifactsDir(dir string) ClientOption {
+	return func(c *Client) error {
+		c.artifactsDir = dir
+		return nil
+	}
+}
+
+// WithWireCapture records the exact request and response of every executed request, after all
+// variable substitution and header injection, into Response.RawRequestDump and RawResponseDump.
+// Bodies are buffered in memory, so avoid it for very large payloads.
+func WithWireCapture() ClientOption {
+	return func(c *Client) error {
+		c.wireCapture = true
+		return nil
+	}
+}
+
+// WithStreamedBodies leaves response bodies unread after execution, so large payloads are not held
+// in memory twice as Body and BodyString. Read them on demand with Response.BodyReader, or
+// Response.BufferBody to populate Body; validation and the JSON helpers buffer automatically.
+// Unread bodies keep their connection open until closed with Response.Close.
+func WithStreamedBodies() ClientOption {
+	return func(c *Client) error {
+		c.streamBodies = true
+		return nil
+	}
+}
+
+// WithURLVariableEncoding controls whether variable values substituted into a request URL are
+// percent-encoded for the component they land in: url.PathEscape for path segments and the fragment,
+// url.QueryEscape for query parameter names and values. Values in the scheme and host part, such as
+// {{baseUrl}} in "{{baseUrl}}/users", are left as is. With encoding on, a value like "John Doe #1"
+// produces a valid URL instead of a parse error or a truncated path; do not pre-encode values.
+// Off by default, on in strict mode (see WithStrictMode); an explicit setting takes precedence.
+func WithURLVariableEncoding(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.urlVariableEncoding = &enabled
+		return nil
+	}
+}
+
+// WithStrictMode enables the stricter defaults of the client, currently percent-encoding of
+// substituted URL variables (see WithURLVariableEncoding).
+func WithStrictMode() ClientOption {
+	return func(c *Client) error {
+		c.strictMode = true
+		return nil
+	}
+}
+
+// WithContentTypeInference sets a Content-Type for request bodies that have none, neither in the
+// request nor in the default headers: application/json for JSON, application/xml for well-formed
+// XML and application/x-www-form-urlencoded for "key=value&..." bodies. The body is inspected after
+// variable substitution. Disable it for a single request with the "# @no-infer-content-type" directive.
+func WithContentTypeInference() ClientOption {
+	return func(c *Client) error {
+		c.inferContentType = true
+		return nil
+	}
+}
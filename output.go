@@ -0,0 +1,76 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// outputDirPerm is the permission of directories created for the files of @output directives
+const outputDirPerm = 0o755
+
+// handleOutputDirective processes "@output <file>" directives
+func (p *requestParserState) handleOutputDirective(commentContent string) bool {
+	if commentContent != "@output" && !strings.HasPrefix(commentContent, "@output ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	path := strings.TrimSpace(strings.TrimPrefix(commentContent, "@output"))
+	if path == "" {
+		slog.Warn("Invalid @output directive",
+			"error", errors.New("missing file path"),
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.OutputFile = path
+	return true
+}
+
+// outputPath returns the file of the @output directive of req, relative to the request file
+func outputPath(req *Request) string {
+	if filepath.IsAbs(req.OutputFile) || req.FilePath == "" {
+		return req.OutputFile
+	}
+	return filepath.Join(filepath.Dir(req.FilePath), req.OutputFile)
+}
+
+// writeResponseBody streams the body of httpResp to the file of the @output directive of the request,
+// without buffering it: Body stays empty and OutputFile is set to the path written. Directories of the
+// path are created as needed, and an existing file is replaced.
+func writeResponseBody(resp *Response, httpResp *http.Response) {
+	defer func() { _ = httpResp.Body.Close() }()
+	path := outputPath(resp.Request)
+	written, err := copyToFile(path, httpResp.Body)
+	resp.BytesReceived = responseWireSize(httpResp, int(written))
+	if err != nil {
+		resp.Error = fmt.Errorf("failed to write response body to %s: %w", path, err)
+		return
+	}
+	resp.OutputFile = path
+	if resp.Size <= 0 {
+		resp.Size = written
+	}
+	resp.Trailers = receivedTrailers(httpResp)
+}
+
+// copyToFile writes body to the file at path, returning the number of bytes written
+func copyToFile(path string, body io.Reader) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), outputDirPerm); err != nil {
+		return 0, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return written, err
+}
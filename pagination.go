@@ -0,0 +1,284 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Pagination modes of the @paginate directive
+const (
+	PaginateLinkHeader = "link-header" // follow the rel="next" URL of the Link header (RFC 8288)
+	PaginateCursor     = "cursor"      // pass a cursor read from the body as a query parameter
+)
+
+// defaultMaxPages is the number of pages fetched when @paginate has no max= option
+const defaultMaxPages = 10
+
+// Pagination configures how a request with a "# @paginate" directive follows paginated responses.
+type Pagination struct {
+	Mode        string // PaginateLinkHeader or PaginateCursor
+	MaxPages    int    // max=N: upper bound for the number of pages, including the first
+	ItemsPath   string // items=<jsonpath>: the array of items in each page; the whole body if empty
+	CursorPath  string // path=<jsonpath>: the next cursor in the body (cursor mode)
+	CursorParam string // param=<name>: the query parameter carrying the cursor (cursor mode)
+}
+
+// handlePaginateDirective processes "@paginate <mode> [options...]" directives
+func (p *requestParserState) handlePaginateDirective(commentContent string) bool {
+	if !strings.HasPrefix(commentContent, "@paginate") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	pagination, err := parsePaginateDirective(strings.TrimPrefix(commentContent, "@paginate"))
+	if err != nil {
+		slog.Warn("Invalid @paginate directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.Pagination = pagination
+	return true
+}
+
+// parsePaginateDirective parses the arguments of "@paginate link-header max=10" or
+// "@paginate cursor path=$.meta.next param=cursor items=$.data"
+func parsePaginateDirective(args string) (*Pagination, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("missing mode, expected %s or %s", PaginateLinkHeader, PaginateCursor)
+	}
+	pagination := &Pagination{Mode: fields[0], MaxPages: defaultMaxPages, CursorParam: "cursor"}
+	if pagination.Mode != PaginateLinkHeader && pagination.Mode != PaginateCursor {
+		return nil, fmt.Errorf("unknown mode '%s', expected %s or %s", fields[0], PaginateLinkHeader, PaginateCursor)
+	}
+	for _, option := range fields[1:] {
+		name, value, _ := strings.Cut(option, "=")
+		switch name {
+		case "max":
+			maxPages, err := strconv.Atoi(value)
+			if err != nil || maxPages < 1 {
+				return nil, fmt.Errorf("max must be a positive number of pages, got '%s'", value)
+			}
+			pagination.MaxPages = maxPages
+		case "items":
+			pagination.ItemsPath = value
+		case "path":
+			pagination.CursorPath = value
+		case "param":
+			pagination.CursorParam = value
+		default:
+			return nil, fmt.Errorf("unknown option '%s'", option)
+		}
+	}
+	if pagination.Mode == PaginateCursor && pagination.CursorPath == "" {
+		return nil, fmt.Errorf("cursor mode needs path=<jsonpath> of the next cursor")
+	}
+	return pagination, nil
+}
+
+// String renders the directive arguments, e.g. "link-header max=10"
+func (p *Pagination) String() string {
+	parts := []string{p.Mode, fmt.Sprintf("max=%d", p.MaxPages)}
+	if p.Mode == PaginateCursor {
+		parts = append(parts, "path="+p.CursorPath, "param="+p.CursorParam)
+	}
+	if p.ItemsPath != "" {
+		parts = append(parts, "items="+p.ItemsPath)
+	}
+	return strings.Join(parts, " ")
+}
+
+// followPages fetches the pages following first according to the request's @paginate directive and
+// returns the combined response: the status and headers of the first page, with a body holding the
+// items of all pages as one JSON array. The individual pages are kept in Response.Pages. A first
+// page that failed or was not successful is returned as is. Pages on another host than the request
+// are not followed, as they would receive its credentials.
+func (c *Client) followPages(ctx context.Context, rcRequest *Request, first *Response) *Response {
+	if checkPage(first, 1) != nil {
+		return first
+	}
+	pagination := rcRequest.Pagination
+	pages := []*Response{first}
+	current := first
+	var pageErr error
+	for len(pages) < pagination.MaxPages {
+		next, err := pagination.nextPageURL(current)
+		if err != nil {
+			pageErr = fmt.Errorf("page %d: %w", len(pages), err)
+			break
+		}
+		if next == nil {
+			break
+		}
+		if !strings.EqualFold(next.Host, rcRequest.URL.Host) {
+			// like redirects, pages must not take the request's credentials to another host
+			pageErr = fmt.Errorf("page %d: next page %s is not on host %s", len(pages), next.Redacted(),
+				rcRequest.URL.Host)
+			break
+		}
+
+		pageRequest := *rcRequest
+		pageRequest.URL, pageRequest.RawURLString = next, next.String()
+		pageRequest.Headers = rcRequest.Headers.Clone()
+		pageRequest.Headers.Del("Content-Length")
+		pageRequest.Body, pageRequest.RawBody, pageRequest.GetBody = nil, "", nil
+		if current, err = c.executeRequest(ctx, &pageRequest); err != nil {
+			pageErr = fmt.Errorf("page %d: %w", len(pages)+1, err)
+			break
+		}
+		pages = append(pages, current)
+		if pageErr = checkPage(current, len(pages)); pageErr != nil {
+			break
+		}
+	}
+	return combinePages(pages, pagination.ItemsPath, pageErr)
+}
+
+// checkPage buffers the body of a page and reports a page that failed or was not successful,
+// which ends pagination
+func checkPage(page *Response, number int) error {
+	if page.Error != nil {
+		return fmt.Errorf("page %d: %w", number, page.Error)
+	}
+	if page.StatusCode < 200 || page.StatusCode > 299 {
+		return fmt.Errorf("page %d: unexpected status %s", number, page.Status)
+	}
+	if err := page.BufferBody(); err != nil {
+		return fmt.Errorf("page %d: %w", number, err)
+	}
+	return nil
+}
+
+// nextPageURL returns the URL of the page after page, or nil on the last page
+func (p *Pagination) nextPageURL(page *Response) (*url.URL, error) {
+	current := page.Request.URL
+	if page.FinalURL != "" {
+		if finalURL, err := url.Parse(page.FinalURL); err == nil {
+			current = finalURL
+		}
+	}
+
+	if p.Mode == PaginateLinkHeader {
+		link := nextLink(page.Headers)
+		if link == "" {
+			return nil, nil
+		}
+		next, err := current.Parse(link)
+		if err != nil {
+			return nil, fmt.Errorf("invalid next link '%s': %w", link, err)
+		}
+		return next, nil
+	}
+
+	var data any
+	if err := json.Unmarshal(page.Body, &data); err != nil {
+		return nil, fmt.Errorf("cannot read cursor: body is not JSON: %w", err)
+	}
+	value, err := evaluateJSONPath(data, p.CursorPath)
+	if err != nil || value == nil || value == "" {
+		return nil, nil // no cursor: last page
+	}
+	cursor := fmt.Sprint(value)
+	if number, isNumber := value.(float64); isNumber {
+		cursor = strconv.FormatFloat(number, 'f', -1, 64)
+	}
+	next := *current
+	query := next.Query()
+	query.Set(p.CursorParam, cursor)
+	next.RawQuery = query.Encode()
+	return &next, nil
+}
+
+// nextLink returns the target of the rel="next" link of a Link header, or ""
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				name, relations, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				for _, relation := range strings.Fields(strings.Trim(relations, `"`)) {
+					if strings.EqualFold(relation, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// combinePages builds the combined response of a paginated request; pageErr, if any, is the reason
+// pagination stopped early and becomes the combined response's error
+func combinePages(pages []*Response, itemsPath string, pageErr error) *Response {
+	first, last := pages[0], pages[len(pages)-1]
+	combined := *first
+	combined.Pages = pages
+	combined.Headers = first.Headers.Clone()
+	combined.Headers.Del("Content-Length")
+	combined.FinalURL = last.FinalURL
+	combined.Error = pageErr
+	combined.Duration, combined.BytesSent, combined.BytesReceived = 0, 0, 0
+	combined.Timings = Timings{}
+	for _, page := range pages {
+		combined.Duration += page.Duration
+		combined.Timings = combined.Timings.plus(page.Timings)
+		combined.BytesSent += page.BytesSent
+		combined.BytesReceived += page.BytesReceived
+	}
+
+	items := make([]any, 0)
+	for i, page := range pages {
+		if checkPage(page, i+1) != nil {
+			continue
+		}
+		pageItems, err := paginatedItems(page, itemsPath)
+		if err != nil {
+			combined.Error = fmt.Errorf("page %d: %w", i+1, err)
+			return &combined
+		}
+		items = append(items, pageItems...)
+	}
+	body, err := json.Marshal(items)
+	if err != nil {
+		combined.Error = fmt.Errorf("failed to combine pages: %w", err)
+		return &combined
+	}
+	combined.Body, combined.BodyString, combined.Size = body, string(body), int64(len(body))
+	return &combined
+}
+
+// paginatedItems returns the items of a page: the array at itemsPath, or the body itself
+func paginatedItems(page *Response, itemsPath string) ([]any, error) {
+	var data any
+	if err := json.Unmarshal(page.Body, &data); err != nil {
+		return nil, fmt.Errorf("body is not JSON: %w", err)
+	}
+	if itemsPath != "" {
+		var err error
+		if data, err = evaluateJSONPath(data, itemsPath); err != nil {
+			return nil, err
+		}
+	}
+	items, ok := data.([]any)
+	if !ok {
+		if itemsPath == "" {
+			return nil, fmt.Errorf("body is not a JSON array; use items=<jsonpath> to select the items")
+		}
+		return nil, fmt.Errorf("%s is not an array", itemsPath)
+	}
+	return items, nil
+}
@@ -2,6 +2,7 @@ package restclient
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -42,13 +43,21 @@ func loadEnvironmentFile(filePath string, selectedEnvName string) (map[string]st
 		return nil, fmt.Errorf("reading environment file %s: %w", filePath, readErr)
 	}
 
-	var allEnvs map[string]map[string]string
-	if unmarshalErr := json.Unmarshal(envFileBytes, &allEnvs); unmarshalErr != nil {
+	var allEnvs map[string]map[string]json.RawMessage
+	if unmarshalErr := json.Unmarshal(normalizeLineEndings(envFileBytes), &allEnvs); unmarshalErr != nil {
 		slog.Warn("Failed to unmarshal environment file", "error", unmarshalErr, "file", filePath)
 		return nil, fmt.Errorf("unmarshalling environment file %s: %w", filePath, unmarshalErr)
 	}
 
-	if selectedEnvVars, ok := allEnvs[selectedEnvName]; ok {
+	if selectedEnv, ok := allEnvs[selectedEnvName]; ok {
+		// Only string values are variables; objects like the Security section are read separately
+		selectedEnvVars := make(map[string]string, len(selectedEnv))
+		for name, raw := range selectedEnv {
+			var value string
+			if json.Unmarshal(raw, &value) == nil {
+				selectedEnvVars[name] = value
+			}
+		}
 		return selectedEnvVars, nil
 	}
 
@@ -70,26 +79,47 @@ func parseRequestFile(filePath string, client *Client, importStack []string) (*P
 		return nil, err
 	}
 
-	file, err := os.Open(absFilePath)
+	content, err := os.ReadFile(absFilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open request file %s: %w", absFilePath, err)
 	}
-	defer func() { _ = file.Close() }()
+	return parseRequestContent(filePath, absFilePath, content, client, newImportStack)
+}
 
+// parseRequestContent parses the content of the request file at filePath, read from disk or supplied
+// by ExecuteSource and ExecuteReader, and loads the variables of its environment
+func parseRequestContent(filePath, absFilePath string, content []byte, client *Client,
+	importStack []string) (*ParsedFile, error) {
 	parsingVars := setupParsingVariables(filePath, client)
 
-	reader := bufio.NewReader(file)
+	reader := bufio.NewReader(bytes.NewReader(normalizeLineEndings(content)))
 	parsedFile, err := parseRequests(
 		reader, absFilePath, client, parsingVars.requestScopedSystemVars,
-		parsingVars.osEnvGetter, parsingVars.dotEnvVars, newImportStack)
+		parsingVars.osEnvGetter, parsingVars.dotEnvVars, importStack)
 	if err != nil {
 		return nil, err
 	}
 
-	loadEnvironmentSpecificVariables(filePath, client, parsedFile)
+	if err := loadEnvironmentSpecificVariables(filePath, client, parsedFile); err != nil {
+		return nil, err
+	}
 	return parsedFile, nil
 }
 
+// ParseRequests parses the content of a .http or .rest file without executing anything, e.g. an
+// unsaved editor buffer. filePath names the document: it is recorded on the ParsedFile and its
+// requests and anchors relative imports. File variables are kept as written, unresolved.
+func ParseRequests(filePath string, content []byte) (*ParsedFile, error) {
+	absFilePath, importStack, err := prepareParsingContext(filePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(normalizeLineEndings(content)))
+	return parseRequests(reader, absFilePath, nil, make(map[string]string), os.LookupEnv,
+		make(map[string]string), importStack)
+}
+
 // parsingVariables holds variables needed for parsing
 type parsingVariables struct {
 	dotEnvVars               map[string]string
@@ -128,7 +158,7 @@ func checkCircularImports(absFilePath string, importStack []string) error {
 func setupParsingVariables(filePath string, client *Client) parsingVariables {
 	return parsingVariables{
 		dotEnvVars:              loadDotEnvForParsing(filePath),
-		osEnvGetter:             func(key string) (string, bool) { return os.LookupEnv(key) },
+		osEnvGetter:             client.lookupVariable,
 		requestScopedSystemVars: generateRequestScopedVarsForParsing(client),
 	}
 }
@@ -157,29 +187,44 @@ func generateRequestScopedVarsForParsing(client *Client) map[string]string {
 // http-client.env.json and http-client.private.env.json based on the client's
 // selected environment. It updates parsedFile.EnvironmentVariables.
 // originalFilePath is the path originally passed to parseRequestFile, used for resolving .env.json files.
-func loadEnvironmentSpecificVariables(originalFilePath string, client *Client, parsedFile *ParsedFile) {
+func loadEnvironmentSpecificVariables(originalFilePath string, client *Client, parsedFile *ParsedFile) error {
 	if client == nil || client.selectedEnvironmentName == "" || parsedFile == nil {
-		return
+		return nil
 	}
 
 	fileDir := filepath.Dir(originalFilePath)
-	mergedEnvVars := loadEnvironmentFiles(fileDir, client.selectedEnvironmentName)
+	mergedEnvVars, err := loadEnvironmentFiles(fileDir, client.selectedEnvironmentName)
+	if err != nil {
+		return err
+	}
 
 	if len(mergedEnvVars) > 0 {
 		parsedFile.EnvironmentVariables = mergedEnvVars
 	} else {
 		ensureEnvironmentVariablesInitialized(parsedFile, client.selectedEnvironmentName, fileDir)
 	}
+
+	auth, err := loadEnvironmentAuth(fileDir, client.selectedEnvironmentName)
+	if err != nil {
+		return err
+	}
+	auth.apply(parsedFile.Requests)
+	return nil
 }
 
-// loadEnvironmentFiles loads variables from both public and private environment files
-func loadEnvironmentFiles(fileDir, selectedEnvName string) map[string]string {
+// loadEnvironmentFiles loads variables from both public and private environment files and resolves
+// references between them (see resolveComputedEnvironmentVariables)
+func loadEnvironmentFiles(fileDir, selectedEnvName string) (map[string]string, error) {
 	mergedEnvVars := make(map[string]string)
-	
+
 	loadPublicEnvFile(fileDir, selectedEnvName, mergedEnvVars)
 	loadPrivateEnvFile(fileDir, selectedEnvName, mergedEnvVars)
-	
-	return mergedEnvVars
+
+	resolvedEnvVars, err := resolveComputedEnvironmentVariables(mergedEnvVars)
+	if err != nil {
+		return nil, fmt.Errorf("environment '%s': %w", selectedEnvName, err)
+	}
+	return resolvedEnvVars, nil
 }
 
 // loadPublicEnvFile loads variables from http-client.env.json
@@ -242,6 +287,7 @@ func initializeParserState(filePath string, client *Client, requestScopedSystemV
 			Requests: make([]*Request, 0),
 			FileVariables: make(map[string]string),
 			FilePath: filePath,
+			variableLines: make(map[string]int),
 		},
 		currentFileVariables:    make(map[string]string),
 		lineNumber:              0,
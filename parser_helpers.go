@@ -21,7 +21,7 @@ func isPotentialRequestLine(line string) bool {
 	methodToken := strings.ToUpper(parts[0])
 	validMethods := map[string]bool{
 		"GET": true, "POST": true, "PUT": true, "DELETE": true, "PATCH": true,
-		"HEAD": true, "OPTIONS": true, "TRACE": true, "CONNECT": true,
+		"HEAD": true, "OPTIONS": true, "TRACE": true, "CONNECT": true, grpcMethod: true,
 	}
 	return validMethods[methodToken]
 }
@@ -36,25 +36,27 @@ func parseNameFromAtNameDirective(commentContent string) (nameValue string, isAt
 	}
 
 	// It starts with "@name". Now check if it's a valid form.
-	// Valid forms: "@name" (no value), or "@name<whitespace>value"
+	// Valid forms: "@name" (no value), "@name<whitespace>value", or "@name = value" (JetBrains)
 
 	// Case 1: Exactly "@name"
 	if len(commentContent) == len("@name") {
 		return "", true // It's the @name pattern, value is empty.
 	}
 
-	// Case 2: Must be "@name" followed by whitespace to be our pattern.
-	if !unicode.IsSpace(rune(commentContent[len("@name")])) {
+	// Case 2: Must be "@name" followed by whitespace or "=" to be our pattern.
+	next := rune(commentContent[len("@name")])
+	if !unicode.IsSpace(next) && next != '=' {
 		// e.g., "@nametag". This is not the "@name <value>" pattern.
 		return "", false
 	}
 
-	// It's "@name" followed by whitespace. This is a recognized @name pattern.
+	// It's "@name" followed by whitespace or "=". This is a recognized @name pattern.
 	// Extract the potential value.
 	// commentContent[len("@name"):] will get the part after "@name", including leading spaces.
 	valuePart := commentContent[len("@name"):]
-	// First, trim leading/trailing whitespace from the raw value part.
+	// First, trim leading/trailing whitespace and the "=" of "@name = value" from the raw value part.
 	trimmedValue := strings.TrimSpace(valuePart)
+	trimmedValue = strings.TrimSpace(strings.TrimPrefix(trimmedValue, "="))
 	// Then, normalize internal whitespace sequences (tabs, multiple spaces) to single spaces.
 	// strings.Fields splits by any whitespace and removes empty strings resulting from multiple spaces.
 	// strings.Join then puts them back with single spaces.
@@ -142,6 +144,9 @@ func parseExpectedStatusLine(line string, lineNumber int, resp *ExpectedResponse
 			"line %d: invalid status line: '%s'. Expected HTTP_VERSION STATUS_CODE [STATUS_TEXT]",
 			lineNumber, line)
 	}
+	if isStatusAlternatives(parts[1]) {
+		return parseStatusAlternatives(parts[1], lineNumber, resp)
+	}
 	// parts[0] is HTTP Version, parts[1] is StatusCode, rest is StatusText
 	statusCodeInt, err := parseInt(parts[1])
 	if err != nil {
@@ -202,6 +207,10 @@ type responseParserState struct {
 	parsingBody             bool
 	lineNumber              int
 	processedAnyLine        bool
+
+	// Folded header support: the last header and its line, which an indented line may continue
+	lastHeaderName string
+	lastHeaderLine int
 }
 
 func parseExpectedResponses(reader io.Reader, filePath string) ([]*ExpectedResponse, error) {
@@ -243,6 +252,14 @@ func (s *responseParserState) processLine(originalLine, trimmedLine string) erro
 		return nil
 	}
 
+	if handled, err := s.processDirectiveLine(trimmedLine); handled || err != nil {
+		return err
+	}
+
+	if handled, err := s.processAssertionLine(trimmedLine); handled || err != nil {
+		return err
+	}
+
 	if s.isComment(trimmedLine) {
 		return nil
 	}
@@ -276,13 +293,19 @@ func (s *responseParserState) hasResponseContent() bool {
 	return (s.currentExpectedResponse.Status != nil && *s.currentExpectedResponse.Status != "") ||
 		s.currentExpectedResponse.StatusCode != nil ||
 		len(s.currentExpectedResponse.Headers) > 0 ||
+		len(s.currentExpectedResponse.XMLAssertions) > 0 ||
 		len(s.bodyLines) > 0
 }
 
 // finalizeCurrentResponse adds the current response to the list
 func (s *responseParserState) finalizeCurrentResponse() {
 	bodyStr := strings.Join(s.bodyLines, "\n")
-	s.currentExpectedResponse.Body = &bodyStr
+	// XPath assertions replace the whole-document comparison when no body is given
+	if len(s.currentExpectedResponse.XMLAssertions) > 0 && strings.TrimSpace(bodyStr) == "" {
+		s.currentExpectedResponse.Body = nil
+	} else {
+		s.currentExpectedResponse.Body = &bodyStr
+	}
 	s.expectedResponses = append(s.expectedResponses, s.currentExpectedResponse)
 }
 
@@ -303,6 +326,9 @@ func (s *responseParserState) processContentLine(originalLine, trimmedLine strin
 	}
 
 	if s.parsingBody {
+		if handled, err := s.processBodyFileLine(trimmedLine); handled || err != nil {
+			return err
+		}
 		s.bodyLines = append(s.bodyLines, originalLine)
 		return nil
 	}
@@ -313,7 +339,20 @@ func (s *responseParserState) processContentLine(originalLine, trimmedLine strin
 		return nil
 	}
 
-	return processExpectedStatusOrHeaderLine(trimmedLine, s.lineNumber, s.currentExpectedResponse)
+	if isFoldedHeaderLine(originalLine, s.lineNumber, s.lastHeaderLine) {
+		s.handleFoldedHeaderLine(trimmedLine)
+		return nil
+	}
+
+	isHeaderLine := s.currentExpectedResponse.StatusCode != nil || len(s.currentExpectedResponse.StatusCodes) > 0
+	if err := processExpectedStatusOrHeaderLine(trimmedLine, s.lineNumber, s.currentExpectedResponse); err != nil {
+		return err
+	}
+	if isHeaderLine {
+		s.lastHeaderName = strings.TrimSpace(strings.SplitN(trimmedLine, ":", 2)[0])
+		s.lastHeaderLine = s.lineNumber
+	}
+	return nil
 }
 
 // shouldStartBodyParsing determines if we should start parsing the body
@@ -8,9 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
-	"time"
 )
 
 // RequestLineResult represents the result of parsing a request line
@@ -45,6 +43,13 @@ type requestParserState struct {
 	// Multi-line query parameter support
 	queryParams        []string // Accumulated query parameters from multi-line syntax
 	parsingQueryParams bool     // Flag to indicate we're collecting query parameters
+
+	// Folded header support: the last header and its line, which an indented line may continue
+	lastHeaderName string
+	lastHeaderLine int
+
+	openScript *scriptBlock // Script block ("< {%" or "> {%") whose closing "%}" has not been seen yet
+	curlLines  []string     // Lines of a curl command continued on the next line
 }
 
 // processFileLines reads and processes all lines from the reader
@@ -93,6 +98,10 @@ func shouldProcessLine(line string, parserState *requestParserState) bool {
 
 // finalizeParseResults completes the parsing process
 func finalizeParseResults(parserState *requestParserState) {
+	if parserState.openScript != nil {
+		slog.Warn("Unterminated script block: missing %}", "filePath", parserState.filePath)
+	}
+	parserState.warnUnterminatedCurl()
 	if parserState.currentRequest != nil {
 		parserState.finalizeCurrentRequest()
 	}
@@ -106,6 +115,12 @@ func finalizeParseResults(parserState *requestParserState) {
 func processFileLine(parserState *requestParserState, line string) error {
 	// Remove trailing newline and carriage return if present
 	line = strings.TrimRight(line, "\r\n")
+	if parserState.handleScriptLine(line) {
+		return nil
+	}
+	if handled, err := parserState.handleCurlLine(line); handled || err != nil {
+		return err
+	}
 	trimmedLine := strings.TrimSpace(line)
 	// Process the line based on content
 	if trimmedLine == "" {
@@ -169,6 +184,11 @@ func (p *requestParserState) handleContent(trimmedLine, originalLine string) err
 		return nil
 	}
 
+	if isFoldedHeaderLine(originalLine, p.lineNumber, p.lastHeaderLine) {
+		p.handleFoldedHeaderLine(trimmedLine)
+		return nil
+	}
+
 	// Check for multi-line query parameters (? or & prefixed lines)
 	if p.isQueryParameterLine(trimmedLine) {
 		return p.handleQueryParameterLine(trimmedLine)
@@ -270,9 +290,57 @@ func (p *requestParserState) processCommentDirectives(commentContent string) err
 	if p.handleNoCookieJarDirective(commentContent) {
 		return nil
 	}
+	if p.handleNoLogDirective(commentContent) {
+		return nil
+	}
+	if p.handleNoInferContentTypeDirective(commentContent) {
+		return nil
+	}
 	if p.handleTimeoutDirective(commentContent) {
 		return nil
 	}
+	if p.handleAuthDirective(commentContent) {
+		return nil
+	}
+	if p.handleTagDirective(commentContent) {
+		return nil
+	}
+	if p.handleProxyDirective(commentContent) {
+		return nil
+	}
+	if p.handleConditionalDirective(commentContent) {
+		return nil
+	}
+	if p.handlePaginateDirective(commentContent) {
+		return nil
+	}
+	if p.handlePollDirective(commentContent) {
+		return nil
+	}
+	if p.handleRetryDirective(commentContent) {
+		return nil
+	}
+	if p.handleRetryDelayDirective(commentContent) {
+		return nil
+	}
+	if p.handleCaptureDirective(commentContent) {
+		return nil
+	}
+	if p.handleWaitForDirective(commentContent) {
+		return nil
+	}
+	if p.handleSOAPDirective(commentContent) {
+		return nil
+	}
+	if p.handleOutputDirective(commentContent) {
+		return nil
+	}
+	if p.handleSSETimeoutDirective(commentContent) {
+		return nil
+	}
+	if p.handleNoProxyDirective(commentContent) {
+		return nil
+	}
 	return nil // Other comment content - no special handling needed
 }
 
@@ -303,10 +371,19 @@ func (p *requestParserState) handleNoCookieJarDirective(commentContent string) b
 	return false
 }
 
-// handleTimeoutDirective processes @timeout directives
-func (p *requestParserState) handleTimeoutDirective(commentContent string) bool {
-	if strings.HasPrefix(commentContent, "@timeout ") {
-		p.processTimeoutDirective(commentContent)
+// handleNoLogDirective processes @no-log directives
+func (p *requestParserState) handleNoLogDirective(commentContent string) bool {
+	if strings.HasPrefix(commentContent, "@no-log") {
+		p.currentRequest.NoLog = true
+		return true
+	}
+	return false
+}
+
+// handleNoInferContentTypeDirective processes @no-infer-content-type directives
+func (p *requestParserState) handleNoInferContentTypeDirective(commentContent string) bool {
+	if strings.HasPrefix(commentContent, "@no-infer-content-type") {
+		p.currentRequest.NoInferContentType = true
 		return true
 	}
 	return false
@@ -320,6 +397,8 @@ func (p *requestParserState) handleEmptyLine() error {
 	if p.currentRequest != nil && p.currentRequest.Method != "" && !p.parsingBody {
 		p.parsingBody = true               // Crucial: now we are officially parsing the body
 		p.justSawEmptyLineSeparator = true // This flag can still be useful for other logic
+	} else if p.parsingBody && p.keepsEmptyBodyLines() {
+		p.bodyLines = append(p.bodyLines, "")
 	}
 	// If no method yet, or already parsing body, it's an ignored empty line or an empty line within the body.
 	return nil
@@ -398,8 +477,10 @@ func (p *requestParserState) handleHeader(trimmedLine string) error {
 	headerName := strings.TrimSpace(parts[0])
 	headerValue := strings.TrimSpace(parts[1])
 
-	// Add or append the header
+	// Add or append the header; repeated headers keep one value per line
 	p.currentRequest.Headers.Add(headerName, headerValue)
+	p.lastHeaderName = headerName
+	p.lastHeaderLine = p.lineNumber
 	return nil
 }
 
@@ -449,9 +530,9 @@ func (p *requestParserState) handleExternalFileReference(line string) {
 	if strings.HasPrefix(content, "@") {
 		p.parseExternalFileWithVariables(content[1:]) // Remove the '@'
 	} else {
-		// Static file reference (< ./path/to/file)
-		p.currentRequest.ExternalFilePath = strings.TrimSpace(content)
+		// Static file reference (< ./path/to/file or < encoding ./path/to/file)
 		p.currentRequest.ExternalFileWithVariables = false
+		p.parseExternalFilePathWithEncoding(content)
 	}
 
 	// Set RawBody to indicate external file usage (for backward compatibility)
@@ -461,12 +542,18 @@ func (p *requestParserState) handleExternalFileReference(line string) {
 // parseExternalFileWithVariables handles parsing of external file references with variable substitution
 func (p *requestParserState) parseExternalFileWithVariables(contentAfterAt string) {
 	p.currentRequest.ExternalFileWithVariables = true
-	parts := strings.Fields(contentAfterAt)
+	p.parseExternalFilePathWithEncoding(contentAfterAt)
+}
+
+// parseExternalFilePathWithEncoding sets the external file path, taking a leading source
+// encoding (e.g. "latin1 ./file.txt") into account
+func (p *requestParserState) parseExternalFilePathWithEncoding(content string) {
+	parts := strings.Fields(content)
 	if len(parts) >= 2 && isValidEncoding(parts[0]) {
 		p.currentRequest.ExternalFileEncoding = parts[0]
 		p.currentRequest.ExternalFilePath = strings.Join(parts[1:], " ")
 	} else {
-		p.currentRequest.ExternalFilePath = strings.TrimSpace(contentAfterAt)
+		p.currentRequest.ExternalFilePath = strings.TrimSpace(content)
 	}
 }
 
@@ -497,6 +584,7 @@ func (p *requestParserState) handleVariableDefinition(trimmedLine string) error
 
 	// Store in the file variables using the full @name (e.g. "@foo")
 	p.currentFileVariables[varNameWithAt] = varValue
+	p.parsedFile.variableLines[varNameWithAt] = p.lineNumber
 	return nil
 }
 
@@ -516,6 +604,7 @@ func (p *requestParserState) finalizeCurrentRequest() {
 		// Set the request body from collected lines (only if external file is not used)
 		if p.currentRequest.ExternalFilePath == "" {
 			rawBody := strings.Join(p.bodyLines, "\n") // Use \n as per HTTP spec for line endings in body
+			rawBody = strings.TrimRight(rawBody, "\n") // Empty lines kept by keepsEmptyBodyLines
 			p.currentRequest.RawBody = rawBody
 		}
 		// Note: p.currentRequest.Body (io.Reader) will be set by the consumer (e.g., Send) after variable substitution
@@ -543,26 +632,6 @@ func (p *requestParserState) finalizeCurrentRequest() {
 	p.queryParams = []string{}
 }
 
-// processTimeoutDirective handles the @timeout directive with milliseconds value
-func (p *requestParserState) processTimeoutDirective(commentContent string) {
-	p.ensureCurrentRequest()
-	timeoutStr := strings.TrimSpace(commentContent[len("@timeout "):])
-	if timeoutStr == "" {
-		return
-	}
-
-	timeoutMs, err := strconv.Atoi(timeoutStr)
-	if err != nil || timeoutMs <= 0 {
-		slog.Warn("Invalid timeout value in @timeout directive",
-			"value", timeoutStr,
-			"lineNumber", p.lineNumber,
-			"filePath", p.filePath)
-		return
-	}
-
-	p.currentRequest.Timeout = time.Duration(timeoutMs) * time.Millisecond
-}
-
 // _setRawURLFromLine sets the RawURLString and attempts to parse it into the URL field of the current request.
 // It logs the outcome with the provided context hint.
 func (p *requestParserState) _setRawURLFromLine(requestLine, contextHint string) {
@@ -746,6 +815,7 @@ func (p *requestParserState) handleIncompleteMethodLine(
 func (p *requestParserState) parseURLAndVersion(parts []string) {
 	urlAndVersionStr := strings.TrimSpace(strings.Join(parts[1:], " "))
 	urlStr, httpVersion := p.extractURLAndVersion(urlAndVersionStr)
+	urlStr = grpcRequestURL(p.currentRequest.Method, urlStr)
 
 	p.currentRequest.RawURLString = urlStr
 	p.currentRequest.HTTPVersion = httpVersion
@@ -795,7 +865,9 @@ func (p *requestParserState) handleQueryParameterLine(trimmedLine string) error
 
 	// Remove the ? or & prefix and trim whitespace
 	paramLine := strings.TrimSpace(trimmedLine[1:])
-	if paramLine != "" {
+	if param, ok := queryBlockParam(paramLine); ok {
+		p.currentRequest.QueryParams = append(p.currentRequest.QueryParams, param)
+	} else if paramLine != "" {
 		p.queryParams = append(p.queryParams, paramLine)
 	}
 
@@ -894,9 +966,6 @@ func (p *requestParserState) handleFormDataLine(line string) {
 
 // isValidEncoding checks if the given string is a valid encoding name
 func isValidEncoding(encoding string) bool {
-	validEncodings := map[string]bool{
-		"utf-8": true, "utf8": true, "latin1": true, "iso-8859-1": true,
-		"ascii": true, "cp1252": true, "windows-1252": true,
-	}
-	return validEncodings[strings.ToLower(encoding)]
+	_, err := lookupEncoding(encoding)
+	return err == nil
 }
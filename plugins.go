@@ -0,0 +1,274 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AuthProvider authenticates outgoing requests, e.g. by adding an Authorization header or a
+// signature. It is selected per request with the "# @auth <name> [args...]" directive.
+type AuthProvider interface {
+	Authenticate(req *http.Request, args []string) error
+}
+
+// AuthProviderFunc adapts a function to AuthProvider.
+type AuthProviderFunc func(req *http.Request, args []string) error
+
+// Authenticate calls f(req, args).
+func (f AuthProviderFunc) Authenticate(req *http.Request, args []string) error {
+	return f(req, args)
+}
+
+// BodyEncoder converts a substituted request body into its wire form for a media type, e.g. YAML
+// authored in the .http file into JSON. It replaces the charset encoding of the body.
+type BodyEncoder interface {
+	EncodeBody(body string, contentType string) (string, error)
+}
+
+// BodyEncoderFunc adapts a function to BodyEncoder.
+type BodyEncoderFunc func(body string, contentType string) (string, error)
+
+// EncodeBody calls f(body, contentType).
+func (f BodyEncoderFunc) EncodeBody(body string, contentType string) (string, error) {
+	return f(body, contentType)
+}
+
+// ResponseValidator is an additional check of a response, applied by ValidateResponses for the
+// "# validate <name> [args...]" directive of an .hresp expectation.
+type ResponseValidator interface {
+	ValidateResponse(resp *Response, args []string) error
+}
+
+// ResponseValidatorFunc adapts a function to ResponseValidator.
+type ResponseValidatorFunc func(resp *Response, args []string) error
+
+// ValidateResponse calls f(resp, args).
+func (f ResponseValidatorFunc) ValidateResponse(resp *Response, args []string) error {
+	return f(resp, args)
+}
+
+// BodyValidator compares the expected body of an .hresp file, after variable substitution, with the
+// body of a response of the media type it is registered for, e.g. ignoring the order of elements a
+// format does not define. It replaces the built-in comparison and its placeholders for that type.
+type BodyValidator interface {
+	ValidateBody(expected, actual string) error
+}
+
+// BodyValidatorFunc adapts a function to BodyValidator.
+type BodyValidatorFunc func(expected, actual string) error
+
+// ValidateBody calls f(expected, actual).
+func (f BodyValidatorFunc) ValidateBody(expected, actual string) error {
+	return f(expected, actual)
+}
+
+// SystemVariable generates the value of a {{$name args...}} placeholder. Registered system variables
+// are substituted after the built-in ones, so they cannot change the meaning of a built-in name.
+type SystemVariable interface {
+	Value(args []string) (string, error)
+}
+
+// SystemVariableFunc adapts a function to SystemVariable.
+type SystemVariableFunc func(args []string) (string, error)
+
+// Value calls f(args).
+func (f SystemVariableFunc) Value(args []string) (string, error) {
+	return f(args)
+}
+
+// reRegisteredSystemVariable matches {{$name args...}} placeholders of registered system variables
+var reRegisteredSystemVariable = regexp.MustCompile(`\{\{\s*\$([A-Za-z_][A-Za-z0-9_.-]*)((?:\s+[^{}]*?)?)\s*\}\}`)
+
+// plugins holds the extensions registered with the Register functions
+var plugins = struct {
+	mu              sync.RWMutex
+	authProviders   map[string]AuthProvider
+	bodyEncoders    map[string]BodyEncoder
+	validators      map[string]ResponseValidator
+	bodyValidators  map[string]BodyValidator
+	systemVariables map[string]SystemVariable
+}{
+	authProviders:   make(map[string]AuthProvider),
+	bodyEncoders:    make(map[string]BodyEncoder),
+	validators:      make(map[string]ResponseValidator),
+	bodyValidators:  make(map[string]BodyValidator),
+	systemVariables: make(map[string]SystemVariable),
+}
+
+// RegisterAuthProvider makes an auth provider available under name to the "# @auth" request
+// directive of every client. It is meant to be called from an init function and panics if provider
+// is nil or name is empty or already registered.
+func RegisterAuthProvider(name string, provider AuthProvider) {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	registerPlugin(plugins.authProviders, "auth provider", name, provider, provider == nil)
+}
+
+// handleAuthDirective processes "@auth <provider> [args...]" directives
+func (p *requestParserState) handleAuthDirective(commentContent string) bool {
+	if !strings.HasPrefix(commentContent, "@auth ") {
+		return false
+	}
+	fields := strings.Fields(commentContent[len("@auth "):])
+	if len(fields) > 0 {
+		p.currentRequest.AuthProvider = fields[0]
+		p.currentRequest.AuthArgs = fields[1:]
+	}
+	return true
+}
+
+// RegisterBodyEncoder makes encoder apply to request bodies whose Content-Type has the given media
+// type, e.g. "application/x-msgpack". It is meant to be called from an init function and panics if
+// encoder is nil or the media type is empty or already registered.
+func RegisterBodyEncoder(mediaType string, encoder BodyEncoder) {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	registerPlugin(plugins.bodyEncoders, "body encoder", strings.ToLower(mediaType), encoder, encoder == nil)
+}
+
+// RegisterValidator makes a response validator available under name to the "# validate" .hresp
+// directive. It is meant to be called from an init function and panics if validator is nil or
+// name is empty or already registered.
+func RegisterValidator(name string, validator ResponseValidator) {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	registerPlugin(plugins.validators, "validator", name, validator, validator == nil)
+}
+
+// RegisterBodyValidator makes validator compare the expected and actual bodies of responses whose
+// Content-Type has the given media type, e.g. "application/problem+json", in ValidateResponses. It
+// is meant to be called from an init function and panics if validator is nil or the media type is
+// empty or already registered.
+func RegisterBodyValidator(mediaType string, validator BodyValidator) {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	registerPlugin(plugins.bodyValidators, "body validator", strings.ToLower(mediaType), validator,
+		validator == nil)
+}
+
+// RegisterSystemVariable makes {{$name args...}} placeholders available in request and response
+// files; name is given without the '$'. It is meant to be called from an init function and panics
+// if variable is nil or name is invalid or already registered.
+func RegisterSystemVariable(name string, variable SystemVariable) {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	match := reRegisteredSystemVariable.FindStringSubmatch("{{$" + name + "}}")
+	if match == nil || match[1] != name {
+		panic(fmt.Sprintf("restclient: invalid system variable name %q", name))
+	}
+	registerPlugin(plugins.systemVariables, "system variable", name, variable, variable == nil)
+}
+
+// registerPlugin adds plugin to registry, panicking on nil plugins, empty and duplicate names
+func registerPlugin[T any](registry map[string]T, kind, name string, plugin T, isNil bool) {
+	if isNil {
+		panic(fmt.Sprintf("restclient: Register %s %q is nil", kind, name))
+	}
+	if name == "" {
+		panic(fmt.Sprintf("restclient: Register %s with an empty name", kind))
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("restclient: Register called twice for %s %q", kind, name))
+	}
+	registry[name] = plugin
+}
+
+// RegisteredPlugins lists the names of the registered extensions by kind ("auth", "encoder",
+// "validator", "bodyValidator" and "variable"), sorted, e.g. for diagnostics.
+func RegisteredPlugins() map[string][]string {
+	plugins.mu.RLock()
+	defer plugins.mu.RUnlock()
+	return map[string][]string{
+		"auth":          sortedKeys(plugins.authProviders),
+		"encoder":       sortedKeys(plugins.bodyEncoders),
+		"validator":     sortedKeys(plugins.validators),
+		"bodyValidator": sortedKeys(plugins.bodyValidators),
+		"variable":      sortedKeys(plugins.systemVariables),
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupAuthProvider returns the auth provider registered under name
+func lookupAuthProvider(name string) (AuthProvider, bool) {
+	plugins.mu.RLock()
+	defer plugins.mu.RUnlock()
+	provider, ok := plugins.authProviders[name]
+	return provider, ok
+}
+
+// lookupBodyEncoder returns the body encoder registered for mediaType
+func lookupBodyEncoder(mediaType string) (BodyEncoder, bool) {
+	plugins.mu.RLock()
+	defer plugins.mu.RUnlock()
+	encoder, ok := plugins.bodyEncoders[strings.ToLower(mediaType)]
+	return encoder, ok
+}
+
+// lookupValidator returns the response validator registered under name
+func lookupValidator(name string) (ResponseValidator, bool) {
+	plugins.mu.RLock()
+	defer plugins.mu.RUnlock()
+	validator, ok := plugins.validators[name]
+	return validator, ok
+}
+
+// lookupBodyValidator returns the body validator registered for mediaType
+func lookupBodyValidator(mediaType string) (BodyValidator, bool) {
+	plugins.mu.RLock()
+	defer plugins.mu.RUnlock()
+	validator, ok := plugins.bodyValidators[strings.ToLower(mediaType)]
+	return validator, ok
+}
+
+// substituteRegisteredSystemVariables replaces placeholders of registered system variables.
+// Placeholders of unknown names, and those whose variable returns an error, are kept as is.
+func substituteRegisteredSystemVariables(text string) string {
+	plugins.mu.RLock()
+	defer plugins.mu.RUnlock()
+	if len(plugins.systemVariables) == 0 {
+		return text
+	}
+	return reRegisteredSystemVariable.ReplaceAllStringFunc(text, func(placeholder string) string {
+		match := reRegisteredSystemVariable.FindStringSubmatch(placeholder)
+		variable, ok := plugins.systemVariables[match[1]]
+		if !ok {
+			return placeholder
+		}
+		value, err := variable.Value(strings.Fields(match[2]))
+		if err != nil {
+			return placeholder
+		}
+		return value
+	})
+}
+
+// authenticateRequest applies the auth provider selected with the request's @auth directive, if any
+func authenticateRequest(httpReq *http.Request, rcRequest *Request) error {
+	switch rcRequest.AuthProvider {
+	case "", OAuth2AuthProvider:
+		return nil // see authorizeOAuth2
+	case OAuth1AuthProvider:
+		return nil // OAuth1 signs the final request, see signOAuth1
+	}
+	provider, ok := lookupAuthProvider(rcRequest.AuthProvider)
+	if !ok {
+		return fmt.Errorf("unknown auth provider %q", rcRequest.AuthProvider)
+	}
+	if err := provider.Authenticate(httpReq, rcRequest.AuthArgs); err != nil {
+		return fmt.Errorf("auth provider %q: %w", rcRequest.AuthProvider, err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Defaults of the @poll directive options
+const (
+	defaultPollEvery   = time.Second
+	defaultPollTimeout = 30 * time.Second
+)
+
+// Polling configures a request with a "# @poll" directive, which is sent again until a condition on
+// its response holds, e.g. for asynchronous job APIs.
+type Polling struct {
+	Every   time.Duration // every=<duration>: delay between attempts
+	Timeout time.Duration // timeout=<duration>: give up when the next attempt would start later
+	Until   string        // until=<condition>: the rest of the line, e.g. body.$.status == "READY"
+}
+
+// rePollBodyPath matches body.$ JSONPath operands of a poll condition, e.g. body.$.items[0]['id']
+var rePollBodyPath = regexp.MustCompile(`\bbody\.\$(?:\.[A-Za-z0-9_-]+|\[[^\]]*\])*`)
+
+// handlePollDirective processes "@poll [every=<d>] [timeout=<d>] until=<condition>" directives
+func (p *requestParserState) handlePollDirective(commentContent string) bool {
+	if !strings.HasPrefix(commentContent, "@poll") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	polling, err := parsePollDirective(strings.TrimPrefix(commentContent, "@poll"))
+	if err != nil {
+		slog.Warn("Invalid @poll directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.Poll = polling
+	return true
+}
+
+// parsePollDirective parses the arguments of `@poll every=2s timeout=60s until=body.$.status == "READY"`.
+// until= must come last, as the condition extends to the end of the line.
+func parsePollDirective(args string) (*Polling, error) {
+	polling := &Polling{Every: defaultPollEvery, Timeout: defaultPollTimeout}
+	options, until, hasUntil := strings.Cut(args, "until=")
+	if !hasUntil || strings.TrimSpace(until) == "" {
+		return nil, errors.New("missing until=<condition>")
+	}
+	polling.Until = strings.TrimSpace(until)
+	if _, err := evaluatePollCondition(polling.Until, &Response{}); err != nil {
+		return nil, fmt.Errorf("invalid condition '%s': %w", polling.Until, err)
+	}
+	for _, option := range strings.Fields(options) {
+		name, value, _ := strings.Cut(option, "=")
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration like 2s, got '%s'", name, value)
+		}
+		switch name {
+		case "every":
+			polling.Every = duration
+		case "timeout":
+			polling.Timeout = duration
+		default:
+			return nil, fmt.Errorf("unknown option '%s'", option)
+		}
+	}
+	return polling, nil
+}
+
+// String renders the directive arguments, e.g. `every=2s timeout=1m0s until=status == 200`
+func (p *Polling) String() string {
+	return fmt.Sprintf("every=%s timeout=%s until=%s", p.Every, p.Timeout, p.Until)
+}
+
+// pollUntil sends the request again every Polling.Every until the poll condition holds on the
+// response, or the timeout would elapse before the next attempt. Failed attempts, e.g. connection
+// errors, are retried too. The last response is returned; its Error reports a timeout.
+func (c *Client) pollUntil(ctx context.Context, rcRequest *Request, resp *Response) *Response {
+	polling := rcRequest.Poll
+	deadline := time.Now().Add(polling.Timeout)
+	for attempt := 1; ; attempt++ {
+		resp.Attempts = attempt
+		if resp.Error == nil {
+			if met, err := evaluatePollCondition(polling.Until, resp); err != nil || met {
+				resp.Error = err
+				return resp
+			}
+		}
+		if time.Now().Add(polling.Every).After(deadline) {
+			cause := fmt.Errorf("condition '%s' not met after %d attempts within %s",
+				polling.Until, attempt, polling.Timeout)
+			resp.Error = multierror.Append(cause, resp.Error).ErrorOrNil()
+			return resp
+		}
+		select {
+		case <-ctx.Done():
+			resp.Error = multierror.Append(ctx.Err(), resp.Error).ErrorOrNil()
+			return resp
+		case <-time.After(polling.Every):
+		}
+
+		resp = c.resendRequest(ctx, rcRequest)
+	}
+}
+
+// evaluatePollCondition evaluates a poll condition on resp with the placeholder expression syntax.
+// Operands are status (the status code), header.<Name>, body (the whole body), body.$<jsonpath>
+// and literals; a missing header or JSON value is empty.
+func evaluatePollCondition(condition string, resp *Response) (bool, error) {
+	if err := resp.BufferBody(); err != nil {
+		return false, err
+	}
+	bodyPaths := make(map[string]string)
+	expression := rePollBodyPath.ReplaceAllStringFunc(condition, func(path string) string {
+		name := fmt.Sprintf("__body%d", len(bodyPaths))
+		bodyPaths[name] = strings.TrimPrefix(path, "body.")
+		return name
+	})
+
+	lookup := func(name string) string {
+		switch {
+		case name == "status":
+			return strconv.Itoa(resp.StatusCode)
+		case name == "body":
+			return resp.BodyString
+		case strings.HasPrefix(name, "header."):
+			return resp.Header(strings.TrimPrefix(name, "header."))
+		}
+		if path, ok := bodyPaths[name]; ok {
+			value, err := resp.JSONPath(path)
+			if err != nil {
+				return ""
+			}
+			return pollConditionValue(value)
+		}
+		return ""
+	}
+	// "!!" makes a single operand an expression, so conditions like body.$.done work too
+	value, err := evaluatePlaceholderExpression("!!("+expression+")", lookup)
+	if err != nil {
+		return false, err
+	}
+	return exprTruthy(value), nil
+}
+
+// pollConditionValue formats a decoded JSON value as an expression operand
+func pollConditionValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return formatExprNumber(v)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,101 @@
+package restclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// ProgressFunc reports the transfer of the bodies of req: sent and received are the body bytes
+// uploaded and downloaded so far, total is the Content-Length of the body being transferred, or -1
+// when it is unknown (e.g. chunked). Uploads are reported from the goroutine of the transport.
+type ProgressFunc func(req *Request, sent, received, total int64)
+
+// WithProgress calls progress as request and response bodies are transferred, once per chunk read,
+// so CLIs can show progress of large uploads and downloads and notice stalled transfers. Streamed
+// bodies (see WithStreamedBodies) are reported as the caller reads them. Requests sent in parallel
+// call progress concurrently.
+func WithProgress(progress ProgressFunc) ClientOption {
+	return func(c *Client) error {
+		if progress == nil {
+			return errors.New("progress callback must not be nil")
+		}
+		c.progress = progress
+		return nil
+	}
+}
+
+// progressTracker counts the body bytes of one request and its response
+type progressTracker struct {
+	report   ProgressFunc
+	req      *Request
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+// trackUpload reports the upload of the body of httpReq, if the client reports progress. Bodies
+// obtained from GetBody to send the request again, e.g. after a 307 redirect, are reported from zero.
+// The returned tracker is nil without progress reporting.
+func (c *Client) trackUpload(httpReq *http.Request, rcRequest *Request) *progressTracker {
+	if c.progress == nil {
+		return nil
+	}
+	tracker := &progressTracker{report: c.progress, req: rcRequest}
+	total := httpReq.ContentLength
+	if total == 0 {
+		total = -1
+	}
+	httpReq.Body = tracker.trackBody(httpReq.Body, total)
+	if getBody := httpReq.GetBody; getBody != nil {
+		httpReq.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			tracker.sent.Store(0)
+			return tracker.trackBody(body, total), nil
+		}
+	}
+	return tracker
+}
+
+// trackBody counts the bytes read from an upload body
+func (t *progressTracker) trackBody(body io.ReadCloser, total int64) io.ReadCloser {
+	// http.NoBody must stay as is: any other body without a length is sent chunked
+	if body == nil || body == http.NoBody {
+		return body
+	}
+	return &progressReader{body: body, tracker: t, count: &t.sent, total: total}
+}
+
+// trackDownload reports the download of the body of httpResp; a nil tracker does nothing
+func (t *progressTracker) trackDownload(httpResp *http.Response) {
+	if t == nil || httpResp == nil || httpResp.Body == nil {
+		return
+	}
+	httpResp.Body = &progressReader{body: httpResp.Body, tracker: t, count: &t.received, total: httpResp.ContentLength}
+}
+
+// progressReader counts the bytes read from a body and reports them to its tracker
+type progressReader struct {
+	body    io.ReadCloser
+	tracker *progressTracker
+	count   *atomic.Int64
+	total   int64
+}
+
+// Read reads from the body, reporting the progress after every chunk
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if n > 0 {
+		r.count.Add(int64(n))
+		r.tracker.report(r.tracker.req, r.tracker.sent.Load(), r.tracker.received.Load(), r.total)
+	}
+	return n, err
+}
+
+// Close closes the body
+func (r *progressReader) Close() error {
+	return r.body.Close()
+}
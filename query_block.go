@@ -0,0 +1,43 @@
+package restclient
+
+import (
+	"net/url"
+	"strings"
+)
+
+// QueryParam is one "key: value" line of a query parameter block
+type QueryParam struct {
+	Name  string
+	Value string
+}
+
+// queryBlockParam parses the text after the '?' or '&' of a multi-line query parameter line written as
+// "key: value"; lines such as "page=2" are taken into the URL as written instead
+func queryBlockParam(paramLine string) (QueryParam, bool) {
+	name, value, found := strings.Cut(paramLine, ":")
+	name = strings.TrimSpace(name)
+	if !found || name == "" || strings.ContainsAny(name, "= \t") {
+		return QueryParam{}, false
+	}
+	return QueryParam{Name: name, Value: strings.TrimSpace(value)}, true
+}
+
+// mergeQueryParams appends query parameters to the query of u, with names and values resolved by
+// resolve and percent-encoded; parameters already in the URL are kept in place
+func mergeQueryParams(u *url.URL, params []QueryParam, resolve func(string) string) {
+	pairs := make([]string, 0, len(params)+1)
+	if u.RawQuery != "" {
+		pairs = append(pairs, u.RawQuery)
+	}
+	for _, param := range params {
+		pairs = append(pairs, url.QueryEscape(resolve(param.Name))+"="+url.QueryEscape(resolve(param.Value)))
+	}
+	u.RawQuery = strings.Join(pairs, "&")
+}
+
+// writeQueryBlock renders query parameters as "& key: value" lines below the request line
+func writeQueryBlock(sb *strings.Builder, params []QueryParam) {
+	for _, param := range params {
+		sb.WriteString("    & " + param.Name + ": " + param.Value + "\n")
+	}
+}
@@ -0,0 +1,116 @@
+package restclient
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reRandomFromFile matches {{$randomFromFile ./values.txt}}
+var reRandomFromFile = regexp.MustCompile(`{{\s*\$randomFromFile\s+([^}\s]+)\s*}}`)
+
+// weightedValue is a single candidate value read by {{$randomFromFile}}
+type weightedValue struct {
+	value  string
+	weight float64
+}
+
+// substituteRandomFromFileVariables replaces {{$randomFromFile path}} placeholders with a value
+// picked at random from the referenced file. Relative paths are resolved against the directory
+// of the request file. Each occurrence is picked independently.
+// It returns an error naming the first file that could not be read or parsed.
+func (c *Client) substituteRandomFromFileVariables(text, requestFilePath string) (string, error) {
+	if !reRandomFromFile.MatchString(text) {
+		return text, nil
+	}
+
+	cache := make(map[string][]weightedValue)
+	var firstErr error
+	result := reRandomFromFile.ReplaceAllStringFunc(text, func(match string) string {
+		path := reRandomFromFile.FindStringSubmatch(match)[1]
+		values, ok := cache[path]
+		if !ok {
+			var err error
+			values, err = c.loadWeightedValues(path, requestFilePath)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return match
+			}
+			cache[path] = values
+		}
+		return pickWeightedValue(values)
+	})
+
+	return result, firstErr
+}
+
+// loadWeightedValues reads and parses the values file referenced by a {{$randomFromFile}} placeholder
+func (c *Client) loadWeightedValues(path, requestFilePath string) ([]weightedValue, error) {
+	fullPath := path
+	if !filepath.IsAbs(fullPath) {
+		fullPath = filepath.Join(filepath.Dir(requestFilePath), path)
+	}
+
+	content, err := c.readFileWithEncoding(fullPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file for $randomFromFile %s: %w", path, err)
+	}
+
+	values, err := parseWeightedValues(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid values file for $randomFromFile %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// parseWeightedValues parses one value per line. Blank lines and lines starting with '#' are
+// skipped. A line may end with '|<weight>' to make the value more or less likely than the
+// default weight of 1; a trailing segment that is not a number is kept as part of the value.
+func parseWeightedValues(content string) ([]weightedValue, error) {
+	var values []weightedValue
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entry := weightedValue{value: line, weight: 1}
+		if sepIdx := strings.LastIndex(line, "|"); sepIdx >= 0 {
+			weight, err := strconv.ParseFloat(strings.TrimSpace(line[sepIdx+1:]), 64)
+			if err == nil {
+				if weight <= 0 {
+					return nil, fmt.Errorf("line %d: weight must be positive, got %s", i+1, line[sepIdx+1:])
+				}
+				entry = weightedValue{value: strings.TrimSpace(line[:sepIdx]), weight: weight}
+			}
+		}
+		values = append(values, entry)
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values found")
+	}
+	return values, nil
+}
+
+// pickWeightedValue selects a value with probability proportional to its weight
+func pickWeightedValue(values []weightedValue) string {
+	total := 0.0
+	for _, v := range values {
+		total += v.weight
+	}
+
+	target := rand.Float64() * total
+	for _, v := range values {
+		target -= v.weight
+		if target < 0 {
+			return v.value
+		}
+	}
+	return values[len(values)-1].value
+}
@@ -0,0 +1,59 @@
+package restclient
+
+import (
+	"errors"
+	"net/http"
+)
+
+// maxRedirects mirrors the default redirect limit of net/http
+const maxRedirects = 10
+
+// RedirectHop describes one redirect response that was followed on the way to the final response.
+type RedirectHop struct {
+	URL        string         // URL that answered with the redirect
+	StatusCode int            // e.g., 302
+	Status     string         // e.g., "302 Found"
+	Location   string         // Value of the Location header
+	Cookies    []*http.Cookie // Cookies set by the redirect response (Set-Cookie)
+}
+
+// stopRedirects is the redirect policy of requests with @no-redirect: the redirect response itself is
+// returned
+func stopRedirects(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// recordingCheckRedirect wraps an http.Client CheckRedirect policy (nil meaning the net/http
+// default) and appends every redirect that the policy allows to hops.
+func recordingCheckRedirect(
+	policy func(req *http.Request, via []*http.Request) error,
+	hops *[]RedirectHop,
+) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		var err error
+		if policy != nil {
+			err = policy(req, via)
+		} else if len(via) >= maxRedirects {
+			err = errors.New("stopped after 10 redirects")
+		}
+		// http.ErrUseLastResponse (and any other error) means the redirect is not followed
+		if err == nil && req.Response != nil {
+			*hops = append(*hops, newRedirectHop(req.Response))
+		}
+		return err
+	}
+}
+
+// newRedirectHop summarizes a redirect response
+func newRedirectHop(resp *http.Response) RedirectHop {
+	hop := RedirectHop{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Location:   resp.Header.Get("Location"),
+		Cookies:    resp.Cookies(),
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		hop.URL = resp.Request.URL.String()
+	}
+	return hop
+}
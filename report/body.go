@@ -0,0 +1,40 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/bmcszk/go-restclient/history"
+)
+
+// diffBodies reports a changed response body as a unified diff of the normalized bodies
+func diffBodies(before, after *history.Entry) []Change {
+	beforeBody := normalizeBody(before.Response.Body)
+	afterBody := normalizeBody(after.Response.Body)
+	if beforeBody == afterBody {
+		return nil
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(beforeBody + "\n"),
+		B:        difflib.SplitLines(afterBody + "\n"),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  3,
+	})
+	return []Change{{Kind: ChangeBody, Before: before.Response.Body, After: after.Response.Body, Diff: diff}}
+}
+
+// normalizeBody pretty-prints JSON bodies with sorted keys, so formatting and key order do not
+// count as changes; other bodies are compared with line endings and surrounding space normalized.
+func normalizeBody(body string) string {
+	var data any
+	if err := json.Unmarshal([]byte(body), &data); err == nil {
+		if normalized, err := json.MarshalIndent(data, "", "  "); err == nil {
+			return string(normalized)
+		}
+	}
+	return strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+}
@@ -0,0 +1,355 @@
+// Package report compares recorded executions, e.g. runs captured with a history.Store before and
+// after a deployment, and highlights regressions.
+package report
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmcszk/go-restclient/history"
+)
+
+// Defaults for DiffRuns
+const (
+	DefaultSlowdownFactor = 1.5
+	DefaultMinSlowdown    = 100 * time.Millisecond
+)
+
+// defaultIgnoredHeaders change on every response and are not compared by default
+var defaultIgnoredHeaders = []string{"Date", "Age", "Expires", "Last-Modified"}
+
+// ChangeKind names the aspect of a response that differs between runs.
+type ChangeKind string
+
+// Change kinds
+const (
+	ChangeStatus   ChangeKind = "status"
+	ChangeError    ChangeKind = "error"
+	ChangeHeader   ChangeKind = "header"
+	ChangeBody     ChangeKind = "body"
+	ChangeDuration ChangeKind = "duration"
+	ChangeMissing  ChangeKind = "missing" // the request has no entry in the second run
+	ChangeAdded    ChangeKind = "added"   // the request has no entry in the first run
+)
+
+// Change is a single difference between the two executions of a request.
+type Change struct {
+	Kind       ChangeKind
+	Header     string // canonical header name, for ChangeHeader
+	Before     string
+	After      string
+	Diff       string // unified diff of the normalized bodies, for ChangeBody
+	Regression bool
+}
+
+// RequestDiff compares the executions of one request, matched by its position in the file.
+type RequestDiff struct {
+	Index   int
+	Name    string
+	Method  string
+	URL     string
+	Before  *history.Entry // nil if the request is missing from the first run
+	After   *history.Entry // nil if the request is missing from the second run
+	Changes []Change
+}
+
+// Regressed reports whether any change of the request is a regression.
+func (d RequestDiff) Regressed() bool {
+	for _, change := range d.Changes {
+		if change.Regression {
+			return true
+		}
+	}
+	return false
+}
+
+// RunDiff is the result of DiffRuns: one RequestDiff per request of either run, in index order.
+type RunDiff struct {
+	BeforeRunID string
+	AfterRunID  string
+	Requests    []RequestDiff
+}
+
+// Changed returns the requests with at least one change.
+func (d *RunDiff) Changed() []RequestDiff {
+	var changed []RequestDiff
+	for _, req := range d.Requests {
+		if len(req.Changes) > 0 {
+			changed = append(changed, req)
+		}
+	}
+	return changed
+}
+
+// Regressions returns the requests with at least one regression.
+func (d *RunDiff) Regressions() []RequestDiff {
+	var regressed []RequestDiff
+	for _, req := range d.Requests {
+		if req.Regressed() {
+			regressed = append(regressed, req)
+		}
+	}
+	return regressed
+}
+
+// HasRegressions reports whether any request regressed.
+func (d *RunDiff) HasRegressions() bool {
+	return len(d.Regressions()) > 0
+}
+
+// diffConfig holds the settings applied by DiffOptions
+type diffConfig struct {
+	ignoredHeaders map[string]bool
+	slowdownFactor float64
+	minSlowdown    time.Duration
+}
+
+// DiffOption is a functional option for configuring DiffRuns.
+type DiffOption func(*diffConfig)
+
+// WithIgnoredHeaders excludes headers from the comparison, in addition to the defaults
+// (Date, Age, Expires and Last-Modified).
+func WithIgnoredHeaders(names ...string) DiffOption {
+	return func(cfg *diffConfig) {
+		for _, name := range names {
+			cfg.ignoredHeaders[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// WithSlowdownThreshold sets when a longer duration is reported: the second run must take at least
+// factor times as long and at least minDelta longer. Slowdowns are regressions, speedups beyond the
+// same threshold are reported as changes. The defaults are DefaultSlowdownFactor and DefaultMinSlowdown.
+func WithSlowdownThreshold(factor float64, minDelta time.Duration) DiffOption {
+	return func(cfg *diffConfig) {
+		cfg.slowdownFactor = factor
+		cfg.minSlowdown = minDelta
+	}
+}
+
+// DiffRuns compares two recorded runs of the same request file, e.g. history.Store.Run results
+// from before and after a deployment. Requests are matched by index. Statuses, errors, headers,
+// bodies (JSON is compared normalized) and durations are compared; a status moving into a worse
+// class (2xx/3xx to 4xx, or to 5xx), a new error, a missing request and a slowdown are regressions.
+func DiffRuns(before, after []history.Entry, options ...DiffOption) *RunDiff {
+	cfg := &diffConfig{
+		ignoredHeaders: make(map[string]bool),
+		slowdownFactor: DefaultSlowdownFactor,
+		minSlowdown:    DefaultMinSlowdown,
+	}
+	WithIgnoredHeaders(defaultIgnoredHeaders...)(cfg)
+	for _, option := range options {
+		option(cfg)
+	}
+
+	diff := &RunDiff{BeforeRunID: runID(before), AfterRunID: runID(after)}
+	beforeByIndex, afterByIndex := entriesByIndex(before), entriesByIndex(after)
+	for _, index := range unionIndexes(beforeByIndex, afterByIndex) {
+		diff.Requests = append(diff.Requests, cfg.diffRequest(index, beforeByIndex[index], afterByIndex[index]))
+	}
+	return diff
+}
+
+// diffRequest compares the two executions of the request at index; either may be nil
+func (cfg *diffConfig) diffRequest(index int, before, after *history.Entry) RequestDiff {
+	reqDiff := RequestDiff{Index: index, Before: before, After: after}
+	described := after
+	if described == nil {
+		described = before
+	}
+	reqDiff.Name, reqDiff.Method, reqDiff.URL = described.Name, described.Request.Method, described.Request.URL
+
+	switch {
+	case after == nil:
+		reqDiff.Changes = []Change{{Kind: ChangeMissing, Regression: true}}
+	case before == nil:
+		reqDiff.Changes = []Change{{Kind: ChangeAdded}}
+	default:
+		reqDiff.Changes = append(reqDiff.Changes, diffStatus(before, after)...)
+		reqDiff.Changes = append(reqDiff.Changes, diffError(before, after)...)
+		reqDiff.Changes = append(reqDiff.Changes, cfg.diffHeaders(before.Response.Headers, after.Response.Headers)...)
+		reqDiff.Changes = append(reqDiff.Changes, diffBodies(before, after)...)
+		reqDiff.Changes = append(reqDiff.Changes, cfg.diffDurations(before.Response.Duration, after.Response.Duration)...)
+	}
+	return reqDiff
+}
+
+// diffStatus reports a changed status code; moving to a worse status class is a regression
+func diffStatus(before, after *history.Entry) []Change {
+	if before.Response.StatusCode == after.Response.StatusCode {
+		return nil
+	}
+	return []Change{{
+		Kind:       ChangeStatus,
+		Before:     strconv.Itoa(before.Response.StatusCode),
+		After:      strconv.Itoa(after.Response.StatusCode),
+		Regression: statusClassRank(after.Response.StatusCode) > statusClassRank(before.Response.StatusCode),
+	}}
+}
+
+// statusClassRank orders status codes from healthy to failing: 0 for 1xx-3xx, 1 for 4xx,
+// 2 for 5xx and 3 for no status (the request failed)
+func statusClassRank(code int) int {
+	switch {
+	case code == 0:
+		return 3
+	case code >= http.StatusInternalServerError:
+		return 2
+	case code >= http.StatusBadRequest:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// diffError reports a changed execution error; a new error is a regression
+func diffError(before, after *history.Entry) []Change {
+	if before.Error == after.Error {
+		return nil
+	}
+	return []Change{{Kind: ChangeError, Before: before.Error, After: after.Error, Regression: after.Error != ""}}
+}
+
+// diffHeaders reports each header whose values differ, ignoring the configured headers
+func (cfg *diffConfig) diffHeaders(before, after http.Header) []Change {
+	names := make(map[string]bool)
+	for name := range before {
+		names[http.CanonicalHeaderKey(name)] = true
+	}
+	for name := range after {
+		names[http.CanonicalHeaderKey(name)] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		if !cfg.ignoredHeaders[name] {
+			sorted = append(sorted, name)
+		}
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, name := range sorted {
+		beforeValue := strings.Join(before.Values(name), ", ")
+		afterValue := strings.Join(after.Values(name), ", ")
+		if beforeValue != afterValue {
+			changes = append(changes, Change{Kind: ChangeHeader, Header: name, Before: beforeValue, After: afterValue})
+		}
+	}
+	return changes
+}
+
+// diffDurations reports a duration change beyond the configured threshold
+func (cfg *diffConfig) diffDurations(before, after time.Duration) []Change {
+	slower := after > before && exceedsThreshold(before, after, cfg)
+	faster := before > after && exceedsThreshold(after, before, cfg)
+	if !slower && !faster {
+		return nil
+	}
+	return []Change{{Kind: ChangeDuration, Before: before.String(), After: after.String(), Regression: slower}}
+}
+
+// exceedsThreshold reports whether long is at least slowdownFactor times and minSlowdown longer than short
+func exceedsThreshold(short, long time.Duration, cfg *diffConfig) bool {
+	return long-short >= cfg.minSlowdown && float64(long) >= float64(short)*cfg.slowdownFactor
+}
+
+// runID returns the run ID of the entries, or "" when there are none
+func runID(entries []history.Entry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+	return entries[0].RunID
+}
+
+// entriesByIndex maps request indexes to entries; for repeated indexes the last entry wins
+func entriesByIndex(entries []history.Entry) map[int]*history.Entry {
+	byIndex := make(map[int]*history.Entry, len(entries))
+	for i := range entries {
+		byIndex[entries[i].Index] = &entries[i]
+	}
+	return byIndex
+}
+
+// unionIndexes returns the sorted indexes present in either map
+func unionIndexes(a, b map[int]*history.Entry) []int {
+	seen := make(map[int]bool, len(a)+len(b))
+	var indexes []int
+	for _, m := range []map[int]*history.Entry{a, b} {
+		for index := range m {
+			if !seen[index] {
+				seen[index] = true
+				indexes = append(indexes, index)
+			}
+		}
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// String renders the diff as text, one block per changed request.
+func (d *RunDiff) String() string {
+	var sb strings.Builder
+	changed := d.Changed()
+	fmt.Fprintf(&sb, "run %s -> run %s: %d of %d requests changed, %d regressed\n",
+		d.BeforeRunID, d.AfterRunID, len(changed), len(d.Requests), len(d.Regressions()))
+	for _, req := range changed {
+		sb.WriteString(req.String())
+	}
+	return sb.String()
+}
+
+// String renders the changes of the request, marking regressions.
+func (d RequestDiff) String() string {
+	var sb strings.Builder
+	label := d.Name
+	if label == "" {
+		label = d.Method + " " + d.URL
+	}
+	fmt.Fprintf(&sb, "#%d %s", d.Index+1, label)
+	if d.Regressed() {
+		sb.WriteString(" [REGRESSION]")
+	}
+	sb.WriteString("\n")
+	for _, change := range d.Changes {
+		sb.WriteString("  ")
+		sb.WriteString(change.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// String renders a single change on one line, followed by the body diff if any.
+func (c Change) String() string {
+	var text string
+	switch c.Kind {
+	case ChangeMissing:
+		text = "missing from the second run"
+	case ChangeAdded:
+		text = "not in the first run"
+	case ChangeHeader:
+		text = fmt.Sprintf("header %s: %q -> %q", c.Header, c.Before, c.After)
+	case ChangeBody:
+		text = "body changed:\n" + indent(c.Diff, "    ")
+	default:
+		text = fmt.Sprintf("%s: %q -> %q", c.Kind, c.Before, c.After)
+	}
+	if c.Regression {
+		text += " (regression)"
+	}
+	return strings.TrimRight(text, "\n")
+}
+
+// indent prefixes every non-empty line of text
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
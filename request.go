@@ -50,8 +50,63 @@ type Request struct {
 	NoRedirect bool
 	// NoCookieJar indicates that this request should not use the cookie jar (from @no-cookie-jar directive)
 	NoCookieJar bool
+	// NoLog excludes this request from the history recorded with WithHistory (from @no-log directive)
+	NoLog bool
 	// Timeout specifies a custom timeout for this request (from @timeout directive)
 	Timeout time.Duration
+	// NoInferContentType disables Content-Type inference for this request (from @no-infer-content-type directive)
+	NoInferContentType bool
+	// AuthProvider names the registered AuthProvider applied before sending (from @auth directive)
+	AuthProvider string
+	// AuthArgs are the arguments following the provider name in the @auth directive
+	AuthArgs []string
+	// Tags label the request for selection with RunOptions.Tags (from "@tag smoke slow" directives)
+	Tags []string
+	// OAuth1 signs the request when AuthProvider is "oauth1": the configuration named by AuthArgs in the
+	// Security.Auth section of the selected environment (see OAuth1Config)
+	OAuth1 *OAuth1Config
+	// OAuth2 authorizes the request when AuthProvider is "oauth2": the configuration named by AuthArgs in the
+	// Security.Auth section of the selected environment (see OAuth2Config)
+	OAuth2 *OAuth2Config
+	// Proxy is the URL of the proxy this request is sent through instead of the client's (from @proxy
+	// directive), e.g. "http://localhost:8888"; it may contain variables
+	Proxy string
+	// NoProxy sends this request directly, bypassing the proxy of the client and of the HTTP_PROXY and
+	// HTTPS_PROXY environment variables (from @no-proxy directive)
+	NoProxy bool
+	// ConditionalHeaders maps the headers filled by conditional request directives (@if-match,
+	// @if-none-match, @if-modified-since, @if-unmodified-since) to the @name of the earlier request
+	// whose ETag or Last-Modified they take ("" for the preceding request)
+	ConditionalHeaders map[string]string
+	// Pagination makes the client follow paginated responses and combine their items (from
+	// @paginate directive); nil for a single request
+	Pagination *Pagination
+	// Retry makes the client send the request again when it fails transiently (from @retry directive or
+	// the workspace defaults file); nil for a single attempt
+	Retry *RetryPolicy
+	// Poll makes the client send the request again until a condition on its response holds (from
+	// @poll directive); nil for a single attempt
+	Poll *Polling
+	// WaitFor holds the request back until a readiness URL answers 2xx (from @wait-for directive); nil
+	// to send it right away
+	WaitFor *WaitFor
+	// Captures store values of the response in variables for the following requests of the run (from
+	// @capture directives)
+	Captures []Capture
+	// SOAP wraps the body in a SOAP envelope and sets the SOAP headers (from @soap directive); the
+	// expected body in .hresp files is then compared with the response's SOAP Body content
+	SOAP *SOAP
+	// OutputFile is the file the response body is streamed to instead of being buffered (from @output
+	// directive), relative to the request file; it may contain variables
+	OutputFile string
+	// SSETimeout ends the reading of a text/event-stream response after this long, keeping the events
+	// received until then (from @sse-timeout directive); 0 reads until the server closes the stream
+	SSETimeout time.Duration
+
+	// QueryParams are the parameters of "? key: value" and "& key: value" lines below the request line, in
+	// order; names and values may contain variables. They are percent-encoded and appended to the
+	// URL's query on substitution, after which the field is cleared.
+	QueryParams []QueryParam
 
 	// External file body configuration
 	// ExternalFilePath stores the path for external file body references (< ./path/to/file or <@ ./path/to/file)
@@ -60,6 +115,15 @@ type Request struct {
 	ExternalFileEncoding string
 	// ExternalFileWithVariables indicates if the external file should have variable substitution applied (<@ syntax)
 	ExternalFileWithVariables bool
+
+	// bodyLength is the length of a Body streamed from its parts, e.g. a multipart form with files; 0
+	// for other bodies, whose length the http package takes from their reader
+	bodyLength int64
+	// authConfigs are the OAuth2 configurations of the Security.Auth section of the selected environment,
+	// by id, for {{$auth.token("id")}} placeholders
+	authConfigs map[string]*OAuth2Config
+	// clientCert is the SSLConfiguration of the selected environment; nil when it has none
+	clientCert *clientCertificate
 }
 
 // ParsedFile represents all content parsed from a single .rest or .http file.
@@ -74,9 +138,13 @@ type ParsedFile struct {
 	EnvironmentVariables map[string]string
 	// GlobalVariables are key-value pairs accumulated during the execution of
 	// requests in this file (or imported files).
-	// These are set by `client.global.set()` in response handler scripts and are available to subsequent requests.
+	// These are set by "# @capture" directives and are available to subsequent requests, taking precedence
+	// over EnvironmentVariables.
 	GlobalVariables map[string]string
 	// FileVariables are key-value pairs defined directly within the .http file using the `@name = value` syntax.
 	// Their scope is the current file, and they are resolved at parse time.
 	FileVariables map[string]string
+
+	// variableLines records the line of the last definition of each file variable, for error messages
+	variableLines map[string]int
 }
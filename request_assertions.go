@@ -0,0 +1,94 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TestingT is the subset of *testing.T available to request assertions. It is satisfied by the
+// assert and require packages of testify, so their helpers can be used inside assertions.
+type TestingT interface {
+	Errorf(format string, args ...any)
+	FailNow()
+}
+
+// RequestAssertion is a Go-native check of the response of a named request, see WithRequestAssertion.
+type RequestAssertion func(t TestingT, resp *Response)
+
+// WithRequestAssertion attaches assertion to the requests named name (by "# @name"). During ExecuteFile
+// it runs after each such request received a response; requests that could not be sent are skipped.
+// Failures reported through t (Errorf, or FailNow which stops the assertion) are returned in the error
+// of ExecuteFile as a RequestError of phase PhaseAssert. Several assertions may be attached to one name.
+func WithRequestAssertion(name string, assertion func(t TestingT, resp *Response)) ClientOption {
+	return func(c *Client) error {
+		if name == "" {
+			return errors.New("request assertion requires a request name")
+		}
+		if assertion == nil {
+			return fmt.Errorf("request assertion for %q is nil", name)
+		}
+		if c.requestAssertions == nil {
+			c.requestAssertions = make(map[string][]RequestAssertion)
+		}
+		c.requestAssertions[name] = append(c.requestAssertions[name], assertion)
+		return nil
+	}
+}
+
+// failNowSignal is the panic value used by assertionRecorder.FailNow to stop an assertion
+type failNowSignal struct{}
+
+// assertionRecorder is the TestingT passed to request assertions; it collects reported failures
+type assertionRecorder struct {
+	failures []string
+}
+
+// Errorf records a failure and lets the assertion continue
+func (r *assertionRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+// FailNow stops the assertion; runAssertion recovers
+func (r *assertionRecorder) FailNow() {
+	if len(r.failures) == 0 {
+		r.failures = append(r.failures, "FailNow called")
+	}
+	panic(failNowSignal{})
+}
+
+// Helper is a no-op, accepted by testify helpers that mark themselves as test helpers
+func (*assertionRecorder) Helper() {}
+
+// runRequestAssertions runs the assertions attached to the name of the response's request and
+// returns their failures as one RequestError, or nil
+func (c *Client) runRequestAssertions(index int, response *Response) error {
+	if response == nil || response.Error != nil || response.Request == nil || response.Request.Name == "" {
+		return nil
+	}
+	assertions := c.requestAssertions[response.Request.Name]
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	recorder := &assertionRecorder{}
+	for _, assertion := range assertions {
+		runAssertion(assertion, recorder, response)
+	}
+	if len(recorder.failures) == 0 {
+		return nil
+	}
+	return newRequestError(response.Request, index, PhaseAssert, errors.New(strings.Join(recorder.failures, "\n")))
+}
+
+// runAssertion calls assertion, recovering from the panic raised by FailNow
+func runAssertion(assertion RequestAssertion, recorder *assertionRecorder, response *Response) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if _, stopped := recovered.(failNowSignal); !stopped {
+				panic(recovered)
+			}
+		}
+	}()
+	assertion(recorder, response)
+}
@@ -0,0 +1,154 @@
+package restclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestBuilder builds a request in code, for the few ad hoc requests of a test that do not deserve an
+// .http file:
+//
+//	resp, err := restclient.NewRequest("POST", "{{baseUrl}}/users").
+//		Header("Authorization", "Bearer {{token}}").
+//		JSONBody(user).
+//		Execute(ctx, client)
+//
+// The URL, query parameters and header values may contain variables, substituted like those of a file
+// when the request is executed (see ExecuteRequest); the body is sent verbatim. A builder method that
+// fails, e.g. JSONBody with a value that cannot be encoded, makes Build and Execute return its error.
+type RequestBuilder struct {
+	req *Request
+	err error
+}
+
+// NewRequest starts building a request with method to rawURL, which may be relative to the BaseURL of
+// the client executing it
+func NewRequest(method, rawURL string) *RequestBuilder {
+	b := &RequestBuilder{req: &Request{
+		Method:       strings.ToUpper(method),
+		RawURLString: rawURL,
+		Headers:      make(http.Header),
+	}}
+	if method == "" {
+		b.err = errors.New("request method must not be empty")
+	} else if strings.TrimSpace(rawURL) == "" {
+		b.err = errors.New("request URL must not be empty")
+	}
+	return b
+}
+
+// Name sets the name of the request, as "# @name" does, e.g. to tell requests apart in RequestErrors
+func (b *RequestBuilder) Name(name string) *RequestBuilder {
+	b.req.Name = name
+	return b
+}
+
+// Header adds a header value
+func (b *RequestBuilder) Header(name, value string) *RequestBuilder {
+	b.req.Headers.Add(name, value)
+	return b
+}
+
+// Query adds a query parameter, percent-encoded and appended to the query of the URL
+func (b *RequestBuilder) Query(name, value string) *RequestBuilder {
+	b.req.QueryParams = append(b.req.QueryParams, QueryParam{Name: name, Value: value})
+	return b
+}
+
+// Var sets a variable of the request, taking precedence over the variables of the environment but not
+// over programmatic ones (see Request.SetVariable)
+func (b *RequestBuilder) Var(name, value string) *RequestBuilder {
+	b.req.SetVariable(name, value)
+	return b
+}
+
+// Body sets the body, sent verbatim
+func (b *RequestBuilder) Body(body string) *RequestBuilder {
+	b.req.RawBody = body
+	return b
+}
+
+// JSONBody sets the body to v encoded as JSON, with the Content-Type application/json unless a
+// Content-Type header was added
+func (b *RequestBuilder) JSONBody(v any) *RequestBuilder {
+	body, err := json.Marshal(v)
+	if err != nil {
+		b.setErr(fmt.Errorf("failed to encode JSON body: %w", err))
+		return b
+	}
+	b.req.RawBody = string(body)
+	if b.req.Headers.Get("Content-Type") == "" {
+		b.req.Headers.Set("Content-Type", "application/json")
+	}
+	return b
+}
+
+// FormBody sets the body to the URL-encoded form of values, with the Content-Type
+// application/x-www-form-urlencoded unless a Content-Type header was added
+func (b *RequestBuilder) FormBody(values url.Values) *RequestBuilder {
+	b.req.RawBody = values.Encode()
+	if b.req.Headers.Get("Content-Type") == "" {
+		b.req.Headers.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return b
+}
+
+// Timeout sets the timeout of the request, as "# @timeout" does
+func (b *RequestBuilder) Timeout(timeout time.Duration) *RequestBuilder {
+	if timeout <= 0 {
+		b.setErr(fmt.Errorf("request timeout must be positive, got %s", timeout))
+		return b
+	}
+	b.req.Timeout = timeout
+	return b
+}
+
+// NoRedirect keeps the client from following redirects, as "# @no-redirect" does
+func (b *RequestBuilder) NoRedirect() *RequestBuilder {
+	b.req.NoRedirect = true
+	return b
+}
+
+// Build returns the request, or the first error of the builder methods. Each call returns a new
+// request, so a builder can serve as the template of several requests.
+func (b *RequestBuilder) Build() (*Request, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	req := *b.req
+	req.Headers = b.req.Headers.Clone()
+	req.QueryParams = append([]QueryParam(nil), b.req.QueryParams...)
+	if b.req.ActiveVariables != nil {
+		req.ActiveVariables = make(map[string]string, len(b.req.ActiveVariables))
+		for name, value := range b.req.ActiveVariables {
+			req.ActiveVariables[name] = value
+		}
+	}
+	return &req, nil
+}
+
+// Execute builds the request and sends it with client.ExecuteRequest. The response can be validated
+// against .hresp files with ValidateResponses like those of ExecuteFile.
+func (b *RequestBuilder) Execute(ctx context.Context, client *Client) (*Response, error) {
+	if client == nil {
+		return nil, errors.New("cannot execute a request with a nil client")
+	}
+	req, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.ExecuteRequest(ctx, req)
+}
+
+// setErr records the first error of the builder methods
+func (b *RequestBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
@@ -0,0 +1,116 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ErrorPhase names the stage of processing a request in which a RequestError occurred
+type ErrorPhase string
+
+// Phases reported by RequestError
+const (
+	PhaseParse      ErrorPhase = "parse"      // parsing the request file, before any request is sent
+	PhaseSubstitute ErrorPhase = "substitute" // variable substitution and body preparation
+	PhaseSend       ErrorPhase = "send"       // sending the request and reading the response
+	PhaseValidate   ErrorPhase = "validate"   // comparing the response with the .hresp expectation
+	PhaseAssert     ErrorPhase = "assert"     // Go assertions attached with WithRequestAssertion
+	PhaseScript     ErrorPhase = "script"     // pre-request and response handler scripts, see WithScriptHandler
+	PhaseHook       ErrorPhase = "hook"       // Go hooks, see WithBeforeRequestHook and the related options
+)
+
+// RequestError describes the failure of a single request. The errors returned by ExecuteFile and
+// ValidateResponses are *multierror.Error values whose entries are RequestErrors where the failure
+// belongs to a request; use RequestErrors to enumerate them. Index is -1 for file-level parse errors.
+// FilePath and Line locate the request block in its file, also when requests were selected by name or tag.
+type RequestError struct {
+	Index    int    // zero-based position of the request in the file (or of the response being validated)
+	Name     string // @name of the request, if any
+	Method   string
+	URL      string // substituted URL, or the raw URL when substitution failed
+	FilePath string // file the request was parsed from, if any
+	Line     int    // line of the request block in FilePath, if any
+	Phase    ErrorPhase
+	Err      error // underlying cause
+}
+
+// Error keeps the established messages: execution failures are prefixed with the request number
+// and target, while parse and validation errors already name their file and response.
+func (e *RequestError) Error() string {
+	switch e.Phase {
+	case PhaseSubstitute, PhaseSend:
+		return fmt.Sprintf("request %d%s (%s %s) processing resulted in error: %v",
+			e.Index+1, e.quotedName(), e.Method, e.URL, e.Err)
+	case PhaseAssert:
+		return fmt.Sprintf("request %d%s (%s %s) assertion failed: %v",
+			e.Index+1, e.quotedName(), e.Method, e.URL, e.Err)
+	case PhaseScript:
+		return fmt.Sprintf("request %d%s (%s %s) script failed: %v",
+			e.Index+1, e.quotedName(), e.Method, e.URL, e.Err)
+	case PhaseHook:
+		if e.Index < 0 {
+			return e.Err.Error()
+		}
+		return fmt.Sprintf("request %d%s (%s %s) hook failed: %v",
+			e.Index+1, e.quotedName(), e.Method, e.URL, e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+// Unwrap returns the underlying cause, for errors.Is and errors.As
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// quotedName returns ` "name"` for named requests, or ""
+func (e *RequestError) quotedName() string {
+	if e.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf(" %q", e.Name)
+}
+
+// RequestErrors returns the RequestErrors contained in err, which may be a single RequestError or
+// an aggregate returned by ExecuteFile or ValidateResponses, in the order they occurred. Entries
+// that do not belong to a request (e.g. a response count mismatch) are skipped.
+func RequestErrors(err error) []*RequestError {
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		var requestErrors []*RequestError
+		for _, entry := range merr.Errors {
+			var reqErr *RequestError
+			if errors.As(entry, &reqErr) {
+				requestErrors = append(requestErrors, reqErr)
+			}
+		}
+		if len(requestErrors) > 0 {
+			return requestErrors
+		}
+	}
+	// a single RequestError, which may itself wrap an aggregate of plain errors
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return []*RequestError{reqErr}
+	}
+	return nil
+}
+
+// newRequestError describes a failure of req in the given phase
+func newRequestError(req *Request, index int, phase ErrorPhase, err error) *RequestError {
+	reqErr := &RequestError{Index: index, Phase: phase, Err: err}
+	if req == nil {
+		return reqErr
+	}
+	reqErr.Name = req.Name
+	reqErr.Method = req.Method
+	reqErr.FilePath = req.FilePath
+	reqErr.Line = req.LineNumber
+	reqErr.URL = req.RawURLString
+	if req.URL != nil {
+		reqErr.URL = req.URL.String()
+	}
+	return reqErr
+}
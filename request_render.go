@@ -0,0 +1,196 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// String renders the request back into .http syntax: name and settings directives,
+// the request line, headers (sorted by name) and the body. After substitution (e.g. via
+// Client.RenderResolved) it shows exactly what is executed; before, what was authored.
+func (r *Request) String() string {
+	var sb strings.Builder
+	if r.Name != "" {
+		fmt.Fprintf(&sb, "# @name %s\n", r.Name)
+	}
+	if r.NoRedirect {
+		sb.WriteString("# @no-redirect\n")
+	}
+	if r.NoCookieJar {
+		sb.WriteString("# @no-cookie-jar\n")
+	}
+	if r.NoLog {
+		sb.WriteString("# @no-log\n")
+	}
+	if r.NoInferContentType {
+		sb.WriteString("# @no-infer-content-type\n")
+	}
+	if r.Timeout > 0 {
+		fmt.Fprintf(&sb, "# @timeout %d\n", r.Timeout.Milliseconds())
+	}
+	if r.AuthProvider != "" {
+		fmt.Fprintf(&sb, "# @auth %s\n", strings.Join(append([]string{r.AuthProvider}, r.AuthArgs...), " "))
+	}
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(&sb, "# @tag %s\n", strings.Join(r.Tags, " "))
+	}
+	if r.Proxy != "" {
+		fmt.Fprintf(&sb, "# @proxy %s\n", r.Proxy)
+	}
+	if r.NoProxy {
+		sb.WriteString("# @no-proxy\n")
+	}
+	for _, directive := range conditionalDirectives(r.ConditionalHeaders) {
+		fmt.Fprintf(&sb, "# %s\n", directive)
+	}
+	if r.Pagination != nil {
+		fmt.Fprintf(&sb, "# @paginate %s\n", r.Pagination)
+	}
+	if r.Poll != nil {
+		fmt.Fprintf(&sb, "# @poll %s\n", r.Poll)
+	}
+	if r.Retry != nil {
+		fmt.Fprintf(&sb, "# @retry %s\n", r.Retry)
+	}
+	if r.WaitFor != nil {
+		fmt.Fprintf(&sb, "# @wait-for %s\n", r.WaitFor)
+	}
+	for _, capture := range r.Captures {
+		fmt.Fprintf(&sb, "# @capture %s\n", capture)
+	}
+	if r.SOAP != nil {
+		fmt.Fprintf(&sb, "# @soap %s\n", r.SOAP)
+	}
+	if r.OutputFile != "" {
+		fmt.Fprintf(&sb, "# @output %s\n", r.OutputFile)
+	}
+	if r.SSETimeout > 0 {
+		fmt.Fprintf(&sb, "# @sse-timeout %s\n", r.SSETimeout)
+	}
+	if r.PreRequestScript != nil {
+		fmt.Fprintf(&sb, "< %s\n", r.PreRequestScript)
+	}
+
+	sb.WriteString(r.requestLine())
+	sb.WriteString("\n")
+	writeQueryBlock(&sb, r.QueryParams)
+	writeSortedHeaders(&sb, r.Headers)
+
+	if r.RawBody != "" {
+		sb.WriteString("\n")
+		sb.WriteString(strings.TrimRight(r.RawBody, "\n"))
+		sb.WriteString("\n")
+	}
+	if r.ResponseHandlerScript != nil {
+		fmt.Fprintf(&sb, "\n> %s\n", r.ResponseHandlerScript)
+	}
+	return sb.String()
+}
+
+// requestLine renders "METHOD URL [HTTP-version]", preferring the parsed URL when available
+func (r *Request) requestLine() string {
+	target := r.RawURLString
+	if r.URL != nil {
+		target = r.URL.String()
+	}
+	line := r.Method + " " + target
+	if r.HTTPVersion != "" {
+		line += " " + r.HTTPVersion
+	}
+	return line
+}
+
+// writeSortedHeaders writes one "Name: value" line per header value, ordered by name
+func writeSortedHeaders(sb *strings.Builder, headers http.Header) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range headers[name] {
+			fmt.Fprintf(sb, "%s: %s\n", name, value)
+		}
+	}
+}
+
+// RenderResolved parses a request file and resolves every request exactly as ExecuteFile
+// would (variables, system variables, file references, BaseURL and default headers) without
+// sending anything. The result is in .http syntax, with requests separated by "###", so it can
+// be diffed against the authored file to debug unexpected substitutions.
+// Note that dynamic values such as {{$uuid}} are freshly generated and differ from a real run.
+func (c *Client) RenderResolved(requestFilePath string) (string, error) {
+	requests, err := c.resolveFileRequests(requestFilePath)
+	if err != nil {
+		return "", err
+	}
+	return FormatRequests(requests...), nil
+}
+
+// resolveFileRequests parses a request file and resolves its requests without sending them
+func (c *Client) resolveFileRequests(requestFilePath string) ([]*Request, error) {
+	parsedFile, err := c.parseAndValidateFile(requestFilePath)
+	if err != nil {
+		return nil, err
+	}
+	inherited, err := c.withWorkspaceDefaults(requestFilePath, parsedFile.Requests)
+	if err != nil {
+		return nil, err
+	}
+	return inherited.resolveParsedRequests(requestFilePath, parsedFile)
+}
+
+// resolveParsedRequests resolves the requests of a parsed file without sending them
+func (c *Client) resolveParsedRequests(requestFilePath string, parsedFile *ParsedFile) ([]*Request, error) {
+	c.loadDotEnvVars(requestFilePath)
+	c.resolveFileScopedSystemVariables(parsedFile)
+	osEnvGetter := c.lookupVariable
+
+	requests := make([]*Request, 0, len(parsedFile.Requests))
+	for i, req := range parsedFile.Requests {
+		resolved, err := c.resolveRequestForRender(req, parsedFile, osEnvGetter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve request %d (%s): %w", i+1, req.Name, err)
+		}
+		requests = append(requests, resolved)
+	}
+	return requests, nil
+}
+
+// resolveRequestForRender substitutes variables in req and returns a copy with the URL resolved
+// against the client's BaseURL and rewritten (see WithURLRewrite), and default headers merged in,
+// mirroring executeRequest.
+func (c *Client) resolveRequestForRender(
+	req *Request,
+	parsedFile *ParsedFile,
+	osEnvGetter func(string) (string, bool),
+) (*Request, error) {
+	requestScopedSystemVars := c.generateRequestScopedSystemVariables()
+	if err := c.substituteRequestURLAndHeaders(req, parsedFile, requestScopedSystemVars, osEnvGetter); err != nil {
+		return nil, fmt.Errorf("variable substitution failed: %w", err)
+	}
+	if err := c.substituteRequestBody(req, parsedFile, requestScopedSystemVars, osEnvGetter); err != nil {
+		return nil, fmt.Errorf("error processing body: %w", err)
+	}
+
+	resolved := *req
+	finalURL, err := c._resolveRequestURL(c.BaseURL, req.URL, req.RawURLString)
+	if err == nil {
+		finalURL, err = c.rewriteURL(finalURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resolved.URL = finalURL
+
+	resolved.Headers = c.DefaultHeaders.Clone()
+	if resolved.Headers == nil {
+		resolved.Headers = make(http.Header)
+	}
+	for key, values := range req.Headers {
+		resolved.Headers[http.CanonicalHeaderKey(key)] = values
+	}
+	return &resolved, nil
+}
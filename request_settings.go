@@ -0,0 +1,73 @@
+package restclient
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// clientSetting is a request setting that changes the http.Client sending the request. ExecuteFile
+// applies it to its per-request client and GenerateGo writes its code, so generated code and the
+// library behave alike.
+type clientSetting struct {
+	apply func(client *http.Client)
+	code  string // Go statements applying the setting to the *http.Client copy named custom
+}
+
+// requestClientSettings returns the settings of the @no-redirect, @no-cookie-jar and @timeout directives
+// of a request
+func requestClientSettings(req *Request) []clientSetting {
+	var settings []clientSetting
+	if req.NoRedirect {
+		settings = append(settings, clientSetting{
+			apply: func(client *http.Client) { client.CheckRedirect = stopRedirects },
+			code: "\tcustom.CheckRedirect = func(*http.Request, []*http.Request) error {\n" +
+				"\t\treturn http.ErrUseLastResponse\n\t}\n",
+		})
+	}
+	if req.NoCookieJar {
+		settings = append(settings, clientSetting{
+			apply: func(client *http.Client) { client.Jar = nil },
+			code:  "\tcustom.Jar = nil\n",
+		})
+	}
+	if req.Timeout > 0 {
+		timeout := req.Timeout
+		settings = append(settings, clientSetting{
+			// per attempt, including reading the body
+			apply: func(client *http.Client) { client.Timeout = timeout },
+			code:  fmt.Sprintf("\tcustom.Timeout = %d * time.Millisecond\n", timeout.Milliseconds()),
+		})
+	}
+	return settings
+}
+
+// handleTimeoutDirective processes @timeout directives
+func (p *requestParserState) handleTimeoutDirective(commentContent string) bool {
+	if strings.HasPrefix(commentContent, "@timeout ") {
+		p.processTimeoutDirective(commentContent)
+		return true
+	}
+	return false
+}
+
+// processTimeoutDirective handles the @timeout directive with milliseconds or a duration value
+func (p *requestParserState) processTimeoutDirective(commentContent string) {
+	p.ensureCurrentRequest()
+	timeoutStr := strings.TrimSpace(commentContent[len("@timeout "):])
+	if timeoutStr == "" {
+		return
+	}
+
+	timeout, err := parseTimeout(timeoutStr)
+	if err != nil {
+		slog.Warn("Invalid timeout value in @timeout directive",
+			"value", timeoutStr,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return
+	}
+
+	p.currentRequest.Timeout = timeout
+}
@@ -14,12 +14,64 @@ type Response struct {
 	Headers        http.Header
 	Body           []byte        // Raw response body
 	BodyString     string        // Response body as a string (convenience)
+	StartTime      time.Time     // When the request was sent
 	Duration       time.Duration // Time taken for the request-response cycle
+	Timings        Timings       // Duration broken down into DNS lookup, connect, TLS handshake and first byte
 	Size           int64         // Response size in bytes (Content-Length or actual)
+	BytesSent      int64         // Request size: request line, headers and body (HTTP/1.1 framing)
+	BytesReceived  int64         // Response size: status line, headers and body as read
 	IsTLS          bool          // True if the connection was over TLS
 	TLSVersion     string        // e.g., "TLS 1.3" (if IsTLS is true)
 	TLSCipherSuite string        // e.g., "TLS_AES_128_GCM_SHA256" (if IsTLS is true)
+	TLS            *TLSInfo      // Negotiated TLS details and peer certificate chain (nil if not TLS)
 	Error          error         // Error encountered during request execution or response processing
+	FinalURL       string        // URL of the request that produced this response, after any redirects
+	Redirects      []RedirectHop // Redirect responses followed before this one, in order
+	CacheStatus    CacheStatus   // HIT, MISS or REVALIDATED for GET requests of a client with WithHTTPCache
+	Pages          []*Response   // With @paginate: every page fetched, in order, starting with the first
+	Attempts       int           // With @poll or @retry: number of times the request was sent
+
+	// Failures are the RequestErrors reported for this request by ExecuteFile, in the order they
+	// occurred: substitution and send failures, which also set Error, failed scripts and assertions.
+	Failures []*RequestError
+
+	// Trailers are the trailer headers sent after the body; for streamed bodies they are set once the
+	// body has been read to the end. Informational lists the 1xx responses received before this one,
+	// e.g. 100 Continue or 103 Early Hints, including those of followed redirects.
+	Trailers      http.Header
+	Informational []InformationalResponse
+
+	// OutputFile is the path the body was written to for a request with an @output directive, whose
+	// Body stays empty
+	OutputFile string
+
+	// RawRequestDump and RawResponseDump hold the request and response as serialized on the
+	// wire (final round trip only), populated when the client is created with WithWireCapture.
+	RawRequestDump  []byte
+	RawResponseDump []byte
+
+	// stream holds the unread body of a response from a client created with WithStreamedBodies
+	stream *streamedBody
+}
+
+// RequestName returns the name of the request that led to the response, from its "# @name" directive
+// or "### name" separator, or "" for unnamed requests.
+func (r *Response) RequestName() string {
+	if r == nil || r.Request == nil {
+		return ""
+	}
+	return r.Request.Name
+}
+
+// FindResponse returns the first of responses whose request is named name, or nil, so that callers
+// can pick responses of ExecuteFile by name instead of by position.
+func FindResponse(responses []*Response, name string) *Response {
+	for _, resp := range responses {
+		if resp != nil && resp.RequestName() == name {
+			return resp
+		}
+	}
+	return nil
 }
 
 // ExpectedResponse defines what an actual response should be compared against.
@@ -30,4 +82,30 @@ type ExpectedResponse struct {
 	Status     *string
 	Headers    http.Header // For header presence/value checks
 	Body       *string     // Expected body content (exact match or regex)
+	BodyFile   string      // "< path": file the body was read from, relative to the .hresp file
+
+	// StatusCodes are the acceptable codes of a status line with alternatives or status classes, e.g.
+	// "HTTP/1.1 200|201|204" or "HTTP/1.1 2xx", for which StatusCode is nil and Status holds "200|201|204"
+	StatusCodes []string
+
+	// Assertions from .hresp directives (nil when not specified)
+	FinalURL      *string           // "# final-url": full URL, or a path (starting with '/') compared to path and query
+	RedirectCount *int              // "# redirects": number of redirect hops followed
+	MaxBytes      *int64            // "# max-bytes" or "# max-size": upper bound for the response body size in bytes
+	MaxDuration   *time.Duration    // "# max-duration": upper bound for Response.Duration, e.g. 500ms
+	Validators    []ValidatorCall   // "# validate": registered response validators to apply, in order
+	CacheStatus   *CacheStatus      // "# cache-status": HIT, MISS or REVALIDATED (see WithHTTPCache)
+	NDJSONLines   *int              // "# ndjson-lines": number of records of an NDJSON body
+	XMLNamespaces map[string]string // "# xmlns": namespace URIs by prefix, for XMLAssertions
+	XMLAssertions []XMLAssertion    // "?? xml": XPath assertions on an XML body
+	Trailers      http.Header       // "# trailer": trailer values that must be present, like Headers
+	Informational []int             // "# informational": status codes of 1xx responses that must be received
+	MatchMode     MatchMode         // "# match-mode": exact (default) or subset comparison of JSON bodies
+	Unordered     []string          // "# unordered": JSON paths of arrays whose elements may come in any order
+}
+
+// ValidatorCall is a "# validate <name> [args...]" directive of an .hresp expectation.
+type ValidatorCall struct {
+	Name string
+	Args []string
 }
@@ -0,0 +1,93 @@
+package restclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// errBodyAlreadyStreamed is returned when a streamed body is needed after BodyReader consumed it
+var errBodyAlreadyStreamed = errors.New("response body was already consumed via BodyReader")
+
+// streamedBody is an unread response body kept open for Response.BodyReader. Bytes are added to
+// Response.BytesReceived as they are read.
+type streamedBody struct {
+	body     io.ReadCloser
+	resp     *Response
+	httpResp *http.Response // source of the trailers, known once the body was read to the end
+	// taken is set once the stream was handed out or buffered; it cannot be read twice
+	taken bool
+}
+
+// Read implements io.Reader
+func (s *streamedBody) Read(p []byte) (int, error) {
+	n, err := s.body.Read(p)
+	s.resp.BytesReceived += int64(n)
+	if err == io.EOF {
+		s.resp.Trailers = receivedTrailers(s.httpResp)
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (s *streamedBody) Close() error {
+	return s.body.Close()
+}
+
+// streamResponseBody keeps the body of httpResp open on resp instead of reading it,
+// used when the client is created with WithStreamedBodies.
+func streamResponseBody(resp *Response, httpResp *http.Response) {
+	resp.stream = &streamedBody{body: httpResp.Body, resp: resp, httpResp: httpResp}
+}
+
+// BodyReader returns the response body as a stream. For responses of a client created with
+// WithStreamedBodies the body is read from the connection on demand; it can be consumed only
+// once and the caller must close it. Otherwise it reads from the buffered Body.
+func (r *Response) BodyReader() (io.ReadCloser, error) {
+	if r.stream == nil {
+		return io.NopCloser(bytes.NewReader(r.Body)), nil
+	}
+	if r.stream.taken {
+		return nil, errBodyAlreadyStreamed
+	}
+	r.stream.taken = true
+	return r.stream, nil
+}
+
+// BufferBody reads a streamed body into Body and BodyString and closes the stream. It is a no-op
+// for buffered responses or when the body was already buffered, and is called automatically
+// by ValidateResponses, SaveBody artifacts and the JSON helpers.
+func (r *Response) BufferBody() error {
+	if r.stream == nil {
+		return nil
+	}
+	if r.stream.taken {
+		return errBodyAlreadyStreamed
+	}
+	stream := r.stream
+	stream.taken = true
+	defer func() { _ = stream.Close() }()
+
+	bodyBytes, err := io.ReadAll(stream)
+	r.stream = nil
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	r.Body = bodyBytes
+	r.BodyString = string(bodyBytes)
+	if r.Size <= 0 {
+		r.Size = int64(len(bodyBytes))
+	}
+	return nil
+}
+
+// Close releases an unread streamed body. It is safe to call on any response, more than once.
+func (r *Response) Close() error {
+	if r.stream == nil || r.stream.taken {
+		return nil
+	}
+	r.stream.taken = true
+	return r.stream.Close()
+}
@@ -0,0 +1,61 @@
+package restclient
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Header returns the first value of the named response header, or "" if it is absent.
+// The name is case-insensitive.
+func (r *Response) Header(name string) string {
+	return r.Headers.Get(name)
+}
+
+// Cookies parses the Set-Cookie headers of the response.
+func (r *Response) Cookies() []*http.Cookie {
+	return (&http.Response{Header: r.Headers}).Cookies()
+}
+
+// Cookie returns the named cookie set by the response, or nil if it was not set.
+func (r *Response) Cookie(name string) *http.Cookie {
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// ContentType returns the lowercased media type of the response, without parameters
+// (e.g. "application/json" for "application/json; charset=utf-8").
+// It returns "" if the header is absent.
+func (r *Response) ContentType() string {
+	mediaType, _ := r.parseContentType()
+	return mediaType
+}
+
+// Charset returns the lowercased charset parameter of the Content-Type header, or "" if none is given.
+func (r *Response) Charset() string {
+	_, params := r.parseContentType()
+	return strings.ToLower(params["charset"])
+}
+
+// parseContentType splits the Content-Type header into media type and parameters.
+func (r *Response) parseContentType() (string, map[string]string) {
+	return parseContentType(r.Header("Content-Type"))
+}
+
+// parseContentType splits a Content-Type header value into media type and parameters.
+// Malformed parameters are ignored so that the media type is still reported.
+func parseContentType(header string) (string, map[string]string) {
+	if header == "" {
+		return "", nil
+	}
+	mediaType, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(header, ";")
+		return strings.ToLower(strings.TrimSpace(mediaType)), nil
+	}
+	return mediaType, params
+}
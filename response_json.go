@@ -0,0 +1,37 @@
+package restclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON decodes the response body into target, like json.Unmarshal.
+func (r *Response) JSON(target any) error {
+	if err := r.BufferBody(); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(r.Body, target); err != nil {
+		return fmt.Errorf("failed to decode response body as JSON: %w", err)
+	}
+	return nil
+}
+
+// Map decodes a JSON object response body into a map.
+func (r *Response) Map() (map[string]any, error) {
+	var result map[string]any
+	if err := r.JSON(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// JSONPath decodes the response body as JSON and returns the value at path,
+// e.g. "$.data.id" or "$.items[0].name". Objects are returned as map[string]any,
+// arrays as []any and numbers as float64, as with encoding/json.
+func (r *Response) JSONPath(path string) (any, error) {
+	var data any
+	if err := r.JSON(&data); err != nil {
+		return nil, err
+	}
+	return evaluateJSONPath(data, path)
+}
@@ -0,0 +1,44 @@
+package restclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// countingWriter counts the bytes written to it
+type countingWriter struct {
+	n int64
+}
+
+// Write implements io.Writer
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// requestWireSize estimates the HTTP/1.1 wire size of a request: request line, Host and other
+// headers, and body. Headers added later by the transport (e.g. User-Agent) are not included.
+func requestWireSize(httpReq *http.Request) int64 {
+	counter := &countingWriter{}
+	fmt.Fprintf(counter, "%s %s HTTP/1.1\r\n", httpReq.Method, httpReq.URL.RequestURI())
+	if httpReq.Host != "" {
+		fmt.Fprintf(counter, "Host: %s\r\n", httpReq.Host)
+	}
+	_ = httpReq.Header.Write(counter)
+	_, _ = io.WriteString(counter, "\r\n")
+	if httpReq.ContentLength > 0 {
+		counter.n += httpReq.ContentLength
+	}
+	return counter.n
+}
+
+// responseWireSize computes the size of a response as received: status line, headers and the
+// body bytes read. For transparently decompressed responses the body is counted decompressed.
+func responseWireSize(httpResp *http.Response, bodyLen int) int64 {
+	counter := &countingWriter{}
+	fmt.Fprintf(counter, "%s %s\r\n", httpResp.Proto, httpResp.Status)
+	_ = httpResp.Header.Write(counter)
+	_, _ = io.WriteString(counter, "\r\n")
+	return counter.n + int64(bodyLen)
+}
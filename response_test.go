@@ -0,0 +1,24 @@
+package restclient_test
+
+import (
+	"testing"
+
+	"github.com/bmcszk/go-restclient/test"
+)
+
+// Response helper tests
+func TestResponse_JSONHelpers(t *testing.T) {
+	test.RunResponse_JSONHelpers(t)
+}
+
+func TestResponse_HeaderAndCookieAccessors(t *testing.T) {
+	test.RunResponse_HeaderAndCookieAccessors(t)
+}
+
+func TestResponse_StreamedBodyAccess(t *testing.T) {
+	test.RunResponse_StreamedBodyAccess(t)
+}
+
+func TestResponse_RequestName(t *testing.T) {
+	test.RunResponse_RequestName(t)
+}
@@ -0,0 +1,128 @@
+// Package restclienttest provides an in-memory restclient.Executor for unit testing code that
+// orchestrates request files, without starting HTTP servers.
+package restclienttest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+// Executor is a fake restclient.Executor. Responses and errors are configured per file path;
+// every call is recorded. It is safe for concurrent use.
+type Executor struct {
+	mu               sync.Mutex
+	responses        map[string][]*rc.Response
+	executeErrors    map[string]error
+	validationErrors map[string]error
+	executed         []string
+	executedVars     []rc.Vars
+	validated        []ValidateCall
+}
+
+// ValidateCall records a single ValidateResponses invocation.
+type ValidateCall struct {
+	ResponseFilePath string
+	Responses        []*rc.Response
+}
+
+// Executor implements restclient.Executor.
+var _ rc.Executor = (*Executor)(nil)
+
+// NewExecutor creates a fake with no configured responses.
+func NewExecutor() *Executor {
+	return &Executor{
+		responses:        make(map[string][]*rc.Response),
+		executeErrors:    make(map[string]error),
+		validationErrors: make(map[string]error),
+	}
+}
+
+// SetResponses configures the responses returned by ExecuteFile for requestFilePath.
+func (e *Executor) SetResponses(requestFilePath string, responses ...*rc.Response) *Executor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.responses[requestFilePath] = responses
+	return e
+}
+
+// SetExecuteError configures the error returned by ExecuteFile for requestFilePath,
+// alongside any configured responses (as Client returns partial results with a multierror).
+func (e *Executor) SetExecuteError(requestFilePath string, err error) *Executor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executeErrors[requestFilePath] = err
+	return e
+}
+
+// SetValidationError configures the error returned by ValidateResponses for responseFilePath.
+// Validations of files without a configured error pass.
+func (e *Executor) SetValidationError(responseFilePath string, err error) *Executor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.validationErrors[responseFilePath] = err
+	return e
+}
+
+// ExecuteFile returns the responses and error configured for requestFilePath. Files with
+// neither configured fail, so missing test setup is not mistaken for an empty run.
+func (e *Executor) ExecuteFile(ctx context.Context, requestFilePath string, vars ...rc.Vars) ([]*rc.Response, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.executed = append(e.executed, requestFilePath)
+	var merged rc.Vars
+	for _, v := range vars {
+		for name, value := range v {
+			if merged == nil {
+				merged = make(rc.Vars)
+			}
+			merged[name] = value
+		}
+	}
+	e.executedVars = append(e.executedVars, merged)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	responses, hasResponses := e.responses[requestFilePath]
+	err, hasErr := e.executeErrors[requestFilePath]
+	if !hasResponses && !hasErr {
+		return nil, fmt.Errorf("restclienttest: no responses configured for %s", requestFilePath)
+	}
+	return append([]*rc.Response(nil), responses...), err
+}
+
+// ValidateResponses records the call and returns the error configured for responseFilePath.
+func (e *Executor) ValidateResponses(responseFilePath string, actualResponses ...*rc.Response) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.validated = append(e.validated, ValidateCall{
+		ResponseFilePath: responseFilePath,
+		Responses:        append([]*rc.Response(nil), actualResponses...),
+	})
+	return e.validationErrors[responseFilePath]
+}
+
+// ExecutedFiles returns the request file paths passed to ExecuteFile, in call order.
+func (e *Executor) ExecutedFiles() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.executed...)
+}
+
+// ExecutedVars returns the variables passed to each ExecuteFile call, merged like Client does, in
+// call order; nil for calls without variables.
+func (e *Executor) ExecutedVars() []rc.Vars {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]rc.Vars(nil), e.executedVars...)
+}
+
+// ValidateCalls returns the recorded ValidateResponses calls, in call order.
+func (e *Executor) ValidateCalls() []ValidateCall {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]ValidateCall(nil), e.validated...)
+}
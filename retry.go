@@ -0,0 +1,163 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultRetryDelay is the delay between attempts of the @retry directive without delay=
+const defaultRetryDelay = time.Second
+
+// RetryPolicy configures a request with a "# @retry" directive, which is sent again when it fails
+// transiently: it could not be sent, or the server answered 429 Too Many Requests or 5xx.
+type RetryPolicy struct {
+	Retries int           // how many times a failed attempt is sent again
+	Delay   time.Duration // delay=<duration>: delay between attempts
+}
+
+// parseRetryDirective parses the arguments of `@retry 3 delay=500ms`
+func parseRetryDirective(args string) (*RetryPolicy, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("missing number of retries")
+	}
+	retries, err := strconv.Atoi(fields[0])
+	if err != nil || retries < 0 {
+		return nil, fmt.Errorf("number of retries must be a non-negative integer, got '%s'", fields[0])
+	}
+	policy := &RetryPolicy{Retries: retries, Delay: defaultRetryDelay}
+	for _, option := range fields[1:] {
+		value, isDelay := strings.CutPrefix(option, "delay=")
+		if !isDelay {
+			return nil, fmt.Errorf("unknown option '%s'", option)
+		}
+		delay, err := parseRetryDelay(value)
+		if err != nil {
+			return nil, err
+		}
+		policy.Delay = delay
+	}
+	return policy, nil
+}
+
+// parseRetryDelay parses the delay between attempts, e.g. `500ms`
+func parseRetryDelay(value string) (time.Duration, error) {
+	delay, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("delay must be a duration like 500ms, got '%s'", value)
+	}
+	return delay, nil
+}
+
+// withRetryDelay returns a copy of policy with delay, or a policy without retries when policy is nil,
+// so that a later "@retry <n>" keeps the delay
+func withRetryDelay(policy *RetryPolicy, delay time.Duration) *RetryPolicy {
+	if policy == nil {
+		return &RetryPolicy{Delay: delay}
+	}
+	updated := *policy
+	updated.Delay = delay
+	return &updated
+}
+
+// parseTimeout parses the value of a @timeout directive: a number of milliseconds, e.g. `5000`, or a
+// duration, e.g. `5s`
+func parseTimeout(value string) (time.Duration, error) {
+	if timeoutMs, err := strconv.Atoi(value); err == nil && timeoutMs > 0 {
+		return time.Duration(timeoutMs) * time.Millisecond, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be a positive number of milliseconds or a duration like 5s, got '%s'",
+			value)
+	}
+	return timeout, nil
+}
+
+// String renders the directive arguments, e.g. `3 delay=500ms`
+func (r *RetryPolicy) String() string {
+	return fmt.Sprintf("%d delay=%s", r.Retries, r.Delay)
+}
+
+// handleRetryDirective processes "@retry <retries> [delay=<d>]" directives
+func (p *requestParserState) handleRetryDirective(commentContent string) bool {
+	if commentContent != "@retry" && !strings.HasPrefix(commentContent, "@retry ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	args := strings.TrimPrefix(commentContent, "@retry")
+	policy, err := parseRetryDirective(args)
+	if err != nil {
+		slog.Warn("Invalid @retry directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	if previous := p.currentRequest.Retry; previous != nil && !strings.Contains(args, "delay=") {
+		policy.Delay = previous.Delay // set by a preceding @retry-delay
+	}
+	p.currentRequest.Retry = policy
+	return true
+}
+
+// handleRetryDelayDirective processes "@retry-delay <duration>" directives, an alternative to the
+// delay= option of @retry
+func (p *requestParserState) handleRetryDelayDirective(commentContent string) bool {
+	if commentContent != "@retry-delay" && !strings.HasPrefix(commentContent, "@retry-delay ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	delay, err := parseRetryDelay(strings.TrimPrefix(commentContent, "@retry-delay"))
+	if err != nil {
+		slog.Warn("Invalid @retry-delay directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.Retry = withRetryDelay(p.currentRequest.Retry, delay)
+	return true
+}
+
+// retryFailed sends the request again while the last attempt failed transiently, at most
+// RetryPolicy.Retries times, and returns the last attempt. Response.Attempts counts the attempts.
+func (c *Client) retryFailed(ctx context.Context, rcRequest *Request, resp *Response, err error) (*Response, error) {
+	policy := rcRequest.Retry
+	attempt := 1
+	for ; attempt <= policy.Retries && retryable(resp, err); attempt++ {
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(policy.Delay):
+		}
+		if resp != nil {
+			_ = resp.Close()
+		}
+		if rcRequest.GetBody != nil {
+			body, bodyErr := rcRequest.GetBody()
+			if bodyErr != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", bodyErr)
+			}
+			rcRequest.Body = body
+		}
+		resp, err = c.executeRequest(ctx, rcRequest)
+	}
+	if resp != nil {
+		resp.Attempts = attempt
+	}
+	return resp, err
+}
+
+// retryable reports whether an attempt failed transiently
+func retryable(resp *Response, err error) bool {
+	if err != nil || resp == nil || resp.Error != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,312 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Vars are programmatic variables for a single ExecuteFile call, e.g. the values of one iteration of
+// a test loop. Unlike variables set on a shared client, they cannot leak into concurrent calls.
+type Vars map[string]any
+
+// mergeVars combines per-call variables, later values overriding earlier ones; nil without any
+func mergeVars(vars []Vars) map[string]any {
+	var merged map[string]any
+	for _, v := range vars {
+		for name, value := range v {
+			if merged == nil {
+				merged = make(map[string]any)
+			}
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// RunOptions adjusts a single ExecuteFileWithOptions call without changing the client. Zero values
+// keep the behavior of ExecuteFile.
+type RunOptions struct {
+	Environment string         // environment of the http-client.env.json files, instead of WithEnvironment
+	ExtraVars   map[string]any // programmatic variables added to, and taking precedence over, WithVars
+	Tags        []string       // only requests with at least one of these tags (from @tag directives)
+	Names       []string       // only requests with one of these names (from @name directives)
+	Parallelism int            // how many requests are sent at once; 1 sends them one by one, 0 see WithConcurrency
+	FailFast    bool           // stop at the first failed request, see below
+}
+
+// ExecuteFileWithOptions is ExecuteFile with per-call options, so callers can run the same client
+// against several environments or subsets of a file without building a client for each combination.
+//
+// When Tags and Names are both set, a request must match both. With a Parallelism above 1, the
+// selected requests are sent concurrently and must not depend on each other: conditional directives
+// such as @if-match do not see the responses of the same run. Responses keep the order of the file.
+// With FailFast, no request is sent after one failed, e.g. could not be sent or failed an assertion;
+// requests already sent in parallel are still reported. Tags and Names selecting no request fail the
+// run like a file without requests.
+func (c *Client) ExecuteFileWithOptions(ctx context.Context, requestFilePath string,
+	opts RunOptions) ([]*Response, error) {
+	if opts.Parallelism < 0 {
+		return nil, fmt.Errorf("parallelism must not be negative, got %d", opts.Parallelism)
+	}
+	return c.withRunOptions(opts).executeFile(ctx, requestFilePath, opts)
+}
+
+// withRunOptions returns a copy of the client with the environment and variables of opts; the copy
+// shares the HTTP client, caches and token store of c
+func (c *Client) withRunOptions(opts RunOptions) *Client {
+	if opts.Environment == "" && len(opts.ExtraVars) == 0 {
+		return c
+	}
+	run := *c
+	if opts.Environment != "" {
+		run.selectedEnvironmentName = opts.Environment
+	}
+	if len(opts.ExtraVars) > 0 {
+		run.programmaticVars = make(map[string]any, len(c.programmaticVars)+len(opts.ExtraVars))
+		for name, value := range c.programmaticVars {
+			run.programmaticVars[name] = value
+		}
+		for name, value := range opts.ExtraVars {
+			run.programmaticVars[name] = value
+		}
+	}
+	return &run
+}
+
+// WithNameFilter runs only the requests whose @name matches the regular expression pattern, like
+// "go test -run", e.g. "^user_" or "login|logout". It applies to every ExecuteFile and
+// ExecuteFileWithOptions call, in addition to RunOptions.Names and Tags; unnamed requests only run when
+// the pattern matches the empty string. A file without any matching request fails the run.
+func WithNameFilter(pattern string) ClientOption {
+	return func(c *Client) error {
+		filter, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid name filter %q: %w", pattern, err)
+		}
+		c.nameFilter = filter
+		return nil
+	}
+}
+
+// WithNameGlob runs only the requests whose whole @name matches the glob pattern, in which '*' matches
+// any text and '?' any single character, e.g. "user_*" or "*_list". It is WithNameFilter with the
+// pattern written as a glob, and replaces the filter of an earlier WithNameFilter or WithNameGlob.
+func WithNameGlob(pattern string) ClientOption {
+	return WithNameFilter(globToRegexp(pattern))
+}
+
+// globToRegexp converts a glob pattern of WithNameGlob into an anchored regular expression
+func globToRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// WithIncludeTags runs only the requests with at least one of tags (from "# @tag" directives) in every
+// ExecuteFile and ExecuteFileWithOptions call, so suites can run a subset of shared files, e.g. the smoke
+// tests. It adds to the tags of earlier calls, and applies in addition to RunOptions.Tags.
+func WithIncludeTags(tags ...string) ClientOption {
+	return func(c *Client) error {
+		if len(tags) == 0 {
+			return errors.New("include tags must not be empty")
+		}
+		c.includeTags = append(c.includeTags, tags...)
+		return nil
+	}
+}
+
+// WithExcludeTags skips the requests with any of tags in every ExecuteFile and ExecuteFileWithOptions
+// call, e.g. the slow ones. It adds to the tags of earlier calls, and takes precedence over
+// WithIncludeTags and RunOptions.Tags.
+func WithExcludeTags(tags ...string) ClientOption {
+	return func(c *Client) error {
+		if len(tags) == 0 {
+			return errors.New("exclude tags must not be empty")
+		}
+		c.excludeTags = append(c.excludeTags, tags...)
+		return nil
+	}
+}
+
+// WithConcurrency sends up to n requests of a file at once in every ExecuteFile call and the related
+// methods, like RunOptions.Parallelism, which takes precedence when set. Responses keep the order of the
+// file. Only use it for files whose requests are independent: with n above 1, requests do not see the
+// responses or @capture variables of the same run.
+func WithConcurrency(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("concurrency must be at least 1, got %d", n)
+		}
+		c.concurrency = n
+		return nil
+	}
+}
+
+// matches reports whether a request is selected by the Tags and Names of the options
+func (o RunOptions) matches(req *Request) bool {
+	if len(o.Names) > 0 && !containsString(o.Names, req.Name) {
+		return false
+	}
+	if len(o.Tags) == 0 {
+		return true
+	}
+	return hasAnyTag(req.Tags, o.Tags)
+}
+
+// handleTagDirective processes "@tag <tag> [tags...]" directives; repeated directives add tags
+func (p *requestParserState) handleTagDirective(commentContent string) bool {
+	if !strings.HasPrefix(commentContent, "@tag ") {
+		return false
+	}
+	p.currentRequest.Tags = append(p.currentRequest.Tags, strings.Fields(commentContent[len("@tag "):])...)
+	return true
+}
+
+// hasAnyTag reports whether tags holds one of the selected tags
+func hasAnyTag(tags, selected []string) bool {
+	for _, tag := range tags {
+		if containsString(selected, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sentRequest is the outcome of sending the request at index of a file
+type sentRequest struct {
+	index    int
+	response *Response
+	err      error
+}
+
+// requestFilter is the selection of requests of the client options, in addition to RunOptions
+type requestFilter struct {
+	name        *regexp.Regexp // see WithNameFilter
+	includeTags []string       // see WithIncludeTags
+	excludeTags []string       // see WithExcludeTags
+}
+
+// requestFilter returns the selection of requests of the client options
+func (c *Client) requestFilter() requestFilter {
+	return requestFilter{name: c.nameFilter, includeTags: c.includeTags, excludeTags: c.excludeTags}
+}
+
+// matches reports whether a request is selected by the filter
+func (f requestFilter) matches(req *Request) bool {
+	if f.name != nil && !f.name.MatchString(req.Name) {
+		return false
+	}
+	if len(f.includeTags) > 0 && !hasAnyTag(req.Tags, f.includeTags) {
+		return false
+	}
+	return !hasAnyTag(req.Tags, f.excludeTags)
+}
+
+// String describes the filter in the error of a run selecting no request, "" without any selection
+func (f requestFilter) String() string {
+	var parts []string
+	if f.name != nil {
+		parts = append(parts, fmt.Sprintf("name filter %q", f.name))
+	}
+	if len(f.includeTags) > 0 {
+		parts = append(parts, fmt.Sprintf("include tags [%s]", strings.Join(f.includeTags, ", ")))
+	}
+	if len(f.excludeTags) > 0 {
+		parts = append(parts, fmt.Sprintf("exclude tags [%s]", strings.Join(f.excludeTags, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// selectRequests returns the indexes of the requests selected by the Tags and Names of the options
+// and the client's filter (see WithNameFilter, WithIncludeTags and WithExcludeTags)
+func (o RunOptions) selectRequests(requests []*Request, filter requestFilter) ([]int, error) {
+	var selected []int
+	for i, req := range requests {
+		if o.matches(req) && filter.matches(req) {
+			selected = append(selected, i)
+		}
+	}
+	if len(selected) == 0 {
+		if description := filter.String(); description != "" {
+			return nil, fmt.Errorf("no request matches tags [%s], names [%s] and %s",
+				strings.Join(o.Tags, ", "), strings.Join(o.Names, ", "), description)
+		}
+		return nil, fmt.Errorf("no request matches tags [%s] and names [%s]",
+			strings.Join(o.Tags, ", "), strings.Join(o.Names, ", "))
+	}
+	return selected, nil
+}
+
+// runSelectedRequests sends the selected requests with send and passes the outcomes to handle in file
+// order; handle reports whether the request failed, which stops the run with FailFast. With a
+// Parallelism above 1, the outcomes are handled once all requests have been sent, and FailFast only
+// stops sending further requests.
+func runSelectedRequests(requests []*Request, selected []int, opts RunOptions,
+	send func(index int, req *Request) (*Response, error), handle func(sentRequest) bool) {
+	if opts.Parallelism <= 1 {
+		for _, i := range selected {
+			response, err := send(i, requests[i])
+			if handle(sentRequest{index: i, response: response, err: err}) && opts.FailFast {
+				return
+			}
+		}
+		return
+	}
+
+	outcomes := make([]*sentRequest, len(selected))
+	slots := make(chan struct{}, opts.Parallelism)
+	var wg sync.WaitGroup
+	var failed atomic.Bool
+	for k, i := range selected {
+		slots <- struct{}{}
+		if opts.FailFast && failed.Load() {
+			<-slots
+			break
+		}
+		wg.Add(1)
+		go func(k, i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			response, err := send(i, requests[i])
+			if err != nil || (response != nil && response.Error != nil) {
+				failed.Store(true)
+			}
+			outcomes[k] = &sentRequest{index: i, response: response, err: err}
+		}(k, i)
+	}
+	wg.Wait()
+	for _, outcome := range outcomes {
+		if outcome == nil {
+			return // not sent after a failure with FailFast
+		}
+		handle(*outcome)
+	}
+}
@@ -0,0 +1,168 @@
+package restclient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Script block delimiters of the JetBrains HTTP Client syntax
+const (
+	scriptOpen  = "{%"
+	scriptClose = "%}"
+)
+
+// ScriptHandler runs the script blocks of requests, e.g. by delegating to a JavaScript engine or by
+// interpreting the few calls a suite relies on. It is called with the source of a pre-request script
+// (`< {% ... %}` above the request line) and a nil resp before the request is substituted and sent, and
+// with the source of a response handler (`> {% ... %}` below the request) and the response once it was
+// received. External scripts (`< ./pre.js`, `> ./handler.js`) are read relative to the request file.
+//
+// A pre-request handler may provide variables for the request with req.SetVariable. An error fails the
+// request with a RequestError of phase PhaseScript.
+type ScriptHandler func(script string, req *Request, resp *Response) error
+
+// WithScriptHandler runs the script blocks of requests executed with ExecuteFile and related methods
+// with handler. Without a handler, script blocks are parsed and ignored.
+func WithScriptHandler(handler ScriptHandler) ClientOption {
+	return func(c *Client) error {
+		if handler == nil {
+			return errors.New("script handler must not be nil")
+		}
+		c.scriptHandler = handler
+		return nil
+	}
+}
+
+// SetVariable defines a variable for the substitution of the request, like `request.variables.set` of
+// a pre-request script. It takes precedence over the file variables, but not over programmatic ones.
+func (r *Request) SetVariable(name, value string) {
+	if r.ActiveVariables == nil {
+		r.ActiveVariables = make(map[string]string)
+	}
+	r.ActiveVariables["@"+name] = value
+}
+
+// String renders the script as it follows "<" or ">" in .http syntax: the path of an external script,
+// or the inline source between "{%" and "%}"
+func (s *Script) String() string {
+	if s.IsExternal {
+		return s.Path
+	}
+	return scriptOpen + "\n" + strings.Trim(s.Content, "\n") + "\n" + scriptClose
+}
+
+// source returns the content of the script, reading external scripts relative to the request file
+func (s *Script) source(requestFilePath string) (string, error) {
+	if !s.IsExternal {
+		return s.Content, nil
+	}
+	path := s.Path
+	if !filepath.IsAbs(path) && requestFilePath != "" {
+		path = filepath.Join(filepath.Dir(requestFilePath), path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read script file %s: %w", s.Path, err)
+	}
+	return string(content), nil
+}
+
+// scriptBlock is an inline script being parsed, until the line closing it with "%}"
+type scriptBlock struct {
+	preRequest bool
+	lines      []string
+}
+
+// handleScriptLine parses the lines of script blocks: `< {% ... %}` before the request line,
+// `> {% ... %}` after it, possibly spanning several lines, and references to external scripts
+// (`< ./pre.js`, `> ./handler.js`). Lines inside a block are not interpreted as .http syntax.
+func (p *requestParserState) handleScriptLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if p.openScript != nil {
+		content, closed := strings.CutSuffix(trimmed, scriptClose)
+		if !closed {
+			p.openScript.lines = append(p.openScript.lines, line)
+			return true
+		}
+		if content != "" {
+			p.openScript.lines = append(p.openScript.lines, content)
+		}
+		p.setScript(p.openScript.preRequest, &Script{Content: strings.Join(p.openScript.lines, "\n")})
+		p.openScript = nil
+		return true
+	}
+
+	preRequest, rest, ok := p.scriptPrefix(trimmed)
+	if !ok {
+		return false
+	}
+	if !strings.HasPrefix(rest, scriptOpen) {
+		if preRequest || strings.HasSuffix(rest, ".js") {
+			p.setScript(preRequest, &Script{Path: rest, IsExternal: true})
+			return true
+		}
+		return false
+	}
+	source := strings.TrimSpace(strings.TrimPrefix(rest, scriptOpen))
+	if content, closed := strings.CutSuffix(source, scriptClose); closed {
+		p.setScript(preRequest, &Script{Content: strings.TrimSpace(content)})
+		return true
+	}
+	p.openScript = &scriptBlock{preRequest: preRequest}
+	if source != "" {
+		p.openScript.lines = append(p.openScript.lines, source)
+	}
+	return true
+}
+
+// scriptPrefix reports whether trimmed starts a script: "<" before the request line and ">" after it,
+// returning the rest of the line
+func (p *requestParserState) scriptPrefix(trimmed string) (preRequest bool, rest string, ok bool) {
+	hasRequestLine := p.currentRequest != nil && p.currentRequest.Method != ""
+	switch {
+	case strings.HasPrefix(trimmed, "<") && !hasRequestLine:
+		rest = strings.TrimSpace(strings.TrimPrefix(trimmed, "<"))
+		return true, rest, rest != "" && !strings.HasPrefix(rest, "@")
+	case strings.HasPrefix(trimmed, ">") && !strings.HasPrefix(trimmed, ">>") && hasRequestLine:
+		rest = strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+		return false, rest, rest != ""
+	default:
+		return false, "", false
+	}
+}
+
+// setScript attaches a parsed script to the current request
+func (p *requestParserState) setScript(preRequest bool, script *Script) {
+	p.ensureCurrentRequest()
+	if preRequest {
+		p.currentRequest.PreRequestScript = script
+		return
+	}
+	p.currentRequest.ResponseHandlerScript = script
+}
+
+// runScript calls the script handler with script, if both are set
+func (c *Client) runScript(script *Script, req *Request, resp *Response) error {
+	if c.scriptHandler == nil || script == nil {
+		return nil
+	}
+	source, err := script.source(req.FilePath)
+	if err != nil {
+		return err
+	}
+	return c.scriptHandler(source, req, resp)
+}
+
+// runResponseHandler runs the response handler script of the request of a received response
+func (c *Client) runResponseHandler(index int, response *Response) error {
+	if response == nil || response.Error != nil || response.Request == nil {
+		return nil
+	}
+	if err := c.runScript(response.Request.ResponseHandlerScript, response.Request, response); err != nil {
+		return newRequestError(response.Request, index, PhaseScript, err)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package restclient
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// SOAP versions of the @soap directive and their envelope namespaces
+const (
+	SOAP11 = "1.1"
+	SOAP12 = "1.2"
+
+	soap11Namespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	soap12Namespace = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+// SOAP configures a request with a "# @soap [1.1|1.2] [action]" directive: the body is wrapped in a
+// SOAP envelope of that version, the content type and SOAP action headers are set, and the expected
+// body in .hresp files is compared with the content of the response's SOAP Body.
+type SOAP struct {
+	Version string // SOAP11 or SOAP12
+	Action  string // the SOAP action, e.g. "urn:example:GetOrder"; may contain variables
+}
+
+// handleSOAPDirective processes "@soap [1.1|1.2] [action]" directives
+func (p *requestParserState) handleSOAPDirective(commentContent string) bool {
+	if commentContent != "@soap" && !strings.HasPrefix(commentContent, "@soap ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	soap, err := parseSOAPDirective(strings.TrimPrefix(commentContent, "@soap"))
+	if err != nil {
+		slog.Warn("Invalid @soap directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.SOAP = soap
+	return true
+}
+
+// parseSOAPDirective parses the arguments of "@soap 1.2 urn:example:GetOrder"; the version defaults
+// to 1.1 and the action may be omitted
+func parseSOAPDirective(args string) (*SOAP, error) {
+	fields := strings.Fields(args)
+	soap := &SOAP{Version: SOAP11}
+	if len(fields) > 0 && (fields[0] == SOAP11 || fields[0] == SOAP12) {
+		soap.Version, fields = fields[0], fields[1:]
+	}
+	if len(fields) > 1 {
+		return nil, fmt.Errorf("expected [1.1|1.2] [action], got '%s'", strings.TrimSpace(args))
+	}
+	if len(fields) == 1 {
+		soap.Action = fields[0]
+	}
+	return soap, nil
+}
+
+// String renders the directive arguments, e.g. "1.2 urn:example:GetOrder"
+func (s *SOAP) String() string {
+	return strings.TrimSpace(s.Version + " " + s.Action)
+}
+
+// namespace returns the envelope namespace of the SOAP version
+func (s *SOAP) namespace() string {
+	if s.Version == SOAP12 {
+		return soap12Namespace
+	}
+	return soap11Namespace
+}
+
+// wrap puts body into a SOAP envelope; a body that already is an envelope is returned as is
+func (s *SOAP) wrap(body string) string {
+	if soapEnvelopeNamespace(body) != "" {
+		return body
+	}
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	fmt.Fprintf(&sb, `<soap:Envelope xmlns:soap="%s">`+"\n", s.namespace())
+	sb.WriteString("  <soap:Body>\n")
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if strings.TrimSpace(line) != "" {
+			sb.WriteString("    " + strings.TrimRight(line, "\r") + "\n")
+		}
+	}
+	sb.WriteString("  </soap:Body>\n")
+	sb.WriteString("</soap:Envelope>\n")
+	return sb.String()
+}
+
+// applyHeaders sets the content type and the SOAP action for the version, keeping authored headers:
+// SOAP 1.1 uses text/xml with a SOAPAction header, SOAP 1.2 application/soap+xml with an action
+// parameter, which is also added to an authored content type without one
+func (s *SOAP) applyHeaders(req *Request) {
+	if req.Headers == nil {
+		req.Headers = make(http.Header)
+	}
+	contentType := req.Headers.Get("Content-Type")
+	if s.Version == SOAP12 {
+		if contentType == "" {
+			contentType = "application/soap+xml; charset=utf-8"
+		}
+		if s.Action != "" && !hasActionParameter(contentType) {
+			contentType += fmt.Sprintf("; action=%q", s.Action)
+		}
+		req.Headers.Set("Content-Type", contentType)
+		return
+	}
+	if contentType == "" {
+		req.Headers.Set("Content-Type", "text/xml; charset=utf-8")
+	}
+	if req.Headers.Get("SOAPAction") == "" {
+		req.Headers.Set("SOAPAction", fmt.Sprintf("%q", s.Action))
+	}
+}
+
+// hasActionParameter reports whether a content type has an action parameter
+func hasActionParameter(contentType string) bool {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.Contains(strings.ToLower(contentType), "action=")
+	}
+	_, ok := params["action"]
+	return ok
+}
+
+// SOAPBody returns the content of the SOAP Body element of a SOAP 1.1 or 1.2 response, e.g. the
+// operation's response element or a Fault, with its common indentation removed.
+func (r *Response) SOAPBody() (string, error) {
+	body, err := r.Text()
+	if err != nil {
+		return "", err
+	}
+	return extractSOAPBody(body)
+}
+
+// soapEnvelopeNamespace returns the SOAP namespace of a document whose root is a SOAP Envelope, or ""
+func soapEnvelopeNamespace(document string) string {
+	decoder := newXMLDecoder(document)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local == "Envelope" &&
+				(start.Name.Space == soap11Namespace || start.Name.Space == soap12Namespace) {
+				return start.Name.Space
+			}
+			return ""
+		}
+	}
+}
+
+// extractSOAPBody returns the inner XML of the Body element of a SOAP envelope
+func extractSOAPBody(document string) (string, error) {
+	namespace := soapEnvelopeNamespace(document)
+	if namespace == "" {
+		return "", errors.New("response body is not a SOAP envelope")
+	}
+	decoder := newXMLDecoder(document)
+	depth, start := 0, int64(-1)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", errors.New("SOAP envelope has no Body element")
+			}
+			return "", fmt.Errorf("response body is not well-formed XML: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && start < 0 && t.Name.Space == namespace && t.Name.Local == "Body" {
+				start = decoder.InputOffset()
+			}
+		case xml.EndElement:
+			if depth == 2 && start >= 0 {
+				return dedent(document[start:offset]), nil
+			}
+			depth--
+		}
+	}
+}
+
+// newXMLDecoder returns a decoder for an already decoded document, whatever encoding it declares
+func newXMLDecoder(document string) *xml.Decoder {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	return decoder
+}
+
+// dedent trims blank leading and trailing lines and removes the indentation common to all lines
+func dedent(text string) string {
+	lines := strings.Split(strings.ReplaceAll(strings.Trim(text, "\r\n"), "\r\n", "\n"), "\n")
+	common := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common < 0 || indent < common {
+			common = indent
+		}
+	}
+	for i, line := range lines {
+		if len(line) >= common && common > 0 {
+			lines[i] = line[common:]
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
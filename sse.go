@@ -0,0 +1,162 @@
+package restclient
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ServerSentEvent is an event of a text/event-stream response
+type ServerSentEvent struct {
+	ID    string        // id field, or the last id received before the event
+	Event string        // event field; "" for the default "message" type
+	Data  string        // data fields, joined with newlines
+	Retry time.Duration // retry field: the reconnection time the server asks for; 0 when not sent
+}
+
+// SSEHandler receives the events of text/event-stream responses as they arrive, before the response
+// is complete
+type SSEHandler func(req *Request, event ServerSentEvent)
+
+// WithSSEHandler passes the events of text/event-stream responses to handler as they arrive, e.g. to
+// follow a long-lived stream. Every event is also available on the response with Response.Events.
+func WithSSEHandler(handler SSEHandler) ClientOption {
+	return func(c *Client) error {
+		if handler == nil {
+			return errors.New("SSE handler must not be nil")
+		}
+		c.sseHandler = handler
+		return nil
+	}
+}
+
+// isEventStreamContentType reports whether a Content-Type header denotes Server-Sent Events
+func isEventStreamContentType(header string) bool {
+	mediaType, _ := parseContentType(header)
+	return mediaType == "text/event-stream"
+}
+
+// Events returns the Server-Sent Events of a text/event-stream response, in order.
+func (r *Response) Events() ([]ServerSentEvent, error) {
+	if err := r.BufferBody(); err != nil {
+		return nil, err
+	}
+	var parser sseParser
+	var events []ServerSentEvent
+	lines := strings.Split(r.BodyString, "\n")
+	for _, line := range lines[:len(lines)-1] { // the last one is an incomplete line, if any
+		if event, ok := parser.parseLine(strings.TrimSuffix(line, "\r")); ok {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// handleSSETimeoutDirective processes "@sse-timeout <duration>" directives
+func (p *requestParserState) handleSSETimeoutDirective(commentContent string) bool {
+	if commentContent != "@sse-timeout" && !strings.HasPrefix(commentContent, "@sse-timeout ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	timeout, err := parseTimeout(strings.TrimSpace(strings.TrimPrefix(commentContent, "@sse-timeout")))
+	if err != nil {
+		slog.Warn("Invalid @sse-timeout directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.SSETimeout = timeout
+	return true
+}
+
+// readEventStream reads a text/event-stream body into resp, passing each event to the client's SSE
+// handler as it arrives. With an @sse-timeout, the stream is closed once the timeout elapses and the
+// events received until then make up the body; otherwise it is read until the server closes it.
+func (c *Client) readEventStream(resp *Response, httpResp *http.Response) {
+	defer func() { _ = httpResp.Body.Close() }()
+	var expired atomic.Bool
+	if timeout := resp.Request.SSETimeout; timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			expired.Store(true)
+			_ = httpResp.Body.Close()
+		})
+		defer timer.Stop()
+	}
+
+	var body bytes.Buffer
+	var parser sseParser
+	reader := bufio.NewReader(io.TeeReader(httpResp.Body, &body))
+	var readErr error
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// An event not completed by an empty line when the stream ends is discarded
+			if !errors.Is(err, io.EOF) && !expired.Load() {
+				readErr = err
+			}
+			break
+		}
+		if event, ok := parser.parseLine(strings.TrimRight(line, "\r\n")); ok && c.sseHandler != nil {
+			c.sseHandler(resp.Request, event)
+		}
+	}
+	c._populateResponseDetails(resp, httpResp, body.Bytes(), readErr)
+	resp.BytesReceived = responseWireSize(httpResp, body.Len())
+	resp.Trailers = receivedTrailers(httpResp)
+}
+
+// sseParser assembles events from the lines of an event stream, following the WHATWG HTML
+// "event stream interpretation": fields accumulate until an empty line dispatches the event.
+type sseParser struct {
+	lastID string
+	event  ServerSentEvent
+	data   []string
+}
+
+// parseLine interprets a line without its line break, returning the event an empty line completes
+func (p *sseParser) parseLine(line string) (ServerSentEvent, bool) {
+	if line == "" {
+		return p.dispatch()
+	}
+	if strings.HasPrefix(line, ":") {
+		return ServerSentEvent{}, false // comment, e.g. a keep-alive
+	}
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+	switch field {
+	case "data":
+		p.data = append(p.data, value)
+	case "event":
+		p.event.Event = value
+	case "id":
+		if !strings.Contains(value, "\x00") {
+			p.lastID = value
+		}
+	case "retry":
+		if millis, err := strconv.Atoi(value); err == nil && millis >= 0 {
+			p.event.Retry = time.Duration(millis) * time.Millisecond
+		}
+	}
+	return ServerSentEvent{}, false
+}
+
+// dispatch completes the event of the fields read so far; events without data are not dispatched
+func (p *sseParser) dispatch() (ServerSentEvent, bool) {
+	event := p.event
+	p.event = ServerSentEvent{}
+	if len(p.data) == 0 {
+		return ServerSentEvent{}, false
+	}
+	event.ID = p.lastID
+	event.Data = strings.Join(p.data, "\n")
+	p.data = nil
+	return event, true
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.33 - Client Core Execution: Captured Variables
+// Corresponds to: The "# @capture <name> = <source>" directive, which stores values of a response in
+// variables for the following requests, and the WithCapturedVarsFile client option, which writes them
+// back to http-client.private.env.json or a .env file.
+// This test verifies that captured values replace stale environment values within the run, that they
+// are persisted to both file formats without losing other content, that a later run reuses them, and
+// that a missing value fails the run.
+func RunExecuteFile_CaptureAndPersistVars(t *testing.T) {
+	t.Helper()
+	// Given
+	var authorizations []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Location", "/users/42")
+			_, _ = w.Write([]byte(`{"access_token": "fresh-token", "user": {"id": 42}}`))
+		default:
+			authorizations = append(authorizations, r.URL.Path+" "+r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	privateEnv := filepath.Join(dir, "http-client.private.env.json")
+	require.NoError(t, os.WriteFile(privateEnv,
+		[]byte(`{"dev": {"token": "stale-token", "password": "secret"}, "prod": {"token": "prod-token"}}`), 0644))
+	login := filepath.Join(dir, "login.http")
+	loginContent := fmt.Sprintf(`# @name login
+# @capture token = body.$.access_token
+# @capture userId = body.$.user.id
+# @capture userPath = header.Location
+POST %s/login
+
+###
+GET %s/users/{{userId}}
+Authorization: Bearer {{token}}
+`, server.URL, server.URL)
+	require.NoError(t, os.WriteFile(login, []byte(loginContent), 0644))
+
+	// When
+	client, err := rc.NewClient(rc.WithEnvironment("dev"), rc.WithCapturedVarsFile(privateEnv))
+	require.NoError(t, err)
+	responses, execErr := client.ExecuteFile(context.Background(), login)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"/users/42 Bearer fresh-token"}, authorizations)
+	data, err := os.ReadFile(privateEnv)
+	require.NoError(t, err)
+	var environments map[string]map[string]string
+	require.NoError(t, json.Unmarshal(data, &environments))
+	assert.Equal(t, map[string]string{
+		"token": "fresh-token", "userId": "42", "userPath": "/users/42", "password": "secret",
+	}, environments["dev"])
+	assert.Equal(t, map[string]string{"token": "prod-token"}, environments["prod"])
+
+	// When a later run uses the persisted values
+	me := filepath.Join(dir, "me.http")
+	meContent := fmt.Sprintf("GET %s{{userPath}}\nAuthorization: Bearer {{token}}\n", server.URL)
+	require.NoError(t, os.WriteFile(me, []byte(meContent), 0644))
+	client, err = rc.NewClient(rc.WithEnvironment("dev"))
+	require.NoError(t, err)
+	_, execErr = client.ExecuteFile(context.Background(), me)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "/users/42 Bearer fresh-token", authorizations[len(authorizations)-1])
+
+	// When the values are persisted to a .env file
+	dotEnv := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(dotEnv, []byte("# local settings\nuserId=7\nOTHER=kept\n"), 0644))
+	client, err = rc.NewClient(rc.WithCapturedVarsFile(dotEnv))
+	require.NoError(t, err)
+	_, execErr = client.ExecuteFile(context.Background(), login)
+
+	// Then
+	require.NoError(t, execErr)
+	data, err = os.ReadFile(dotEnv)
+	require.NoError(t, err)
+	assert.Equal(t, "# local settings\nuserId=42\nOTHER=kept\n"+
+		"token=\"fresh-token\"\nuserPath=\"/users/42\"\n", string(data))
+
+	// When a captured value is missing
+	missing := filepath.Join(dir, "missing.http")
+	missingContent := fmt.Sprintf("# @capture token = body.$.refresh_token\nPOST %s/login\n", server.URL)
+	require.NoError(t, os.WriteFile(missing, []byte(missingContent), 0644))
+	_, execErr = client.ExecuteFile(context.Background(), missing)
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), "@capture token = body.$.refresh_token")
+}
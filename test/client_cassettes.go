@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.55 - Client Core Execution: Recording and Replaying Responses
+// Corresponds to: the WithRecording and WithReplay client options (VCR-style cassettes).
+// This test verifies that the responses of a run are recorded to disk, in order for repeated requests,
+// that a later run is served from the recordings without the server, that requests are told apart by
+// their body, and that a request without a recording fails with ErrNoRecording.
+func RunExecuteFile_RecordAndReplay(t *testing.T) {
+	t.Helper()
+	// Given
+	calls := 0
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Call", fmt.Sprint(calls))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"call": %d, "echo": %q}`, calls, body)
+	})
+
+	dir := t.TempDir()
+	cassettes := filepath.Join(dir, "cassettes")
+	httpFile := filepath.Join(dir, "orders.http")
+	content := fmt.Sprintf("POST %[1]s/orders\n\n{\"item\": \"book\"}\n\n"+
+		"###\nPOST %[1]s/orders\n\n{\"item\": \"pen\"}\n\n"+
+		"###\nPOST %[1]s/orders\n\n{\"item\": \"book\"}\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	recorder, err := rc.NewClient(rc.WithRecording(cassettes))
+	require.NoError(t, err)
+
+	// When
+	recorded, recordErr := recorder.ExecuteFile(context.Background(), httpFile)
+	server.Close()
+	replayer, err := rc.NewClient(rc.WithReplay(cassettes))
+	require.NoError(t, err)
+	replayed, replayErr := replayer.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, recordErr)
+	require.NoError(t, replayErr)
+	entries, err := os.ReadDir(cassettes)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	require.Len(t, replayed, 3)
+	for i := range recorded {
+		assert.Equal(t, http.StatusCreated, replayed[i].StatusCode)
+		assert.Equal(t, recorded[i].BodyString, replayed[i].BodyString)
+		assert.Equal(t, recorded[i].Headers.Get("X-Call"), replayed[i].Headers.Get("X-Call"))
+	}
+	assert.Equal(t, `{"call": 3, "echo": "{\"item\": \"book\"}"}`, replayed[2].BodyString)
+	assert.Equal(t, 3, calls)
+
+	// When a request has no recording
+	missingFile := filepath.Join(dir, "missing.http")
+	require.NoError(t, os.WriteFile(missingFile, []byte("GET "+server.URL+"/unknown\n"), 0644))
+	responses, missingErr := replayer.ExecuteFile(context.Background(), missingFile)
+
+	// Then
+	require.Error(t, missingErr)
+	require.Len(t, responses, 1)
+	assert.True(t, errors.Is(responses[0].Error, rc.ErrNoRecording))
+
+	// When recording and replay are combined
+	_, err = rc.NewClient(rc.WithRecording(cassettes), rc.WithReplay(cassettes))
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "recording and replay cannot be combined")
+}
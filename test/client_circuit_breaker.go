@@ -0,0 +1,75 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.18 - Client Core Execution: Circuit Breaker
+// Corresponds to: The WithCircuitBreaker(threshold, cooldown) client option, which skips requests to
+// a host after threshold consecutive failures with an ErrCircuitOpen error, and lets a trial request
+// through after the cooldown.
+// This test verifies that a failing host is skipped without being contacted while other hosts are
+// not affected, and that a successful trial request after the cooldown closes the circuit.
+func RunExecuteFile_WithCircuitBreaker(t *testing.T) {
+	t.Helper()
+	// Given
+	var failingHits atomic.Int32
+	var recovered atomic.Bool
+	failing := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		failingHits.Add(1)
+		if !recovered.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	defer failing.Close()
+	healthy := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer healthy.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "circuit.http")
+	content := fmt.Sprintf("GET %[1]s/a\n\n###\nGET %[1]s/b\n\n###\nGET %[1]s/c\n\n###\nGET %[2]s/ok\n",
+		failing.URL, healthy.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithCircuitBreaker(2, 50*time.Millisecond))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 4)
+	assert.Equal(t, http.StatusServiceUnavailable, responses[0].StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, responses[1].StatusCode)
+	assert.True(t, errors.Is(responses[2].Error, rc.ErrCircuitOpen), "got %v", responses[2].Error)
+	assert.Contains(t, responses[2].Error.Error(), "circuit open for host")
+	assert.NoError(t, responses[3].Error)
+	assert.Equal(t, int32(2), failingHits.Load(), "the open circuit should skip the host")
+
+	// When the cooldown has passed and the host recovered
+	time.Sleep(60 * time.Millisecond)
+	recovered.Store(true)
+	responses, execErr = client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 4)
+	assert.Equal(t, int32(5), failingHits.Load(), "the trial request should close the circuit")
+
+	_, err = rc.NewClient(rc.WithCircuitBreaker(0, time.Second))
+	assert.Error(t, err)
+}
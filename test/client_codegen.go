@@ -0,0 +1,75 @@
+package test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.25 - Client Core Execution: Go Code Generation
+// Corresponds to: Client.GenerateGo(requestFilePath, packageName) and the restclient-codegen command,
+// which convert resolved requests into Go functions using net/http.
+// This test verifies that the generated code parses as Go, that functions are named after @name or
+// the method and path, and that resolved URLs, headers, bodies and request settings are reproduced.
+func RunGenerateGo(t *testing.T) {
+	t.Helper()
+	// Given
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "users.http")
+	content := `@host = https://api.example.com
+
+# @name create user
+# @no-redirect
+# @timeout 5000
+POST {{host}}/users
+Content-Type: application/json
+X-Trace: {{trace}}
+
+{"name": "Ann"}
+
+###
+GET {{host}}/users/42/orders
+
+###
+GET {{host}}/users/42/orders
+`
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithVars(map[string]any{"trace": "abc"}))
+	require.NoError(t, err)
+
+	// When
+	code, genErr := client.GenerateGo(httpFile, "users")
+
+	// Then
+	require.NoError(t, genErr)
+	file, parseErr := parser.ParseFile(token.NewFileSet(), "users.go", code, 0)
+	require.NoError(t, parseErr, code)
+	assert.Equal(t, "users", file.Name.Name)
+	var functions []string
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			functions = append(functions, fn.Name.Name)
+		}
+	}
+	assert.Equal(t, []string{"CreateUser", "GetUsers42Orders", "GetUsers42Orders2"}, functions)
+	assert.Contains(t, code, "// Code generated by go-restclient from users.http. DO NOT EDIT.")
+	assert.Contains(t, code, `http.NewRequestWithContext(ctx, http.MethodPost, "https://api.example.com/users", `+
+		"strings.NewReader(`{\"name\": \"Ann\"}`))")
+	assert.Contains(t, code, `req.Header.Add("X-Trace", "abc")`)
+	assert.Contains(t, code, "return http.ErrUseLastResponse")
+	assert.Contains(t, code, "custom.Timeout = 5000 * time.Millisecond")
+
+	// When
+	_, genErr = client.GenerateGo(httpFile, "not a package")
+
+	// Then
+	require.Error(t, genErr)
+	assert.Contains(t, genErr.Error(), `invalid Go package name "not a package"`)
+}
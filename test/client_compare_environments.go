@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.34 - Client Core Execution: Cross-Environment Comparison
+// Corresponds to: client.CompareEnvironments(ctx, file, first, second), which runs a file against two
+// environments and reports the differences of statuses, headers and normalized bodies.
+// This test verifies that equivalent JSON bodies and volatile headers do not count as differences,
+// that differing statuses, headers and bodies are reported per request, that ignored headers are
+// skipped, and that an unparsable file fails the comparison.
+func RunCompareEnvironments(t *testing.T) {
+	t.Helper()
+	// Given
+	handler := func(version string, featureStatus int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Version", version)
+			switch r.URL.Path {
+			case "/users/1":
+				if version == "1" {
+					_, _ = w.Write([]byte(`{"id": 1, "name": "Ann"}`))
+				} else {
+					_, _ = w.Write([]byte(`{"name":"Ann","id":1}`))
+				}
+			case "/features":
+				w.WriteHeader(featureStatus)
+				_, _ = fmt.Fprintf(w, `{"dark_mode": %t}`, featureStatus == http.StatusOK)
+			}
+		}
+	}
+	staging := startMockServer(handler("2", http.StatusOK))
+	defer staging.Close()
+	prod := startMockServer(handler("1", http.StatusNotFound))
+	defer prod.Close()
+
+	dir := t.TempDir()
+	envContent := fmt.Sprintf(`{"staging": {"host": %q}, "prod": {"host": %q}}`, staging.URL, prod.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(envContent), 0644))
+	httpFile := filepath.Join(dir, "parity.http")
+	content := "# @name user\nGET {{host}}/users/1\n\n###\n# @name features\nGET {{host}}/features\n"
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	comparison, err := client.CompareEnvironments(context.Background(), httpFile, "staging", "prod")
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "staging", comparison.First)
+	assert.Equal(t, "prod", comparison.Second)
+	require.Len(t, comparison.Requests, 2)
+	user, features := comparison.Requests[0], comparison.Requests[1]
+	assert.Equal(t, "user", user.Name)
+	assert.Equal(t, "{{host}}/users/1", user.URL)
+	assert.Equal(t, []rc.Difference{
+		{Kind: rc.DifferenceHeader, Header: "X-Version", First: "2", Second: "1"},
+	}, user.Differences)
+	require.Len(t, features.Differences, 3)
+	assert.Equal(t, rc.Difference{Kind: rc.DifferenceStatus, First: "200", Second: "404"}, features.Differences[0])
+	assert.Equal(t, rc.DifferenceBody, features.Differences[2].Kind)
+	assert.Contains(t, features.Differences[2].Diff, `-  "dark_mode": true`)
+	assert.Contains(t, features.Differences[2].Diff, `+  "dark_mode": false`)
+	assert.False(t, comparison.Equal())
+	assert.Contains(t, comparison.String(), "staging vs prod: 2 of 2 requests differ")
+
+	// When
+	comparison, err = client.CompareEnvironments(context.Background(), httpFile, "staging", "prod",
+		rc.IgnoreHeaders("x-version"))
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, comparison.Requests[0].Differences)
+	assert.Len(t, comparison.Differing(), 1)
+
+	// When
+	broken := filepath.Join(dir, "broken.http")
+	require.NoError(t, os.WriteFile(broken, []byte("# nothing to send\n"), 0644))
+	_, err = client.CompareEnvironments(context.Background(), broken, "staging", "prod")
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "environment 'staging'")
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.23 - Client Core Execution: JetBrains Cookies File
+// Corresponds to: The WithCookiesFile(path) client option, which reads and writes cookies in the
+// tab separated http-client.cookies format of the JetBrains HTTP Client.
+// This test verifies that cookies of an existing file are sent, expired ones are dropped, cookies
+// set by responses are written to the file, and a new client picks them up from the file.
+func RunExecuteFile_WithCookiesFile(t *testing.T) {
+	t.Helper()
+	// Given
+	var received []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", MaxAge: 3600})
+			return
+		}
+		var names []string
+		for _, cookie := range r.Cookies() {
+			names = append(names, cookie.Name+"="+cookie.Value)
+		}
+		sort.Strings(names)
+		received = append(received, strings.Join(names, "; "))
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	cookiesFile := filepath.Join(dir, ".idea", "httpRequests", "http-client.cookies")
+	require.NoError(t, os.MkdirAll(filepath.Dir(cookiesFile), 0755))
+	ideCookies := "# domain\tpath\tname\tvalue\tdate\n" +
+		"127.0.0.1\t/\tide\tfrom-ide\t-1\n" +
+		"127.0.0.1\t/\told\tgone\tThu, 01 Jan 2015 00:00:00 GMT\n"
+	require.NoError(t, os.WriteFile(cookiesFile, []byte(ideCookies), 0600))
+	loginFile := filepath.Join(dir, "login.http")
+	require.NoError(t, os.WriteFile(loginFile,
+		[]byte(fmt.Sprintf("GET %[1]s/login\n\n###\nGET %[1]s/me\n", server.URL)), 0644))
+	meFile := filepath.Join(dir, "me.http")
+	require.NoError(t, os.WriteFile(meFile, []byte(fmt.Sprintf("GET %s/me\n", server.URL)), 0644))
+	first, err := rc.NewClient(rc.WithCookiesFile(cookiesFile))
+	require.NoError(t, err)
+
+	// When
+	_, execErr := first.ExecuteFile(context.Background(), loginFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, []string{"ide=from-ide; session=abc"}, received)
+	written, err := os.ReadFile(cookiesFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(written)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "# domain\tpath\tname\tvalue\tdate", lines[0])
+	assert.Equal(t, "127.0.0.1\t/\tide\tfrom-ide\t-1", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "127.0.0.1\t/\tsession\tabc\t"), lines[2])
+	assert.True(t, strings.HasSuffix(lines[2], " GMT"), lines[2])
+
+	// When
+	received = nil
+	second, err := rc.NewClient(rc.WithCookiesFile(cookiesFile))
+	require.NoError(t, err)
+	_, execErr = second.ExecuteFile(context.Background(), meFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, []string{"ide=from-ide; session=abc"}, received)
+}
@@ -2,11 +2,13 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url" // Added import
 	"os"
+	"path/filepath"
 	"testing"
 
 	rc "github.com/bmcszk/go-restclient"
@@ -248,14 +250,8 @@ func RunRedirectHandling(t *testing.T) {
 
 	// When/Then: Test without redirect following (@no-redirect directive)
 
-	// For the @no-redirect test, create a client with a custom CheckRedirect function
-	// that prevents following redirects, and with server variables
-	customRedirectHTTPClient := &http.Client{
-		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
-			return http.ErrUseLastResponse // Don't follow redirects
-		},
-	}
-	client, err = rc.NewClient(rc.WithVars(serverVars), rc.WithHTTPClient(customRedirectHTTPClient))
+	// The directive alone keeps a default client from following the redirect
+	client, err = rc.NewClient(rc.WithVars(serverVars))
 	require.NoError(t, err, "Should create client without error")
 
 	// Execute file with @no-redirect directive
@@ -298,3 +294,63 @@ func dir(filePath string) string {
 	}
 	return ""
 }
+
+// PRD-COMMENT: FR9.3 - Client Execution: Redirect History
+// Corresponds to: Response.Redirects and Response.FinalURL recording each followed redirect hop
+// (URL, status, Location, Set-Cookie), and the '# final-url' / '# redirects' .hresp directives.
+// This test verifies a two-hop redirect chain is recorded in order and that the .hresp
+// directives pass for the actual chain and report mismatches otherwise.
+func RunRedirectHistory(t *testing.T) {
+	t.Helper()
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/start":
+			http.SetCookie(w, &http.Cookie{Name: "step", Value: "one"})
+			http.Redirect(w, r, "/middle", http.StatusFound)
+		case "/middle":
+			http.Redirect(w, r, "/target?done=1", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	httpFile := filepath.Join(tempDir, "redirects.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(fmt.Sprintf("GET %s/start\n", server.URL)), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	resp := responses[0]
+	assert.Equal(t, server.URL+"/target?done=1", resp.FinalURL)
+	require.Len(t, resp.Redirects, 2)
+	assert.Equal(t, server.URL+"/start", resp.Redirects[0].URL)
+	assert.Equal(t, http.StatusFound, resp.Redirects[0].StatusCode)
+	assert.Equal(t, "/middle", resp.Redirects[0].Location)
+	require.Len(t, resp.Redirects[0].Cookies, 1)
+	assert.Equal(t, "one", resp.Redirects[0].Cookies[0].Value)
+	assert.Equal(t, http.StatusMovedPermanently, resp.Redirects[1].StatusCode)
+
+	// Given .hresp files asserting the redirect chain
+	passingHresp := filepath.Join(tempDir, "passing.hresp")
+	require.NoError(t, os.WriteFile(passingHresp,
+		[]byte("# final-url /target?done=1\n# redirects 2\nHTTP/1.1 200 OK\n"), 0644))
+	failingHresp := filepath.Join(tempDir, "failing.hresp")
+	require.NoError(t, os.WriteFile(failingHresp,
+		[]byte("# final-url https://elsewhere.example.com/target\n# redirects 1\nHTTP/1.1 200 OK\n"), 0644))
+
+	// When / Then
+	require.NoError(t, client.ValidateResponses(passingHresp, resp))
+	err = client.ValidateResponses(failingHresp, resp)
+	assertMultierrorContains(t, err, 2, []string{
+		"final URL mismatch: expected 'https://elsewhere.example.com/target'",
+		"redirect count mismatch: expected 1, got 2",
+	})
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// curlCapture is a request received by the server of the curl import test
+type curlCapture struct {
+	method, query, contentType, trace, auth, body string
+	form                                          map[string]string
+}
+
+// PRD-COMMENT: FR2.11 - Parser: cURL Command Import
+// Corresponds to: requests written as curl commands in .http files (http_syntax.md "cURL Import/Export").
+// This test verifies that curl commands spanning lines with backslashes and multi-line quotes are parsed
+// into the method, URL, headers and body of the request, including basic auth, -G query data and -F form
+// fields with file uploads, and that an unsupported option fails the parse with its line.
+func RunExecuteFile_CurlImport(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	received := map[string]curlCapture{}
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		capture := curlCapture{method: r.Method, query: r.URL.RawQuery, contentType: r.Header.Get("Content-Type"),
+			trace: r.Header.Get("X-Trace"), auth: r.Header.Get("Authorization")}
+		if r.URL.Path == "/upload" {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			file, _, err := r.FormFile("file")
+			require.NoError(t, err)
+			content, _ := io.ReadAll(file)
+			capture.form = map[string]string{"title": r.FormValue("title"), "file": string(content)}
+		} else {
+			body, _ := io.ReadAll(r.Body)
+			capture.body = string(body)
+		}
+		received[r.URL.Path] = capture
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report.txt"), []byte("quarterly numbers"), 0644))
+	content := fmt.Sprintf("@host = %s\n\n"+
+		"curl -X POST '{{host}}/users?source=curl' \\\n"+
+		"  -H 'Content-Type: application/json' \\\n"+
+		"  -H \"X-Trace: abc\" \\\n"+
+		"  --data-raw '{\n  \"name\": \"Ann\"\n}'\n\n"+
+		"###\ncurl -sS -u admin:secret {{host}}/search -G --data-urlencode 'q=a b'\n\n"+
+		"###\ncurl {{host}}/upload -F 'title=Report' -F 'file=@./report.txt;type=text/plain'\n", server.URL)
+	httpFile := filepath.Join(dir, "curl.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 3)
+	assert.Equal(t, curlCapture{method: http.MethodPost, query: "source=curl", contentType: "application/json",
+		trace: "abc", body: "{\n  \"name\": \"Ann\"\n}"}, received["/users"])
+	assert.Equal(t, curlCapture{method: http.MethodGet, query: "q=a+b", auth: "Basic YWRtaW46c2VjcmV0"},
+		received["/search"])
+	upload := received["/upload"]
+	assert.Equal(t, http.MethodPost, upload.method)
+	assert.Contains(t, upload.contentType, "multipart/form-data")
+	assert.Equal(t, map[string]string{"title": "Report", "file": "quarterly numbers"}, upload.form)
+
+	// Given an unsupported option
+	invalidFile := filepath.Join(dir, "invalid.http")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("curl --unknown x https://example.com\n"), 0644))
+
+	// When
+	_, invalidErr := client.ExecuteFile(context.Background(), invalidFile)
+
+	// Then
+	require.Error(t, invalidErr)
+	assert.Contains(t, invalidErr.Error(), "line 1: invalid curl command: unsupported option --unknown")
+}
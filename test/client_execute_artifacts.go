@@ -0,0 +1,71 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.2 - Response Artifacts: Saving Response Bodies
+// Corresponds to: Response.SaveBody and the WithArtifactsDir client option, which stores every
+// response body of an ExecuteFile run under <dir>/<http file name>/<index>-<request name>.<ext>
+// so failed CI runs can be inspected afterwards.
+// This test verifies named and unnamed requests, content-type based extensions and SaveBody
+// creating missing parent directories.
+func RunExecuteFile_SavesResponseArtifacts(t *testing.T) {
+	t.Helper()
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users" {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = w.Write([]byte(`{"id": 1}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte{0x00, 0x01, 0x02})
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	httpFile := filepath.Join(tempDir, "user api.http")
+	content := fmt.Sprintf("### Get user\n# @name getUser\nGET %s/users\n\n###\nDELETE %s/blob\n",
+		server.URL, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	artifactsDir := filepath.Join(tempDir, "artifacts")
+	client, err := rc.NewClient(rc.WithArtifactsDir(artifactsDir))
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+
+	runDir := filepath.Join(artifactsDir, "user_api")
+	jsonBody, err := os.ReadFile(filepath.Join(runDir, "001-getUser.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1}`, string(jsonBody))
+
+	binaryBody, err := os.ReadFile(filepath.Join(runDir, "002-delete.body"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x01, 0x02}, binaryBody)
+
+	// When saving a single body to a nested path
+	nestedPath := filepath.Join(tempDir, "out", "nested", "user.json")
+	require.NoError(t, responses[0].SaveBody(nestedPath))
+
+	// Then
+	savedBody, err := os.ReadFile(nestedPath)
+	require.NoError(t, err)
+	assert.Equal(t, responses[0].Body, savedBody)
+}
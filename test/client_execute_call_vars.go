@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/bmcszk/go-restclient/restclienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.28 - Variable Substitution: Per-Call Variables
+// Corresponds to: The variadic rc.Vars argument of Client.ExecuteFile (and of the Executor
+// interface), holding variables for a single call.
+// This test verifies that concurrent calls on a shared client each see their own variables, that
+// per-call variables override WithVars without changing the client, and that the fake records them.
+func RunExecuteFile_WithCallVars(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.URL.RequestURI()] = true
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "user.http")
+	content := fmt.Sprintf("GET %s/users/{{userId}}?tenant={{tenant}}\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithVars(map[string]any{"userId": "default", "tenant": "acme"}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.ExecuteFile(ctx, httpFile, rc.Vars{"userId": i})
+		}(i)
+	}
+	wg.Wait()
+
+	// Then
+	for i, execErr := range errs {
+		require.NoError(t, execErr)
+		assert.True(t, seen[fmt.Sprintf("/users/%d?tenant=acme", i)], "user %d", i)
+	}
+
+	// When later Vars override earlier ones, and the client's own variables are used again
+	_, err = client.ExecuteFile(ctx, httpFile, rc.Vars{"userId": 1, "tenant": "x"}, rc.Vars{"tenant": "y"})
+	require.NoError(t, err)
+	_, err = client.ExecuteFile(ctx, httpFile)
+	require.NoError(t, err)
+
+	// Then
+	assert.True(t, seen["/users/1?tenant=y"])
+	assert.True(t, seen["/users/default?tenant=acme"])
+
+	// When
+	fake := restclienttest.NewExecutor().SetResponses("user.http")
+	_, _ = fake.ExecuteFile(ctx, "user.http", rc.Vars{"userId": 7})
+	_, _ = fake.ExecuteFile(ctx, "user.http")
+
+	// Then
+	assert.Equal(t, []rc.Vars{{"userId": 7}, nil}, fake.ExecutedVars())
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR4.5 - Request/Response Bodies: Charset Handling
+// Corresponds to: Client's handling of 'charset=' in Content-Type and declared source encodings of
+// external body files ('< latin1 ./file'), and decoding of non-UTF-8 responses before validation.
+// This test verifies source decoding of a Latin-1 file, encoding of an inline body into the declared
+// request charset, byte-for-byte static files, and validating a UTF-16 response against UTF-8 text.
+func RunExecuteFile_CharsetAwareBodies(t *testing.T) {
+	t.Helper()
+	// Given
+	latin1Cafe := []byte{'c', 'a', 'f', 0xE9} // "café" in ISO-8859-1
+	utf16Hello := []byte{'h', 0, 0xE9, 0, 'l', 0, 'l', 0, 'o', 0}
+	received := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received[r.URL.Path] = body
+		if r.URL.Path == "/utf16" {
+			w.Header().Set("Content-Type", "text/plain; charset=UTF-16LE")
+			_, _ = w.Write(utf16Hello)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "latin1.txt"), latin1Cafe, 0644))
+	content := fmt.Sprintf("POST %[1]s/decoded\nContent-Type: text/plain; charset=utf-8\n\n< latin1 ./latin1.txt\n\n"+
+		"###\nPOST %[1]s/encoded\nContent-Type: text/plain; charset=ISO-8859-1\n\ncafé\n\n"+
+		"###\nPOST %[1]s/raw\nContent-Type: text/plain; charset=ISO-8859-1\n\n< ./latin1.txt\n\n"+
+		"###\nGET %[1]s/utf16\n", server.URL)
+	httpFile := filepath.Join(dir, "charset.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	hrespFile := filepath.Join(dir, "charset.hresp")
+	hresp := "HTTP/1.1 200 OK\n\n###\nHTTP/1.1 200 OK\n\n###\nHTTP/1.1 200 OK\n\n###\nHTTP/1.1 200 OK\n\nhéllo\n"
+	require.NoError(t, os.WriteFile(hrespFile, []byte(hresp), 0644))
+
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 4)
+	assert.Equal(t, "café", string(received["/decoded"]), "Latin-1 file must be decoded to UTF-8")
+	assert.Equal(t, latin1Cafe, received["/encoded"], "inline body must be encoded as ISO-8859-1")
+	assert.Equal(t, latin1Cafe, received["/raw"], "static file without encoding must be sent as is")
+
+	text, err := responses[3].Text()
+	require.NoError(t, err)
+	assert.Equal(t, "héllo", text)
+	assert.Equal(t, utf16Hello, responses[3].Body, "raw body bytes are kept")
+	require.NoError(t, client.ValidateResponses(hrespFile, responses...))
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR2.6 - Request Settings: Conditional Request Directives
+// Corresponds to: '# @if-match', '# @if-none-match', '# @if-modified-since' and '# @if-unmodified-since'
+// filling the conditional header from the ETag or Last-Modified of the preceding or a named earlier
+// response, for optimistic-concurrency flows.
+// This test verifies the headers sent for named and preceding responses, and that a missing validator
+// fails the request.
+func RunExecuteFile_WithConditionalDirectives(t *testing.T) {
+	t.Helper()
+	// Given
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	received := make(map[string]http.Header)
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		received[r.Method+" "+r.URL.Path] = r.Header.Clone()
+		switch {
+		case r.URL.Path == "/plain":
+		case r.Method == http.MethodGet && r.Header.Get("If-None-Match") == `"v1"`:
+			w.WriteHeader(http.StatusNotModified)
+			return
+		case r.Method == http.MethodGet:
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Last-Modified", lastModified)
+		case r.Header.Get("If-Match") != `"v1"`:
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "conditional.http")
+	content := fmt.Sprintf(`# @name getItem
+GET %[1]s/items/1
+
+###
+# @if-none-match
+GET %[1]s/items/1/again
+
+###
+# @if-match getItem
+# @if-unmodified-since getItem
+PUT %[1]s/items/1
+
+{"name": "updated"}
+
+###
+GET %[1]s/plain
+
+###
+# @if-match
+DELETE %[1]s/items/1
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 5)
+	assert.Equal(t, http.StatusNotModified, responses[1].StatusCode)
+	assert.Equal(t, http.StatusOK, responses[2].StatusCode)
+	assert.Equal(t, `"v1"`, received["PUT /items/1"].Get("If-Match"))
+	assert.Equal(t, lastModified, received["PUT /items/1"].Get("If-Unmodified-Since"))
+	assert.NotContains(t, received, "DELETE /items/1", "a request without its validator is not sent")
+	require.Error(t, execErr)
+	assert.ErrorContains(t, responses[4].Error, "@if-match: response from the preceding request has no ETag header")
+	assert.Contains(t, responses[2].Request.String(), "# @if-match getItem\n# @if-unmodified-since getItem\n")
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedContentHeaders are the content headers and body seen by the server for one request
+type receivedContentHeaders struct {
+	contentType   string
+	contentLength int64
+	body          string
+}
+
+// PRD-COMMENT: FR4.6 - Request Bodies: Content Headers After Substitution
+// Corresponds to: Client's recomputation of an authored Content-Length from the substituted body and
+// Content-Type inference for JSON, XML and form bodies (WithContentTypeInference, @no-infer-content-type).
+// This test verifies that a stale template Content-Length is corrected, that each body kind gets its
+// inferred type, and that explicit headers and the opt-out directive are respected.
+func RunExecuteFile_ContentHeadersAfterSubstitution(t *testing.T) {
+	t.Helper()
+	// Given
+	received := map[string]receivedContentHeaders{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received[r.URL.Path] = receivedContentHeaders{
+			contentType:   r.Header.Get("Content-Type"),
+			contentLength: r.ContentLength,
+			body:          string(body),
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	content := `@name = A much longer name than the template suggests
+
+POST {{baseUrl}}/length
+Content-Type: application/json
+Content-Length: 16
+
+{"name":"{{name}}"}
+
+###
+POST {{baseUrl}}/json
+
+[{"id": 1}]
+
+###
+POST {{baseUrl}}/xml
+
+<?xml version="1.0"?><user><name>{{name}}</name></user>
+
+###
+POST {{baseUrl}}/form
+
+name=John&age=42
+
+###
+POST {{baseUrl}}/text
+
+just some text
+
+###
+POST {{baseUrl}}/explicit
+Content-Type: text/plain
+
+{"id": 1}
+
+###
+# @no-infer-content-type
+POST {{baseUrl}}/opt-out
+
+{"id": 1}
+`
+	httpFile := filepath.Join(t.TempDir(), "content_headers.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithVars(map[string]any{"baseUrl": server.URL}), rc.WithContentTypeInference())
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 7)
+	for _, resp := range responses {
+		require.NoError(t, resp.Error)
+	}
+
+	lengthReq := received["/length"]
+	expectedBody := `{"name":"A much longer name than the template suggests"}`
+	assert.Equal(t, expectedBody, lengthReq.body)
+	assert.Equal(t, int64(len(expectedBody)), lengthReq.contentLength)
+	assert.Equal(t, strconv.Itoa(len(expectedBody)), responses[0].Request.Headers.Get("Content-Length"),
+		"authored Content-Length must be rewritten to the substituted body size")
+
+	assert.Equal(t, "application/json", received["/json"].contentType)
+	assert.Equal(t, "application/xml", received["/xml"].contentType)
+	assert.Equal(t, "application/x-www-form-urlencoded", received["/form"].contentType)
+	assert.Empty(t, received["/text"].contentType, "plain text must not get an inferred type")
+	assert.Equal(t, "text/plain", received["/explicit"].contentType)
+	assert.Empty(t, received["/opt-out"].contentType)
+	assert.True(t, responses[6].Request.NoInferContentType)
+}
+
+// PRD-COMMENT: FR4.6 - Request Bodies: Content Headers After Substitution
+// Corresponds to: Content-Type inference being opt-in.
+// This test verifies that without WithContentTypeInference a JSON body is sent without a Content-Type.
+func RunExecuteFile_ContentTypeInferenceOffByDefault(t *testing.T) {
+	t.Helper()
+	// Given
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "no_inference.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte("POST "+server.URL+"\n\n{\"id\": 1}\n"), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	require.NoError(t, responses[0].Error)
+	assert.Empty(t, contentType)
+}
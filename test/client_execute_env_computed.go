@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR1.4 - Environment Variables: Computed Values
+// Corresponds to: http-client.env.json values built from other keys of the same environment
+// ("baseUrl": "{{scheme}}://{{host}}:{{port}}") or from expressions over them, resolved when the
+// environment is loaded, after the private file is merged in.
+// This test verifies references, expressions and placeholders left for request time, and that keys
+// referencing each other in a cycle are reported.
+func RunExecuteFile_WithComputedEnvironmentVariables(t *testing.T) {
+	t.Helper()
+	// Given
+	var gotPath, gotOrigin, gotToken string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotOrigin, gotToken = r.URL.RequestURI(), r.Header.Get("X-Origin"), r.Header.Get("X-Token")
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	publicEnv := `{
+  "dev": {
+    "scheme": "http",
+    "host": "example.invalid",
+    "port": "` + serverURL.Port() + `",
+    "baseUrl": "{{scheme}}://{{host}}:{{port}}",
+    "apiUrl": "{{baseUrl}}/api/v{{ version + 1 }}",
+    "version": "1",
+    "origin": "{{ env == 'prod' ? 'live' : host }}",
+    "env": "dev",
+    "token": "{{userToken | anonymous}}"
+  },
+  "loop": {"a": "{{b}}-x", "b": "{{ a + 'y' }}"}
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(publicEnv), 0644))
+	privateEnv := `{"dev": {"host": "` + serverURL.Hostname() + `"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.private.env.json"), []byte(privateEnv), 0644))
+	httpFile := filepath.Join(dir, "computed.http")
+	content := "@userToken = file-token\n\nGET {{apiUrl}}/items\nX-Origin: {{origin}}\nX-Token: {{token}}\n"
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	client, err := rc.NewClient(rc.WithEnvironment("dev"))
+	require.NoError(t, err)
+	loopClient, err := rc.NewClient(rc.WithEnvironment("loop"))
+	require.NoError(t, err)
+
+	// When
+	_, execErr := client.ExecuteFile(context.Background(), httpFile)
+	_, loopErr := loopClient.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "/api/v2/items", gotPath)
+	assert.Equal(t, serverURL.Hostname(), gotOrigin, "private host should be used in computed values")
+	assert.Equal(t, "file-token", gotToken, "non-environment references should resolve at request time")
+	require.Error(t, loopErr)
+	assert.Contains(t, loopErr.Error(), "environment 'loop': circular environment variable reference: a -> b -> a")
+}
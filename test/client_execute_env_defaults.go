@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR1.3.9 - System Variables: Inline Defaults for Environment Lookups
+// Corresponds to: Client's ability to fall back to an inline default in '{{$env.VAR default}}',
+// '{{$processEnv VAR default}}' and '{{$dotenv VAR default}}' when the variable is unset, while still
+// honouring values provided by the environment (e.g. CI) and the .env file.
+// This test verifies bare and quoted defaults, empty quoted defaults, and that set values win.
+func RunExecuteFile_WithEnvironmentLookupDefaults(t *testing.T) {
+	t.Helper()
+	// Given
+	t.Setenv("GO_RESTCLIENT_CI_TOKEN", "ci-token")
+	_ = os.Unsetenv("GO_RESTCLIENT_UNSET_HOST")
+	_ = os.Unsetenv("GO_RESTCLIENT_UNSET_REGION")
+
+	var interceptedHeaders http.Header
+	var interceptedBody string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		interceptedHeaders = r.Header.Clone()
+		bodyBytes, _ := io.ReadAll(r.Body)
+		interceptedBody = string(bodyBytes)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, ".env"), []byte("DOTENV_USER=alice\n"), 0644))
+	httpContent := fmt.Sprintf(`POST %s/deploy
+Content-Type: application/json
+X-Token: {{$processEnv GO_RESTCLIENT_CI_TOKEN local-token}}
+X-Host: {{$env.GO_RESTCLIENT_UNSET_HOST localhost:8080}}
+X-Region: {{$processEnv GO_RESTCLIENT_UNSET_REGION "eu west 1"}}
+
+{
+  "user": "{{$dotenv DOTENV_USER bob}}",
+  "team": "{{$dotenv DOTENV_TEAM "platform team"}}",
+  "suffix": "{{$env.GO_RESTCLIENT_UNSET_HOST ""}}",
+  "ciToken": "{{$env.GO_RESTCLIENT_CI_TOKEN fallback}}"
+}`, server.URL)
+	httpFile := filepath.Join(tempDir, "request.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(httpContent), 0644))
+
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.NoError(t, responses[0].Error)
+
+	assert.Equal(t, "ci-token", interceptedHeaders.Get("X-Token"), "Set variable should win over default")
+	assert.Equal(t, "localhost:8080", interceptedHeaders.Get("X-Host"), "Bare default should be used")
+	assert.Equal(t, "eu west 1", interceptedHeaders.Get("X-Region"), "Quoted default may contain spaces")
+
+	var bodyJSON map[string]string
+	require.NoError(t, json.Unmarshal([]byte(interceptedBody), &bodyJSON), "Body: %s", interceptedBody)
+	assert.Equal(t, "alice", bodyJSON["user"], ".env value should win over default")
+	assert.Equal(t, "platform team", bodyJSON["team"])
+	assert.Equal(t, "", bodyJSON["suffix"], "Empty quoted default should resolve to empty string")
+	assert.Equal(t, "ci-token", bodyJSON["ciToken"])
+}
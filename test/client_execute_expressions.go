@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR1.8 - Custom Variables: Placeholder Expressions
+// Corresponds to: Expressions inside placeholders ({{ count + 1 }}, {{ env == "prod" ? prodKey : devKey }})
+// evaluated against the merged variable context of file, programmatic and environment variables.
+// This test verifies arithmetic, comparisons, conditionals and concatenation in the URL, headers and body,
+// while plain names, hyphenated names, names ending in '-<digit>' and fallbacks keep their meaning.
+func RunExecuteFile_PlaceholderExpressions(t *testing.T) {
+	t.Helper()
+	// Given
+	var gotPath, gotKey, gotFoo, gotBody string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotPath, gotKey, gotBody = r.URL.RequestURI(), r.Header.Get("X-Api-Key"), string(body)
+		gotFoo = r.Header.Get("X-Foo")
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "expressions.http")
+	content := fmt.Sprintf(`@count = 4
+@prodKey = prod-secret
+@devKey = dev-secret
+@api-version = v2
+@foo-1 = first
+
+POST %s/{{api-version}}/pages/{{ count + 1 }}?half={{count / 8}}&big={{ (count - 1) * 2 >= 6 }}
+X-Api-Key: {{ env == "prod" ? prodKey : devKey }}
+X-Foo: {{foo-1}} {{count-1}}
+
+{"next": {{count+1}}, "label": "{{ 'page-' + count }}",
+ "missing": "{{ unknown | none }}", "ok": {{ !false && count > 3 }}}
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	devClient, err := rc.NewClient(rc.WithVars(map[string]any{"env": "dev"}))
+	require.NoError(t, err)
+	prodClient, err := rc.NewClient(rc.WithVars(map[string]any{"env": "prod"}))
+	require.NoError(t, err)
+
+	// When
+	_, devErr := devClient.ExecuteFile(context.Background(), httpFile)
+	devKey := gotKey
+	_, prodErr := prodClient.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, devErr)
+	require.NoError(t, prodErr)
+	assert.Equal(t, "dev-secret", devKey)
+	assert.Equal(t, "prod-secret", gotKey)
+	assert.Equal(t, "first 3", gotFoo, "a defined name wins over subtraction")
+	assert.Equal(t, "/v2/pages/5?half=0.5&big=true", gotPath)
+	assert.JSONEq(t, `{"next": 5, "label": "page-4", "missing": "none", "ok": true}`, gotBody)
+}
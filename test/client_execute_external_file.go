@@ -565,6 +565,9 @@ Content-Type: application/json
 	require.Contains(t, err.Error(), "error processing body for request")
 	require.Contains(t, err.Error(), "nonexistent.json")
 
-	// Should still get responses array but with error
-	require.Len(t, responses, 0) // No responses should be returned on file processing error
+	// The request is not sent, but still has a response carrying the error
+	require.Len(t, responses, 1)
+	require.Error(t, responses[0].Error)
+	require.Contains(t, responses[0].Error.Error(), "nonexistent.json")
+	require.NotNil(t, responses[0].Request)
 }
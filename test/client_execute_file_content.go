@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR4.4 - Request Body: Inline File Fragments ({{$fileContent}})
+// Corresponds to: Client's ability to inline the content of a file into a header or body fragment
+// via '{{$fileContent ./path}}' (static) or '{{$fileContent @./path}}' (with variable substitution),
+// distinct from whole-body '< ./path' references.
+// This test verifies that a shared JSON fragment is composed into a larger payload, that the static
+// form leaves placeholders inside the fragment untouched, and that the '@' form resolves them.
+func RunExecuteFile_FileContentVariable(t *testing.T) {
+	t.Helper()
+	// Given
+	tempDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "address.json"),
+		[]byte(`{"city": "{{city}}"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "token.txt"), []byte("secret-token"), 0644))
+
+	var receivedBody, receivedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		receivedBody = string(bodyBytes)
+		receivedAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpContent := fmt.Sprintf(`@city = Berlin
+
+### Compose payload from fragments
+POST %s/users
+Content-Type: application/json
+Authorization: Bearer {{$fileContent ./token.txt}}
+
+{
+  "static": {{$fileContent ./address.json}},
+  "resolved": {{$fileContent @./address.json}}
+}`, server.URL)
+	httpFile := filepath.Join(tempDir, "request.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(httpContent), 0644))
+
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.NoError(t, responses[0].Error)
+	assert.Equal(t, "Bearer secret-token", receivedAuth)
+	assert.Contains(t, receivedBody, `"static": {"city": "{{city}}"}`)
+	assert.Contains(t, receivedBody, `"resolved": {"city": "Berlin"}`)
+}
+
+// PRD-COMMENT: FR4.4 - Request Body: Inline File Fragments ({{$fileContent}}) Not Found
+// Corresponds to: Client's error handling when a '{{$fileContent ./path}}' reference names a missing file.
+// This test verifies that the error surfaces instead of the unresolved placeholder being sent.
+func RunExecuteFile_FileContentVariableMissingFile(t *testing.T) {
+	t.Helper()
+	// Given
+	tempDir := t.TempDir()
+	httpContent := "POST http://localhost:1/unreachable\nContent-Type: application/json\n\n" +
+		"{\"data\": {{$fileContent ./missing.json}}}"
+	httpFile := filepath.Join(tempDir, "request.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(httpContent), 0644))
+
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	_, err = client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read file for $fileContent ./missing.json")
+}
+
+// PRD-COMMENT: G5 Phase 2 - Weighted Random Values from an External File ({{$randomFromFile}})
+// Corresponds to: Client's ability to substitute '{{$randomFromFile ./values.txt}}' with a value
+// picked from a team-curated file (one value per line, optional '|weight' suffix, '#' comments).
+// This test verifies that only listed values are produced, comments and blank lines are ignored,
+// weights bias the selection, and a missing file surfaces an error.
+func RunExecuteFile_RandomFromFileVariable(t *testing.T) {
+	t.Helper()
+	// Given
+	tempDir := t.TempDir()
+	valuesContent := "# Curated cities\nBerlin|1000000\n\nRare City|0.000001\nNew York, NY | 1000000\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "cities.txt"), []byte(valuesContent), 0644))
+
+	var receivedBody, receivedCity string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, _ := io.ReadAll(r.Body)
+		receivedBody = string(bodyBytes)
+		receivedCity = r.Header.Get("X-City")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	picks := strings.TrimSuffix(strings.Repeat("{{$randomFromFile ./cities.txt}}\n", 50), "\n")
+	httpContent := fmt.Sprintf("POST %s/cities\nContent-Type: text/plain\nX-City: {{$randomFromFile ./cities.txt}}\n\n%s",
+		server.URL, picks)
+	httpFile := filepath.Join(tempDir, "request.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(httpContent), 0644))
+
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.NoError(t, responses[0].Error)
+	allowed := []string{"Berlin", "New York, NY"}
+	assert.Contains(t, allowed, receivedCity)
+	lines := strings.Split(receivedBody, "\n")
+	require.Len(t, lines, 50)
+	for _, line := range lines {
+		assert.Contains(t, allowed, line, "Only weighted, non-comment values should be picked")
+	}
+
+	// Given a reference to a file that does not exist
+	missingFile := filepath.Join(tempDir, "missing.http")
+	require.NoError(t, os.WriteFile(missingFile,
+		[]byte("POST http://localhost:1/unreachable\n\n{{$randomFromFile ./nope.txt}}"), 0644))
+
+	// When
+	_, err = client.ExecuteFile(context.Background(), missingFile)
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read file for $randomFromFile ./nope.txt")
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.39 - Client Core Execution: Running Named Requests
+// Corresponds to: Client.ExecuteFileRequest(ctx, path, name) and Client.ExecuteFileRequests(ctx, path,
+// names...), which run selected requests of a file by their "# @name" or "### name".
+// This test verifies that only the named requests are sent, in file order, and that unknown, empty and
+// ambiguous names fail before anything is sent.
+func RunExecuteFileRequest_ByName(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var paths []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	content := fmt.Sprintf("### createUser\nPOST %[1]s/users\n\n###\n# @name getUser\nGET %[1]s/users/1\n\n"+
+		"### listUsers\nGET %[1]s/users\n\n### dup\nGET %[1]s/dup/1\n\n### dup\nGET %[1]s/dup/2\n", server.URL)
+	httpFile := filepath.Join(t.TempDir(), "users.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	resp, err := client.ExecuteFileRequest(ctx, httpFile, "getUser")
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, "getUser", resp.Request.Name)
+	assert.Equal(t, []string{"/users/1"}, paths)
+
+	// When
+	responses, err := client.ExecuteFileRequests(ctx, httpFile, "listUsers", "createUser", "dup")
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 4)
+	assert.Equal(t, []string{"/users/1", "/users", "/users", "/dup/1", "/dup/2"}, paths)
+	assert.Equal(t, http.MethodPost, responses[0].Request.Method, "responses follow the order of the file")
+
+	// When
+	_, unknownErr := client.ExecuteFileRequests(ctx, httpFile, "getUser", "missing", "")
+	_, ambiguousErr := client.ExecuteFileRequest(ctx, httpFile, "dup")
+	_, noNamesErr := client.ExecuteFileRequests(ctx, httpFile)
+
+	// Then
+	require.Error(t, unknownErr)
+	assert.Contains(t, unknownErr.Error(), `no request named "missing"`)
+	assert.Contains(t, unknownErr.Error(), "request name must not be empty")
+	require.Error(t, ambiguousErr)
+	assert.Contains(t, ambiguousErr.Error(), `request name "dup" is used by 2 requests`)
+	require.Error(t, noNamesErr)
+	requestErrors := rc.RequestErrors(unknownErr)
+	require.Len(t, requestErrors, 1)
+	assert.Equal(t, rc.PhaseParse, requestErrors[0].Phase)
+	assert.Len(t, paths, 5, "nothing is sent when a name is not found")
+}
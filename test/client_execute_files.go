@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.35 - Client Core Execution: Multi-File Scenarios
+// Corresponds to: client.ExecuteFiles(ctx, paths...), which runs several request files in order as
+// one scenario sharing captured variables and cookies, and returns the results per file.
+// This test verifies that a provisioning file's captured ID and session cookie reach the test and
+// cleanup files, that a failing file does not stop the scenario, and that results and errors are
+// reported per file.
+func RunExecuteFiles(t *testing.T) {
+	t.Helper()
+	// Given
+	var calls []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		session := ""
+		if cookie, err := r.Cookie("session"); err == nil {
+			session = cookie.Value
+		}
+		calls = append(calls, fmt.Sprintf("%s %s session=%s", r.Method, r.URL.Path, session))
+		if r.Method == http.MethodPost {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/"})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "item-7"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	provision := writeFile("provision.http",
+		fmt.Sprintf("# @capture itemId = body.$.id\nPOST %s/items\n", server.URL))
+	exercise := writeFile("test.http",
+		fmt.Sprintf("GET %s/items/{{itemId}}\n\n###\nGET http://127.0.0.1:1/unreachable\n", server.URL))
+	cleanup := writeFile("cleanup.http", fmt.Sprintf("DELETE %s/items/{{itemId}}\n", server.URL))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	results, execErr := client.ExecuteFiles(context.Background(), provision, exercise, cleanup)
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), exercise+": ")
+	require.Len(t, results, 3)
+	assert.Equal(t, []string{provision, exercise, cleanup},
+		[]string{results[0].Path, results[1].Path, results[2].Path})
+	assert.NoError(t, results[0].Err)
+	require.Error(t, results[1].Err)
+	require.Len(t, rc.RequestErrors(results[1].Err), 1)
+	assert.Equal(t, 1, rc.RequestErrors(results[1].Err)[0].Index)
+	assert.NoError(t, results[2].Err)
+	assert.Len(t, results[1].Responses, 2)
+	assert.Equal(t, []string{
+		"POST /items session=",
+		"GET /items/item-7 session=s1",
+		"DELETE /items/item-7 session=s1",
+	}, calls)
+
+	// When the files run one by one
+	calls = nil
+	_, err = client.ExecuteFile(context.Background(), provision)
+	require.NoError(t, err)
+	_, err = client.ExecuteFile(context.Background(), cleanup)
+
+	// Then neither variables nor cookies are shared
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /items session=", "DELETE /items/ session="}, calls)
+}
@@ -0,0 +1,192 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	shortUUIDPattern = `^[1-9A-HJ-NP-Za-km-z]{22}$`
+	slugPattern      = `^[a-z0-9]+(-[a-z0-9]+)*$`
+)
+
+// PRD-COMMENT: G5 Phase 2 - Compact Identifier Generators: {{$shortUuid}} and {{$randomSlug N}}
+// Corresponds to: Client's ability to generate base58-encoded UUIDs and URL-safe slugs of a
+// configurable length for APIs that reject 36-character UUIDs or enforce slug formats.
+// This test uses 'test/data/system_variables/identifier_generators.http' to verify the format
+// and length of generated identifiers in the URL, headers, body and file-scoped variables.
+func RunExecuteFile_WithIdentifierGenerators(t *testing.T) {
+	t.Helper()
+	// Given
+	var interceptedPath string
+	var interceptedHeaders http.Header
+	var interceptedBody string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		interceptedPath = r.URL.Path
+		interceptedHeaders = r.Header.Clone()
+		bodyBytes, _ := io.ReadAll(r.Body)
+		interceptedBody = string(bodyBytes)
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "ok")
+	})
+	defer server.Close()
+
+	client, _ := rc.NewClient()
+	requestFilePath := createTestFileFromTemplate(t, "test/data/system_variables/identifier_generators.http",
+		struct{ ServerURL string }{ServerURL: server.URL})
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), requestFilePath)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.NoError(t, responses[0].Error)
+
+	pathID := strings.TrimPrefix(interceptedPath, "/articles/")
+	assert.Regexp(t, shortUUIDPattern, pathID, "Short UUID in URL should be 22 base58 characters")
+
+	headerID := interceptedHeaders.Get("X-Short-Id")
+	assert.Regexp(t, shortUUIDPattern, headerID)
+	assert.NotEqual(t, pathID, headerID, "Each {{$shortUuid}} occurrence should be unique")
+
+	slug := interceptedHeaders.Get("X-Slug")
+	assert.Len(t, slug, 8, "Default slug length should be 8")
+	assert.Regexp(t, slugPattern, slug)
+
+	fileSlug := interceptedHeaders.Get("X-File-Slug")
+	assert.Len(t, fileSlug, 12, "File-scoped slug should honour its length argument")
+	assert.Regexp(t, slugPattern, fileSlug)
+
+	var bodyJSON map[string]string
+	require.NoError(t, json.Unmarshal([]byte(interceptedBody), &bodyJSON), "Body: %s", interceptedBody)
+	assert.Regexp(t, shortUUIDPattern, bodyJSON["shortId"])
+	assert.Len(t, bodyJSON["slug"], 20)
+	assert.Regexp(t, slugPattern, bodyJSON["slug"])
+	assert.Len(t, bodyJSON["tiny"], 3)
+	assert.Regexp(t, `^[a-z0-9]{3}$`, bodyJSON["tiny"])
+}
+
+// PRD-COMMENT: G5 Phase 2 - Lorem Ipsum Faker Variables with Size Control
+// Corresponds to: Client's ability to substitute {{$randomLoremWord}}, {{$randomLoremSentence N}}
+// and {{$randomLoremParagraphs N}} to fill text fields of a chosen length.
+// This test uses 'test/data/system_variables/faker_lorem_text.http' to verify word counts,
+// sentence shape and paragraph counts in headers, JSON bodies and plain text bodies.
+func RunExecuteFile_WithLoremFakerData(t *testing.T) {
+	t.Helper()
+	// Given
+	var interceptedHeaders []http.Header
+	var interceptedBodies []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		interceptedHeaders = append(interceptedHeaders, r.Header.Clone())
+		bodyBytes, _ := io.ReadAll(r.Body)
+		interceptedBodies = append(interceptedBodies, string(bodyBytes))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	client, _ := rc.NewClient()
+	requestFilePath := createTestFileFromTemplate(t, "test/data/system_variables/faker_lorem_text.http",
+		struct{ ServerURL string }{ServerURL: server.URL})
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), requestFilePath)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	require.Len(t, interceptedBodies, 2)
+
+	tag := interceptedHeaders[0].Get("X-Tag")
+	assert.Regexp(t, `^[a-z]+$`, tag, "Lorem word should be a single lowercase word")
+	assertLoremSentence(t, interceptedHeaders[0].Get("X-Summary"), 5)
+
+	var bodyJSON map[string]string
+	require.NoError(t, json.Unmarshal([]byte(interceptedBodies[0]), &bodyJSON), "Body: %s", interceptedBodies[0])
+	assertLoremSentence(t, bodyJSON["title"], 8)
+	assertLoremSentence(t, bodyJSON["excerpt"], 40)
+
+	paragraphs := strings.Split(strings.TrimSpace(interceptedBodies[1]), "\n\n")
+	require.Len(t, paragraphs, 3, "Body: %s", interceptedBodies[1])
+	for _, paragraph := range paragraphs {
+		assert.Regexp(t, `^([A-Z][a-z]*( [a-z]+)*\. ?)+$`, paragraph)
+	}
+}
+
+// assertLoremSentence checks that sentence is capitalised, ends with a period and has wordCount words
+func assertLoremSentence(t *testing.T, sentence string, wordCount int) {
+	t.Helper()
+	assert.NotContains(t, sentence, "{{", "Sentence should not contain placeholder")
+	assert.Regexp(t, `^[A-Z][a-z]*( [a-z]+)*\.$`, sentence)
+	assert.Len(t, strings.Fields(sentence), wordCount, "Sentence: %s", sentence)
+}
+
+// PRD-COMMENT: G5 Phase 2 - Geo Coordinate Faker Variables
+// Corresponds to: Client's ability to substitute {{$randomLatitude}}, {{$randomLongitude}} and
+// {{$randomGeoPoint "minLon,minLat,maxLon,maxLat"}} for location-based API testing.
+// This test uses 'test/data/system_variables/faker_geo_data.http' to verify coordinate ranges,
+// that bounded points stay inside their bounding box, and that a malformed box is left untouched.
+func RunExecuteFile_WithGeoFakerData(t *testing.T) {
+	t.Helper()
+	// Given
+	var interceptedRequest *http.Request
+	var interceptedBody string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		interceptedRequest = r.Clone(context.Background())
+		bodyBytes, _ := io.ReadAll(r.Body)
+		interceptedBody = string(bodyBytes)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	client, _ := rc.NewClient()
+	requestFilePath := createTestFileFromTemplate(t, "test/data/system_variables/faker_geo_data.http",
+		struct{ ServerURL string }{ServerURL: server.URL})
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), requestFilePath)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.NoError(t, responses[0].Error)
+
+	assertCoordinateInRange(t, interceptedRequest.Header.Get("X-Latitude"), -90, 90)
+	assertCoordinateInRange(t, interceptedRequest.Header.Get("X-Longitude"), -180, 180)
+	assertGeoPointInBox(t, interceptedRequest.URL.Query().Get("near"), 13.08, 52.33, 13.76, 52.67)
+
+	var bodyJSON map[string]string
+	require.NoError(t, json.Unmarshal([]byte(interceptedBody), &bodyJSON), "Body: %s", interceptedBody)
+	assertGeoPointInBox(t, bodyJSON["anywhere"], -180, -90, 180, 90)
+	assertGeoPointInBox(t, bodyJSON["berlin"], 13.08, 52.33, 13.76, 52.67)
+	assert.Equal(t, `{{$randomGeoPoint "1,2,3"}}`, interceptedRequest.Header.Get("X-Invalid"),
+		"Malformed bbox should remain as placeholder")
+}
+
+// assertCoordinateInRange parses value as a float and checks it lies in [minVal, maxVal]
+func assertCoordinateInRange(t *testing.T, value string, minVal, maxVal float64) {
+	t.Helper()
+	coord, err := strconv.ParseFloat(value, 64)
+	require.NoError(t, err, "Coordinate should be a number: %q", value)
+	assert.GreaterOrEqual(t, coord, minVal)
+	assert.LessOrEqual(t, coord, maxVal)
+}
+
+// assertGeoPointInBox checks that a "lat,lon" point lies inside the given bounding box
+func assertGeoPointInBox(t *testing.T, point string, minLon, minLat, maxLon, maxLat float64) {
+	t.Helper()
+	parts := strings.Split(point, ",")
+	require.Len(t, parts, 2, "Geo point should be 'lat,lon': %q", point)
+	assertCoordinateInRange(t, parts[0], minLat, maxLat)
+	assertCoordinateInRange(t, parts[1], minLon, maxLon)
+}
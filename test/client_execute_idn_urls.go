@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR1.4 - Request URLs: Internationalized Domains and Non-ASCII Characters
+// Corresponds to: Client's WHATWG-style URL normalization after variable substitution.
+// This test verifies that internationalized host names are sent as punycode, with the port and
+// userinfo kept, and that existing percent-escapes and ASCII hosts are left unchanged.
+func RunExecuteFile_InternationalizedDomainNames(t *testing.T) {
+	t.Helper()
+	// Given
+	var hosts []string
+	mockTransport := &mockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Host+" "+req.URL.EscapedPath())
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+	content := "@host = Bücher.example\n\nGET https://{{host}}/a%2Fb\n\n###\n" +
+		"GET http://user@münchen.example:8080/straße\n\n###\nGET https://例え.テスト/\n\n###\n" +
+		"GET https://Plain.Example.com/ok\n"
+	httpFile := filepath.Join(t.TempDir(), "idn.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 4)
+	for _, resp := range responses {
+		require.NoError(t, resp.Error)
+	}
+	assert.Equal(t, []string{
+		"xn--bcher-kva.example /a%2Fb",
+		"xn--mnchen-3ya.example:8080 /stra%C3%9Fe",
+		"xn--r8jz45g.xn--zckzah /",
+		"Plain.Example.com /ok",
+	}, hosts)
+	assert.Equal(t, "user", responses[1].Request.URL.User.Username())
+}
+
+// PRD-COMMENT: FR1.4 - Request URLs: Internationalized Domains and Non-ASCII Characters
+// Corresponds to: Client's percent-encoding of characters not allowed in URLs, per WHATWG rules.
+// This test verifies that non-ASCII characters, spaces and quotes in the path and query, and a
+// stray '%', produce a valid request instead of a url.Parse error or a malformed request line.
+func RunExecuteFile_NonASCIIPathAndQuery(t *testing.T) {
+	t.Helper()
+	// Given
+	var gotPath, gotRawQuery string
+	var gotItem, gotDiscount string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotRawQuery = r.URL.RawQuery
+		gotItem = r.URL.Query().Get("item")
+		gotDiscount = r.URL.Query().Get("discount")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	content := fmt.Sprintf("@dish = crème brûlée\n\nGET %s/café/menü?item={{dish}}&note=\"hi\"&discount=10%%\n",
+		server.URL)
+	httpFile := filepath.Join(t.TempDir(), "non_ascii.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	require.NoError(t, responses[0].Error)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Equal(t, "/café/menü", gotPath)
+	assert.Equal(t, "crème brûlée", gotItem)
+	assert.Equal(t, "10%", gotDiscount)
+	assert.Equal(t, "item=cr%C3%A8me%20br%C3%BBl%C3%A9e&note=%22hi%22&discount=10%25", gotRawQuery)
+}
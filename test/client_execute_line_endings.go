@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineEndingTemplate is a two-request file written with "\n"; the test rewrites its endings
+const lineEndingTemplate = "@token = abc\n\n### First\nPOST {{baseUrl}}/first\nX-Token: {{token}}\n" +
+	"Content-Type: text/plain\n\nline one\nline two\n\n###\nGET {{baseUrl}}/second\nAccept: text/plain\n"
+
+// PRD-COMMENT: FR1.6 - Parsing: Line Endings and Byte Order Marks
+// Corresponds to: The parser's handling of files authored on Windows (CRLF, UTF-8 BOM), with
+// classic Mac endings (CR) or mixed endings, for both .http and .hresp files.
+// This test verifies that every style yields the same requests: separators are detected, header
+// values carry no stray carriage returns and bodies keep their line boundaries.
+func RunExecuteFile_LineEndingStyles(t *testing.T) {
+	t.Helper()
+	// Given
+	type captured struct{ path, token, accept, body string }
+	var mu sync.Mutex
+	var requests []captured
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, captured{r.URL.Path, r.Header.Get("X-Token"), r.Header.Get("Accept"), string(body)})
+		mu.Unlock()
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	styles := map[string]func(string) string{
+		"LF":       func(s string) string { return s },
+		"CRLF":     func(s string) string { return strings.ReplaceAll(s, "\n", "\r\n") },
+		"CRLF+BOM": func(s string) string { return "\uFEFF" + strings.ReplaceAll(s, "\n", "\r\n") },
+		"CR":       func(s string) string { return strings.ReplaceAll(s, "\n", "\r") },
+		"mixed":    mixLineEndings,
+	}
+	client, err := rc.NewClient(rc.WithVars(map[string]any{"baseUrl": server.URL}))
+	require.NoError(t, err)
+
+	for name, convert := range styles {
+		t.Run(name, func(t *testing.T) {
+			requests = nil
+			dir := t.TempDir()
+			httpFile := filepath.Join(dir, "requests.http")
+			require.NoError(t, os.WriteFile(httpFile, []byte(convert(lineEndingTemplate)), 0644))
+			hrespFile := filepath.Join(dir, "expected.hresp")
+			hresp := "HTTP/1.1 200 OK\n\nok\n\n###\nHTTP/1.1 200 OK\n\nok\n"
+			require.NoError(t, os.WriteFile(hrespFile, []byte(convert(hresp)), 0644))
+
+			// When
+			responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+			// Then
+			require.NoError(t, err)
+			require.Len(t, responses, 2, "separator must be detected")
+			require.Len(t, requests, 2)
+			assert.Equal(t, captured{"/first", "abc", "", "line one\nline two"}, requests[0])
+			assert.Equal(t, captured{"/second", "", "text/plain", ""}, requests[1])
+			assert.Equal(t, "First", responses[0].Request.Name)
+			require.NoError(t, client.ValidateResponses(hrespFile, responses...))
+		})
+	}
+}
+
+// mixLineEndings alternates LF, CRLF and CR endings line by line. A CR directly followed by an
+// LF-terminated empty line would read as a single CRLF, so CRLF is used there instead.
+func mixLineEndings(s string) string {
+	endings := []string{"\n", "\r\n", "\r"}
+	lines := strings.Split(s, "\n")
+	var sb strings.Builder
+	for i, line := range lines {
+		sb.WriteString(line)
+		if i == len(lines)-1 {
+			break
+		}
+		ending := endings[i%len(endings)]
+		if ending == "\r" && lines[i+1] == "" {
+			ending = "\r\n"
+		}
+		sb.WriteString(ending)
+	}
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR2.3 - Request Headers: Multi-Value and Folded Headers
+// Corresponds to: Client's handling of repeated and folded (indented continuation) header lines in .http
+// and .hresp files, and validation of individual values of multi-value response headers.
+// This test verifies that repeated request headers are sent as multiple values, repeated Cookie lines are
+// combined with jar cookies, folded values are unfolded, and each response header value is assertable,
+// with only list headers such as Vary matched element by element.
+func RunExecuteFile_MultiValueAndFoldedHeaders(t *testing.T) {
+	t.Helper()
+	// Given
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Add("Link", `<https://example.com/2>; rel="next"`)
+		w.Header().Add("Link", `<https://example.com/1>; rel="prev"`)
+		w.Header().Set("Vary", "Accept, Origin")
+		w.Header().Set("X-Description", "part one part two")
+		w.Header().Set("X-Note", "one, two")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	content := fmt.Sprintf("GET %s/multi\nAccept: application/json\nAccept: text/plain\n"+
+		"Cookie: a=1\nCookie: b=2\nX-Folded: first part\n  second part\n\tthird part\n", server.URL)
+	httpFile := filepath.Join(dir, "multi.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	jar.SetCookies(serverURL, []*http.Cookie{{Name: "c", Value: "3"}})
+	client, err := rc.NewClient(rc.WithHTTPClient(&http.Client{Jar: jar}))
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	require.NoError(t, responses[0].Error)
+	assert.Equal(t, []string{"application/json", "text/plain"}, received.Values("Accept"))
+	cookieReq := &http.Request{Header: received}
+	for _, name := range []string{"a", "b", "c"} {
+		_, cookieErr := cookieReq.Cookie(name)
+		assert.NoError(t, cookieErr, "cookie %s must be sent", name)
+	}
+	assert.Equal(t, "first part second part third part", received.Get("X-Folded"))
+	assert.Equal(t, []string{"a=1", "b=2"}, responses[0].Request.Headers.Values("Cookie"))
+	assert.Len(t, responses[0].Headers.Values("Link"), 2, "repeated response headers must be preserved")
+
+	hrespFile := filepath.Join(dir, "multi.hresp")
+	hresp := "HTTP/1.1 200 OK\nLink: <https://example.com/1>; rel=\"prev\"\n" +
+		"Link: <https://example.com/2>; rel=\"next\"\nVary: Origin\nX-Description: part one\n  part two\n" +
+		"X-Note: one, two\n\n{{$any}}\n"
+	require.NoError(t, os.WriteFile(hrespFile, []byte(hresp), 0644))
+	require.NoError(t, client.ValidateResponses(hrespFile, responses...))
+
+	mismatchFile := filepath.Join(dir, "mismatch.hresp")
+	mismatch := "HTTP/1.1 200 OK\nVary: Cookie\nX-Note: one\n\n{{$any}}\n"
+	require.NoError(t, os.WriteFile(mismatchFile, []byte(mismatch), 0644))
+	err = client.ValidateResponses(mismatchFile, responses...)
+	assertMultierrorContains(t, err, 2, []string{
+		"expected value 'Cookie' for header 'Vary' not found",
+		"expected value 'one' for header 'X-Note' not found",
+	})
+}
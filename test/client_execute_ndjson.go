@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR4.7 - Request/Response Bodies: NDJSON
+// Corresponds to: application/x-ndjson request bodies, substituted line by line with fresh system
+// variables per record, and line-wise validation of NDJSON responses in .hresp files with per-line
+// placeholders and the '# ndjson-lines' count directive.
+// This test verifies the records sent for a bulk request and the validation of a streamed response.
+func RunExecuteFile_WithNDJSON(t *testing.T) {
+	t.Helper()
+	// Given
+	var sentBody string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sentBody = string(body)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = fmt.Fprint(w, "{\"index\": 1, \"id\": \"a1\"}\n\n{\"index\": 2, \"id\": \"b2\"}\n")
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "bulk.http")
+	content := fmt.Sprintf(`@tenant = acme
+POST %s/bulk
+Content-Type: application/x-ndjson
+
+{"tenant": "{{tenant}}", "id": "{{$uuid}}"}
+
+{"tenant": "{{tenant}}", "id": "{{$uuid}}"}
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	require.True(t, strings.HasSuffix(sentBody, "}\n"), "records should be newline-terminated: %q", sentBody)
+	lines := strings.Split(strings.TrimSuffix(sentBody, "\n"), "\n")
+	require.Len(t, lines, 2, "blank lines should be dropped: %q", sentBody)
+	assert.Contains(t, lines[0], `"tenant": "acme"`)
+	assert.NotEqual(t, lines[0], lines[1], "each record should get its own {{$uuid}}")
+
+	// When validating line by line
+	matching := filepath.Join(dir, "matching.hresp")
+	require.NoError(t, os.WriteFile(matching, []byte("HTTP/1.1 200 OK\n# ndjson-lines 2\n\n"+
+		"{\"index\": 1, \"id\": \"{{$any}}\"}\n{\"id\": \"b2\", \"index\": 2}\n"), 0644))
+	mismatching := filepath.Join(dir, "mismatching.hresp")
+	require.NoError(t, os.WriteFile(mismatching, []byte("HTTP/1.1 200 OK\n# ndjson-lines 3\n\n"+
+		"{\"index\": 1, \"id\": \"a1\"}\n{\"index\": 3, \"id\": \"b2\"}\n"), 0644))
+
+	// Then
+	assert.NoError(t, client.ValidateResponses(matching, responses...))
+	validationErr := client.ValidateResponses(mismatching, responses...)
+	require.Error(t, validationErr)
+	assert.Contains(t, validationErr.Error(), "NDJSON line count mismatch: expected 3, got 2")
+	assert.Contains(t, validationErr.Error(), "NDJSON line 2:")
+	assert.NotContains(t, validationErr.Error(), "NDJSON line 1:")
+}
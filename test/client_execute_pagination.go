@@ -0,0 +1,122 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR2.7 - Request Settings: Pagination
+// Corresponds to: The '# @paginate link-header|cursor [max=N] [items=<jsonpath>] [path=<jsonpath>]
+// [param=<name>]' directive following paginated responses and combining their items into one JSON
+// array body, with the individual pages in Response.Pages.
+// This test verifies Link header and cursor pagination, the max= page limit and validation of the
+// combined body against an .hresp file.
+func RunExecuteFile_WithPagination(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/items":
+			page := r.URL.Query().Get("page")
+			if page == "" {
+				page = "1"
+			}
+			if page != "3" {
+				next := map[string]string{"1": "2", "2": "3"}[page]
+				w.Header().Set("Link", fmt.Sprintf(`</items?page=1>; rel="first", </items?page=%s>; rel="next"`, next))
+			}
+			_, _ = fmt.Fprintf(w, `[{"page": %s}]`, page)
+		case "/cursor":
+			switch r.URL.Query().Get("after") {
+			case "":
+				_, _ = w.Write([]byte(`{"data": ["a", "b"], "meta": {"next": "c1"}}`))
+			case "c1":
+				_, _ = w.Write([]byte(`{"data": ["c"], "meta": {"next": null}}`))
+			}
+		}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "pages.http")
+	content := fmt.Sprintf(`# @paginate link-header
+GET %[1]s/items
+
+###
+# @paginate link-header max=2
+GET %[1]s/items
+
+###
+# @paginate cursor path=$.meta.next param=after items=$.data
+GET %[1]s/cursor?limit=2
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	hrespFile := filepath.Join(dir, "pages.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte(
+		"HTTP/1.1 200 OK\n\n[{\"page\":1},{\"page\":2},{\"page\":3}]\n\n###\n\n"+
+			"HTTP/1.1 200 OK\n\n[{\"page\":1},{\"page\":2}]\n\n###\n\nHTTP/1.1 200 OK\n\n[\"a\",\"b\",\"c\"]\n"), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 3)
+	require.Len(t, responses[0].Pages, 3)
+	assert.Equal(t, server.URL+"/items?page=3", responses[0].FinalURL)
+	assert.Len(t, responses[1].Pages, 2, "max= limits the number of pages")
+	require.Len(t, responses[2].Pages, 2)
+	assert.Equal(t, "/cursor?after=c1&limit=2", responses[2].Pages[1].Request.URL.RequestURI())
+	assert.NoError(t, client.ValidateResponses(hrespFile, responses...))
+}
+
+// PRD-COMMENT: FR2.7 - Request Settings: Pagination Across Hosts
+// Corresponds to: Link header pagination with a rel="next" URL on another host.
+// This test verifies that such a page is not requested, so the Authorization and API key headers of the
+// request do not reach the other host, and that pagination stops with an error.
+func RunExecuteFile_PaginationCrossHost(t *testing.T) {
+	t.Helper()
+	// Given
+	var otherHostCalls int
+	otherHost := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		otherHostCalls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"page": 2}]`))
+	})
+	defer otherHost.Close()
+	server := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=2>; rel="next"`, otherHost.URL))
+		_, _ = w.Write([]byte(`[{"page": 1}]`))
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "pages.http")
+	content := fmt.Sprintf("# @paginate link-header\nGET %s/items\nAuthorization: Bearer s3cr3t\n"+
+		"X-Api-Key: k3y\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, _ := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 1)
+	assert.Zero(t, otherHostCalls, "the page on the other host is not requested")
+	assert.Len(t, responses[0].Pages, 1)
+	require.Error(t, responses[0].Error)
+	assert.Contains(t, responses[0].Error.Error(), "page 1: next page "+otherHost.URL+"/items?page=2 is not on host")
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR2.8 - Request Settings: Polling
+// Corresponds to: The '# @poll every=<d> timeout=<d> until=<condition>' directive sending a request
+// again until a condition on its response holds (status, header.<Name>, body.$<jsonpath> operands
+// with the placeholder expression operators), covering asynchronous job APIs.
+// This test verifies polling until a JSON field is READY with the body re-sent on every attempt, and
+// that a condition never met fails the request after the timeout.
+func RunExecuteFile_WithPollDirective(t *testing.T) {
+	t.Helper()
+	// Given
+	var jobAttempts, jobBodies atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if body, _ := io.ReadAll(r.Body); string(body) == `{"id": 7}` {
+			jobBodies.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		status := "PENDING"
+		if r.URL.Path == "/jobs" && jobAttempts.Add(1) >= 3 {
+			status = "READY"
+		}
+		_, _ = fmt.Fprintf(w, `{"job": {"status": %q}}`, status)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "poll.http")
+	content := fmt.Sprintf(`# @poll every=10ms timeout=2s until=body.$.job.status == "READY" && status == 200
+POST %[1]s/jobs
+
+{"id": 7}
+
+###
+# @poll every=10ms timeout=35ms until=body.$.job.status == 'READY'
+GET %[1]s/stuck
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 2)
+	assert.NoError(t, responses[0].Error)
+	assert.Equal(t, 3, responses[0].Attempts)
+	assert.JSONEq(t, `{"job": {"status": "READY"}}`, responses[0].BodyString)
+	require.Error(t, execErr)
+	assert.ErrorContains(t, responses[1].Error, "condition 'body.$.job.status == 'READY'' not met after")
+	assert.GreaterOrEqual(t, responses[1].Attempts, 2)
+	assert.Equal(t, int32(3), jobBodies.Load(), "the body is sent with every attempt")
+}
@@ -0,0 +1,276 @@
+package test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR2.5 - Request Settings: Per-Request Proxy
+// Corresponds to: The '# @proxy <url>' directive routing a single request through a proxy (e.g. a
+// debugging proxy such as mitmproxy) while the other requests of the file go direct.
+// This test verifies the proxied request reaches the proxy with its absolute target URL, that the proxy
+// URL may use variables, that other requests bypass it, and that an invalid proxy URL fails the request.
+func RunExecuteFile_WithProxyDirective(t *testing.T) {
+	t.Helper()
+	// Given
+	var proxiedURI string
+	proxy := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURI = r.RequestURI
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer proxy.Close()
+	var directPaths []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		directPaths = append(directPaths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "proxy.http")
+	content := fmt.Sprintf(`@proxyUrl = %s
+
+# @proxy {{proxyUrl}}
+GET http://api.example.invalid/items?page=1
+
+###
+
+GET %s/direct
+
+###
+
+# @proxy not a url
+GET %s/broken
+`, proxy.URL, server.URL, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 3)
+	assert.Equal(t, http.StatusAccepted, responses[0].StatusCode)
+	assert.Equal(t, "http://api.example.invalid/items?page=1", proxiedURI)
+	assert.Equal(t, http.StatusOK, responses[1].StatusCode)
+	assert.Equal(t, []string{"/direct"}, directPaths, "only the request without @proxy should go direct")
+	require.Error(t, execErr)
+	assert.ErrorContains(t, responses[2].Error, `invalid @proxy URL "not a url"`)
+}
+
+// PRD-COMMENT: FR2.5 - Request Settings: Per-Request Proxy
+// Corresponds to: The transports the client keeps for the proxies of '# @proxy <url>' directives.
+// This test verifies that a file with more distinct proxies than the client keeps transports for closes
+// the idle connections of the oldest proxy transport, while every request still reaches its proxy.
+func RunExecuteFile_WithManyProxies(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var closed, proxied int
+	proxy := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		proxied++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	proxy.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			mu.Lock()
+			closed++
+			mu.Unlock()
+		}
+	}
+	proxy.Start()
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	var content strings.Builder
+	for i := 0; i < 17; i++ {
+		fmt.Fprintf(&content, "###\n# @proxy http://user%d@%s\nGET http://api.example.invalid/%d\n\n", i, proxyURL.Host, i)
+	}
+	httpFile := filepath.Join(t.TempDir(), "proxies.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content.String()), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	_, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return proxied == 17 && closed >= 1
+	}, 2*time.Second, 10*time.Millisecond, "the oldest proxy transport's idle connection is closed")
+}
+
+// PRD-COMMENT: FR2.10 - Request Settings: Client Proxy and @no-proxy
+// Corresponds to: The WithProxy client option with http:// and socks5:// proxy URLs, the NO_PROXY environment
+// variable, and the '# @no-proxy' directive sending a request directly.
+// This test verifies that requests go through the client's proxy, that NO_PROXY hosts and @no-proxy requests
+// bypass it, that SOCKS5 proxies receive the target host, and that unsupported proxy schemes are rejected.
+func RunExecuteFile_WithClientProxy(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var proxiedURIs []string
+	proxy := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		proxiedURIs = append(proxiedURIs, r.RequestURI)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer proxy.Close()
+	t.Setenv("NO_PROXY", "internal.example.invalid")
+	t.Setenv("no_proxy", "")
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "proxy.http")
+	content := "GET http://api.example.invalid/items\n\n" +
+		"###\n# @no-proxy\nGET http://direct.example.invalid/items\n\n" +
+		"###\nGET http://internal.example.invalid/items\n"
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithProxy(proxy.URL))
+	require.NoError(t, err)
+
+	// When
+	responses, _ := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 3)
+	assert.Equal(t, http.StatusAccepted, responses[0].StatusCode)
+	require.Error(t, responses[1].Error, "@no-proxy connects directly to the unresolvable host")
+	require.Error(t, responses[2].Error, "NO_PROXY hosts are connected directly")
+	assert.Equal(t, []string{"http://api.example.invalid/items"}, proxiedURIs)
+
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Host)
+	})
+	defer server.Close()
+	socksAddr, socksTargets := startSOCKS5Server(t, server.Listener.Addr().String())
+	socksFile := filepath.Join(dir, "socks.http")
+	require.NoError(t, os.WriteFile(socksFile, []byte("GET http://orders.example.invalid:8080/orders\n"), 0644))
+	socksClient, err := rc.NewClient(rc.WithProxy("socks5://" + socksAddr))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := socksClient.ExecuteFile(context.Background(), socksFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "orders.example.invalid:8080", responses[0].BodyString)
+	assert.Equal(t, []string{"orders.example.invalid:8080"}, socksTargets())
+
+	// When
+	_, err = rc.NewClient(rc.WithProxy("ftp://proxy.example.com"))
+
+	// Then
+	assert.ErrorContains(t, err, "unsupported scheme")
+}
+
+// startSOCKS5Server starts a SOCKS5 proxy without authentication that connects every CONNECT request to
+// target, returning its address and a function listing the requested host:port targets
+func startSOCKS5Server(t *testing.T, target string) (string, func() []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	var mu sync.Mutex
+	var requested []string
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				host, err := readSOCKS5Connect(conn)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				requested = append(requested, host)
+				mu.Unlock()
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer func() { _ = upstream.Close() }()
+				_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+				go func() { _, _ = io.Copy(upstream, conn) }()
+				_, _ = io.Copy(conn, upstream)
+			}()
+		}
+	}()
+	return listener.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requested...)
+	}
+}
+
+// readSOCKS5Connect performs the SOCKS5 greeting and reads a CONNECT request, returning its host:port
+func readSOCKS5Connect(conn net.Conn) (string, error) {
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, greeting[1])); err != nil {
+		return "", err
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return "", err
+	}
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	var host string
+	switch header[3] {
+	case 1, 4: // IPv4, IPv6
+		ip := make([]byte, map[byte]int{1: net.IPv4len, 4: net.IPv6len}[header[3]])
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", err
+		}
+		host = net.IP(ip).String()
+	case 3: // domain name
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return "", err
+		}
+		name := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return "", err
+		}
+		host = string(name)
+	default:
+		return "", fmt.Errorf("unsupported address type %d", header[3])
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR1.9 - Request Line: Query Parameter Block
+// Corresponds to: '? key: value' and '& key: value' lines below the request line declaring query parameters,
+// percent-encoded (including substituted variable values) and appended to the URL's own query, with
+// repeated keys producing multi-value parameters.
+// This test verifies the query received by the server, that headers after the block still parse and that
+// indented headers directly below the request line stay headers.
+func RunExecuteFile_WithQueryParameterBlock(t *testing.T) {
+	t.Helper()
+	// Given
+	var rawQueries []string
+	var acceptHeaders []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		rawQueries = append(rawQueries, r.URL.RawQuery)
+		acceptHeaders = append(acceptHeaders, r.Header.Get("Accept"))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "query.http")
+	content := fmt.Sprintf(`@search = rock & roll
+GET %[1]s/albums?sort=year
+    ? q: {{search}}
+    & tag: live
+    & tag: 1970s
+    & note: 50%% off
+Accept: application/json
+
+###
+GET %[1]s/albums
+    ?page=2
+    & limit: 10
+
+###
+GET %[1]s/albums
+    Accept: text/csv
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 3)
+	require.Len(t, rawQueries, 3)
+	assert.Equal(t, "sort=year&q=rock+%26+roll&tag=live&tag=1970s&note=50%25+off", rawQueries[0])
+	assert.Equal(t, "application/json", acceptHeaders[0])
+	assert.Equal(t, "page=2&limit=10", rawQueries[1])
+	assert.Empty(t, rawQueries[2], "indented headers are not query parameters")
+	assert.Equal(t, "text/csv", acceptHeaders[2])
+
+	parsed, err := client.RenderResolved(httpFile)
+	require.NoError(t, err)
+	assert.Contains(t, parsed, "q=rock+%26+roll&tag=live")
+}
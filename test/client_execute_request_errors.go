@@ -0,0 +1,107 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.10 - Client Core Execution: Structured Per-Request Errors
+// Corresponds to: Client's aggregate error from ExecuteFile and ValidateResponses, whose entries are
+// RequestErrors carrying the request index, name, phase and underlying cause.
+// This test verifies that substitution, send and validation failures are enumerated individually with
+// their phase, one entry per failed request, and that the established messages are kept. Every request
+// has a response, also when it could not be sent, carrying its failures and the location of its block.
+func RunExecuteFile_StructuredRequestErrors(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+	closedServer := startMockServer(func(http.ResponseWriter, *http.Request) {})
+	closedURL := closedServer.URL
+	closedServer.Close()
+
+	dir := t.TempDir()
+	content := fmt.Sprintf("# @name ok\nGET %[1]s/ok\n\n###\n# @name missingBody\nPOST %[1]s/body\n\n"+
+		"<@ ./nonexistent.json\n\n###\nGET %[2]s/down\n\n###\n# @name wrongStatus\nGET %[1]s/status\n",
+		server.URL, closedURL)
+	httpFile := filepath.Join(dir, "errors.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	assertMultierrorContains(t, err, 2, []string{
+		"error processing body for request",
+		fmt.Sprintf("request 3 (GET %s/down) processing resulted in error", closedURL),
+	})
+	requestErrors := rc.RequestErrors(err)
+	require.Len(t, requestErrors, 2)
+	assert.Equal(t, 1, requestErrors[0].Index)
+	assert.Equal(t, "missingBody", requestErrors[0].Name)
+	assert.Equal(t, rc.PhaseSubstitute, requestErrors[0].Phase)
+	assert.Equal(t, http.MethodPost, requestErrors[0].Method)
+	assert.Equal(t, 2, requestErrors[1].Index)
+	assert.Equal(t, rc.PhaseSend, requestErrors[1].Phase)
+	assert.Equal(t, closedURL+"/down", requestErrors[1].URL)
+	require.Error(t, requestErrors[1].Err)
+	assert.Equal(t, httpFile, requestErrors[0].FilePath)
+	assert.Equal(t, 5, requestErrors[0].Line)
+	require.Len(t, responses, 4, "every request has a response, also the one with an unreadable body")
+	assert.Empty(t, responses[0].Failures)
+	assert.Equal(t, "missingBody", responses[1].Request.Name)
+	require.Error(t, responses[1].Error)
+	assert.Equal(t, []*rc.RequestError{requestErrors[0]}, responses[1].Failures)
+	assert.Equal(t, []*rc.RequestError{requestErrors[1]}, responses[2].Failures)
+
+	// Given: expectations for the four responses, expecting 201 from "wrongStatus"
+	hrespFile := filepath.Join(dir, "errors.hresp")
+	hresp := "HTTP/1.1 200 OK\n\n###\nHTTP/1.1 200 OK\n\n###\nHTTP/1.1 200 OK\n\n###\nHTTP/1.1 201 Created\n"
+	require.NoError(t, os.WriteFile(hrespFile, []byte(hresp), 0644))
+
+	// When
+	err = client.ValidateResponses(hrespFile, responses...)
+
+	// Then
+	validationErrors := rc.RequestErrors(err)
+	require.NotEmpty(t, validationErrors)
+	last := validationErrors[len(validationErrors)-1]
+	assert.Equal(t, rc.PhaseValidate, last.Phase)
+	assert.Equal(t, 3, last.Index)
+	assert.Equal(t, "wrongStatus", last.Name)
+	assert.Contains(t, last.Error(), "validation for response #4")
+	assert.Contains(t, last.Error(), "expected '201 Created', got '200 OK'")
+}
+
+// PRD-COMMENT: FR10.10 - Client Core Execution: Structured Per-Request Errors
+// Corresponds to: Parse failures reported as a RequestError without a request (Index -1).
+// This test verifies the parse phase and that the underlying cause stays reachable with errors.Is.
+func RunExecuteFile_ParseErrorPhase(t *testing.T) {
+	t.Helper()
+	// Given
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	_, err = client.ExecuteFile(context.Background(), filepath.Join(t.TempDir(), "missing.http"))
+
+	// Then
+	requestErrors := rc.RequestErrors(err)
+	require.Len(t, requestErrors, 1)
+	assert.Equal(t, rc.PhaseParse, requestErrors[0].Phase)
+	assert.Equal(t, -1, requestErrors[0].Index)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "cause must be reachable: %v", err)
+}
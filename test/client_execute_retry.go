@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.41 - Client Core Execution: Request Timeout and Retry Directives
+// Corresponds to: The "# @timeout <ms|duration>", "# @retry <n>" and "# @retry-delay <duration>" request
+// directives, applied per request without changing client-wide settings.
+// This test verifies that a flaky request is retried with the given delay and reports its attempts, that
+// a slow request is bounded by a duration timeout, and that @retry-delay may precede @retry.
+func RunExecuteFile_WithTimeoutAndRetryDirectives(t *testing.T) {
+	t.Helper()
+	// Given
+	var flakyCalls atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			if flakyCalls.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/slow":
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	defer server.Close()
+
+	content := fmt.Sprintf("# @retry 3\n# @retry-delay 10ms\nGET %[1]s/flaky\n\n###\n"+
+		"# @timeout 50ms\nGET %[1]s/slow\n\n###\n# @retry-delay 5ms\n# @retry 1\nGET %[1]s/down\n", server.URL)
+	httpFile := filepath.Join(t.TempDir(), "flaky.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	start := time.Now()
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+	elapsed := time.Since(start)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 3)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Equal(t, 3, responses[0].Attempts)
+	require.Error(t, responses[1].Error, "the slow request exceeds its timeout")
+	assert.Equal(t, http.StatusServiceUnavailable, responses[2].StatusCode)
+	assert.Equal(t, 2, responses[2].Attempts)
+	assert.Less(t, elapsed, time.Second, "the delays of @retry-delay are used instead of the default")
+	requestErrors := rc.RequestErrors(execErr)
+	require.Len(t, requestErrors, 1)
+	assert.Equal(t, 1, requestErrors[0].Index)
+
+	// When
+	rendered, renderErr := client.RenderResolved(httpFile)
+
+	// Then
+	require.NoError(t, renderErr)
+	assert.Contains(t, rendered, "# @retry 3 delay=10ms\n")
+	assert.Contains(t, rendered, "# @timeout 50\n")
+	assert.Contains(t, rendered, "# @retry 1 delay=5ms\n")
+}
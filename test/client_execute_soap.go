@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR2.9 - Request Settings: SOAP
+// Corresponds to: The '# @soap [1.1|1.2] [action]' directive wrapping the request body in a SOAP
+// envelope, setting the content type and SOAP action of the version, and comparing the expected
+// .hresp body with the content of the response's SOAP Body.
+// This test verifies SOAP 1.1 and 1.2 requests as received by the server, that the SOAP 1.2 action is added
+// to an authored content type unless it has one, and the unwrapped validation.
+func RunExecuteFile_WithSOAPDirective(t *testing.T) {
+	t.Helper()
+	// Given
+	type received struct{ contentType, soapAction, body string }
+	var requests []received
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, received{r.Header.Get("Content-Type"), r.Header.Get("SOAPAction"), string(body)})
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		_, _ = fmt.Fprint(w, `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:o="urn:example:orders">
+  <soap:Body>
+    <o:GetOrderResponse>
+      <o:id>42</o:id>
+    </o:GetOrderResponse>
+  </soap:Body>
+</soap:Envelope>`)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "soap.http")
+	content := fmt.Sprintf(`@ns = urn:example:orders
+# @soap {{ns}}:GetOrder
+POST %[1]s/orders
+
+<o:GetOrder xmlns:o="{{ns}}">
+  <o:id>42</o:id>
+</o:GetOrder>
+
+###
+# @soap 1.2 urn:example:orders:GetOrder
+POST %[1]s/orders
+
+<o:GetOrder xmlns:o="urn:example:orders"/>
+
+###
+# @soap 1.2 urn:example:orders:GetOrder
+POST %[1]s/orders
+Content-Type: application/soap+xml
+
+<o:GetOrder xmlns:o="urn:example:orders"/>
+
+###
+# @soap 1.2 urn:example:orders:GetOrder
+POST %[1]s/orders
+Content-Type: application/soap+xml; action="urn:example:orders:Other"
+
+<o:GetOrder xmlns:o="urn:example:orders"/>
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 4)
+	require.Len(t, requests, 4)
+	assert.Equal(t, "text/xml; charset=utf-8", requests[0].contentType)
+	assert.Equal(t, `"urn:example:orders:GetOrder"`, requests[0].soapAction)
+	assert.Contains(t, requests[0].body, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">`)
+	assert.Contains(t, requests[0].body, "    <o:GetOrder xmlns:o=\"urn:example:orders\">\n      <o:id>42</o:id>")
+	assert.Equal(t, `application/soap+xml; charset=utf-8; action="urn:example:orders:GetOrder"`,
+		requests[1].contentType)
+	assert.Empty(t, requests[1].soapAction)
+	assert.Contains(t, requests[1].body, `xmlns:soap="http://www.w3.org/2003/05/soap-envelope"`)
+	assert.Equal(t, `application/soap+xml; action="urn:example:orders:GetOrder"`, requests[2].contentType)
+	assert.Equal(t, `application/soap+xml; action="urn:example:orders:Other"`, requests[3].contentType)
+
+	payload, err := responses[0].SOAPBody()
+	require.NoError(t, err)
+	assert.Equal(t, "<o:GetOrderResponse>\n  <o:id>42</o:id>\n</o:GetOrderResponse>", payload)
+
+	hrespFile := filepath.Join(dir, "soap.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte(`HTTP/1.1 200 OK
+
+<o:GetOrderResponse>
+  <o:id>{{$regexp `+"`\\d+`"+`}}</o:id>
+</o:GetOrderResponse>
+
+###
+HTTP/1.1 200 OK
+
+<o:GetOrderResponse>
+  <o:id>43</o:id>
+</o:GetOrderResponse>
+`), 0644))
+	validationErr := client.ValidateResponses(hrespFile, responses[:2]...)
+	require.Error(t, validationErr)
+	assert.NotContains(t, validationErr.Error(), "response #1")
+	assert.Contains(t, validationErr.Error(), "response #2")
+}
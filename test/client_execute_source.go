@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.52 - Client Core Execution: Executing Requests from Strings and Readers
+// Corresponds to: the ExecuteSource and ExecuteReader client methods.
+// This test verifies that requests held in a string or read from an io.Reader run like those of a file,
+// with per-call variables, that ExecuteReader resolves external bodies and .env files against its base
+// directory, and that content without requests fails to parse.
+func RunExecuteSource_AndReader(t *testing.T) {
+	t.Helper()
+	// Given
+	var received []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r.Method+" "+r.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "order.json"), []byte(`{"item": "book"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("ORDER_PATH=orders\n"), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	sourceResponses, sourceErr := client.ExecuteSource(context.Background(),
+		"GET {{host}}/health\n\n###\nDELETE {{host}}/cache\n", rc.Vars{"host": server.URL})
+	readerResponses, readerErr := client.ExecuteReader(context.Background(),
+		strings.NewReader("POST {{host}}/{{$dotenv ORDER_PATH}}\nContent-Type: application/json\n\n< ./order.json\n"),
+		dir, rc.Vars{"host": server.URL})
+
+	// Then
+	require.NoError(t, sourceErr)
+	require.NoError(t, readerErr)
+	assert.Len(t, sourceResponses, 2)
+	require.Len(t, readerResponses, 1)
+	assert.Equal(t, http.StatusOK, readerResponses[0].StatusCode)
+	assert.Equal(t, []string{"GET /health ", "DELETE /cache ", `POST /orders {"item": "book"}`}, received)
+
+	// When the content has no requests
+	_, emptyErr := client.ExecuteSource(context.Background(), "# nothing to send\n")
+
+	// Then
+	require.Error(t, emptyErr)
+	var requestErr *rc.RequestError
+	require.True(t, errors.As(emptyErr, &requestErr))
+	assert.Equal(t, rc.PhaseParse, requestErr.Phase)
+	assert.Contains(t, emptyErr.Error(), "no requests found")
+}
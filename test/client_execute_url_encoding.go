@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR1.3 - Variable Substitution: URL Percent-Encoding
+// Corresponds to: Client's percent-encoding of variable values substituted into request URLs
+// (WithURLVariableEncoding, on by default with WithStrictMode).
+// This test verifies that values with spaces, '#', '/' and non-ASCII characters are encoded per path segment
+// and query parameter, that a base URL variable is left intact, and that encoding is off by default.
+func RunExecuteFile_URLVariableEncoding(t *testing.T) {
+	t.Helper()
+	// Given
+	var gotPath, gotRawPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotRawPath = r.URL.EscapedPath()
+		gotQuery = r.URL.Query().Get("q")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	content := "@user = John Doe #1/2\n@term = café & crème\n\nGET {{baseUrl}}/users/{{user}}?q={{term}}\n"
+	httpFile := filepath.Join(t.TempDir(), "encoding.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	baseURLVar := rc.WithVars(map[string]any{"baseUrl": server.URL + "/api"})
+
+	tests := []struct {
+		name    string
+		options []rc.ClientOption
+	}{
+		{name: "explicit option", options: []rc.ClientOption{baseURLVar, rc.WithURLVariableEncoding(true)}},
+		{name: "strict mode", options: []rc.ClientOption{baseURLVar, rc.WithStrictMode()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := rc.NewClient(tt.options...)
+			require.NoError(t, err)
+
+			// When
+			responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+			// Then
+			require.NoError(t, err)
+			require.Len(t, responses, 1)
+			require.NoError(t, responses[0].Error)
+			assert.Equal(t, "/api/users/John Doe #1/2", gotPath)
+			assert.Equal(t, "/api/users/John%20Doe%20%231%2F2", gotRawPath)
+			assert.Equal(t, "café & crème", gotQuery)
+			assert.Equal(t, fmt.Sprintf("%s/api/users/John%%20Doe%%20%%231%%2F2?q=caf%%C3%%A9+%%26+cr%%C3%%A8me",
+				server.URL), responses[0].Request.URL.String())
+		})
+	}
+
+	t.Run("disabled in strict mode", func(t *testing.T) {
+		client, err := rc.NewClient(baseURLVar, rc.WithStrictMode(), rc.WithURLVariableEncoding(false))
+		require.NoError(t, err)
+
+		// When
+		responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+		// Then: the raw value's '#' starts a fragment, truncating the path and dropping the query
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+		require.NoError(t, responses[0].Error)
+		assert.Equal(t, "/api/users/John Doe ", gotPath)
+		assert.Empty(t, gotQuery)
+	})
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR3.6 - In-Place Variables: Circular Reference Detection
+// Corresponds to: Client's detection of in-place variables that reference each other in a cycle
+// (e.g., '@a = {{b}}', '@b = {{a}}'), reported as an error naming the cycle and its definition
+// lines instead of sending a request with unresolved placeholders.
+// This test uses 'test/data/execute_inplace_vars/circular_reference/' to verify two-variable and
+// self-referencing cycles, and that an overriding programmatic variable breaks the cycle.
+func RunExecuteFile_InPlace_CircularReferenceDetected(t *testing.T) {
+	t.Helper()
+	// Given
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requestCount++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverHost := strings.TrimPrefix(server.URL, "http://")
+
+	client, err := rc.NewClient(rc.WithVars(map[string]any{"api_host": serverHost}))
+	require.NoError(t, err)
+
+	// When
+	_, cycleErr := client.ExecuteFile(context.Background(),
+		"test/data/execute_inplace_vars/circular_reference/request.http")
+	_, selfErr := client.ExecuteFile(context.Background(),
+		"test/data/execute_inplace_vars/circular_reference/self_reference.http")
+
+	// Then
+	require.Error(t, cycleErr)
+	assert.Contains(t, cycleErr.Error(),
+		"circular variable reference: @session (line 3) -> @token (line 2) -> @session")
+	require.Error(t, selfErr)
+	assert.Contains(t, selfErr.Error(), "circular variable reference: @path (line 1) -> @path")
+	assert.Equal(t, 0, requestCount, "no request must be sent when variables are circular")
+
+	// Given a programmatic variable overriding one side of the cycle
+	overridingClient, err := rc.NewClient(rc.WithVars(map[string]any{
+		"api_host": serverHost,
+		"session":  "s-1",
+	}))
+	require.NoError(t, err)
+
+	// When
+	responses, err := overridingClient.ExecuteFile(context.Background(),
+		"test/data/execute_inplace_vars/circular_reference/request.http")
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "Bearer s-1", responses[0].Request.Headers.Get("Authorization"))
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.3 - Response Diagnostics: Raw Wire Capture
+// Corresponds to: The WithWireCapture client option exposing Response.RawRequestDump and
+// Response.RawResponseDump, so users can see exactly what was sent after variable substitution
+// and default header injection.
+// This test verifies that the dumps contain the resolved request line, headers and body, the
+// response status line and body, and that nothing is captured when the option is not set.
+func RunExecuteFile_WireCapture(t *testing.T) {
+	t.Helper()
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Server", "mock")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"created": true}`))
+	}))
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "wire.http")
+	content := fmt.Sprintf("@name = widget\n\nPOST %s/items?kind={{name}}\nContent-Type: application/json\n\n"+
+		"{\"name\": \"{{name}}\"}\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	client, err := rc.NewClient(rc.WithWireCapture(), rc.WithDefaultHeader("X-Api-Key", "k-123"))
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	rawRequest := string(responses[0].RawRequestDump)
+	assert.Contains(t, rawRequest, "POST /items?kind=widget HTTP/1.1\r\n")
+	assert.Contains(t, rawRequest, "X-Api-Key: k-123\r\n")
+	assert.Contains(t, rawRequest, "Content-Type: application/json\r\n")
+	assert.Contains(t, rawRequest, `{"name": "widget"}`)
+
+	rawResponse := string(responses[0].RawResponseDump)
+	assert.Contains(t, rawResponse, "HTTP/1.1 201 Created\r\n")
+	assert.Contains(t, rawResponse, "X-Server: mock\r\n")
+	assert.Contains(t, rawResponse, `{"created": true}`)
+	assert.Equal(t, `{"created": true}`, responses[0].BodyString, "capture must not consume the body")
+
+	// Given a client without wire capture
+	plainClient, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	plainResponses, err := plainClient.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, plainResponses, 1)
+	assert.Nil(t, plainResponses[0].RawRequestDump)
+	assert.Nil(t, plainResponses[0].RawResponseDump)
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.32 - Client Core Execution: Fault Injection
+// Corresponds to: The WithFaultInjection(FaultInjection{...}) client option, which randomly delays
+// requests, fails them with connection resets or answers them with synthesized error responses.
+// This test verifies each fault, that a seed repeats the same faults, that @retry recovers from
+// injected faults, and that invalid settings are rejected.
+func RunExecuteFile_WithFaultInjection(t *testing.T) {
+	t.Helper()
+	// Given
+	var hits atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	suite := filepath.Join(dir, "suite.http")
+	content := strings.Repeat(fmt.Sprintf("GET %s/ping\n\n###\n", server.URL), 20)
+	require.NoError(t, os.WriteFile(suite, []byte(content), 0644))
+	ctx := context.Background()
+
+	// When every request is answered with an error response
+	client, err := rc.NewClient(rc.WithFaultInjection(rc.FaultInjection{ErrorRate: 1, ErrorStatus: 502}))
+	require.NoError(t, err)
+	responses, execErr := client.ExecuteFile(ctx, suite)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 20)
+	assert.Equal(t, http.StatusBadGateway, responses[0].StatusCode)
+	assert.Equal(t, "error", responses[0].Headers.Get(rc.FaultInjectedHeader))
+	assert.Equal(t, int32(0), hits.Load())
+
+	// When every request is reset and delayed
+	client, err = rc.NewClient(rc.WithFaultInjection(rc.FaultInjection{
+		ResetRate: 1, DelayRate: 1, Delay: 5 * time.Millisecond,
+	}))
+	require.NoError(t, err)
+	start := time.Now()
+	responses, execErr = client.ExecuteFile(ctx, suite)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 20)
+	assert.True(t, errors.Is(responses[0].Error, syscall.ECONNRESET))
+	assert.True(t, errors.Is(responses[0].Error, rc.ErrFaultInjected))
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, int32(0), hits.Load())
+
+	// When two clients with the same seed run the suite
+	outcomes := func() string {
+		faulty, err := rc.NewClient(rc.WithFaultInjection(rc.FaultInjection{ResetRate: 0.3, ErrorRate: 0.3, Seed: 7}))
+		require.NoError(t, err)
+		responses, _ := faulty.ExecuteFile(ctx, suite)
+		var sb strings.Builder
+		for _, resp := range responses {
+			switch {
+			case resp.Error != nil:
+				sb.WriteString("R")
+			case resp.StatusCode == http.StatusServiceUnavailable:
+				sb.WriteString("E")
+			default:
+				sb.WriteString(".")
+			}
+		}
+		return sb.String()
+	}
+	first := outcomes()
+
+	// Then
+	assert.Equal(t, first, outcomes())
+	assert.Contains(t, first, "R")
+	assert.Contains(t, first, "E")
+	assert.Contains(t, first, ".")
+
+	// When a request retries injected faults
+	retried := filepath.Join(dir, "retried.http")
+	retriedContent := fmt.Sprintf("# @retry 20 delay=1ms\nGET %s/ping\n", server.URL)
+	require.NoError(t, os.WriteFile(retried, []byte(retriedContent), 0644))
+	client, err = rc.NewClient(rc.WithFaultInjection(rc.FaultInjection{ResetRate: 0.5, ErrorRate: 0.4, Seed: 3}))
+	require.NoError(t, err)
+	responses, execErr = client.ExecuteFile(ctx, retried)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Greater(t, responses[0].Attempts, 1)
+
+	// When
+	_, err = rc.NewClient(rc.WithFaultInjection(rc.FaultInjection{ResetRate: 0.6, ErrorRate: 0.6}))
+
+	// Then
+	assert.Error(t, err)
+	_, err = rc.NewClient(rc.WithFaultInjection(rc.FaultInjection{ErrorStatus: 200}))
+	assert.Error(t, err)
+}
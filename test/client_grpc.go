@@ -0,0 +1,237 @@
+package test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/bmcszk/go-restclient/grpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.46 - Client Core Execution: gRPC Requests
+// Corresponds to: JetBrains-style `GRPC host:port/package.Service/Method` requests with a JSON body as the
+// request message, executed with WithGRPC and a grpcclient.Invoker (http_syntax.md "gRPC Requests").
+// This test verifies that the method is resolved with server reflection, that headers are sent as metadata,
+// that the reply, its metadata and the gRPC status come back as an HTTP-like response that validates against
+// an .hresp file, that error statuses map to HTTP status codes, and that GRPC requests fail without WithGRPC.
+func RunExecuteFile_GRPCRequests(t *testing.T) {
+	t.Helper()
+	// Given
+	files := greeterDescriptors(t)
+	target := startGreeterServer(t, files, true)
+	dir := t.TempDir()
+	content := "@name = World\n\n### hello\n# @name hello\nGRPC {{host}}/greet.Greeter/SayHello\n" +
+		"Authorization: Bearer abc\n\n{\"name\": \"{{name}}\"}\n\n" +
+		"### missing name\n# @name invalid\nGRPC {{host}}/greet.Greeter/SayHello\n\n{}\n"
+	httpFile := filepath.Join(dir, "greeter.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	hrespFile := filepath.Join(dir, "greeter.hresp")
+	hresp := "HTTP/1.1 200 OK\nGrpc-Status: 0\nX-Auth: Bearer abc\n\n{\n  \"message\": \"Hello, World\"\n}\n\n" +
+		"###\nHTTP/1.1 400 Bad Request\nGrpc-Status: 3\nGrpc-Message: name is required\n"
+	require.NoError(t, os.WriteFile(hrespFile, []byte(hresp), 0644))
+
+	invoker, err := grpcclient.New()
+	require.NoError(t, err)
+	defer func() { _ = invoker.Close() }()
+	client, err := rc.NewClient(rc.WithGRPC(invoker), rc.WithVars(map[string]any{"host": target}))
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	hello := responses[0]
+	require.NoError(t, hello.Error)
+	assert.Equal(t, "grpc://"+target+"/greet.Greeter/SayHello", hello.FinalURL)
+	assert.Equal(t, "HTTP/2.0", hello.Proto)
+	assert.Equal(t, "test", hello.Trailers.Get("X-Served-By"))
+	assert.JSONEq(t, `{"message": "Hello, World"}`, hello.BodyString)
+	assert.Equal(t, 400, responses[1].StatusCode)
+	assert.Empty(t, responses[1].BodyString)
+	require.NoError(t, client.ValidateResponses(hrespFile, responses...))
+
+	// When
+	plain, err := rc.NewClient(rc.WithVars(map[string]any{"host": target}))
+	require.NoError(t, err)
+	responses, err = plain.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, err)
+	require.Len(t, responses, 2)
+	require.Error(t, responses[0].Error)
+	assert.Contains(t, responses[0].Error.Error(), "WithGRPC")
+}
+
+// PRD-COMMENT: FR10.46 - Client Core Execution: gRPC Requests
+// Corresponds to: the grpcclient options WithDescriptors, WithDescriptorSetFile and WithDialOptions for
+// servers without the reflection service.
+// This test verifies that GRPC requests to a server without reflection succeed with descriptors given as
+// files or as a descriptor set file, that dial options apply to the calls, that unknown services and
+// methods are reported as request errors, and that invalid descriptor options fail grpcclient.New.
+func RunExecuteFile_GRPCWithDescriptors(t *testing.T) {
+	t.Helper()
+	// Given
+	target := startGreeterServer(t, greeterDescriptors(t), false)
+	dir := t.TempDir()
+	content := "### hello\nGRPC {{host}}/greet.Greeter/SayHello\n\n{\"name\": \"Ada\"}\n\n" +
+		"### unknown method\nGRPC {{host}}/greet.Greeter/SayBye\n\n" +
+		"### unknown service\nGRPC {{host}}/greet.Missing/SayHello\n"
+	httpFile := filepath.Join(dir, "greeter.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	setData, err := proto.Marshal(greeterDescriptorSet())
+	require.NoError(t, err)
+	setFile := filepath.Join(dir, "greet.pb")
+	require.NoError(t, os.WriteFile(setFile, setData, 0644))
+
+	calls := 0
+	countCalls := grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		calls++
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+	execute := func(options ...grpcclient.Option) ([]*rc.Response, error) {
+		invoker, err := grpcclient.New(options...)
+		require.NoError(t, err)
+		defer func() { _ = invoker.Close() }()
+		client, err := rc.NewClient(rc.WithGRPC(invoker), rc.WithVars(map[string]any{"host": target}))
+		require.NoError(t, err)
+		return client.ExecuteFile(context.Background(), httpFile)
+	}
+
+	// When
+	fromFiles, filesErr := execute(grpcclient.WithDescriptors(greeterDescriptors(t)),
+		grpcclient.WithDialOptions(countCalls))
+	fromSet, setErr := execute(grpcclient.WithDescriptorSetFile(setFile))
+	reflected, reflectedErr := execute()
+
+	// Then
+	for _, responses := range [][]*rc.Response{fromFiles, fromSet} {
+		require.Len(t, responses, 3)
+		require.NoError(t, responses[0].Error)
+		assert.JSONEq(t, `{"message": "Hello, Ada"}`, responses[0].BodyString)
+		require.Error(t, responses[1].Error)
+		assert.Contains(t, responses[1].Error.Error(), "unknown method SayBye")
+		require.Error(t, responses[2].Error)
+		assert.Contains(t, responses[2].Error.Error(), "unknown gRPC service greet.Missing")
+	}
+	assert.Len(t, rc.RequestErrors(filesErr), 2)
+	assert.Len(t, rc.RequestErrors(setErr), 2)
+	assert.Equal(t, 1, calls, "dial options apply to the calls of the invoker")
+	require.Error(t, reflectedErr)
+	require.Len(t, reflected, 3)
+	assert.Contains(t, reflected[0].Error.Error(), "server reflection for greet.Greeter failed")
+
+	// When
+	_, missingErr := grpcclient.New(grpcclient.WithDescriptorSetFile(filepath.Join(dir, "missing.pb")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.pb"), []byte("not a descriptor set"), 0644))
+	_, invalidErr := grpcclient.New(grpcclient.WithDescriptorSetFile(filepath.Join(dir, "invalid.pb")))
+	_, nilErr := grpcclient.New(grpcclient.WithDescriptors(nil))
+
+	// Then
+	assert.ErrorContains(t, missingErr, "failed to read descriptor set")
+	assert.ErrorContains(t, invalidErr, "failed to parse descriptor set")
+	assert.ErrorContains(t, nilErr, "descriptors must not be nil")
+}
+
+// greeterDescriptors describes greet.Greeter, a service with a unary SayHello method
+func greeterDescriptors(t *testing.T) *protoregistry.Files {
+	t.Helper()
+	files, err := protodesc.NewFiles(greeterDescriptorSet())
+	require.NoError(t, err)
+	return files
+}
+
+// greeterDescriptorSet is the file descriptor set of greet.proto, declaring greet.Greeter
+func greeterDescriptorSet() *descriptorpb.FileDescriptorSet {
+	stringField := func(name string) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(1),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+	}
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("greet.proto"),
+		Package: proto.String("greet"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("HelloRequest"), Field: []*descriptorpb.FieldDescriptorProto{stringField("name")}},
+			{Name: proto.String("HelloReply"), Field: []*descriptorpb.FieldDescriptorProto{stringField("message")}},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Greeter"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("SayHello"),
+				InputType:  proto.String(".greet.HelloRequest"),
+				OutputType: proto.String(".greet.HelloReply"),
+			}},
+		}},
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}}
+}
+
+// startGreeterServer serves greet.Greeter, with server reflection if withReflection, on a local port,
+// returning its address. SayHello greets the name of the request, echoes its Authorization metadata as
+// the X-Auth header and fails with InvalidArgument for an empty name.
+func startGreeterServer(t *testing.T, files *protoregistry.Files, withReflection bool) string {
+	t.Helper()
+	descriptor, err := files.FindDescriptorByName("greet.Greeter")
+	require.NoError(t, err)
+	method := descriptor.(protoreflect.ServiceDescriptor).Methods().ByName("SayHello")
+
+	sayHello := func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+		in := dynamicpb.NewMessage(method.Input())
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		name := in.Get(method.Input().Fields().ByName("name")).String()
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "name is required")
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs("x-auth", strings.Join(md.Get("authorization"), ",")))
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("x-served-by", "test"))
+		out := dynamicpb.NewMessage(method.Output())
+		out.Set(method.Output().Fields().ByName("message"), protoreflect.ValueOfString("Hello, "+name))
+		return out, nil
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "greet.Greeter",
+		HandlerType: (*any)(nil),
+		Methods:     []grpc.MethodDesc{{MethodName: "SayHello", Handler: sayHello}},
+	}, struct{}{})
+	if withReflection {
+		reflectionv1.RegisterServerReflectionServer(server,
+			reflection.NewServerV1(reflection.ServerOptions{Services: server, DescriptorResolver: files}))
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/bmcszk/go-restclient/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.11 - Client Core Execution: Execution History
+// Corresponds to: Client option WithHistory and the JSON-lines history.Store recording every executed
+// request with resolved values, response summary and timing, queryable by request name and run.
+// This test verifies entries are persisted across store instances, grouped by run, that requests marked
+// with @no-log are not recorded, and that a recorded response can be validated again.
+func RunExecuteFile_RecordsHistory(t *testing.T) {
+	t.Helper()
+	// Given
+	calls := 0
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login" {
+			calls++
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"call": %d, "echo": %s}`, calls, body)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "users.http")
+	content := fmt.Sprintf("@user = alice\n\n# @name createUser\nPOST %[1]s/users\nContent-Type: application/json\n\n"+
+		"{\"name\": \"{{user}}\"}\n\n###\nGET %[1]s/users\n\n###\n# @no-log\nPOST %[1]s/login\n\nsecret\n",
+		server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	historyFile := filepath.Join(dir, "history", "runs.jsonl")
+	store, err := history.Open(historyFile)
+	require.NoError(t, err)
+	client, err := rc.NewClient(rc.WithHistory(store))
+	require.NoError(t, err)
+
+	// When
+	_, err = client.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, err)
+	_, err = client.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, err)
+
+	// Then
+	reopened, err := history.Open(historyFile)
+	require.NoError(t, err)
+	entries, err := reopened.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 4, "the @no-log request is not recorded")
+	for _, entry := range entries {
+		assert.NotEqual(t, server.URL+"/login", entry.Request.URL)
+	}
+	runIDs, err := reopened.RunIDs()
+	require.NoError(t, err)
+	require.Len(t, runIDs, 2)
+
+	last, err := reopened.Last("createUser")
+	require.NoError(t, err)
+	assert.Equal(t, runIDs[1], last.RunID)
+	assert.Equal(t, httpFile, last.RequestFile)
+	assert.Equal(t, 0, last.Index)
+	assert.Equal(t, "alice", last.Variables["@user"])
+	assert.Equal(t, http.MethodPost, last.Request.Method)
+	assert.Equal(t, server.URL+"/users", last.Request.URL)
+	assert.Equal(t, `{"name": "alice"}`, last.Request.Body)
+	assert.Equal(t, http.StatusCreated, last.Response.StatusCode)
+	assert.Equal(t, `{"call": 3, "echo": {"name": "alice"}}`, last.Response.Body)
+	assert.Positive(t, last.Response.Duration)
+	assert.False(t, last.Response.StartTime.IsZero())
+
+	lastRun, err := reopened.LastRun()
+	require.NoError(t, err)
+	require.Len(t, lastRun, 2)
+	assert.Equal(t, 1, lastRun[1].Index)
+
+	hrespFile := filepath.Join(dir, "createUser.hresp")
+	require.NoError(t, os.WriteFile(hrespFile,
+		[]byte("HTTP/1.1 201 Created\nContent-Type: application/json\n\n{\"call\": 3, \"echo\": {\"name\": \"alice\"}}\n"),
+		0644))
+	require.NoError(t, client.ValidateResponses(hrespFile, last.ToResponse()))
+
+	_, err = reopened.Last("unknown")
+	assert.ErrorIs(t, err, history.ErrNotFound)
+}
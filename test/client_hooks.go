@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.54 - Client Core Execution: Request and File Hooks
+// Corresponds to: the WithBeforeRequestHook, WithAfterResponseHook, WithBeforeFileHook and
+// WithAfterFileHook client options.
+// This test verifies that hooks run in the execution order of the file, that a before request hook may
+// set variables of the request, and that hook errors fail the request, with phase hook, or the file.
+func RunExecuteFile_WithHooks(t *testing.T) {
+	t.Helper()
+	// Given
+	var events []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		events = append(events, "server "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "orders.http")
+	content := fmt.Sprintf("# @name seed\nPOST %[1]s/orders/{{orderId}}\n\n"+
+		"###\n# @name check\nGET %[1]s/orders/{{orderId}}\n\n"+
+		"###\n# @name blocked\nDELETE %[1]s/orders/{{orderId}}\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(
+		rc.WithBeforeFileHook(func(_ context.Context, filePath string) error {
+			events = append(events, "before file "+filepath.Base(filePath))
+			return nil
+		}),
+		rc.WithBeforeRequestHook(func(_ context.Context, req *rc.Request) error {
+			events = append(events, "before "+req.Name)
+			if req.Name == "blocked" {
+				return errors.New("orders are not deleted")
+			}
+			req.SetVariable("orderId", "42")
+			return nil
+		}),
+		rc.WithAfterResponseHook(func(_ context.Context, resp *rc.Response) error {
+			events = append(events, fmt.Sprintf("after %s %d", resp.Request.Name, resp.StatusCode))
+			return nil
+		}),
+		rc.WithAfterFileHook(func(_ context.Context, _ string, responses []*rc.Response) error {
+			events = append(events, fmt.Sprintf("after file %d", len(responses)))
+			return errors.New("cleanup failed")
+		}),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 3)
+	assert.Equal(t, []string{
+		"before file orders.http",
+		"before seed", "server /orders/42", "after seed 200",
+		"before check", "server /orders/42", "after check 200",
+		"before blocked", "after blocked 0",
+		"after file 3",
+	}, events)
+	requestErrs := rc.RequestErrors(execErr)
+	require.Len(t, requestErrs, 2)
+	assert.Equal(t, rc.PhaseHook, requestErrs[0].Phase)
+	assert.Equal(t, "blocked", requestErrs[0].Name)
+	assert.Contains(t, requestErrs[0].Error(), "hook failed: before request hook: orders are not deleted")
+	assert.Equal(t, "after file hook: cleanup failed", requestErrs[1].Error())
+
+	// When a before file hook fails
+	events = nil
+	failing, err := rc.NewClient(rc.WithBeforeFileHook(func(context.Context, string) error {
+		return errors.New("database unavailable")
+	}))
+	require.NoError(t, err)
+	_, failErr := failing.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, failErr)
+	assert.Contains(t, failErr.Error(), "before file hook: database unavailable")
+	assert.Empty(t, events)
+}
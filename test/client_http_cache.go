@@ -0,0 +1,98 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.17 - Client Core Execution: HTTP Cache
+// Corresponds to: The opt-in RFC 9111 client cache (WithHTTPCache) storing GET responses, serving fresh
+// ones without contacting the server, revalidating stale ones with If-None-Match, and invalidating on
+// unsafe requests; Response.CacheStatus and the '# cache-status' .hresp directive expose the outcome.
+// This test verifies HIT, MISS and REVALIDATED outcomes, no-store and invalidation after a POST.
+func RunExecuteFile_WithHTTPCache(t *testing.T) {
+	t.Helper()
+	// Given
+	var serverHits atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		hit := serverHits.Add(1)
+		switch r.URL.Path {
+		case "/fresh":
+			w.Header().Set("Cache-Control", "max-age=60")
+		case "/etag":
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		case "/nostore":
+			w.Header().Set("Cache-Control", "no-store")
+		}
+		_, _ = fmt.Fprintf(w, "%s %d", r.Method, hit)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "cache.http")
+	content := fmt.Sprintf(`GET %[1]s/fresh
+
+###
+GET %[1]s/fresh
+
+###
+GET %[1]s/etag
+
+###
+GET %[1]s/etag
+
+###
+POST %[1]s/fresh
+
+###
+GET %[1]s/fresh
+
+###
+GET %[1]s/nostore
+
+###
+GET %[1]s/nostore
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	hrespFile := filepath.Join(dir, "cache.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte("HTTP/1.1 200 OK\n# cache-status MISS\n\nGET 1\n\n###\n\n"+
+		"HTTP/1.1 200 OK\n# cache-status hit\n\nGET 1\n"), 0644))
+	client, err := rc.NewClient(rc.WithHTTPCache())
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 8)
+	statuses := make([]rc.CacheStatus, 0, len(responses))
+	for _, resp := range responses {
+		statuses = append(statuses, resp.CacheStatus)
+	}
+	assert.Equal(t, []rc.CacheStatus{rc.CacheMiss, rc.CacheHit, rc.CacheMiss, rc.CacheRevalidated, "",
+		rc.CacheMiss, rc.CacheMiss, rc.CacheMiss}, statuses)
+	assert.Equal(t, "GET 1", responses[1].BodyString, "fresh responses are served from the cache")
+	assert.NotEmpty(t, responses[1].Header("Age"))
+	assert.Equal(t, http.StatusOK, responses[3].StatusCode, "revalidated responses keep the stored status")
+	assert.Equal(t, "GET 2", responses[3].BodyString)
+	assert.Equal(t, "GET 5", responses[5].BodyString, "POST invalidates the stored response")
+	assert.Equal(t, int32(7), serverHits.Load())
+	assert.NoError(t, client.ValidateResponses(hrespFile, responses[:2]...))
+	assert.ErrorContains(t, client.ValidateResponses(hrespFile, responses[2], responses[3]),
+		"cache status mismatch: expected 'HIT', got 'REVALIDATED'")
+}
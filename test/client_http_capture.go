@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.26 - Client Core Execution: Capturing Live Requests as .http Fixtures
+// Corresponds to: rc.FromHTTPRequest(*http.Request) and rc.FormatRequests(...*Request), which let
+// middleware persist the requests a service receives or sends in .http syntax.
+// This test verifies that a request captured by a server handler keeps its body readable, and that
+// the written fixture replays the same method, URL, headers and body with ExecuteFile.
+func RunFromHTTPRequest(t *testing.T) {
+	t.Helper()
+	// Given
+	var captured []*rc.Request
+	var handledBodies []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		req, err := rc.FromHTTPRequest(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		captured = append(captured, req)
+		body, _ := io.ReadAll(r.Body)
+		handledBodies = append(handledBodies, string(body))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	body := `{"name": "Ann"}`
+	httpReq, err := http.NewRequest(http.MethodPost, server.URL+"/users?dry=true", strings.NewReader(body))
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Trace", "abc")
+	httpResp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	_ = httpResp.Body.Close()
+	require.Len(t, captured, 1)
+
+	// When
+	fixture := filepath.Join(t.TempDir(), "captured.http")
+	require.NoError(t, os.WriteFile(fixture, []byte(rc.FormatRequests(captured...)), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+	responses, execErr := client.ExecuteFile(context.Background(), fixture)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	require.Len(t, captured, 2)
+	assert.Equal(t, []string{body, body}, handledBodies)
+	original, replayed := captured[0], captured[1]
+	assert.Equal(t, http.MethodPost, replayed.Method)
+	assert.Equal(t, server.URL+"/users?dry=true", replayed.URL.String())
+	assert.Equal(t, original.URL.String(), replayed.URL.String())
+	assert.Equal(t, "abc", replayed.Headers.Get("X-Trace"))
+	assert.Equal(t, "application/json", replayed.Headers.Get("Content-Type"))
+	assert.Empty(t, replayed.Headers.Get("Content-Length"))
+	assert.Equal(t, body, replayed.RawBody)
+
+	// When
+	_, err = rc.FromHTTPRequest(nil)
+
+	// Then
+	assert.Error(t, err)
+}
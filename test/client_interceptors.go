@@ -0,0 +1,98 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.49 - Client Core Execution: Request and Response Interceptors
+// Corresponds to: the WithRequestInterceptor and WithResponseInterceptor client options.
+// This test verifies that request interceptors run in order on the substituted request before it is sent,
+// that response interceptors see every response, and that an interceptor error fails the request, without
+// sending it when a request interceptor failed.
+func RunExecuteFile_WithInterceptors(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	received := map[string]string{}
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path] = r.Header.Get("Traceparent")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	content := fmt.Sprintf("@host = %s\n\n"+
+		"POST {{host}}/orders\nContent-Type: application/json\n\n{\"id\": 1}\n\n"+
+		"###\nGET {{host}}/missing\n\n"+
+		"###\n# @name blocked\nGET {{host}}/blocked\n", server.URL)
+	httpFile := filepath.Join(dir, "orders.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var calls []string
+	var statuses []int
+	client, err := rc.NewClient(
+		rc.WithRequestInterceptor(func(_ context.Context, req *rc.Request) error {
+			calls = append(calls, "first "+req.URL.Path+" "+req.RawBody)
+			req.Headers.Set("Traceparent", "00-trace-"+req.Method)
+			return nil
+		}),
+		rc.WithRequestInterceptor(func(_ context.Context, req *rc.Request) error {
+			calls = append(calls, "second "+req.Headers.Get("Traceparent"))
+			if req.Name == "blocked" {
+				return errors.New("blocked by policy")
+			}
+			return nil
+		}),
+		rc.WithResponseInterceptor(func(_ context.Context, resp *rc.Response) error {
+			statuses = append(statuses, resp.StatusCode)
+			if resp.StatusCode == http.StatusNotFound {
+				return errors.New("unexpected 404")
+			}
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 3)
+	require.NoError(t, responses[0].Error)
+	assert.Equal(t, []string{
+		`first /orders {"id": 1}`, "second 00-trace-POST",
+		"first /missing ", "second 00-trace-GET",
+		"first /blocked ", "second 00-trace-GET",
+	}, calls)
+	assert.Equal(t, map[string]string{"/orders": "00-trace-POST", "/missing": "00-trace-GET"}, received,
+		"the blocked request is not sent")
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotFound}, statuses, "requests not sent have no response")
+	require.Error(t, responses[1].Error)
+	assert.Contains(t, responses[1].Error.Error(), "unexpected 404")
+	require.Error(t, responses[2].Error)
+	assert.Contains(t, responses[2].Error.Error(), "blocked by policy")
+
+	// When
+	_, err = rc.NewClient(rc.WithRequestInterceptor(nil))
+
+	// Then
+	require.Error(t, err)
+}
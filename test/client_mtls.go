@@ -0,0 +1,162 @@
+package test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.48 - Client Core Execution: TLS Client Certificates
+// Corresponds to: the SSLConfiguration section of an environment in http-client.private.env.json
+// (clientCertificate, clientCertificateKey with PEM or DER format, verifyHostCertificate), and the
+// WithClientCert and WithHostClientCert client options.
+// This test verifies that a server requiring mutual TLS receives the configured certificate, that requests
+// without one fail the handshake, and that host certificates only apply to their host.
+func RunExecuteFile_WithClientCertificates(t *testing.T) {
+	t.Helper()
+	// Given
+	dir := t.TempDir()
+	caCert, caKey := newTestCA(t)
+	certPEM, keyDER := newTestClientCert(t, caCert, caKey, "orders-client")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "certs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "certs", "client.pem"), certPEM, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "certs", "client.key.der"), keyDER, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "certs", "client.key"), keyPEM, 0600))
+	combined := filepath.Join(dir, "certs", "combined.pem")
+	require.NoError(t, os.WriteFile(combined, append(append([]byte{}, certPEM...), keyPEM...), 0600))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(caCert)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	env := `{"secure": {"SSLConfiguration": {"clientCertificate": "certs/client.pem",
+		"clientCertificateKey": {"path": "certs/client.key.der", "format": "DER"},
+		"verifyHostCertificate": false}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.private.env.json"), []byte(env), 0644))
+	httpFile := filepath.Join(dir, "orders.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(fmt.Sprintf("GET %s/orders\n", server.URL)), 0644))
+
+	// When
+	envClient, err := rc.NewClient(rc.WithEnvironment("secure"))
+	require.NoError(t, err)
+	responses, execErr := envClient.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "orders-client", responses[0].BodyString)
+
+	// When
+	plain, err := rc.NewClient(rc.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	responses, execErr = plain.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr, "the server requires a client certificate")
+	require.Len(t, responses, 1)
+	require.Error(t, responses[0].Error)
+
+	// When
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	hostClient, err := rc.NewClient(rc.WithHTTPClient(server.Client()), rc.WithHostClientCert(serverURL.Host,
+		rc.ClientCertificate{CertFile: filepath.Join(dir, "certs", "client.pem"),
+			KeyFile: filepath.Join(dir, "certs", "client.key")}))
+	require.NoError(t, err)
+	responses, execErr = hostClient.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "orders-client", responses[0].BodyString)
+
+	// When
+	otherHost, err := rc.NewClient(rc.WithHTTPClient(server.Client()), rc.WithHostClientCert("other.example.com",
+		rc.ClientCertificate{CertFile: combined}))
+	require.NoError(t, err)
+	_, execErr = otherHost.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr, "the certificate of another host is not presented")
+
+	// When
+	allHosts, err := rc.NewClient(rc.WithHTTPClient(server.Client()), rc.WithClientCert(combined, ""))
+	require.NoError(t, err)
+	responses, execErr = allHosts.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "orders-client", responses[0].BodyString)
+
+	// When
+	_, err = rc.NewClient(rc.WithClientCert(filepath.Join(dir, "certs", "missing.pem"), ""))
+
+	// Then
+	require.Error(t, err)
+}
+
+// newTestCA creates a self-signed certificate authority for client certificates
+func newTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+// newTestClientCert issues a client certificate, returning it as PEM and its key as PKCS #8 DER
+func newTestClientCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, name string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyDER
+}
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// multipartUpload is a multipart request received by the mock server
+type multipartUpload struct {
+	contentType      string
+	contentLength    int64
+	transferEncoding []string
+	body             []byte
+}
+
+// PRD-COMMENT: FR10.43 - Client Core Execution: Multipart Form Bodies
+// Corresponds to: "Content-Type: multipart/form-data; boundary=..." bodies whose parts are fields or local
+// files referenced with `< ./path` (http_syntax.md "Multipart Form Data" and "File Upload").
+// This test verifies that the form is rebuilt with CRLF line breaks and the boundary of the header, that
+// file parts are sent with their content, a filename, a Content-Type by extension and their other headers,
+// that the body has a Content-Length and is sent again on retry, and that a missing file fails the request.
+func RunExecuteFile_MultipartFormBodies(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var uploads []multipartUpload
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		uploads = append(uploads, multipartUpload{
+			contentType:      r.Header.Get("Content-Type"),
+			contentLength:    r.ContentLength,
+			transferEncoding: r.TransferEncoding,
+			body:             body,
+		})
+		first := len(uploads) == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	image := []byte("\x89PNG\r\n\x1a\nnot really an image")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "avatar.png"), image, 0644))
+	upload := fmt.Sprintf("@title = Holiday\n\n# @retry 1 delay=1ms\nPOST %s/upload\n"+
+		"Content-Type: multipart/form-data; boundary=XBoundary\n\n"+
+		"--XBoundary\nContent-Disposition: form-data; name=\"title\"\n\n{{title}}\n"+
+		"--XBoundary\nContent-Disposition: form-data; name=\"avatar\"\nX-Checksum: abc\n\n< ./avatar.png\n"+
+		"--XBoundary--\n", server.URL)
+	missing := fmt.Sprintf("POST %s/upload\nContent-Type: multipart/form-data; boundary=XBoundary\n\n"+
+		"--XBoundary\nContent-Disposition: form-data; name=\"file\"\n\n< ./missing.bin\n--XBoundary--\n", server.URL)
+	httpFile := filepath.Join(dir, "upload.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(upload+"\n###\n"+missing), 0644))
+	renderFile := filepath.Join(dir, "render.http")
+	require.NoError(t, os.WriteFile(renderFile, []byte(upload), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 2)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Equal(t, 2, responses[0].Attempts)
+	require.Error(t, execErr)
+	require.Error(t, responses[1].Error)
+	assert.Contains(t, responses[1].Error.Error(), "missing.bin")
+	require.Len(t, uploads, 2, "the request with a missing file is not sent")
+	assert.Equal(t, uploads[0].body, uploads[1].body, "the retry sends the same form")
+
+	sent := uploads[1]
+	assert.Equal(t, int64(len(sent.body)), sent.contentLength)
+	assert.Empty(t, sent.transferEncoding, "the form is not sent chunked")
+	assert.Contains(t, string(sent.body), "\r\n--XBoundary\r\n")
+	mediaType, params, err := mime.ParseMediaType(sent.contentType)
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+	reader := multipart.NewReader(bytes.NewReader(sent.body), params["boundary"])
+
+	title, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "title", title.FormName())
+	assert.Empty(t, title.FileName())
+	titleValue, _ := io.ReadAll(title)
+	assert.Equal(t, "Holiday", string(titleValue))
+
+	avatar, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "avatar", avatar.FormName())
+	assert.Equal(t, "avatar.png", avatar.FileName())
+	assert.Equal(t, "image/png", avatar.Header.Get("Content-Type"))
+	assert.Equal(t, "abc", avatar.Header.Get("X-Checksum"))
+	avatarContent, _ := io.ReadAll(avatar)
+	assert.Equal(t, image, avatarContent)
+
+	_, err = reader.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+
+	// When
+	rendered, renderErr := client.RenderResolved(renderFile)
+
+	// Then
+	require.NoError(t, renderErr)
+	assert.Contains(t, rendered, "Holiday\n--XBoundary\n")
+	assert.Contains(t, rendered, "< ./avatar.png\n", "files are rendered as references")
+}
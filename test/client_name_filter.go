@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.29 - Client Core Execution: Request Name Filter
+// Corresponds to: The WithNameFilter(pattern) client option, which runs only the requests whose
+// @name matches a regular expression, like "go test -run", and WithNameGlob(pattern) for glob patterns.
+// This test verifies that matching requests run in file order, that unnamed requests are skipped,
+// that the filter combines with RunOptions.Tags, that globs match whole names, and that invalid
+// patterns and files without any matching request fail.
+func RunExecuteFile_WithNameFilter(t *testing.T) {
+	t.Helper()
+	// Given
+	var paths []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "collection.http")
+	content := fmt.Sprintf(`# @name user_create
+# @tag smoke
+POST %[1]s/users
+
+###
+# @name order_list
+GET %[1]s/orders
+
+###
+GET %[1]s/unnamed
+
+###
+# @name user_delete
+DELETE %[1]s/users/1
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithNameFilter("^user_"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	responses, execErr := client.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"/users", "/users/1"}, paths)
+
+	// When
+	paths = nil
+	responses, execErr = client.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{Tags: []string{"smoke"}})
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, []string{"/users"}, paths)
+
+	// When
+	noMatch, err := rc.NewClient(rc.WithNameFilter("^admin_"))
+	require.NoError(t, err)
+	_, execErr = noMatch.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), `name filter "^admin_"`)
+
+	// When
+	paths = nil
+	globClient, err := rc.NewClient(rc.WithNameGlob("*_l?st"))
+	require.NoError(t, err)
+	_, execErr = globClient.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, []string{"/orders"}, paths)
+
+	// When
+	_, err = rc.NewClient(rc.WithNameFilter("user_("))
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid name filter "user_("`)
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.19 - Client Core Execution: Network Shaping
+// Corresponds to: The WithNetworkShaping(latency, bandwidth, jitter) client option, which delays
+// requests and throttles request and response bodies to simulate slow networks.
+// This test verifies the added latency, the bandwidth limit on a response body, and that a
+// context deadline shorter than the latency fails the request.
+func RunExecuteFile_WithNetworkShaping(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			_, _ = fmt.Fprint(w, strings.Repeat("x", 1000))
+		}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "shaping.http")
+	content := fmt.Sprintf("GET %[1]s/small\n\n###\nGET %[1]s/large\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithNetworkShaping(40*time.Millisecond, 5000, 5*time.Millisecond))
+	require.NoError(t, err)
+
+	// When
+	start := time.Now()
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+	elapsed := time.Since(start)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.GreaterOrEqual(t, responses[0].Duration, 35*time.Millisecond, "latency minus jitter")
+	assert.Len(t, responses[1].BodyString, 1000)
+	// 1000 bytes at 5000 bytes/s take 200ms on top of the latency
+	assert.GreaterOrEqual(t, elapsed, 35*time.Millisecond+35*time.Millisecond+200*time.Millisecond)
+
+	// When the deadline is shorter than the latency
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	responses, execErr = client.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	require.NotEmpty(t, responses)
+	assert.True(t, errors.Is(responses[0].Error, context.DeadlineExceeded), "got %v", responses[0].Error)
+
+	_, err = rc.NewClient(rc.WithNetworkShaping(-time.Second, 0, 0))
+	assert.Error(t, err)
+}
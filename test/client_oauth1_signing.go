@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.20 - Client Core Execution: OAuth 1.0a Request Signing
+// Corresponds to: The WithOAuth1(host, config) client option and the OAuth1 entries of the
+// Security.Auth section of http-client.env.json selected with "# @auth oauth1 <id>", which sign
+// requests with HMAC-SHA1 or RSA-SHA1 in the Authorization header or the query string.
+// This test verifies that the server can recompute the HMAC-SHA1 signature over the method, URL,
+// query and form body parameters, and verify an RSA-SHA1 signature sent in the query string.
+func RunExecuteFile_WithOAuth1Signing(t *testing.T) {
+	t.Helper()
+	// Given
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	var verified []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified = append(verified, verifyOAuth1Request(r, string(body), &privateKey.PublicKey))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "consumer.pem"), keyPEM, 0600))
+	env := `{"dev": {"user": "ann", "Security": {"Auth": {"legacy": {"Type": "OAuth1",
+		"Consumer Key": "rsa-key", "Signature Method": "RSA-SHA1", "Private Key File": "consumer.pem",
+		"Placement": "query"}}}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(env), 0644))
+	httpFile := filepath.Join(dir, "oauth1.http")
+	content := fmt.Sprintf(`POST %[1]s/statuses/update?include_entities=true
+Content-Type: application/x-www-form-urlencoded
+
+status=Hello%%20Ladies%%20%%2B%%20Gentlemen&user={{user}}
+
+###
+# @auth oauth1 legacy
+GET %[1]s/photos?size=original
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	host := strings.TrimPrefix(server.URL, "http://")
+	client, err := rc.NewClient(
+		rc.WithEnvironment("dev"),
+		rc.WithOAuth1(host, rc.OAuth1Config{
+			ConsumerKey: "hmac-key", ConsumerSecret: "consumer secret",
+			Token: "token", TokenSecret: "token&secret", Realm: "Photos",
+		}),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"header:HMAC-SHA1:hmac-key", "query:RSA-SHA1:rsa-key"}, verified)
+}
+
+// verifyOAuth1Request checks the OAuth1 signature of a request and describes it as
+// "placement:method:consumer key", or returns the reason the signature is invalid
+func verifyOAuth1Request(r *http.Request, body string, publicKey *rsa.PublicKey) string {
+	params := url.Values{}
+	placement := "query"
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "OAuth ") {
+		placement = "header"
+		for _, part := range strings.Split(strings.TrimPrefix(header, "OAuth "), ", ") {
+			key, value, _ := strings.Cut(part, "=")
+			if key == "realm" {
+				continue
+			}
+			unescaped, _ := url.PathUnescape(strings.Trim(value, `"`))
+			params.Add(key, unescaped)
+		}
+	}
+	for key, values := range r.URL.Query() {
+		params[key] = append(params[key], values...)
+	}
+	if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		form, _ := url.ParseQuery(body)
+		for key, values := range form {
+			params[key] = append(params[key], values...)
+		}
+	}
+	signature := params.Get("oauth_signature")
+	params.Del("oauth_signature")
+
+	var pairs []string
+	for key, values := range params {
+		for _, value := range values {
+			pairs = append(pairs, oauthEscape(key)+"="+oauthEscape(value))
+		}
+	}
+	sort.Strings(pairs)
+	baseString := r.Method + "&" + oauthEscape("http://"+r.Host+r.URL.EscapedPath()) + "&" +
+		oauthEscape(strings.Join(pairs, "&"))
+
+	method := params.Get("oauth_signature_method")
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "undecodable signature"
+	}
+	switch method {
+	case "HMAC-SHA1":
+		mac := hmac.New(sha1.New, []byte(oauthEscape("consumer secret")+"&"+oauthEscape("token&secret")))
+		mac.Write([]byte(baseString))
+		if !hmac.Equal(mac.Sum(nil), decoded) {
+			return "HMAC-SHA1 signature mismatch"
+		}
+	case "RSA-SHA1":
+		digest := sha1.Sum([]byte(baseString))
+		if rsa.VerifyPKCS1v15(publicKey, crypto.SHA1, digest[:], decoded) != nil {
+			return "RSA-SHA1 signature mismatch"
+		}
+	default:
+		return "unexpected signature method " + method
+	}
+	return placement + ":" + method + ":" + params.Get("oauth_consumer_key")
+}
+
+// oauthEscape percent-encodes all but the RFC 3986 unreserved characters
+func oauthEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.47 - Client Core Execution: OAuth2 Client Credentials and {{$auth.token}}
+// Corresponds to: OAuth2 entries with "Grant Type": "Client Credentials" in the Security.Auth section of
+// http-client.env.json, referenced with the {{$auth.token("id")}} system variable or "# @auth oauth2 <id>",
+// and WithOAuth2 configurations with GrantType OAuth2ClientCredentials.
+// This test verifies that the token is obtained once with the client's ID, secret and scope and reused by
+// every request of the run, in headers and file variables alike, that an unknown configuration fails the
+// request, and that WithOAuth2 authorizes requests with the client credentials grant.
+func RunExecuteFile_WithOAuth2ClientCredentials(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var tokenRequests []url.Values
+	authorizations := map[string]string{}
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/token" {
+			_ = r.ParseForm()
+			tokenRequests = append(tokenRequests, r.PostForm)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"access_token": "cc-1", "token_type": "Bearer", "expires_in": 3600}`)
+			return
+		}
+		authorizations[r.URL.Path] = r.Header.Get("Authorization") + r.Header.Get("X-Token")
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	env := fmt.Sprintf(`{"dev": {"Security": {"Auth": {"api": {"Type": "OAuth2",
+		"Grant Type": "Client Credentials", "Token URL": "%s/token",
+		"Client ID": "svc", "Client Secret": "s3cret", "Scope": "orders:read"}}}}}`, server.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(env), 0644))
+	content := fmt.Sprintf("@token = {{$auth.token(\"api\")}}\n\n"+
+		"GET %[1]s/header\nAuthorization: Bearer {{$auth.token(\"api\")}}\n\n"+
+		"###\nGET %[1]s/variable\nX-Token: {{token}}\n\n"+
+		"###\n# @auth oauth2 api\nGET %[1]s/directive\n\n"+
+		"###\nGET %[1]s/unknown\nAuthorization: Bearer {{$auth.token(\"other\")}}\n", server.URL)
+	httpFile := filepath.Join(dir, "orders.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithEnvironment("dev"))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 4)
+	require.Error(t, responses[3].Error)
+	assert.Contains(t, responses[3].Error.Error(), "'other'")
+	assert.Equal(t, map[string]string{
+		"/header":    "Bearer cc-1",
+		"/variable":  "cc-1",
+		"/directive": "Bearer cc-1",
+	}, authorizations)
+	require.Len(t, tokenRequests, 1, "the token is cached for the run")
+	assert.Equal(t, "client_credentials", tokenRequests[0].Get("grant_type"))
+	assert.Equal(t, "svc", tokenRequests[0].Get("client_id"))
+	assert.Equal(t, "s3cret", tokenRequests[0].Get("client_secret"))
+	assert.Equal(t, "orders:read", tokenRequests[0].Get("scope"))
+
+	// Given
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	programmatic, err := rc.NewClient(rc.WithOAuth2(serverURL.Host, rc.OAuth2Config{
+		GrantType:    rc.OAuth2ClientCredentials,
+		TokenURL:     server.URL + "/token",
+		ClientID:     "svc",
+		ClientSecret: "s3cret",
+	}))
+	require.NoError(t, err)
+	request := fmt.Sprintf("GET %s/programmatic\n", server.URL)
+	programmaticFile := filepath.Join(dir, "programmatic.http")
+	require.NoError(t, os.WriteFile(programmaticFile, []byte(request), 0644))
+
+	// When
+	_, execErr = programmatic.ExecuteFile(context.Background(), programmaticFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "Bearer cc-1", authorizations["/programmatic"])
+	require.Len(t, tokenRequests, 2)
+
+	// When
+	_, err = rc.NewClient(rc.WithOAuth2("example.com", rc.OAuth2Config{
+		GrantType: rc.OAuth2ClientCredentials, TokenURL: server.URL + "/token", ClientID: "svc",
+	}))
+
+	// Then
+	require.Error(t, err, "the client credentials grant needs a client secret")
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.21 - Client Core Execution: OAuth2 Device Authorization Grant
+// Corresponds to: OAuth2 entries with "Grant Type": "Device Authorization" in the Security.Auth
+// section of http-client.env.json, selected with "# @auth oauth2 <id>", and the
+// WithDeviceCodeHandler client option receiving the user code and verification URI.
+// This test verifies that the client polls the token endpoint while authorization is pending, sends
+// the obtained token as a Bearer token, and reuses the cached token for later requests.
+func RunExecuteFile_WithOAuth2DeviceFlow(t *testing.T) {
+	t.Helper()
+	// Given
+	var deviceRequests, tokenRequests atomic.Int32
+	var authorizations []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device":
+			deviceRequests.Add(1)
+			_ = r.ParseForm()
+			assert.Equal(t, "cli", r.PostForm.Get("client_id"))
+			assert.Equal(t, "read write", r.PostForm.Get("scope"))
+			_, _ = fmt.Fprint(w, `{"device_code": "dev-123", "user_code": "WDJB-MJHT",
+				"verification_uri": "https://example.com/device", "expires_in": 60, "interval": 0}`)
+		case "/token":
+			_ = r.ParseForm()
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "dev-123", r.PostForm.Get("device_code"))
+			if tokenRequests.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"error": "authorization_pending"}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"access_token": "at-1", "token_type": "bearer", "expires_in": 3600}`)
+		default:
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	env := fmt.Sprintf(`{"dev": {"Security": {"Auth": {"device": {"Type": "OAuth2",
+		"Grant Type": "Device Authorization", "Device Auth URL": "%[1]s/device",
+		"Token URL": "%[1]s/token", "Client ID": "cli", "Scope": "read write"}}}}}`, server.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(env), 0644))
+	httpFile := filepath.Join(dir, "device.http")
+	content := fmt.Sprintf("# @auth oauth2 device\nGET %[1]s/a\n\n###\n# @auth oauth2 device\nGET %[1]s/b\n",
+		server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	var shown []rc.DeviceAuthorization
+	client, err := rc.NewClient(
+		rc.WithEnvironment("dev"),
+		rc.WithDeviceCodeHandler(func(_ context.Context, authorization rc.DeviceAuthorization) error {
+			shown = append(shown, authorization)
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"Bearer at-1", "Bearer at-1"}, authorizations)
+	require.Len(t, shown, 1)
+	assert.Equal(t, "WDJB-MJHT", shown[0].UserCode)
+	assert.Equal(t, "https://example.com/device", shown[0].VerificationURI)
+	assert.Equal(t, int32(1), deviceRequests.Load())
+	assert.Equal(t, int32(2), tokenRequests.Load())
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.22 - Client Core Execution: OAuth2 Token Store and Refresh
+// Corresponds to: The WithTokenStore(store) client option with NewMemoryTokenStore, NewFileTokenStore
+// or a custom TokenStore, and the renewal of OAuth2 tokens with their refresh token when the server
+// rejects them with 401 Unauthorized, retrying the request once.
+// This test verifies that a request rejected mid-run is retried with a refreshed token, and that a
+// second client sharing the token file reuses the refreshed token without authorizing again.
+func RunExecuteFile_WithOAuth2TokenRefresh(t *testing.T) {
+	t.Helper()
+	// Given
+	var deviceRequests, apiRequests atomic.Int32
+	var refreshTokens, authorizations []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device":
+			deviceRequests.Add(1)
+			_, _ = fmt.Fprint(w, `{"device_code": "dev", "user_code": "CODE",
+				"verification_uri": "https://example.com/device", "interval": 0}`)
+		case "/token":
+			_ = r.ParseForm()
+			if r.PostForm.Get("grant_type") == "refresh_token" {
+				refreshTokens = append(refreshTokens, r.PostForm.Get("refresh_token"))
+				_, _ = fmt.Fprint(w, `{"access_token": "at-2", "expires_in": 3600}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"access_token": "at-1", "refresh_token": "rt-1", "expires_in": 3600}`)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			authorizations = append(authorizations, r.Header.Get("Authorization")+" "+string(body))
+			// at-1 is revoked after its first use
+			if apiRequests.Add(1) > 1 && r.Header.Get("Authorization") == "Bearer at-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "refresh.http")
+	content := fmt.Sprintf("GET %[1]s/a\n\n###\nPOST %[1]s/b\nContent-Type: text/plain\n\npayload\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	storePath := filepath.Join(dir, "tokens", "oauth2.json")
+	config := rc.OAuth2Config{
+		DeviceAuthURL: server.URL + "/device", TokenURL: server.URL + "/token", ClientID: "cli",
+	}
+	host := strings.TrimPrefix(server.URL, "http://")
+	newClient := func() *rc.Client {
+		client, err := rc.NewClient(
+			rc.WithOAuth2(host, config),
+			rc.WithTokenStore(rc.NewFileTokenStore(storePath)),
+			rc.WithDeviceCodeHandler(func(context.Context, rc.DeviceAuthorization) error { return nil }),
+		)
+		require.NoError(t, err)
+		return client
+	}
+
+	// When
+	responses, execErr := newClient().ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Equal(t, http.StatusOK, responses[1].StatusCode)
+	assert.Equal(t, []string{"Bearer at-1 ", "Bearer at-1 payload", "Bearer at-2 payload"}, authorizations)
+	assert.Equal(t, []string{"rt-1"}, refreshTokens)
+	info, err := os.Stat(storePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// When
+	authorizations = nil
+	responses, execErr = newClient().ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"Bearer at-2 ", "Bearer at-2 payload"}, authorizations)
+	assert.Equal(t, int32(1), deviceRequests.Load())
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openAPITestSpec is the spec of the OpenAPI generation test, %s being the URL of its server
+const openAPITestSpec = `openapi: 3.0.3
+servers:
+  - url: %s/
+paths:
+  /users:
+    post:
+      operationId: createUser
+      tags: [users]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+  /users/{userId}:
+    parameters:
+      - name: userId
+        in: path
+        required: true
+        example: 42
+        schema: {type: integer}
+    get:
+      operationId: getUser
+      tags: [users]
+      parameters:
+        - name: expand
+          in: query
+          schema: {type: string, enum: [orders, payments]}
+        - name: X-Request-ID
+          in: header
+          schema: {type: string, format: uuid}
+    delete:
+      tags: [admin]
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name: {type: string, example: Ann}
+        email: {type: string, format: email}
+        age: {type: integer}
+        roles:
+          type: array
+          items: {type: string, default: reader}
+`
+
+// PRD-COMMENT: FR10.50 - Client Core Execution: OpenAPI Request Generation
+// Corresponds to: GenerateRequestsFromOpenAPI and GenerateHTTPFromOpenAPI.
+// This test verifies that a request is generated for each operation of an OpenAPI 3 spec, named after
+// its operationId, with path, query and header parameters templated as variables set to their examples,
+// and a JSON body built from the schema, both as requests to execute and as an .http file, and that
+// the Tags option selects operations.
+func RunGenerateRequestsFromOpenAPI(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var received []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, fmt.Sprintf("%s %s %s %s", r.Method, r.URL.RequestURI(),
+			r.Header.Get("X-Request-ID"), body))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "openapi.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(fmt.Sprintf(openAPITestSpec, server.URL)), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	requests, genErr := rc.GenerateRequestsFromOpenAPI(specPath, rc.OpenAPIOptions{})
+	httpContent, httpErr := rc.GenerateHTTPFromOpenAPI(specPath, rc.OpenAPIOptions{})
+
+	// Then
+	require.NoError(t, genErr)
+	require.NoError(t, httpErr)
+	require.Len(t, requests, 3)
+	assert.Equal(t, []string{"createUser", "getUser", "delete-users-userId"},
+		[]string{requests[0].Name, requests[1].Name, requests[2].Name})
+	assert.Equal(t, "{{baseUrl}}/users/{{userId}}?expand={{expand}}", requests[1].RawURLString)
+	assert.Equal(t, []string{"users"}, requests[1].Tags)
+	assert.Equal(t, "application/json", requests[0].Headers.Get("Content-Type"))
+	assert.Contains(t, httpContent, "@baseUrl = "+server.URL+"\n")
+	assert.Contains(t, httpContent, "@userId = 42\n")
+
+	// When the generated .http file and an in-memory request are executed
+	httpFile := filepath.Join(dir, "openapi.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(httpContent), 0644))
+	_, execErr := client.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, execErr)
+	_, reqErr := client.ExecuteRequest(context.Background(), requests[1])
+	require.NoError(t, reqErr)
+
+	// Then
+	userBody := "{\n  \"age\": 0,\n  \"email\": \"user@example.com\",\n  \"name\": \"Ann\",\n  \"roles\": [\n" +
+		"    \"reader\"\n  ]\n}"
+	getUser := "GET /users/42?expand=orders 00000000-0000-0000-0000-000000000000 "
+	assert.Equal(t, []string{"POST /users  " + userBody, getUser, "DELETE /users/42  ", getUser}, received)
+
+	// When operations are selected by tag
+	adminRequests, adminErr := rc.GenerateRequestsFromOpenAPI(specPath,
+		rc.OpenAPIOptions{Tags: []string{"admin"}, BaseURL: "https://staging.example.com"})
+
+	// Then
+	require.NoError(t, adminErr)
+	require.Len(t, adminRequests, 1)
+	assert.Equal(t, "DELETE", adminRequests[0].Method)
+	assert.Equal(t, "https://staging.example.com", adminRequests[0].ActiveVariables["@baseUrl"])
+}
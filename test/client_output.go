@@ -0,0 +1,75 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.44 - Client Core Execution: Response Bodies Written to Files
+// Corresponds to: The "# @output <file>" request directive, which streams the response body to a file
+// relative to the request file instead of buffering it, for large downloads.
+// This test verifies that the body is written to the file, with variables substituted and directories
+// created, that Body stays empty while sizes are still reported, that other requests are unaffected, and
+// that a file which cannot be written fails the request.
+func RunExecuteFile_OutputDirective(t *testing.T) {
+	t.Helper()
+	// Given
+	artifact := bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/artifact" {
+			_, _ = w.Write(artifact)
+			return
+		}
+		_, _ = w.Write([]byte("report"))
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blocker"), nil, 0644))
+	content := fmt.Sprintf("@dir = downloads\n\n# @output ./{{dir}}/artifact.bin\nGET %[1]s/artifact\n\n###\n"+
+		"GET %[1]s/report\n\n###\n# @output ./blocker/artifact.bin\nGET %[1]s/artifact\n", server.URL)
+	httpFile := filepath.Join(dir, "download.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 3)
+	download := responses[0]
+	require.NoError(t, download.Error)
+	assert.Equal(t, http.StatusOK, download.StatusCode)
+	assert.Empty(t, download.Body, "the body is not buffered")
+	assert.Equal(t, filepath.Join(dir, "downloads", "artifact.bin"), download.OutputFile)
+	written, err := os.ReadFile(download.OutputFile)
+	require.NoError(t, err)
+	assert.Equal(t, artifact, written)
+	assert.Equal(t, int64(len(artifact)), download.Size)
+	assert.Greater(t, download.BytesReceived, int64(len(artifact)))
+
+	assert.Equal(t, "report", responses[1].BodyString)
+	assert.Empty(t, responses[1].OutputFile)
+
+	require.Error(t, responses[2].Error)
+	assert.Contains(t, responses[2].Error.Error(), "failed to write response body to")
+	assert.Empty(t, responses[2].OutputFile)
+
+	// When
+	rendered, renderErr := client.RenderResolved(httpFile)
+
+	// Then
+	require.NoError(t, renderErr)
+	assert.Contains(t, rendered, "# @output ./downloads/artifact.bin\nGET ")
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerTestPlugins registers the plugins used by the plugin tests once per test binary
+var registerTestPlugins = sync.OnceFunc(func() {
+	rc.RegisterAuthProvider("test-token", rc.AuthProviderFunc(func(req *http.Request, args []string) error {
+		if len(args) != 1 {
+			return errors.New("expected a token scope")
+		}
+		req.Header.Set("Authorization", "Token scope="+args[0])
+		return nil
+	}))
+	rc.RegisterBodyEncoder("application/x-test-upper", rc.BodyEncoderFunc(func(body, _ string) (string, error) {
+		return strings.ToUpper(body), nil
+	}))
+	rc.RegisterValidator("test-has-header", rc.ResponseValidatorFunc(func(resp *rc.Response, args []string) error {
+		for _, name := range args {
+			if resp.Header(name) == "" {
+				return fmt.Errorf("missing header %s", name)
+			}
+		}
+		return nil
+	}))
+	rc.RegisterBodyValidator("application/x-test-problem+json", rc.BodyValidatorFunc(compareProblemType))
+	rc.RegisterSystemVariable("testTenant", rc.SystemVariableFunc(func(args []string) (string, error) {
+		return "tenant-" + strings.Join(args, "-"), nil
+	}))
+})
+
+// compareProblemType is a test body validator comparing only the "type" member of problem details
+func compareProblemType(expected, actual string) error {
+	var expectedProblem, actualProblem struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedProblem); err != nil {
+		return fmt.Errorf("expected body: %w", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &actualProblem); err != nil {
+		return fmt.Errorf("actual body: %w", err)
+	}
+	if expectedProblem.Type != actualProblem.Type {
+		return fmt.Errorf("problem type %q, want %q", actualProblem.Type, expectedProblem.Type)
+	}
+	return nil
+}
+
+// PRD-COMMENT: FR10.15 - Client Core Execution: Plugin Registry
+// Corresponds to: restclient.Register* functions extending every client with auth providers (@auth
+// directive), body encoders (by media type), response validators ("# validate" directive) and system
+// variables ({{$name args}}).
+// This test verifies each kind of plugin is applied during execution and validation, and that unknown
+// names and duplicate registrations are reported.
+func RunExecuteFile_Plugins(t *testing.T) {
+	t.Helper()
+	// Given
+	registerTestPlugins()
+	var gotAuth, gotBody, gotPath string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotAuth, gotBody, gotPath = r.Header.Get("Authorization"), string(body), r.URL.Path
+		w.Header().Set("X-Trace", "abc")
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "plugins.http")
+	content := fmt.Sprintf("# @auth test-token read\nPOST %s/{{$testTenant eu 1}}/items\n"+
+		"Content-Type: application/x-test-upper\n\nhello {{$testTenant}}\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	hrespFile := filepath.Join(dir, "plugins.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte("HTTP/1.1 200 OK\n# validate test-has-header X-Trace X-Missing\n"),
+		0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+	validateErr := client.ValidateResponses(hrespFile, responses...)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "Token scope=read", gotAuth)
+	assert.Equal(t, "/tenant-eu-1/items", gotPath)
+	assert.Equal(t, "HELLO TENANT-", gotBody)
+	require.Error(t, validateErr)
+	assert.Contains(t, validateErr.Error(), "validator 'test-has-header' failed: missing header X-Missing")
+	assert.Contains(t, rc.RegisteredPlugins()["validator"], "test-has-header")
+	assert.Panics(t, func() { rc.RegisterValidator("test-has-header", rc.ResponseValidatorFunc(nil)) })
+
+	unknownFile := filepath.Join(dir, "unknown.hresp")
+	require.NoError(t, os.WriteFile(unknownFile, []byte("HTTP/1.1 200 OK\n# validate nope\n"), 0644))
+	assert.ErrorContains(t, client.ValidateResponses(unknownFile, responses...), "unknown validator 'nope'")
+}
+
+// PRD-COMMENT: FR10.15 - Client Core Execution: Plugin Registry (Body Validators)
+// Corresponds to: restclient.RegisterBodyValidator(mediaType, validator), extending ValidateResponses with
+// body comparisons for media types the built-in one does not understand.
+// This test verifies that a registered validator replaces the built-in body comparison for responses of
+// its media type, whatever their Content-Type parameters.
+func RunValidateResponses_BodyValidatorPlugin(t *testing.T) {
+	t.Helper()
+	// Given
+	registerTestPlugins()
+	hrespFile := filepath.Join(t.TempDir(), "problem.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte("HTTP/1.1 409 Conflict\n\n"+
+		`{"type": "/errors/out-of-stock", "title": "{{$any}}"}`), 0644))
+	response := func(body string) *rc.Response {
+		return &rc.Response{
+			Status: "409 Conflict", StatusCode: http.StatusConflict,
+			Headers:    http.Header{"Content-Type": {"application/x-test-problem+json; charset=utf-8"}},
+			BodyString: body,
+		}
+	}
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	matchErr := client.ValidateResponses(hrespFile,
+		response(`{"type": "/errors/out-of-stock", "title": "Out of stock", "instance": "/orders/7"}`))
+	mismatchErr := client.ValidateResponses(hrespFile, response(`{"type": "/errors/gone"}`))
+
+	// Then
+	assert.NoError(t, matchErr)
+	require.Error(t, mismatchErr)
+	assert.Contains(t, mismatchErr.Error(),
+		`body mismatch: problem type "/errors/gone", want "/errors/out-of-stock"`)
+	assert.Contains(t, rc.RegisteredPlugins()["bodyValidator"], "application/x-test-problem+json")
+	assert.Panics(t, func() {
+		rc.RegisterBodyValidator("Application/X-Test-Problem+JSON", rc.BodyValidatorFunc(compareProblemType))
+	})
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// progressEvent is one call of a ProgressFunc
+type progressEvent struct {
+	sent, received, total int64
+}
+
+// PRD-COMMENT: FR10.38 - Client Core Execution: Transfer Progress
+// Corresponds to: Client option WithProgress(func(req *Request, sent, received, total int64)).
+// This test verifies that the upload and download of large bodies are reported chunk by chunk with
+// growing byte counts and their Content-Length, for the request being transferred.
+func RunExecuteFile_Progress(t *testing.T) {
+	t.Helper()
+	// Given
+	const uploadSize, downloadSize = 1 << 20, 2 << 20
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Length", fmt.Sprint(downloadSize))
+		_, _ = w.Write(bytes.Repeat([]byte("d"), downloadSize))
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "upload.bin"), bytes.Repeat([]byte("u"), uploadSize), 0644))
+	httpFile := filepath.Join(dir, "transfer.http")
+	content := fmt.Sprintf("# @name transfer\nPOST %s/files\nContent-Type: application/octet-stream\n\n< ./upload.bin\n",
+		server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var mu sync.Mutex
+	var events []progressEvent
+	client, err := rc.NewClient(rc.WithProgress(func(req *rc.Request, sent, received, total int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "transfer", req.Name)
+		events = append(events, progressEvent{sent: sent, received: received, total: total})
+	}))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Len(t, responses[0].Body, downloadSize)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Greater(t, len(events), 2, "large bodies are reported in several chunks")
+	for i := 1; i < len(events); i++ {
+		assert.GreaterOrEqual(t, events[i].sent, events[i-1].sent)
+		assert.GreaterOrEqual(t, events[i].received, events[i-1].received)
+	}
+	assert.Contains(t, events, progressEvent{sent: uploadSize, received: 0, total: uploadSize})
+	assert.Equal(t, progressEvent{sent: uploadSize, received: downloadSize, total: downloadSize}, events[len(events)-1])
+
+	_, err = rc.NewClient(rc.WithProgress(nil))
+	assert.Error(t, err)
+}
+
+// PRD-COMMENT: FR10.38 - Client Core Execution: Transfer Progress
+// Corresponds to: Client option WithProgress for requests whose body is sent again.
+// This test verifies that a body sent again after a 307 redirect is reported from zero like the first
+// upload, instead of going unreported.
+func RunExecuteFile_ProgressAfterRedirect(t *testing.T) {
+	t.Helper()
+	// Given
+	const uploadSize = 256 << 10
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if r.URL.Path == "/files" {
+			http.Redirect(w, r, "/stored", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "upload.bin"), bytes.Repeat([]byte("u"), uploadSize), 0644))
+	httpFile := filepath.Join(dir, "transfer.http")
+	content := fmt.Sprintf("POST %s/files\nContent-Type: application/octet-stream\n\n< ./upload.bin\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var mu sync.Mutex
+	var events []progressEvent
+	client, err := rc.NewClient(rc.WithProgress(func(_ *rc.Request, sent, received, total int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, progressEvent{sent: sent, received: received, total: total})
+	}))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, http.StatusCreated, responses[0].StatusCode)
+	mu.Lock()
+	defer mu.Unlock()
+	uploads := 0
+	for i, event := range events {
+		assert.LessOrEqual(t, event.sent, int64(uploadSize))
+		if event.sent == uploadSize && (i+1 == len(events) || events[i+1].sent < uploadSize) {
+			uploads++
+		}
+	}
+	assert.Equal(t, 2, uploads, "the body is reported once per upload")
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.4 - Request Diagnostics: Rendering Resolved Requests
+// Corresponds to: Request.String() and Client.RenderResolved(path), which render requests back
+// into .http syntax after variable substitution without executing them, so users can diff
+// what was authored against what is executed.
+// This test verifies file and programmatic variable resolution in the URL, headers and body,
+// BaseURL resolution, default header merging, directives and request separators.
+func RunRenderResolved_RendersSubstitutedRequests(t *testing.T) {
+	t.Helper()
+	// Given
+	httpFile := filepath.Join(t.TempDir(), "render.http")
+	content := "@host = api.example.com\n@userId = 42\n\n" +
+		"### Get user\n# @name getUser\n# @no-redirect\nGET https://{{host}}/users/{{userId}}\n" +
+		"Authorization: Bearer {{token}}\n\n" +
+		"###\nPOST /users\nContent-Type: application/json\n\n{\"id\": {{userId}}}\n"
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	client, err := rc.NewClient(
+		rc.WithBaseURL("https://base.example.com"),
+		rc.WithDefaultHeader("X-Client", "suite"),
+		rc.WithVars(map[string]any{"token": "t-1"}),
+	)
+	require.NoError(t, err)
+
+	// When
+	rendered, err := client.RenderResolved(httpFile)
+
+	// Then
+	require.NoError(t, err)
+	expected := "# @name getUser\n# @no-redirect\nGET https://api.example.com/users/42\n" +
+		"Authorization: Bearer t-1\nX-Client: suite\n" +
+		"\n###\n" +
+		"POST https://base.example.com/users\nContent-Type: application/json\nX-Client: suite\n\n{\"id\": 42}\n"
+	assert.Equal(t, expected, rendered)
+	assert.NotContains(t, rendered, "{{")
+}
+
+// PRD-COMMENT: FR6.4 - Request Diagnostics: Rendering Authored Requests
+// Corresponds to: Request.String() on requests parsed but not yet resolved.
+// This test verifies that such requests render as authored, including the HTTP version, the @timeout
+// directive and multi-value headers.
+func RunRequest_StringRendersAuthoredRequest(t *testing.T) {
+	t.Helper()
+	// Given
+	req := &rc.Request{
+		Name:         "upload",
+		Method:       "PUT",
+		RawURLString: "{{baseUrl}}/files",
+		HTTPVersion:  "HTTP/1.1",
+		Headers:      map[string][]string{"Accept": {"text/plain", "application/json"}},
+		RawBody:      "< ./payload.json",
+		Timeout:      1500 * time.Millisecond,
+	}
+
+	// When
+	rendered := req.String()
+
+	// Then
+	lines := strings.Split(strings.TrimSuffix(rendered, "\n"), "\n")
+	assert.Equal(t, []string{
+		"# @name upload",
+		"# @timeout 1500",
+		"PUT {{baseUrl}}/files HTTP/1.1",
+		"Accept: text/plain",
+		"Accept: application/json",
+		"",
+		"< ./payload.json",
+	}, lines)
+}
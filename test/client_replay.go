@@ -0,0 +1,160 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/bmcszk/go-restclient/history"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingServer records the path and body of every request it receives
+func recordingServer(paths, bodies *[]string) *httptest.Server {
+	return startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*paths = append(*paths, r.URL.Path)
+		*bodies = append(*bodies, string(body))
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// PRD-COMMENT: FR10.13 - Client Core Execution: Replaying Recorded Runs
+// Corresponds to: history.Replay and Client.ExecuteRequest re-executing recorded requests with the
+// client's environment and programmatic variables overriding the recorded values.
+// This test verifies a run recorded against one environment is replayed against another, with
+// selected variables overridden and the recorded bodies (including generated values) sent verbatim.
+func RunReplay_RecordedRunWithOverrides(t *testing.T) {
+	t.Helper()
+	// Given
+	var prodPaths, prodBodies, stagingPaths, stagingBodies []string
+	prod := recordingServer(&prodPaths, &prodBodies)
+	defer prod.Close()
+	staging := recordingServer(&stagingPaths, &stagingBodies)
+	defer staging.Close()
+
+	dir := t.TempDir()
+	envFile := fmt.Sprintf(`{"prod": {"host": %q}, "staging": {"host": %q}}`, prod.URL, staging.URL)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(envFile), 0644))
+	httpFile := filepath.Join(dir, "orders.http")
+	content := "@tenant = acme\n\n# @name createOrder\nPOST {{host}}/{{tenant}}/orders\n" +
+		"Content-Type: application/json\n\n{\"id\": \"{{$uuid}}\"}\n"
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	store, err := history.Open(filepath.Join(dir, "history.jsonl"))
+	require.NoError(t, err)
+	prodClient, err := rc.NewClient(rc.WithEnvironment("prod"), rc.WithHistory(store))
+	require.NoError(t, err)
+	_, err = prodClient.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, err)
+	recorded, err := store.LastRun()
+	require.NoError(t, err)
+
+	stagingClient, err := rc.NewClient(rc.WithEnvironment("staging"), rc.WithVars(map[string]any{"tenant": "beta"}))
+	require.NoError(t, err)
+
+	// When
+	responses, err := history.Replay(context.Background(), stagingClient, recorded)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	assert.Equal(t, http.StatusAccepted, responses[0].StatusCode)
+	assert.Equal(t, []string{"/acme/orders"}, prodPaths)
+	assert.Equal(t, []string{"/beta/orders"}, stagingPaths)
+	require.Len(t, stagingBodies, 1)
+	assert.Equal(t, prodBodies[0], stagingBodies[0], "the recorded body is reused verbatim")
+	assert.Equal(t, "application/json", responses[0].Request.Headers.Get("Content-Type"))
+}
+
+// PRD-COMMENT: FR10.13 - Client Core Execution: Replaying Recorded Runs
+// Corresponds to: history.Replay reporting failures as RequestErrors of the recorded request index.
+// This test verifies a replayed request that cannot be sent is reported with its index and phase.
+func RunReplay_ReportsRequestErrors(t *testing.T) {
+	t.Helper()
+	// Given
+	closed := startMockServer(func(http.ResponseWriter, *http.Request) {})
+	closedURL := closed.URL
+	closed.Close()
+	entries := []history.Entry{{
+		Index:   2,
+		Name:    "down",
+		Request: history.RequestRecord{Method: http.MethodGet, URL: closedURL + "/down"},
+	}}
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := history.Replay(context.Background(), client, entries)
+
+	// Then
+	require.Len(t, responses, 1)
+	require.Error(t, responses[0].Error)
+	requestErrors := rc.RequestErrors(err)
+	require.Len(t, requestErrors, 1)
+	assert.Equal(t, 2, requestErrors[0].Index)
+	assert.Equal(t, rc.PhaseSend, requestErrors[0].Phase)
+	assert.Equal(t, closedURL+"/down", requestErrors[0].URL)
+}
+
+// PRD-COMMENT: FR10.13 - Client Core Execution: Replaying Recorded Runs
+// Corresponds to: the redaction of credential headers and the file variables they use in history entries
+// and history.Replay leaving them out.
+// This test verifies that Authorization, Cookie and Set-Cookie values are recorded as history.Redacted,
+// that no secret is written to the history file, and that a replay sends the replaying client's
+// credentials instead of the recorded ones.
+func RunReplay_RedactsCredentials(t *testing.T) {
+	t.Helper()
+	// Given
+	var authorizations, cookies []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		cookies = append(cookies, r.Header.Get("Cookie"))
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3cret"})
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "me.http")
+	content := fmt.Sprintf("@token = prod-token\n@path = me\n\nGET %s/{{path}}\nAuthorization: Bearer {{token}}\n"+
+		"Cookie: id=42\nAccept: text/plain\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	historyFile := filepath.Join(dir, "history.jsonl")
+	store, err := history.Open(historyFile)
+	require.NoError(t, err)
+	prodClient, err := rc.NewClient(rc.WithHistory(store))
+	require.NoError(t, err)
+	_, err = prodClient.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, err)
+	recorded, err := store.LastRun()
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	stagingClient, err := rc.NewClient(rc.WithDefaultHeader("Authorization", "Bearer staging-token"))
+	require.NoError(t, err)
+
+	// When
+	responses, replayErr := history.Replay(context.Background(), stagingClient, recorded)
+	stored, readErr := os.ReadFile(historyFile)
+
+	// Then
+	require.NoError(t, readErr)
+	assert.NotContains(t, string(stored), "prod-token")
+	assert.NotContains(t, string(stored), "s3cret")
+	assert.Equal(t, map[string]string{"@path": "me"}, recorded[0].Variables)
+	assert.Equal(t, history.Redacted, recorded[0].Request.Headers.Get("Authorization"))
+	assert.Equal(t, history.Redacted, recorded[0].Request.Headers.Get("Cookie"))
+	assert.Equal(t, "text/plain", recorded[0].Request.Headers.Get("Accept"))
+	assert.Equal(t, history.Redacted, recorded[0].Response.Headers.Get("Set-Cookie"))
+	require.NoError(t, replayErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, []string{"Bearer prod-token", "Bearer staging-token"}, authorizations)
+	assert.Equal(t, []string{"id=42", ""}, cookies, "recorded cookies are not replayed")
+}
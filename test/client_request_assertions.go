@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.14 - Client Core Execution: Go Assertion Callbacks
+// Corresponds to: Client option WithRequestAssertion attaching Go-native checks, written with testify,
+// to named requests executed by ExecuteFile.
+// This test verifies assertions run only for their request, passing checks report nothing, and failing
+// checks (including require's FailNow) are returned as RequestErrors of phase "assert".
+func RunExecuteFile_RequestAssertions(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"path": %q, "count": 2}`, r.URL.Path)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "assertions.http")
+	content := fmt.Sprintf("# @name listUsers\nGET %[1]s/users\n\n###\n# @name listOrders\nGET %[1]s/orders\n\n"+
+		"###\nGET %[1]s/unnamed\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var checked []string
+	client, err := rc.NewClient(
+		rc.WithRequestAssertion("listUsers", func(at rc.TestingT, resp *rc.Response) {
+			checked = append(checked, resp.Request.Name)
+			assert.Equal(at, http.StatusOK, resp.StatusCode)
+			assert.JSONEq(at, `{"path": "/users", "count": 2}`, resp.BodyString)
+		}),
+		rc.WithRequestAssertion("listOrders", func(at rc.TestingT, resp *rc.Response) {
+			checked = append(checked, resp.Request.Name)
+			assert.Equal(at, "text/plain", resp.ContentType(), "content type")
+			require.NotEmpty(at, resp.Header("X-Count"), "stops here")
+			checked = append(checked, "unreachable")
+		}),
+		rc.WithRequestAssertion("listOrders", func(_ rc.TestingT, _ *rc.Response) {
+			checked = append(checked, "second")
+		}),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 3)
+	assert.Equal(t, []string{"listUsers", "listOrders", "second"}, checked)
+	requestErrors := rc.RequestErrors(err)
+	require.Len(t, requestErrors, 1)
+	assert.Equal(t, 1, requestErrors[0].Index)
+	assert.Equal(t, "listOrders", requestErrors[0].Name)
+	assert.Equal(t, rc.PhaseAssert, requestErrors[0].Phase)
+	assert.Contains(t, requestErrors[0].Error(),
+		fmt.Sprintf(`request 2 "listOrders" (GET %s/orders) assertion failed`, server.URL))
+	assert.Contains(t, requestErrors[0].Err.Error(), "content type")
+	assert.Contains(t, requestErrors[0].Err.Error(), "stops here")
+
+	_, err = rc.NewClient(rc.WithRequestAssertion("", func(rc.TestingT, *rc.Response) {}))
+	assert.Error(t, err)
+}
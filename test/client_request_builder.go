@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.51 - Client Core Execution: Programmatic Request Builder
+// Corresponds to: NewRequest and the RequestBuilder methods.
+// This test verifies that a request built in code is sent with its variables, query parameters, headers
+// and JSON body, that its response validates against an .hresp file, and that builder errors are
+// returned by Execute without sending anything.
+func RunRequestBuilder_Execute(t *testing.T) {
+	t.Helper()
+	// Given
+	var received string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = fmt.Sprintf("%s %s %s %s %s", r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"),
+			r.Header.Get("Content-Type"), body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 7, "name": "Ann"}`))
+	})
+	defer server.Close()
+
+	hrespFile := filepath.Join(t.TempDir(), "created.hresp")
+	require.NoError(t, os.WriteFile(hrespFile,
+		[]byte("HTTP/1.1 201 Created\nContent-Type: application/json\n\n{\"id\": {{$anyNumber}}, \"name\": \"Ann\"}\n"),
+		0644))
+	client, err := rc.NewClient(rc.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	builder := rc.NewRequest("post", "/users/{{tenant}}").
+		Name("createUser").
+		Var("tenant", "acme").
+		Var("token", "secret").
+		Header("Authorization", "Bearer {{token}}").
+		Query("notify", "a b").
+		JSONBody(map[string]string{"name": "Ann"})
+
+	// When
+	resp, execErr := builder.Execute(context.Background(), client)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "POST /users/acme?notify=a+b Bearer secret application/json {\"name\":\"Ann\"}", received)
+	assert.Equal(t, "createUser", resp.Request.Name)
+	assert.NoError(t, client.ValidateResponses(hrespFile, resp))
+
+	// When a builder method failed
+	received = ""
+	_, badErr := rc.NewRequest(http.MethodPost, "/users").JSONBody(func() {}).Execute(context.Background(), client)
+
+	// Then
+	require.Error(t, badErr)
+	assert.Contains(t, badErr.Error(), "failed to encode JSON body")
+	assert.Empty(t, received)
+}
+
+// PRD-COMMENT: FR10.51 - Client Core Execution: Programmatic Request Builder
+// Corresponds to: RequestBuilder.NoRedirect and the equivalent "# @no-redirect" directive.
+// This test verifies that a redirect response is returned as is, without following it, for a request
+// built with NoRedirect, while the same request without it follows the redirect.
+func RunRequestBuilder_NoRedirect(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("moved here"))
+	})
+	defer server.Close()
+	client, err := rc.NewClient(rc.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	// When
+	notFollowed, notFollowedErr := rc.NewRequest(http.MethodGet, "/old").NoRedirect().Execute(context.Background(), client)
+	followed, followedErr := rc.NewRequest(http.MethodGet, "/old").Execute(context.Background(), client)
+
+	// Then
+	require.NoError(t, notFollowedErr)
+	assert.Equal(t, http.StatusFound, notFollowed.StatusCode)
+	assert.Equal(t, "/new", notFollowed.Headers.Get("Location"))
+	assert.Empty(t, notFollowed.Redirects)
+	require.NoError(t, followedErr)
+	assert.Equal(t, http.StatusOK, followed.StatusCode)
+	assert.Equal(t, "moved here", followed.BodyString)
+}
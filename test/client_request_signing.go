@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.16 - Client Core Execution: Request Signing
+// Corresponds to: WithRequestSigner hooks run just before sending, and the built-in HMACSigner
+// (signed headers, request line, body canonicalization, digest algorithm, encoding and target header).
+// This test verifies HMAC signatures computed over the canonical request are received by the server,
+// that custom signers see the body as sent, and that signing failures fail the request.
+func RunExecuteFile_WithRequestSigner(t *testing.T) {
+	t.Helper()
+	// Given
+	var gotSignature, gotBodyDigest, gotBody string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotSignature, gotBodyDigest, gotBody = r.Header.Get("X-Hub-Signature"), r.Header.Get("X-Body-Digest"), string(body)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "signed.http")
+	content := fmt.Sprintf("POST %s/hooks?x=1\nContent-Type: application/json\nX-Timestamp: 123\n\n"+
+		"{ \"a\": 1,\n  \"b\": [true] }\n\n###\n\nPOST %s/hooks\n\nnot json\n", server.URL, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	key := []byte("secret")
+	signer := rc.HMACSigner{
+		Key:                key,
+		Header:             "X-Hub-Signature",
+		Prefix:             "sha256=",
+		SignedHeaders:      []string{"X-Timestamp", "X-Missing"},
+		IncludeRequestLine: true,
+		Body:               rc.BodyCompactJSON,
+	}
+	digestSigner := func(req *http.Request, body []byte) error {
+		sum := sha512.Sum512(body)
+		req.Header.Set("X-Body-Digest", base64.StdEncoding.EncodeToString(sum[:]))
+		return nil
+	}
+	client, err := rc.NewClient(rc.WithRequestSigner(digestSigner), rc.WithRequestSigner(signer.Sign))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("POST /hooks?x=1\nx-timestamp:123\nx-missing:\n{\"a\":1,\"b\":[true]}"))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), gotSignature)
+	sum := sha512.Sum512([]byte(gotBody))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(sum[:]), gotBodyDigest)
+	assert.Equal(t, "{ \"a\": 1,\n  \"b\": [true] }", gotBody, "the body is sent unchanged")
+
+	require.Len(t, responses, 2)
+	assert.NoError(t, responses[0].Error)
+	require.Error(t, execErr)
+	assert.ErrorContains(t, responses[1].Error, "failed to sign request: cannot canonicalize body as JSON")
+	_, nilErr := rc.NewClient(rc.WithRequestSigner(nil))
+	assert.Error(t, nilErr)
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.6 - Response Metrics: Size and Duration Budgets
+// Corresponds to: Response.BytesSent / Response.BytesReceived alongside Response.Duration, and the
+// '# max-bytes' / '# max-duration' .hresp directives used as lightweight payload and latency gates.
+// This test verifies the byte counters of an executed request, that budgets within limits pass,
+// that exceeded budgets are reported, and that malformed directive values are rejected.
+func RunExecuteFile_ResponseSizeAndBudgets(t *testing.T) {
+	t.Helper()
+	// Given
+	responseBody := strings.Repeat("x", 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	tempDir := t.TempDir()
+	httpFile := filepath.Join(tempDir, "budgets.http")
+	requestBody := "payload-of-some-length"
+	require.NoError(t, os.WriteFile(httpFile,
+		[]byte(fmt.Sprintf("POST %s/items\nContent-Type: text/plain\n\n%s\n", server.URL, requestBody)), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	resp := responses[0]
+	assert.Greater(t, resp.BytesSent, int64(len(requestBody)+len("POST /items HTTP/1.1\r\n")))
+	assert.Greater(t, resp.BytesReceived, int64(len(responseBody)+len("HTTP/1.1 200 OK\r\n")))
+	assert.Positive(t, resp.Duration)
+
+	// Given budgets that hold and budgets that are exceeded
+	withinHresp := filepath.Join(tempDir, "within.hresp")
+	require.NoError(t, os.WriteFile(withinHresp,
+		[]byte("# max-bytes 100\n# max-duration 1m\nHTTP/1.1 200 OK\n\n{{$any}}\n"), 0644))
+	exceededHresp := filepath.Join(tempDir, "exceeded.hresp")
+	require.NoError(t, os.WriteFile(exceededHresp,
+		[]byte("# max-bytes 99\n# max-duration 10ms\nHTTP/1.1 200 OK\n\n{{$any}}\n"), 0644))
+	slowResp := *resp
+	slowResp.Duration = 250 * time.Millisecond
+
+	// When / Then
+	require.NoError(t, client.ValidateResponses(withinHresp, resp))
+	err = client.ValidateResponses(exceededHresp, &slowResp)
+	assertMultierrorContains(t, err, 2, []string{
+		"body size 100 bytes exceeds max-bytes 99",
+		"duration 250ms exceeds max-duration 10ms",
+	})
+
+	// Given a malformed directive
+	invalidHresp := filepath.Join(tempDir, "invalid.hresp")
+	require.NoError(t, os.WriteFile(invalidHresp,
+		[]byte("# max-duration fast\nHTTP/1.1 200 OK\n\n{{$any}}\n"), 0644))
+
+	// When
+	err = client.ValidateResponses(invalidHresp, resp)
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1: invalid '# max-duration' directive")
+}
+
+// PRD-COMMENT: FR6.6 - Response Metrics: Size and Duration Budgets
+// Corresponds to: The '# max-size' .hresp directive, bounding the body size with a unit such as KB or MB,
+// written with or without a colon after the directive name.
+// This test verifies that bodies within the limit pass, that an exceeded limit is reported in bytes, and
+// that unknown units are rejected.
+func RunValidateResponses_MaxSize(t *testing.T) {
+	t.Helper()
+	// Given
+	tempDir := t.TempDir()
+	resp := &rc.Response{StatusCode: 200, Status: "200 OK", Body: []byte(strings.Repeat("x", 100))}
+	resp.BodyString = string(resp.Body)
+	writeHresp := func(name, directive string) string {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(directive+"\nHTTP/1.1 200 OK\n\n{{$any}}\n"), 0644))
+		return path
+	}
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When / Then
+	require.NoError(t, client.ValidateResponses(writeHresp("kb.hresp", "# max-size: 64KB"), resp))
+	require.NoError(t, client.ValidateResponses(writeHresp("bytes.hresp", "# max-size 100"), resp))
+	err = client.ValidateResponses(writeHresp("exceeded.hresp", "# max-size: 0.05 kb"), resp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "body size 100 bytes exceeds max-bytes 51")
+	err = client.ValidateResponses(writeHresp("invalid.hresp", "# max-size: 64XB"), resp)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid '# max-size' directive")
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.9 - Response Metrics: Request Timings
+// Corresponds to: Response.Timings breaking the duration of a request down into DNS lookup, TCP connect,
+// TLS handshake and time to first byte, for lightweight performance smoke tests.
+// This test verifies the phases of new connections by host name and over TLS, and that a request sent
+// on a kept-alive connection reports no connection phases.
+func RunExecuteFile_ResponseTimings(t *testing.T) {
+	t.Helper()
+	// Given
+	const serverDelay = 20 * time.Millisecond
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(serverDelay)
+		w.WriteHeader(http.StatusOK)
+	})
+	plainServer := httptest.NewServer(handler)
+	defer plainServer.Close()
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+
+	byName := strings.Replace(plainServer.URL, "127.0.0.1", "localhost", 1)
+	content := fmt.Sprintf("GET %[1]s/first\n\n###\nGET %[1]s/second\n\n###\nGET %[2]s/secure\n",
+		byName, tlsServer.URL)
+	httpFile := filepath.Join(t.TempDir(), "timings.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithHTTPClient(tlsServer.Client()))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 3)
+
+	first := responses[0].Timings
+	assert.False(t, first.ReusedConn)
+	assert.Positive(t, first.DNSLookup, "localhost is resolved")
+	assert.Positive(t, first.TCPConnect)
+	assert.Zero(t, first.TLSHandshake)
+	assert.GreaterOrEqual(t, first.TimeToFirstByte, serverDelay)
+	assert.Equal(t, responses[0].Duration, first.Total)
+	assert.GreaterOrEqual(t, first.Total, first.TimeToFirstByte)
+
+	second := responses[1].Timings
+	assert.True(t, second.ReusedConn, "the connection is kept alive")
+	assert.Zero(t, second.DNSLookup)
+	assert.Zero(t, second.TCPConnect)
+	assert.GreaterOrEqual(t, second.TimeToFirstByte, serverDelay)
+
+	secure := responses[2].Timings
+	assert.Zero(t, secure.DNSLookup, "an IP address needs no lookup")
+	assert.Positive(t, secure.TCPConnect)
+	assert.Positive(t, secure.TLSHandshake)
+	assert.GreaterOrEqual(t, secure.TimeToFirstByte, serverDelay)
+}
@@ -0,0 +1,204 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.24 - Client Core Execution: Per-Call Run Options
+// Corresponds to: Client.ExecuteFileWithOptions(ctx, path, RunOptions{Environment, ExtraVars, Tags,
+// Names, Parallelism, FailFast}) and the "@tag" request directive.
+// This test verifies that the environment and extra variables apply to one call only, that tags and
+// names select requests, that requests are sent concurrently with a Parallelism above 1, and that
+// FailFast stops the run at the first failed request.
+func RunExecuteFileWithOptions(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	var paths []string
+	var inFlight, maxInFlight atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		mu.Lock()
+		paths = append(paths, r.URL.RequestURI())
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	env := `{"dev": {"stage": "dev"}, "prod": {"stage": "prod"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(env), 0644))
+	httpFile := filepath.Join(dir, "suite.http")
+	content := fmt.Sprintf(`# @name health
+# @tag smoke
+GET %[1]s/health?stage={{stage}}&run={{run}}
+
+###
+# @name users
+# @tag smoke
+# @tag slow
+GET %[1]s/users?stage={{stage}}
+
+###
+# @name report
+# @tag slow
+GET %[1]s/report
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithEnvironment("dev"), rc.WithVars(map[string]any{"run": "default"}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	responses, execErr := client.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{
+		Environment: "prod",
+		ExtraVars:   map[string]any{"run": "nightly"},
+		Tags:        []string{"smoke"},
+	})
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"/health?stage=prod&run=nightly", "/users?stage=prod"}, paths)
+	assert.Equal(t, []string{"smoke", "slow"}, responses[1].Request.Tags)
+
+	// When the client's own settings are used again, and names narrow the tags down
+	paths = nil
+	responses, execErr = client.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{
+		Tags:  []string{"smoke"},
+		Names: []string{"health", "report"},
+	})
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, []string{"/health?stage=dev&run=default"}, paths)
+
+	// When
+	maxInFlight.Store(0)
+	responses, execErr = client.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{Parallelism: 3})
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 3)
+	assert.Equal(t, "health", responses[0].Request.Name)
+	assert.Equal(t, "report", responses[2].Request.Name)
+	assert.Greater(t, maxInFlight.Load(), int32(1), "requests are sent in parallel")
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+
+	// When
+	_, execErr = client.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{Names: []string{"missing"}})
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), "no request matches tags [] and names [missing]")
+}
+
+// PRD-COMMENT: FR10.24 - Client Core Execution: Per-Call Run Options (FailFast)
+// Corresponds to: RunOptions.FailFast of Client.ExecuteFileWithOptions.
+// This test verifies that no request is sent after a failed one.
+func RunExecuteFileWithOptions_FailFast(t *testing.T) {
+	t.Helper()
+	// Given
+	var hits atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "failfast.http")
+	content := fmt.Sprintf("GET %[1]s/ok\n\n###\nGET http://127.0.0.1:1/unreachable\n\n###\nGET %[1]s/never\n",
+		server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFileWithOptions(context.Background(), httpFile,
+		rc.RunOptions{FailFast: true})
+
+	// Then
+	require.Error(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Error(t, responses[1].Error)
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+// PRD-COMMENT: FR10.42 - Client Core Execution: Client-Wide Concurrency
+// Corresponds to: Client option WithConcurrency(n), the default Parallelism of ExecuteFile calls.
+// This test verifies that a file's requests are sent by a pool of n workers, that the responses keep the
+// order of the file, and that RunOptions.Parallelism of a call takes precedence.
+func RunExecuteFile_WithConcurrency(t *testing.T) {
+	t.Helper()
+	// Given
+	var inFlight, maxInFlight atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		_, _ = w.Write([]byte(r.URL.Path))
+	})
+	defer server.Close()
+
+	var content strings.Builder
+	for i := 1; i <= 12; i++ {
+		fmt.Fprintf(&content, "GET %s/items/%d\n\n###\n", server.URL, i)
+	}
+	httpFile := filepath.Join(t.TempDir(), "smoke.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content.String()), 0644))
+	client, err := rc.NewClient(rc.WithConcurrency(4))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	responses, execErr := client.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 12)
+	for i, resp := range responses {
+		assert.Equal(t, fmt.Sprintf("/items/%d", i+1), resp.BodyString, "responses keep the order of the file")
+	}
+	assert.Greater(t, maxInFlight.Load(), int32(1), "requests are sent concurrently")
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(4), "at most n requests are in flight")
+
+	// When
+	maxInFlight.Store(0)
+	_, execErr = client.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{Parallelism: 1})
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, int32(1), maxInFlight.Load())
+
+	_, err = rc.NewClient(rc.WithConcurrency(0))
+	assert.Error(t, err)
+}
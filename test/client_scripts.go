@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setVariablePattern matches the `request.variables.set("name", "value")` calls of a pre-request script
+var setVariablePattern = regexp.MustCompile(`request\.variables\.set\("(\w+)", "([^"]*)"\)`)
+
+// PRD-COMMENT: FR10.40 - Parser and Client Core Execution: Script Blocks
+// Corresponds to: JetBrains pre-request (`< {% ... %}`) and response handler (`> {% ... %}`) script blocks,
+// inline or external, run by a handler set with WithScriptHandler.
+// This test verifies that script blocks are parsed out of the request, including their comments, that the
+// handler receives their sources with the request and response, that a pre-request handler can provide
+// variables, that failing scripts are reported with the script phase, and that files with scripts still run
+// without a handler.
+func RunExecuteFile_ScriptBlocks(t *testing.T) {
+	t.Helper()
+	// Given
+	var bodies []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.URL.RawQuery+"|"+string(body))
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handler.js"),
+		[]byte("client.assert(response.status === 201, \"created\");\n"), 0644))
+	content := fmt.Sprintf("< {%%\n    // set the token\n    request.variables.set(\"token\", \"abc\")\n%%}\n"+
+		"GET %[1]s/items?token={{token}}\n\n"+
+		"> {%% client.assert(response.status === 200, \"ok\"); %%}\n\n"+
+		"###\n# @name create\nPOST %[1]s/items\nContent-Type: application/json\n\n{\"a\": 1}\n\n> ./handler.js\n",
+		server.URL)
+	httpFile := filepath.Join(dir, "scripts.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var mu sync.Mutex
+	var calls []string
+	handler := func(script string, req *rc.Request, resp *rc.Response) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if resp == nil {
+			calls = append(calls, "pre: "+script)
+			for _, match := range setVariablePattern.FindAllStringSubmatch(script, -1) {
+				req.SetVariable(match[1], match[2])
+			}
+			return nil
+		}
+		calls = append(calls, fmt.Sprintf("handler %d: %s", resp.StatusCode, strings.TrimSpace(script)))
+		if strings.Contains(script, "=== 201") && resp.StatusCode != http.StatusCreated {
+			return errors.New("created")
+		}
+		return nil
+	}
+	client, err := rc.NewClient(rc.WithScriptHandler(handler))
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"token=abc|", "|{\"a\": 1}"}, bodies)
+	assert.Equal(t, []string{
+		"pre:     // set the token\n    request.variables.set(\"token\", \"abc\")",
+		"handler 200: client.assert(response.status === 200, \"ok\");",
+		"handler 200: client.assert(response.status === 201, \"created\");",
+	}, calls)
+	requestErrors := rc.RequestErrors(execErr)
+	require.Len(t, requestErrors, 1)
+	assert.Equal(t, rc.PhaseScript, requestErrors[0].Phase)
+	assert.Equal(t, "create", requestErrors[0].Name)
+	assert.Contains(t, execErr.Error(), `request 2 "create" (POST `+server.URL+"/items) script failed: created")
+
+	// When
+	plain, err := rc.NewClient()
+	require.NoError(t, err)
+	_, plainErr := plain.ExecuteFile(context.Background(), httpFile)
+	rendered, renderErr := plain.RenderResolved(httpFile)
+
+	// Then
+	require.NoError(t, plainErr)
+	require.NoError(t, renderErr)
+	assert.Contains(t, rendered, "< {%\n    // set the token\n    request.variables.set(\"token\", \"abc\")\n%}\nGET ")
+	assert.Contains(t, rendered, "{\"a\": 1}\n\n> ./handler.js\n")
+}
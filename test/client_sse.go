@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.45 - Client Core Execution: Server-Sent Events
+// Corresponds to: Responses of Content-Type text/event-stream, their events on Response.Events and
+// WithSSEHandler, and the "# @sse-timeout <duration>" request directive.
+// This test verifies that a stream the server keeps open ends after the @sse-timeout with the events
+// received so far, that events are parsed with their id, type, data and retry fields, that comments and
+// incomplete events are skipped, and that the handler receives the events as they arrive.
+func RunExecuteFile_ServerSentEvents(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher, _ := w.(http.Flusher)
+		if r.URL.Path == "/finite" {
+			_, _ = fmt.Fprint(w, "data: a\n\ndata: b\r\n\r\ndata: incomplete")
+			return
+		}
+		_, _ = fmt.Fprint(w, ": keep-alive\n\nid: 1\nevent: greeting\ndata: hello\ndata: world\n\n")
+		flusher.Flush()
+		_, _ = fmt.Fprint(w, "retry: 1500\ndata: second\n\n")
+		flusher.Flush()
+		<-r.Context().Done() // never closes the stream
+	})
+	defer server.Close()
+
+	content := fmt.Sprintf("### live\n# @sse-timeout 200ms\nGET %[1]s/events\n\n### finite\nGET %[1]s/finite\n",
+		server.URL)
+	httpFile := filepath.Join(t.TempDir(), "events.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var mu sync.Mutex
+	var received []string
+	handler := func(req *rc.Request, event rc.ServerSentEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, req.Name+": "+event.Data)
+	}
+	client, err := rc.NewClient(rc.WithSSEHandler(handler))
+	require.NoError(t, err)
+
+	// When
+	start := time.Now()
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Less(t, time.Since(start), 2*time.Second, "the open stream ends after its @sse-timeout")
+	require.Len(t, responses, 2)
+	live, err := responses[0].Events()
+	require.NoError(t, err)
+	assert.Equal(t, []rc.ServerSentEvent{
+		{ID: "1", Event: "greeting", Data: "hello\nworld"},
+		{ID: "1", Data: "second", Retry: 1500 * time.Millisecond},
+	}, live)
+	finite, err := responses[1].Events()
+	require.NoError(t, err)
+	assert.Equal(t, []rc.ServerSentEvent{{Data: "a"}, {Data: "b"}}, finite)
+	assert.Equal(t, []string{"live: hello\nworld", "live: second", "finite: a", "finite: b"}, received)
+
+	// When
+	rendered, renderErr := client.RenderResolved(httpFile)
+
+	// Then
+	require.NoError(t, renderErr)
+	assert.Contains(t, rendered, "# @sse-timeout 200ms\n")
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.53 - Client Core Execution: Request Tag Filters
+// Corresponds to: The WithIncludeTags and WithExcludeTags client options, which select the requests of
+// every run by their "# @tag" directives.
+// This test verifies that only requests with an included tag run, that excluded tags take precedence
+// over included ones, that the filters combine with RunOptions.Tags, and that empty tag lists and files
+// without any selected request fail.
+func RunExecuteFile_WithTagFilters(t *testing.T) {
+	t.Helper()
+	// Given
+	var paths []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "suite.http")
+	content := fmt.Sprintf(`# @tag smoke auth
+POST %[1]s/login
+
+###
+# @tag smoke slow
+GET %[1]s/report
+
+###
+GET %[1]s/untagged
+
+###
+# @tag auth
+POST %[1]s/logout
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithIncludeTags("smoke"), rc.WithIncludeTags("auth"), rc.WithExcludeTags("slow"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	responses, execErr := client.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"/login", "/logout"}, paths)
+
+	// When
+	paths = nil
+	excluding, err := rc.NewClient(rc.WithExcludeTags("auth"))
+	require.NoError(t, err)
+	responses, execErr = excluding.ExecuteFileWithOptions(ctx, httpFile, rc.RunOptions{Tags: []string{"smoke"}})
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, []string{"/report"}, paths)
+
+	// When
+	noMatch, err := rc.NewClient(rc.WithIncludeTags("nightly"))
+	require.NoError(t, err)
+	_, execErr = noMatch.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), "include tags [nightly]")
+
+	// When
+	_, err = rc.NewClient(rc.WithExcludeTags())
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exclude tags must not be empty")
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.5 - Response Diagnostics: TLS Connection Details
+// Corresponds to: Response.TLS exposing the negotiated TLS version, cipher suite, ALPN protocol
+// and a summary of the peer certificate chain, so suites can assert security posture.
+// This test verifies the details against an HTTP/2-enabled TLS test server and that plain HTTP
+// responses have no TLS details.
+func RunExecuteFile_TLSConnectionDetails(t *testing.T) {
+	t.Helper()
+	// Given
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plainServer.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "tls.http")
+	content := fmt.Sprintf("GET %s/secure\n\n###\nGET %s/plain\n", server.URL, plainServer.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	client, err := rc.NewClient(rc.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+
+	tlsInfo := responses[0].TLS
+	require.NotNil(t, tlsInfo)
+	assert.True(t, responses[0].IsTLS)
+	assert.Equal(t, responses[0].TLSVersion, tlsInfo.Version)
+	assert.GreaterOrEqual(t, tlsInfo.VersionID, uint16(tls.VersionTLS12))
+	assert.NotEmpty(t, tlsInfo.CipherSuite)
+	assert.Equal(t, "h2", tlsInfo.NegotiatedProtocol)
+
+	leaf := tlsInfo.Leaf()
+	require.NotNil(t, leaf)
+	assert.Contains(t, leaf.Issuer, "Acme Co")
+	assert.Contains(t, leaf.DNSNames, "example.com")
+	assert.Len(t, leaf.SHA256Fingerprint, 64)
+	assert.True(t, leaf.NotAfter.After(leaf.NotBefore))
+
+	assert.Nil(t, responses[1].TLS)
+	assert.Nil(t, responses[1].TLS.Leaf(), "Leaf must be safe on a nil TLSInfo")
+}
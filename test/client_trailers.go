@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.37 - Client Core Execution: Trailers and Informational Responses
+// Corresponds to: Response.Trailers and Response.Informational, and the "# trailer <Name>: <value>" and
+// "# informational <code>" .hresp directives.
+// This test verifies that 103 Early Hints and 100 Continue responses are recorded with their headers,
+// that trailers are captured for buffered and streamed bodies, and that both can be asserted in .hresp
+// files.
+func RunExecuteFile_TrailersAndInformational(t *testing.T) {
+	t.Helper()
+	// Given
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/page" {
+			w.Header().Set("Link", "</app.css>; rel=preload")
+			w.WriteHeader(http.StatusEarlyHints)
+			w.Header().Del("Link")
+		}
+		body, _ := io.ReadAll(r.Body) // answers "Expect: 100-continue"
+		w.Header().Set("Trailer", "X-Checksum, X-Unsent")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, "received %d bytes", len(body))
+		w.Header().Set("X-Checksum", "abc123")
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "interim.http")
+	content := fmt.Sprintf("GET %s/page\n\n###\nPOST %s/upload\nExpect: 100-continue\n\nhello\n",
+		server.URL, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	require.Len(t, responses[0].Informational, 1)
+	assert.Equal(t, http.StatusEarlyHints, responses[0].Informational[0].StatusCode)
+	assert.Equal(t, "</app.css>; rel=preload", responses[0].Informational[0].Headers.Get("Link"))
+	assert.Equal(t, http.Header{"X-Checksum": {"abc123"}}, responses[0].Trailers)
+	require.Len(t, responses[1].Informational, 1)
+	assert.Equal(t, http.StatusContinue, responses[1].Informational[0].StatusCode)
+
+	// When
+	hrespFile := filepath.Join(dir, "interim.hresp")
+	hresp := "# informational 103\n# trailer X-Checksum: abc123\nHTTP/1.1 200 OK\n\nreceived 0 bytes\n\n###\n" +
+		"# informational 103\n# trailer X-Checksum: other\nHTTP/1.1 200 OK\n\nreceived 5 bytes\n"
+	require.NoError(t, os.WriteFile(hrespFile, []byte(hresp), 0644))
+	validateErr := client.ValidateResponses(hrespFile, responses...)
+
+	// Then
+	require.Error(t, validateErr)
+	assert.Len(t, rc.RequestErrors(validateErr), 2)
+	assert.Contains(t, validateErr.Error(), "response #2")
+	assert.Contains(t, validateErr.Error(), "expected informational response 103 not received, got [100]")
+	assert.Contains(t, validateErr.Error(), "expected value 'other' for trailer 'X-Checksum' not found")
+
+	// When the body is streamed
+	streaming, err := rc.NewClient(rc.WithStreamedBodies())
+	require.NoError(t, err)
+	responses, execErr = streaming.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, execErr)
+	assert.Nil(t, responses[0].Trailers)
+	require.NoError(t, responses[0].BufferBody())
+
+	// Then
+	assert.Equal(t, "abc123", responses[0].Trailers.Get("X-Checksum"))
+	responses[1].Close()
+}
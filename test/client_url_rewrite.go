@@ -0,0 +1,70 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.31 - Client Core Execution: URL Rewrite Rules
+// Corresponds to: The WithURLRewrite(pattern, replacement) client option, which rewrites request URLs
+// with a regular expression after variable substitution.
+// This test verifies that requests hard-coded to a production host reach the mock server, that
+// submatches and chained rules apply in order, that RenderResolved shows the rewritten URL, and that
+// invalid patterns are rejected.
+func RunExecuteFile_WithURLRewrite(t *testing.T) {
+	t.Helper()
+	// Given
+	var paths []string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "prod.http")
+	content := `@host = https://api.example.com
+
+GET {{host}}/v1/users/42
+
+###
+GET https://api.example.com/v1/orders?status=open
+`
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(
+		rc.WithURLRewrite(`^https://api\.example\.com`, server.URL),
+		rc.WithURLRewrite(`/v1/(\w+)`, "/v2/$1"),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, []string{"/v2/users/42", "/v2/orders?status=open"}, paths)
+	assert.Equal(t, server.URL+"/v2/users/42", responses[0].FinalURL)
+	assert.Equal(t, "https://api.example.com/v1/users/42", responses[0].Request.URL.String())
+
+	// When
+	rendered, renderErr := client.RenderResolved(httpFile)
+
+	// Then
+	require.NoError(t, renderErr)
+	assert.True(t, strings.HasPrefix(rendered, "GET "+server.URL+"/v2/users/42\n"), rendered)
+
+	// When
+	_, err = rc.NewClient(rc.WithURLRewrite(`(`, ""))
+
+	// Then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid URL rewrite pattern")
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.27 - Variable Substitution: Lazy Variable Providers
+// Corresponds to: The WithVarProvider(func(name string) (string, bool)) client option, consulted for
+// variables that no programmatic, file, environment or global variable defines.
+// This test verifies that providers resolve referenced variables in order, are only asked for
+// variables the file references and no other source defines, and that a nil provider is rejected.
+func RunExecuteFile_WithVarProvider(t *testing.T) {
+	t.Helper()
+	// Given
+	var gotToken, gotPath string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("Authorization")
+		gotPath = r.URL.RequestURI()
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "provider.http")
+	content := fmt.Sprintf(`@tenant = acme
+
+GET %s/{{tenant}}/orders/{{orderId}}?region={{region}}
+Authorization: Bearer {{vaultToken}}
+`, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+
+	var asked []string
+	vault := func(name string) (string, bool) {
+		asked = append(asked, name)
+		if name == "vaultToken" {
+			return "s3cr3t", true
+		}
+		return "", false
+	}
+	database := func(name string) (string, bool) {
+		switch name {
+		case "vaultToken":
+			return "shadowed", true
+		case "region":
+			return "eu", true
+		}
+		return "", false
+	}
+	client, err := rc.NewClient(
+		rc.WithVars(map[string]any{"orderId": 42}),
+		rc.WithVarProvider(vault),
+		rc.WithVarProvider(database),
+	)
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 1)
+	assert.Equal(t, "Bearer s3cr3t", gotToken)
+	assert.Equal(t, "/acme/orders/42?region=eu", gotPath)
+	assert.NotContains(t, asked, "orderId")
+	assert.NotContains(t, asked, "tenant")
+	assert.Contains(t, asked, "region")
+
+	// When
+	_, err = rc.NewClient(rc.WithVarProvider(nil))
+
+	// Then
+	assert.Error(t, err)
+}
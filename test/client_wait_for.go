@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.36 - Client Core Execution: Readiness Probes
+// Corresponds to: The "# @wait-for <url> [every=<d>] [timeout=<d>]" directive, which holds a request
+// back until a readiness URL answers 2xx.
+// This test verifies that the request is sent once the service becomes ready, that the URL may use
+// variables, and that a service that never becomes ready fails the request without sending it.
+func RunExecuteFile_WaitFor(t *testing.T) {
+	t.Helper()
+	// Given
+	var probes, requests atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			if probes.Add(1) <= 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/never-ready":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			requests.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	ready := filepath.Join(dir, "ready.http")
+	readyContent := fmt.Sprintf("@host = %s\n\n# @wait-for {{host}}/healthz every=10ms timeout=5s\n"+
+		"GET {{host}}/items\n\n###\nGET {{host}}/items\n", server.URL)
+	require.NoError(t, os.WriteFile(ready, []byte(readyContent), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), ready)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, int32(4), probes.Load())
+	assert.Equal(t, int32(2), requests.Load())
+
+	// When
+	neverReady := filepath.Join(dir, "never-ready.http")
+	neverReadyContent := fmt.Sprintf("# @wait-for %s/never-ready every=10ms timeout=100ms\nGET %s/items\n",
+		server.URL, server.URL)
+	require.NoError(t, os.WriteFile(neverReady, []byte(neverReadyContent), 0644))
+	responses, execErr = client.ExecuteFile(context.Background(), neverReady)
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), "not ready after")
+	assert.Contains(t, execErr.Error(), "last: 503 Service Unavailable")
+	require.Len(t, responses, 1)
+	assert.Equal(t, 0, responses[0].StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// When
+	rendered, renderErr := client.RenderResolved(ready)
+
+	// Then
+	require.NoError(t, renderErr)
+	assert.Contains(t, rendered, "# @wait-for "+server.URL+"/healthz every=10ms timeout=5s\n")
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR10.30 - Client Core Execution: Workspace Defaults File
+// Corresponds to: The rest-client.defaults.http file found in the directory of a request file or a
+// parent directory up to the repository root, declaring @base-url, @timeout, @retry and default
+// headers; and the "# @retry <n> [delay=<d>]" request directive.
+// This test verifies that requests inherit the defaults they do not override, that the client's own
+// base URL and default headers take precedence, that transient failures are retried, and that an
+// invalid defaults file fails the run.
+func RunExecuteFile_WithWorkspaceDefaults(t *testing.T) {
+	t.Helper()
+	// Given
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	teams := make(map[string]string)
+	var userAgent string
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		hits[r.URL.Path]++
+		teams[r.URL.Path] = r.Header.Get("X-Team")
+		userAgent = r.Header.Get("User-Agent")
+		if r.URL.Path == "/down" || (r.URL.Path == "/flaky" && hits[r.URL.Path] == 1) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0755))
+	defaults := "# Suite-wide policies\n# @base-url " + server.URL + "\n# @timeout 5000\n" +
+		"# @retry 2 delay=10ms\nX-Team: {{team}}\nUser-Agent: defaults\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "rest-client.defaults.http"), []byte(defaults), 0644))
+	suiteDir := filepath.Join(root, "suites", "orders")
+	require.NoError(t, os.MkdirAll(suiteDir, 0755))
+	httpFile := filepath.Join(suiteDir, "orders.http")
+	content := `GET /flaky
+
+###
+# @timeout 100
+# @retry 0
+GET /down
+X-Team: payments
+`
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithVars(map[string]any{"team": "orders"}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// When
+	responses, execErr := client.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+	assert.Equal(t, http.StatusOK, responses[0].StatusCode)
+	assert.Equal(t, 2, responses[0].Attempts)
+	assert.Equal(t, 5*time.Second, responses[0].Request.Timeout)
+	assert.Equal(t, "orders", teams["/flaky"])
+	assert.Equal(t, "defaults", userAgent)
+	assert.Equal(t, http.StatusServiceUnavailable, responses[1].StatusCode)
+	assert.Equal(t, 1, hits["/down"])
+	assert.Equal(t, 100*time.Millisecond, responses[1].Request.Timeout)
+	assert.Equal(t, "payments", teams["/down"])
+
+	// When the client sets its own default header
+	own, err := rc.NewClient(rc.WithDefaultHeader("User-Agent", "client"))
+	require.NoError(t, err)
+	_, execErr = own.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	assert.Equal(t, "client", userAgent)
+
+	// Given an invalid defaults file closer to the request file
+	require.NoError(t, os.WriteFile(filepath.Join(suiteDir, "rest-client.defaults.http"),
+		[]byte("# @retries 3\n"), 0644))
+
+	// When
+	_, execErr = client.ExecuteFile(ctx, httpFile)
+
+	// Then
+	require.Error(t, execErr)
+	assert.Contains(t, execErr.Error(), "line 1: unknown directive '@retries'")
+}
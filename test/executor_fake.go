@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/bmcszk/go-restclient/restclienttest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runSmokeSuite is example orchestration code depending only on the Executor interface
+func runSmokeSuite(ctx context.Context, executor rc.Executor) (int, error) {
+	responses, err := executor.ExecuteFile(ctx, "smoke.http")
+	if err != nil {
+		return 0, err
+	}
+	return len(responses), executor.ValidateResponses("smoke.hresp", responses...)
+}
+
+// PRD-COMMENT: FR10.1 - Library Integration: Executor Interface and Fake
+// Corresponds to: The Executor interface implemented by Client and the in-memory
+// restclienttest.Executor, letting applications unit test orchestration code without HTTP.
+// This test verifies configured responses and errors are returned, calls are recorded, and
+// unconfigured files fail loudly.
+func RunExecutor_FakeImplementation(t *testing.T) {
+	t.Helper()
+	// Given
+	var _ rc.Executor = (*rc.Client)(nil)
+	fake := restclienttest.NewExecutor().
+		SetResponses("smoke.http", &rc.Response{StatusCode: 200}, &rc.Response{StatusCode: 201})
+
+	// When
+	count, err := runSmokeSuite(context.Background(), fake)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"smoke.http"}, fake.ExecutedFiles())
+	calls := fake.ValidateCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "smoke.hresp", calls[0].ResponseFilePath)
+	assert.Len(t, calls[0].Responses, 2)
+
+	// Given a validation failure
+	validationErr := errors.New("status code mismatch")
+	fake.SetValidationError("smoke.hresp", validationErr)
+
+	// When
+	_, err = runSmokeSuite(context.Background(), fake)
+
+	// Then
+	require.ErrorIs(t, err, validationErr)
+
+	// Given an execution error and a file without configuration
+	executeErr := errors.New("connection refused")
+	fake.SetExecuteError("broken.http", executeErr)
+
+	// When
+	_, brokenErr := fake.ExecuteFile(context.Background(), "broken.http")
+	_, missingErr := fake.ExecuteFile(context.Background(), "missing.http")
+
+	// Then
+	require.ErrorIs(t, brokenErr, executeErr)
+	require.Error(t, missingErr)
+	assert.Contains(t, missingErr.Error(), "no responses configured for missing.http")
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bmcszk/go-restclient/history"
+	"github.com/bmcszk/go-restclient/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// historyEntry builds a recorded execution for run comparisons
+func historyEntry(
+	runID string, index int, name string, status int, body string, duration time.Duration,
+) history.Entry {
+	return history.Entry{
+		RunID: runID,
+		Index: index,
+		Name:  name,
+		Request: history.RequestRecord{
+			Method: http.MethodGet,
+			URL:    "https://api.example.com/" + name,
+		},
+		Response: history.ResponseRecord{
+			StatusCode: status,
+			Headers:    http.Header{"Content-Type": {"application/json"}, "Date": {runID}},
+			Body:       body,
+			Duration:   duration,
+		},
+	}
+}
+
+// PRD-COMMENT: FR10.12 - Client Core Execution: Run Comparison
+// Corresponds to: report.DiffRuns comparing two recorded runs by request index (status, headers,
+// normalized bodies and timings) and flagging regressions.
+// This test verifies unchanged requests, formatting-only body differences, worse statuses, slowdowns,
+// header changes and missing requests are reported as expected.
+func RunReport_DiffRuns(t *testing.T) {
+	t.Helper()
+	// Given
+	before := []history.Entry{
+		historyEntry("a", 0, "health", 200, `{"ok": true}`, 50*time.Millisecond),
+		historyEntry("a", 1, "users", 200, `{"users": [1, 2], "total": 2}`, 100*time.Millisecond),
+		historyEntry("a", 2, "orders", 200, `[]`, 100*time.Millisecond),
+		historyEntry("a", 3, "legacy", 200, `{}`, 10*time.Millisecond),
+	}
+	after := []history.Entry{
+		historyEntry("b", 0, "health", 200, "{\n  \"ok\": true\n}", 60*time.Millisecond),
+		historyEntry("b", 1, "users", 200, `{"total": 3, "users": [1, 2, 3]}`, 100*time.Millisecond),
+		historyEntry("b", 2, "orders", 503, `unavailable`, 400*time.Millisecond),
+	}
+	after[1].Response.Headers.Set("Cache-Control", "no-store")
+
+	// When
+	diff := report.DiffRuns(before, after)
+
+	// Then
+	assert.Equal(t, "a", diff.BeforeRunID)
+	assert.Equal(t, "b", diff.AfterRunID)
+	require.Len(t, diff.Requests, 4)
+	assert.Empty(t, diff.Requests[0].Changes, "JSON formatting and small timing noise are ignored")
+
+	users := diff.Requests[1]
+	assert.False(t, users.Regressed())
+	require.Len(t, users.Changes, 2)
+	assert.Equal(t, report.ChangeHeader, users.Changes[0].Kind)
+	assert.Equal(t, "Cache-Control", users.Changes[0].Header)
+	assert.Equal(t, report.ChangeBody, users.Changes[1].Kind)
+	assert.Contains(t, users.Changes[1].Diff, `+  "total": 3,`)
+
+	orders := diff.Requests[2]
+	assert.True(t, orders.Regressed())
+	kinds := make([]report.ChangeKind, 0, len(orders.Changes))
+	for _, change := range orders.Changes {
+		kinds = append(kinds, change.Kind)
+	}
+	assert.Equal(t, []report.ChangeKind{report.ChangeStatus, report.ChangeBody, report.ChangeDuration}, kinds)
+	assert.True(t, orders.Changes[0].Regression)
+	assert.True(t, orders.Changes[2].Regression)
+
+	assert.Equal(t, report.ChangeMissing, diff.Requests[3].Changes[0].Kind)
+	assert.Len(t, diff.Regressions(), 2)
+	assert.True(t, diff.HasRegressions())
+
+	text := diff.String()
+	assert.Contains(t, text, "run a -> run b: 3 of 4 requests changed, 2 regressed")
+	assert.Contains(t, text, `#3 orders [REGRESSION]`)
+	assert.Contains(t, text, `status: "200" -> "503" (regression)`)
+
+	relaxed := report.DiffRuns(before, after, report.WithIgnoredHeaders("cache-control"),
+		report.WithSlowdownThreshold(10, time.Second))
+	assert.Len(t, relaxed.Requests[1].Changes, 1)
+	assert.Len(t, relaxed.Requests[2].Changes, 2)
+}
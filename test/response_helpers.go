@@ -0,0 +1,234 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR6.1 - Response Helpers: JSON Decoding and Path Access
+// Corresponds to: Convenience methods on Response (JSON, Map, JSONPath) so Go tests consuming
+// responses do not need to reimplement unmarshalling and path navigation.
+// This test verifies decoding into a struct and a map, dot/bracket/index path navigation,
+// and errors for invalid JSON, missing keys and out-of-range indexes.
+func RunResponse_JSONHelpers(t *testing.T) {
+	t.Helper()
+	// Given
+	body := `{"data": {"id": 42, "name": "widget", "tags": ["a", "b", "c"],` +
+		` "items": [{"sku": "X-1"}, {"sku": "X-2"}], "odd.key": true}}`
+	resp := &rc.Response{Body: []byte(body), BodyString: body}
+
+	// When
+	var target struct {
+		Data struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	jsonErr := resp.JSON(&target)
+	asMap, mapErr := resp.Map()
+
+	// Then
+	require.NoError(t, jsonErr)
+	assert.Equal(t, 42, target.Data.ID)
+	assert.Equal(t, "widget", target.Data.Name)
+	require.NoError(t, mapErr)
+	assert.Contains(t, asMap, "data")
+
+	pathCases := map[string]any{
+		"$.data.id":            float64(42),
+		"data.name":            "widget",
+		"$.data.tags[1]":       "b",
+		"$.data.tags[-1]":      "c",
+		"$.data.items[1].sku":  "X-2",
+		"$['data']['odd.key']": true,
+		`$.data["items"][0]`:   map[string]any{"sku": "X-1"},
+	}
+	for path, expected := range pathCases {
+		value, err := resp.JSONPath(path)
+		require.NoError(t, err, "path %s", path)
+		assert.Equal(t, expected, value, "path %s", path)
+	}
+
+	errorCases := map[string]string{
+		"$.data.missing": `key "missing" not found`,
+		"$.data.tags[5]": "index 5 out of range",
+		"$.data.name[0]": "cannot index string",
+		"$.data.tags[x]": "invalid array index",
+		"$.data['broken": "unterminated quoted key",
+	}
+	for path, expectedErr := range errorCases {
+		_, err := resp.JSONPath(path)
+		require.Error(t, err, "path %s", path)
+		assert.Contains(t, err.Error(), expectedErr, "path %s", path)
+	}
+
+	invalid := &rc.Response{Body: []byte("not json")}
+	_, err := invalid.Map()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode response body as JSON")
+}
+
+// PRD-COMMENT: FR6.1 - Response Helpers: Header and Cookie Accessors
+// Corresponds to: Convenience methods on Response (Header, Cookies, Cookie, ContentType, Charset).
+// This test verifies case-insensitive header and cookie lookups and the parsing of Content-Type
+// parameters.
+func RunResponse_HeaderAndCookieAccessors(t *testing.T) {
+	t.Helper()
+	// Given
+	resp := &rc.Response{Headers: http.Header{
+		"Content-Type": {"Application/JSON; Charset=UTF-8"},
+		"X-Request-Id": {"abc-123"},
+		"Set-Cookie": {
+			"session=s3cr3t; Path=/; HttpOnly",
+			"theme=dark; Max-Age=3600",
+		},
+	}}
+
+	// When
+	cookies := resp.Cookies()
+
+	// Then
+	assert.Equal(t, "abc-123", resp.Header("x-request-id"))
+	assert.Equal(t, "", resp.Header("X-Missing"))
+	assert.Equal(t, "application/json", resp.ContentType())
+	assert.Equal(t, "utf-8", resp.Charset())
+
+	require.Len(t, cookies, 2)
+	session := resp.Cookie("session")
+	require.NotNil(t, session)
+	assert.Equal(t, "s3cr3t", session.Value)
+	assert.True(t, session.HttpOnly)
+	assert.Equal(t, 3600, resp.Cookie("theme").MaxAge)
+	assert.Nil(t, resp.Cookie("missing"))
+
+	// Given a malformed parameter and a response without headers
+	malformed := &rc.Response{Headers: http.Header{"Content-Type": {"text/plain; charset"}}}
+	empty := &rc.Response{}
+
+	// Then
+	assert.Equal(t, "text/plain", malformed.ContentType())
+	assert.Equal(t, "", malformed.Charset())
+	assert.Equal(t, "", empty.ContentType())
+	assert.Empty(t, empty.Cookies())
+}
+
+// PRD-COMMENT: FR6.7 - Response Helpers: Streamed Body Access
+// Corresponds to: The WithStreamedBodies client option and Response.BodyReader / BufferBody / Close,
+// which leave large bodies unread until needed instead of always materializing Body and BodyString.
+// This test verifies on-demand streaming, single-use streams, automatic buffering for validation
+// and JSON helpers, and BodyReader on regular buffered responses.
+func RunResponse_StreamedBodyAccess(t *testing.T) {
+	t.Helper()
+	// Given
+	largeBody := `{"data": "` + strings.Repeat("a", 64*1024) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(largeBody))
+	}))
+	defer server.Close()
+
+	httpFile := filepath.Join(t.TempDir(), "stream.http")
+	content := fmt.Sprintf("GET %s/first\n\n###\nGET %s/second\n\n###\nGET %s/third\n",
+		server.URL, server.URL, server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient(rc.WithStreamedBodies())
+	require.NoError(t, err)
+
+	// When
+	responses, err := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, responses, 3)
+	defer func() {
+		for _, resp := range responses {
+			assert.NoError(t, resp.Close())
+		}
+	}()
+	streamed := responses[0]
+	assert.Nil(t, streamed.Body, "body must not be materialized before it is read")
+	receivedBeforeRead := streamed.BytesReceived
+
+	reader, err := streamed.BodyReader()
+	require.NoError(t, err)
+	streamedBytes, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, largeBody, string(streamedBytes))
+	assert.Equal(t, receivedBeforeRead+int64(len(largeBody)), streamed.BytesReceived)
+
+	_, err = streamed.BodyReader()
+	require.Error(t, err, "a streamed body can be consumed only once")
+	assert.Contains(t, err.Error(), "already consumed")
+
+	// Then validation and JSON helpers buffer automatically
+	hrespFile := filepath.Join(t.TempDir(), "stream.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte("HTTP/1.1 200 OK\n\n{{$any}}\n"), 0644))
+	require.NoError(t, client.ValidateResponses(hrespFile, responses[1]))
+	assert.Equal(t, largeBody, responses[1].BodyString)
+
+	value, err := responses[2].JSONPath("$.data")
+	require.NoError(t, err)
+	assert.Len(t, value, 64*1024)
+
+	// Given a regular buffered response
+	buffered := &rc.Response{Body: []byte("plain")}
+
+	// When
+	bufferedReader, err := buffered.BodyReader()
+	require.NoError(t, err)
+	bufferedBytes, err := io.ReadAll(bufferedReader)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(bufferedBytes))
+	assert.NoError(t, buffered.BufferBody())
+	assert.NoError(t, buffered.Close())
+}
+
+// PRD-COMMENT: FR6.8 - Response Helpers: Request Names
+// Corresponds to: Response.RequestName and FindResponse, and the "# @name requestName",
+// "# @name = requestName" and "### requestName" forms of naming a request.
+// This test verifies that every naming form ends up on the responses, so they can be picked by name
+// instead of by position, and that unnamed and unknown names are handled.
+func RunResponse_RequestName(t *testing.T) {
+	t.Helper()
+	// Given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.URL.Path)
+	}))
+	defer server.Close()
+	content := fmt.Sprintf("# @name login\nPOST %[1]s/login\n\n###\n// @name = profile\nGET %[1]s/me\n\n"+
+		"### logout\nPOST %[1]s/logout\n\n###\nGET %[1]s/anonymous\n", server.URL)
+	httpFile := filepath.Join(t.TempDir(), "names.http")
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, execErr)
+	require.Len(t, responses, 4)
+	names := make([]string, 0, len(responses))
+	for _, resp := range responses {
+		names = append(names, resp.RequestName())
+	}
+	assert.Equal(t, []string{"login", "profile", "logout", ""}, names)
+	require.NotNil(t, rc.FindResponse(responses, "profile"))
+	assert.Equal(t, "/me", rc.FindResponse(responses, "profile").BodyString)
+	assert.Nil(t, rc.FindResponse(responses, "missing"))
+	assert.Equal(t, "", (&rc.Response{}).RequestName())
+}
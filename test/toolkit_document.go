@@ -0,0 +1,110 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmcszk/go-restclient/toolkit"
+)
+
+const toolkitDocument = `@host = example.com
+@baseUrl = https://{{host}}/api
+
+### Get users
+# @name listUsers
+GET {{baseUrl}}/users?id={{$uuid}}
+
+###
+POST {{baseUrl}}/users
+X-Trace: {{traceId | none}}
+
+{"name": "é{{user}}"}
+`
+
+// PRD-COMMENT: FR11.1 - Editor Integration: Document Symbols
+// Corresponds to: The toolkit package outlining a document's requests and locating variable
+// definitions and references with LSP positions, without executing anything.
+// This test verifies the outline, definition and reference ranges of an unsaved buffer.
+func RunToolkit_DocumentSymbols(t *testing.T) {
+	t.Helper()
+	// When
+	doc, err := toolkit.Parse("buffer.http", []byte(toolkitDocument))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, doc.Requests, 2)
+	assert.Equal(t, "listUsers", doc.Requests[0].Name)
+	assert.Equal(t, "GET", doc.Requests[0].Method)
+	assert.Equal(t, "{{baseUrl}}/users?id={{$uuid}}", doc.Requests[0].URL)
+	assert.Equal(t, 4, doc.Requests[0].Range.Start.Line)
+	assert.Equal(t, 5, doc.Requests[0].Range.End.Line)
+	assert.Equal(t, "POST", doc.Requests[1].Method)
+	assert.Equal(t, 11, doc.Requests[1].Range.End.Line)
+
+	def, ok := doc.Definition("baseUrl")
+	require.True(t, ok)
+	assert.Equal(t, "https://{{host}}/api", def.Value)
+	assert.Equal(t, toolkit.Range{
+		Start: toolkit.Position{Line: 1, Character: 0},
+		End:   toolkit.Position{Line: 1, Character: 8},
+	}, def.Range)
+
+	refs := doc.ReferencesTo("baseUrl")
+	require.Len(t, refs, 2)
+	assert.Equal(t, toolkit.Position{Line: 5, Character: 4}, refs[0].Range.Start)
+	assert.Equal(t, toolkit.Position{Line: 5, Character: 15}, refs[0].Range.End)
+
+	system := doc.ReferencesTo("$uuid")
+	require.Len(t, system, 1)
+	assert.True(t, system[0].System)
+
+	user := doc.ReferencesTo("user")
+	require.Len(t, user, 1)
+	assert.Equal(t, toolkit.Position{Line: 11, Character: 11}, user[0].Range.Start, "characters count UTF-16 code units")
+
+	req, ok := doc.RequestAt(toolkit.Position{Line: 9, Character: 3})
+	require.True(t, ok)
+	assert.Equal(t, "POST", req.Method)
+}
+
+// PRD-COMMENT: FR11.2 - Editor Integration: Hovers
+// Corresponds to: Resolving the variable under the cursor to its substituted value, with
+// provided variables taking precedence over file definitions as during execution.
+// This test verifies file, provided, fallback, system and unresolved hovers.
+func RunToolkit_Hover(t *testing.T) {
+	t.Helper()
+	// Given
+	doc, err := toolkit.Parse("buffer.http", []byte(toolkitDocument))
+	require.NoError(t, err)
+	at := func(line, character int) toolkit.Position {
+		return toolkit.Position{Line: line, Character: character}
+	}
+
+	// When
+	fileHover, fileOK := doc.Hover(at(5, 6), nil)
+	providedHover, providedOK := doc.Hover(at(5, 6), map[string]string{"host": "localhost:8080"})
+	fallbackHover, _ := doc.Hover(at(9, 12), nil)
+	systemHover, _ := doc.Hover(at(5, 26), nil)
+	unresolvedHover, _ := doc.Hover(at(11, 13), nil)
+	definitionHover, definitionOK := doc.Hover(at(0, 2), nil)
+	_, noneOK := doc.Hover(at(3, 0), nil)
+
+	// Then
+	require.True(t, fileOK)
+	assert.Equal(t, toolkit.Hover{
+		Name: "baseUrl", Value: "https://example.com/api", Source: toolkit.SourceFile,
+		Range: toolkit.Range{Start: at(5, 4), End: at(5, 15)},
+	}, fileHover)
+	require.True(t, providedOK)
+	assert.Equal(t, "https://localhost:8080/api", providedHover.Value)
+	assert.Equal(t, toolkit.SourceFile, providedHover.Source, "baseUrl itself is defined in the file")
+	assert.Equal(t, toolkit.SourceFallback, fallbackHover.Source)
+	assert.Equal(t, "none", fallbackHover.Value)
+	assert.Equal(t, toolkit.SourceSystem, systemHover.Source)
+	assert.Equal(t, toolkit.SourceUnresolved, unresolvedHover.Source)
+	require.True(t, definitionOK)
+	assert.Equal(t, "example.com", definitionHover.Value)
+	assert.False(t, noneOK)
+}
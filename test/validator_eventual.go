@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR3.9 - Response Validation: Eventual Consistency
+// Corresponds to: The WithEventualConsistency(maxWait, interval) client option, which makes
+// ValidateResponses send a mismatching response's request again with backoff until the expected
+// response materializes, failing only when it does not within maxWait.
+// This test verifies a response that matches on the third attempt, with the request body re-sent and
+// the response replaced in place, and a response that never matches.
+func RunValidateResponses_WithEventualConsistency(t *testing.T) {
+	t.Helper()
+	// Given
+	var searchAttempts, searchBodies atomic.Int32
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if body, _ := io.ReadAll(r.Body); string(body) == `{"q": "order-7"}` {
+			searchBodies.Add(1)
+		}
+		if searchAttempts.Add(1) < 3 {
+			_, _ = fmt.Fprint(w, `{"hits": 0}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"hits": 1}`)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "eventual.http")
+	content := fmt.Sprintf("POST %[1]s/search\nContent-Type: application/json\n\n{\"q\": \"order-7\"}\n\n"+
+		"###\nGET %[1]s/missing\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	hrespFile := filepath.Join(dir, "eventual.hresp")
+	require.NoError(t, os.WriteFile(hrespFile, []byte("HTTP/1.1 200 OK\n\n{\"hits\": 1}\n\n###\n\n"+
+		"HTTP/1.1 200 OK\n"), 0644))
+	client, err := rc.NewClient(rc.WithEventualConsistency(300*time.Millisecond, 10*time.Millisecond))
+	require.NoError(t, err)
+	responses, execErr := client.ExecuteFile(context.Background(), httpFile)
+	require.NoError(t, execErr)
+	require.Len(t, responses, 2)
+
+	// When
+	validationErr := client.ValidateResponses(hrespFile, responses...)
+
+	// Then
+	require.Error(t, validationErr)
+	assert.Equal(t, int32(3), searchAttempts.Load())
+	assert.Equal(t, int32(3), searchBodies.Load(), "the request body should be sent on every attempt")
+	assert.JSONEq(t, `{"hits": 1}`, responses[0].BodyString, "the response should be replaced in place")
+	assert.NotContains(t, validationErr.Error(), "response #1")
+	assert.Contains(t, validationErr.Error(), "status code mismatch: expected 200, got 404")
+	assert.Contains(t, validationErr.Error(), "no matching response after")
+	assert.Contains(t, validationErr.Error(), "within 300ms")
+
+	_, err = rc.NewClient(rc.WithEventualConsistency(0, time.Second))
+	assert.Error(t, err)
+}
@@ -194,3 +194,80 @@ func RunValidateResponses_HeadersContain(t *testing.T) {
 		})
 	}
 }
+
+// PRD-COMMENT: FR3.11 - Response Validation: Header Matchers
+// Corresponds to: Placeholders in the expected header values of .hresp files, e.g.
+// "Content-Type: {{$regexp application/json.*}}" or "X-Request-Id: {{$any}}".
+// This test verifies that dynamic header values match their placeholders, that {{$any}} only requires the
+// header to be present, and that mismatches and invalid patterns are reported.
+func RunValidateResponses_HeaderMatchers(t *testing.T) {
+	t.Helper()
+	// Given: Test cases defined in 'tests' slice
+	tests := []struct {
+		name             string
+		actualResponse   *rc.Response
+		expectedFilePath string
+		expectedErrCount int
+		expectedErrTexts []string
+	}{
+		{
+			name: "matching dynamic values",
+			actualResponse: &rc.Response{
+				StatusCode: 200, Status: "200 OK",
+				Headers: http.Header{
+					"Content-Type": {"application/json; charset=utf-8"},
+					"X-Request-Id": {"req-8f2a"},
+					"X-Trace-Id":   {"0b5c3c1e-7d1a-4f4e-9a57-2f0f4d7c9e11"},
+					"Server":       {"nginx/1.25"},
+				},
+			},
+			expectedFilePath: "test/data/http_response_files/validator_headers_matchers.hresp",
+			expectedErrCount: 0,
+		},
+		{
+			name: "mismatching and missing values",
+			actualResponse: &rc.Response{
+				StatusCode: 200, Status: "200 OK",
+				Headers: http.Header{
+					"Content-Type": {"text/html"},
+					"X-Trace-Id":   {"not-a-guid"},
+					"Server":       {"nginx/1.25 (Ubuntu)"},
+				},
+			},
+			expectedFilePath: "test/data/http_response_files/validator_headers_matchers.hresp",
+			expectedErrCount: 4,
+			expectedErrTexts: []string{
+				"expected value '{{$regexp application/json.*}}' for header 'Content-Type' not found",
+				"expected header 'X-Request-Id' not found",
+				"expected value '{{$anyGuid}}' for header 'X-Trace-Id' not found",
+				"for header 'Server' not found",
+			},
+		},
+		{
+			name: "invalid pattern",
+			actualResponse: &rc.Response{
+				StatusCode: 200, Status: "200 OK", Headers: http.Header{"X-Version": {"v1"}},
+			},
+			expectedFilePath: "test/data/http_response_files/validator_headers_matchers_invalid.hresp",
+			expectedErrCount: 1,
+			expectedErrTexts: []string{"header 'X-Version': invalid pattern in expected value '{{$regexp v(}}'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: actualResponse and expectedFilePath from the test case tt
+			client, _ := rc.NewClient()
+
+			// When
+			err := client.ValidateResponses(tt.expectedFilePath, tt.actualResponse)
+
+			// Then
+			if tt.expectedErrCount == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertMultierrorContains(t, err, tt.expectedErrCount, tt.expectedErrTexts)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR3.13 - Response Validation: Subset JSON Matching
+// Corresponds to: the "# match-mode subset" directive of .hresp files and the WithMatchMode client option,
+// under which the expected JSON body only needs to be a subset of the actual one.
+// This test verifies that fields the expected body leaves out are ignored at any depth, also in array
+// elements, that placeholders and missing fields are still checked, and that the directive takes precedence
+// over the client's mode.
+func RunValidateResponses_SubsetMatchMode(t *testing.T) {
+	t.Helper()
+	// Given
+	const actualBody = `{"id": 7, "name": "Lamp", "addedField": true,
+		"owner": {"id": 1, "email": "a@example.com"}, "items": [{"sku": "A", "qty": 1}, {"sku": "B", "qty": 2}]}`
+	const expectedBody = `{"id": {{$anyNumber}}, "owner": {"id": 1}, "items": [{"sku": "A"}, {"sku": "B"}]}`
+	dir := t.TempDir()
+	writeHresp := func(name, directive, body string) string {
+		path := filepath.Join(dir, name)
+		content := "HTTP/1.1 200 OK\n" + directive + "Content-Type: application/json\n\n" + body + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	subsetFile := writeHresp("subset.hresp", "# match-mode subset\n", expectedBody)
+	missingFile := writeHresp("missing.hresp", "# match-mode subset\n", `{"id": 7, "color": "red"}`)
+	plainFile := writeHresp("plain.hresp", "", expectedBody)
+	exactFile := writeHresp("exact.hresp", "# match-mode exact\n", expectedBody)
+	invalidFile := writeHresp("invalid.hresp", "# match-mode loose\n", expectedBody)
+	actual := &rc.Response{
+		StatusCode: http.StatusOK, Status: "200 OK",
+		Headers:    http.Header{"Content-Type": {"application/json"}},
+		BodyString: actualBody,
+	}
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+	subsetClient, err := rc.NewClient(rc.WithMatchMode(rc.MatchSubset))
+	require.NoError(t, err)
+
+	// When / Then
+	assert.NoError(t, client.ValidateResponses(subsetFile, actual))
+
+	missingErr := client.ValidateResponses(missingFile, actual)
+	require.Error(t, missingErr)
+	assert.Contains(t, missingErr.Error(), `"color":"red"`)
+
+	assert.Error(t, client.ValidateResponses(plainFile, actual), "bodies are compared exactly by default")
+	assert.NoError(t, subsetClient.ValidateResponses(plainFile, actual))
+	assert.Error(t, subsetClient.ValidateResponses(exactFile, actual), "the directive takes precedence")
+
+	invalidErr := client.ValidateResponses(invalidFile, actual)
+	require.Error(t, invalidErr)
+	assert.Contains(t, invalidErr.Error(), "match-mode")
+
+	_, err = rc.NewClient(rc.WithMatchMode("loose"))
+	assert.Error(t, err)
+}
+
+// PRD-COMMENT: FR3.14 - Response Validation: Unordered JSON Arrays
+// Corresponds to: the "# unordered [path...]" directive of .hresp files and the WithUnorderedArrays client
+// option, under which the elements of JSON arrays may come in any order.
+// This test verifies that the arrays at the listed paths, and arrays nested in them, match regardless of
+// order, also with placeholders and the subset match mode, while other arrays and missing elements fail.
+func RunValidateResponses_UnorderedArrays(t *testing.T) {
+	t.Helper()
+	// Given
+	const actualBody = `{"items": [{"id": 2, "name": "b", "tags": ["y", "x"], "extra": 1}, {"id": 1, "name": "a",
+		"tags": []}], "codes": [3, 1, 2]}`
+	const expectedBody = `{"items": [{"id": 1, "name": "a", "tags": []}, {"id": {{$anyNumber}}, "name": "b",
+		"tags": ["x", "y"]}], "codes": [1, 2, 3]}`
+	dir := t.TempDir()
+	writeHresp := func(name, directives, body string) string {
+		path := filepath.Join(dir, name)
+		content := "HTTP/1.1 200 OK\n" + directives + "Content-Type: application/json\n\n" + body + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	allFile := writeHresp("all.hresp", "# match-mode subset\n# unordered\n", expectedBody)
+	itemsFile := writeHresp("items.hresp", "# match-mode subset\n# unordered $.items\n", expectedBody)
+	pathsFile := writeHresp("paths.hresp", "# match-mode subset\n# unordered $.items $.codes\n", expectedBody)
+	plainFile := writeHresp("plain.hresp", "# match-mode subset\n", expectedBody)
+	missingFile := writeHresp("missing.hresp", "# unordered $.codes\n", `{"codes": [1, 2, 4]}`)
+	actual := func(body string) *rc.Response {
+		return &rc.Response{
+			StatusCode: http.StatusOK, Status: "200 OK",
+			Headers:    http.Header{"Content-Type": {"application/json"}},
+			BodyString: body,
+		}
+	}
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+	unorderedClient, err := rc.NewClient(rc.WithUnorderedArrays())
+	require.NoError(t, err)
+
+	// When / Then
+	assert.NoError(t, client.ValidateResponses(allFile, actual(actualBody)))
+	assert.Error(t, client.ValidateResponses(itemsFile, actual(actualBody)), "codes are still ordered")
+	assert.NoError(t, client.ValidateResponses(pathsFile, actual(actualBody)))
+	assert.Error(t, client.ValidateResponses(plainFile, actual(actualBody)), "arrays are ordered by default")
+	assert.NoError(t, unorderedClient.ValidateResponses(plainFile, actual(actualBody)))
+
+	missingErr := client.ValidateResponses(missingFile, actual(`{"codes": [2, 3, 1]}`))
+	require.Error(t, missingErr)
+	assert.Contains(t, missingErr.Error(), "-{\"codes\":[1,2,4]}")
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR3.16 - Response Validation: Precise Body Mismatch Reports
+// Corresponds to: the body mismatch errors of ValidateResponses.
+// This test verifies that mismatching JSON bodies report the JSON Pointer of each differing, missing and
+// unexpected value with the expected and actual values, also when the expected body has placeholders,
+// and that mismatching text bodies report the differing lines by number.
+func RunValidateResponses_MismatchReport(t *testing.T) {
+	t.Helper()
+	// Given
+	dir := t.TempDir()
+	writeHresp := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("HTTP/1.1 200 OK\n\n"+body+"\n"), 0644))
+		return path
+	}
+	plainFile := writeHresp("plain.hresp", `{"id": 1, "name": "Lamp", "tags": ["a", "b"], "owner": {"a/b": 1}}`)
+	placeholderFile := writeHresp("placeholder.hresp", `{"id": {{$anyNumber}}, "ref": "{{$anyGuid}}", "qty": 2}`)
+	textFile := writeHresp("text.hresp", "line one\nid {{$anyGuid}}\nline three")
+	response := func(body string) *rc.Response {
+		return &rc.Response{StatusCode: http.StatusOK, Status: "200 OK", BodyString: body}
+	}
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	plainErr := client.ValidateResponses(plainFile,
+		response(`{"id": 2, "name": "Lamp", "tags": ["a"], "owner": {"a/b": 1}, "extra": true}`))
+	placeholderErr := client.ValidateResponses(placeholderFile,
+		response(`{"id": 7, "ref": "not-a-guid", "qty": 3}`))
+	textErr := client.ValidateResponses(textFile, response("line one\nid nope\nline three"))
+
+	// Then
+	require.Error(t, plainErr)
+	assert.Contains(t, plainErr.Error(), "mismatched JSON values (3):\n"+
+		"  /id: expected 1, got 2\n"+
+		"  /tags/1: expected \"b\", missing\n"+
+		"  /extra: unexpected true\n")
+	assert.Contains(t, plainErr.Error(), "--- Expected JSON (normalized)", "the unified diff follows")
+
+	require.Error(t, placeholderErr)
+	assert.Contains(t, placeholderErr.Error(), "mismatched JSON values (2):\n"+
+		"  /qty: expected 2, got 3\n"+
+		"  /ref: expected \"{{$anyGuid}}\", got \"not-a-guid\"\n")
+	assert.NotContains(t, placeholderErr.Error(), "/id:", "values matching their placeholder are not reported")
+
+	require.Error(t, textErr)
+	assert.Contains(t, textErr.Error(), "mismatched lines (1):\n"+
+		"  line 2: expected \"id {{$anyGuid}}\", got \"id nope\"\n")
+	assert.NotContains(t, textErr.Error(), `\n`, "messages use real line breaks")
+}
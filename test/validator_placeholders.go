@@ -1,11 +1,16 @@
 package test
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	rc "github.com/bmcszk/go-restclient"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func RunValidateResponses_BodyRegexpPlaceholder(t *testing.T) {
@@ -423,3 +428,341 @@ func RunValidateResponses_BodyAnyPlaceholder(t *testing.T) {
 		})
 	}
 }
+
+// PRD-COMMENT: FR3.17 - Response Validation: Date Tolerance Placeholders
+// Corresponds to: The {{$dateWithin tolerance}}, {{$dateAfter ref}} and {{$dateBefore ref}} placeholders of
+// expected response bodies (http_syntax.md "Response Body Validation Placeholders").
+// This test verifies that they assert a response timestamp is close to, after or before the time the
+// request was sent.
+func RunValidateResponses_BodyDateTolerancePlaceholders(t *testing.T) {
+	t.Helper()
+	// Given: a request sent "now" that took 200ms
+	requestStart := time.Now().UTC()
+	duration := 200 * time.Millisecond
+	tests := []struct {
+		name             string
+		expectedContent  string
+		actualBody       string
+		expectedErrTexts []string
+	}{
+		{
+			name:            "dateWithin accepts RFC3339 timestamp inside tolerance",
+			expectedContent: `{"createdAt": "{{$dateWithin 5s}}"}`,
+			actualBody:      fmt.Sprintf(`{"createdAt": "%s"}`, requestStart.Add(2*time.Second).Format(time.RFC3339)),
+		},
+		{
+			name:             "dateWithin rejects timestamp outside tolerance",
+			expectedContent:  `{"createdAt": "{{$dateWithin 5s}}"}`,
+			actualBody:       fmt.Sprintf(`{"createdAt": "%s"}`, requestStart.Add(-time.Hour).Format(time.RFC3339)),
+			expectedErrTexts: []string{"{{$dateWithin 5s}}", "is not within 5s of the request time"},
+		},
+		{
+			name:            "dateWithin accepts Unix timestamp in plain text",
+			expectedContent: `created at {{$dateWithin 1m}}`,
+			actualBody:      fmt.Sprintf(`created at %d`, requestStart.Unix()),
+		},
+		{
+			name:             "dateWithin reports invalid tolerance",
+			expectedContent:  `{"createdAt": "{{$dateWithin soon}}"}`,
+			actualBody:       fmt.Sprintf(`{"createdAt": "%s"}`, requestStart.Format(time.RFC3339)),
+			expectedErrTexts: []string{"invalid tolerance \"soon\""},
+		},
+		{
+			name:            "dateAfter nested requestStart accepts second-precision timestamp",
+			expectedContent: `{"updatedAt": "{{$dateAfter {{requestStart}}}}"}`,
+			actualBody:      fmt.Sprintf(`{"updatedAt": "%s"}`, requestStart.Format(time.RFC3339)),
+		},
+		{
+			name:             "dateAfter rejects earlier timestamp",
+			expectedContent:  `{"updatedAt": "{{$dateAfter requestStart}}"}`,
+			actualBody:       fmt.Sprintf(`{"updatedAt": "%s"}`, requestStart.Add(-time.Minute).Format(time.RFC3339)),
+			expectedErrTexts: []string{"is not after"},
+		},
+		{
+			name:            "dateBefore absolute reference",
+			expectedContent: `{"expiresAt": "{{$dateBefore 2999-01-01T00:00:00Z}}", "id": "{{$anyGuid}}"}`,
+			actualBody: fmt.Sprintf(`{"expiresAt": "%s", "id": "123e4567-e89b-12d3-a456-426614174000"}`,
+				requestStart.Format(time.RFC3339Nano)),
+		},
+		{
+			name:             "dateBefore requestEnd rejects later timestamp",
+			expectedContent:  `{"expiresAt": "{{$dateBefore requestEnd}}"}`,
+			actualBody:       fmt.Sprintf(`{"expiresAt": "%s"}`, requestStart.Add(time.Hour).Format(time.RFC3339)),
+			expectedErrTexts: []string{"is not before"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			hrespPath := filepath.Join(t.TempDir(), "expected.hresp")
+			hrespContent := "HTTP/1.1 200 OK\nContent-Type: application/json\n\n" + tt.expectedContent
+			require.NoError(t, os.WriteFile(hrespPath, []byte(hrespContent), 0644))
+			actual := &rc.Response{
+				StatusCode: 200, Status: "200 OK",
+				Headers:    http.Header{"Content-Type": {"application/json"}},
+				BodyString: tt.actualBody,
+				StartTime:  requestStart,
+				Duration:   duration,
+			}
+			client, _ := rc.NewClient()
+
+			// When
+			err := client.ValidateResponses(hrespPath, actual)
+
+			// Then
+			if len(tt.expectedErrTexts) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertMultierrorContains(t, err, 1, tt.expectedErrTexts)
+			}
+		})
+	}
+}
+
+// PRD-COMMENT: FR3.17 - Response Validation: {{$anyOf}} Placeholder
+// Corresponds to: The {{$anyOf "A" "B"}} placeholder of expected response bodies, matching any one of a
+// fixed set of literal values (http_syntax.md "Response Body Validation Placeholders").
+// This test verifies quoted and unquoted values in JSON and plain text bodies, values with spaces and
+// regexp characters, and that other values and partial matches are rejected.
+func RunValidateResponses_BodyAnyOfPlaceholder(t *testing.T) {
+	t.Helper()
+	tests := []struct {
+		name             string
+		contentType      string
+		expectedContent  string
+		actualBody       string
+		expectedErrTexts []string
+	}{
+		{
+			name:            "anyOf matches one of the quoted values in a JSON string",
+			contentType:     "application/json",
+			expectedContent: `{"id": 7, "status": "{{$anyOf "PENDING" "QUEUED"}}"}`,
+			actualBody:      `{"status": "QUEUED", "id": 7}`,
+		},
+		{
+			name:             "anyOf rejects other values",
+			contentType:      "application/json",
+			expectedContent:  `{"status": "{{$anyOf "PENDING" "QUEUED"}}"}`,
+			actualBody:       `{"status": "FAILED"}`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+		{
+			name:            "anyOf matches unquoted numbers in JSON",
+			contentType:     "application/json",
+			expectedContent: `{"retries": {{$anyOf 0 1 2}}}`,
+			actualBody:      `{"retries": 2}`,
+		},
+		{
+			name:            "anyOf values may contain spaces and regexp characters",
+			contentType:     "text/plain",
+			expectedContent: `State: {{$anyOf "in progress (1/2)" done}}.`,
+			actualBody:      `State: in progress (1/2).`,
+		},
+		{
+			name:             "anyOf matches whole values only",
+			contentType:      "text/plain",
+			expectedContent:  `State: {{$anyOf "in progress" done}}.`,
+			actualBody:       `State: in progress or done.`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			hrespPath := filepath.Join(t.TempDir(), "expected.hresp")
+			hrespContent := "HTTP/1.1 200 OK\nContent-Type: " + tt.contentType + "\n\n" + tt.expectedContent
+			require.NoError(t, os.WriteFile(hrespPath, []byte(hrespContent), 0644))
+			actual := &rc.Response{
+				StatusCode: 200, Status: "200 OK",
+				Headers:    http.Header{"Content-Type": {tt.contentType}},
+				BodyString: tt.actualBody,
+			}
+			client, _ := rc.NewClient()
+
+			// When
+			err := client.ValidateResponses(hrespPath, actual)
+
+			// Then
+			if len(tt.expectedErrTexts) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertMultierrorContains(t, err, 1, tt.expectedErrTexts)
+			}
+		})
+	}
+}
+
+// PRD-COMMENT: FR3.17 - Response Validation: Typed Placeholders
+// Corresponds to: The {{$anyNumber}}, {{$anyBool}}, {{$anyString}} and {{$anyArray}} placeholders of expected
+// response bodies (http_syntax.md "Response Body Validation Placeholders").
+// This test verifies that each matches a JSON value of its type only.
+func RunValidateResponses_BodyAnyTypePlaceholders(t *testing.T) {
+	t.Helper()
+	tests := []struct {
+		name             string
+		expectedContent  string
+		actualBody       string
+		expectedErrTexts []string
+	}{
+		{
+			name: "typed placeholders match values of their type",
+			expectedContent: `{"id": {{$anyNumber}}, "price": {{$anyNumber}}, "active": {{$anyBool}},
+				"name": {{$anyString}}, "sku": "{{$anyString}}", "tags": {{$anyArray}}, "stock": 3}`,
+			actualBody: `{"id": 1234, "price": -9.5e2, "active": false, "name": "Lamp \"XL\"", "sku": "",
+				"tags": ["a", ["b"], {"c": 1}], "stock": 3}`,
+		},
+		{
+			name:             "anyNumber rejects a number in a string",
+			expectedContent:  `{"id": {{$anyNumber}}}`,
+			actualBody:       `{"id": "1234"}`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+		{
+			name:             "anyBool rejects other values",
+			expectedContent:  `{"active": {{$anyBool}}}`,
+			actualBody:       `{"active": null}`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+		{
+			name:             "anyString rejects numbers",
+			expectedContent:  `{"name": "{{$anyString}}"}`,
+			actualBody:       `{"name": 7}`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+		{
+			name:             "anyArray rejects objects",
+			expectedContent:  `{"tags": {{$anyArray}}}`,
+			actualBody:       `{"tags": {"a": 1}}`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+		{
+			name:             "anyArray does not span an extra sibling array",
+			expectedContent:  `{"tags":{{$anyArray}}}`,
+			actualBody:       `{"tags":[1],"extra":[2]}`,
+			expectedErrTexts: []string{"body mismatch", "/extra: unexpected [2]"},
+		},
+		{
+			name:             "anyArray does not span an extra key before a sibling",
+			expectedContent:  `{"tags": {{$anyArray}}, "b": 1}`,
+			actualBody:       `{"tags":[1],"x":[2],"b":1}`,
+			expectedErrTexts: []string{"body mismatch", "/x: unexpected [2]"},
+		},
+		{
+			name:            "anyArray with nested arrays and siblings",
+			expectedContent: `{"a": {{$anyArray}}, "b": {{$anyArray}}}`,
+			actualBody:      `{"a":[[1],[2,[3]]],"b":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			hrespPath := filepath.Join(t.TempDir(), "expected.hresp")
+			hrespContent := "HTTP/1.1 200 OK\nContent-Type: application/json\n\n" + tt.expectedContent
+			require.NoError(t, os.WriteFile(hrespPath, []byte(hrespContent), 0644))
+			actual := &rc.Response{
+				StatusCode: 200, Status: "200 OK",
+				Headers:    http.Header{"Content-Type": {"application/json"}},
+				BodyString: tt.actualBody,
+			}
+			client, _ := rc.NewClient()
+
+			// When
+			err := client.ValidateResponses(hrespPath, actual)
+
+			// Then
+			if len(tt.expectedErrTexts) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertMultierrorContains(t, err, 1, tt.expectedErrTexts)
+			}
+		})
+	}
+}
+
+// PRD-COMMENT: FR3.17 - Response Validation: {{$regexp}} Placeholders in JSON Bodies
+// Corresponds to: The {{$regexp pattern}} placeholder of expected JSON response bodies.
+// This test verifies placeholders for values at any depth, bare for scalars and inside strings, alone or
+// next to literal text, with indented expected bodies compared to compact actual ones.
+func RunValidateResponses_BodyRegexpPlaceholderInJSON(t *testing.T) {
+	t.Helper()
+	const nestedExpected = "{\n  \"data\": {\n    \"items\": [\n" +
+		"      {\"id\": {{$regexp `\\d+`}}, \"href\": \"{{$regexp `https://api\\.example\\.com/items/\\d+`}}\"},\n" +
+		"      {\"id\": {{$regexp `\\d+`}}, \"href\": \"https://api.example.com/items/{{$regexp `\\d+`}}\"}\n" +
+		"    ],\n    \"next\": \"{{$regexp `.*\\?page=\\d+`}}\"\n  }\n}"
+	tests := []struct {
+		name             string
+		expectedContent  string
+		actualBody       string
+		expectedErrTexts []string
+	}{
+		{
+			name:            "nested objects and arrays",
+			expectedContent: nestedExpected,
+			actualBody: `{"data":{"next":"https://api.example.com/items?page=2","items":[` +
+				`{"href":"https://api.example.com/items/12","id":12},{"id":13,"href":"https://api.example.com/items/13"}]}}`,
+		},
+		{
+			name:            "nested value not matching",
+			expectedContent: nestedExpected,
+			actualBody: `{"data":{"next":"https://api.example.com/items?page=2","items":[` +
+				`{"href":"https://api.example.com/items/12","id":12},{"id":13,"href":"https://evil.example.com/items/13"}]}}`,
+			expectedErrTexts: []string{"body mismatch", "/data/items/1/href: expected"},
+		},
+		{
+			name:             "string pattern does not run past the closing quote",
+			expectedContent:  "{\"data\": {\"next\": \"{{$regexp `.*\\?page=\\d+`}}\"}, \"n\": 1}",
+			actualBody:       `{"data":{"next":"?page=1","token":"?page=2"},"n":1}`,
+			expectedErrTexts: []string{"body mismatch", "/data/token: unexpected"},
+		},
+		{
+			name:             "string pattern does not absorb an injected sibling of another value",
+			expectedContent:  "{\"a\": \"{{$regexp `.*`}}\", \"b\": \"{{$regexp `.*`}}\"}",
+			actualBody:       `{"a":"x","b":"y","c":"z"}`,
+			expectedErrTexts: []string{"body mismatch", "/c: unexpected"},
+		},
+		{
+			name:             "bare pattern rejects strings",
+			expectedContent:  "{\"ids\": [{{$regexp `\\d+`}}]}",
+			actualBody:       `{"ids": ["12"]}`,
+			expectedErrTexts: []string{"body mismatch"},
+		},
+		{
+			name:            "HTML characters next to the pattern",
+			expectedContent: "{\"tag\": \"<{{$regexp `[a-z]+`}}>\"}",
+			actualBody:      `{"tag": "<b>"}`,
+		},
+		{
+			name:            "escaped quotes matched escaped",
+			expectedContent: "{\"msg\": \"{{$regexp `say \\\\\"\\w+\\\\\"`}}\"}",
+			actualBody:      `{"msg": "say \"hi\""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			hrespPath := filepath.Join(t.TempDir(), "expected.hresp")
+			hrespContent := "HTTP/1.1 200 OK\nContent-Type: application/json\n\n" + tt.expectedContent
+			require.NoError(t, os.WriteFile(hrespPath, []byte(hrespContent), 0644))
+			actual := &rc.Response{
+				StatusCode: 200, Status: "200 OK",
+				Headers:    http.Header{"Content-Type": {"application/json"}},
+				BodyString: tt.actualBody,
+			}
+			client, _ := rc.NewClient()
+
+			// When
+			err := client.ValidateResponses(hrespPath, actual)
+
+			// Then
+			if len(tt.expectedErrTexts) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertMultierrorContains(t, err, 1, tt.expectedErrTexts)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR3.15 - Response Validation: Writing Expected Responses from Live Responses
+// Corresponds to: ExecuteAndWriteExpected, WriteExpectedResponses and the UPDATE_HRESP environment variable
+// honored by ValidateResponses (snapshot update mode).
+// This test verifies that .hresp files are written from actual responses with GUIDs and dates replaced by
+// placeholders and volatile headers left out, that the written files validate later responses with other
+// dynamic values, that UPDATE_HRESP makes ValidateResponses refresh a file instead of comparing, and that
+// nothing is written when a request could not be sent.
+func RunValidateResponses_WriteExpected(t *testing.T) {
+	t.Helper()
+	// Given
+	calls := 0
+	server := startMockServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", fmt.Sprintf("123e4567-e89b-12d3-a456-%012d", calls))
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		created := time.Date(2024, 5, 1, 10, 0, calls, 0, time.UTC).Format(time.RFC3339)
+		_, _ = fmt.Fprintf(w, `{"id":"a1b2c3d4-0000-4000-8000-%012d","name":"Lamp","created":%q,"qty":2}`,
+			calls, created)
+	})
+	defer server.Close()
+
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "items.http")
+	content := fmt.Sprintf("POST %[1]s/items\n\n{\"name\": \"Lamp\"}\n\n###\nDELETE %[1]s/items/1\n", server.URL)
+	require.NoError(t, os.WriteFile(httpFile, []byte(content), 0644))
+	expectedDir := filepath.Join(dir, "expected")
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	_, writeErr := client.ExecuteAndWriteExpected(context.Background(), httpFile, expectedDir)
+	written, readErr := os.ReadFile(filepath.Join(expectedDir, "items.hresp"))
+	later, laterErr := client.ExecuteFile(context.Background(), httpFile)
+
+	// Then
+	require.NoError(t, writeErr)
+	require.NoError(t, readErr)
+	require.NoError(t, laterErr)
+	assert.Equal(t, "HTTP/1.1 201 Created\nContent-Type: application/json\nX-Request-Id: {{$anyGuid}}\n\n"+
+		"{\n  \"id\": \"{{$anyGuid}}\",\n  \"name\": \"Lamp\",\n  \"created\": \"{{$anyDatetime iso8601}}\",\n"+
+		"  \"qty\": 2\n}\n\n###\n\nHTTP/1.1 204 No Content\nContent-Type: application/json\n"+
+		"X-Request-Id: {{$anyGuid}}\n", string(written))
+	assert.NoError(t, client.ValidateResponses(filepath.Join(expectedDir, "items.hresp"), later...))
+
+	// Given: a stale expected file refreshed in update mode
+	staleFile := filepath.Join(dir, "stale.hresp")
+	require.NoError(t, os.WriteFile(staleFile, []byte("HTTP/1.1 200 OK\n\nold\n"), 0644))
+	require.Error(t, client.ValidateResponses(staleFile, later...))
+
+	// When
+	t.Setenv(rc.UpdateExpectedEnv, "1")
+	updateErr := client.ValidateResponses(staleFile, later...)
+	t.Setenv(rc.UpdateExpectedEnv, "")
+
+	// Then
+	require.NoError(t, updateErr)
+	assert.NoError(t, client.ValidateResponses(staleFile, later...))
+
+	// Given: a request that cannot be sent
+	server.Close()
+
+	// When
+	_, failedErr := client.ExecuteAndWriteExpected(context.Background(), httpFile, filepath.Join(dir, "failed"))
+
+	// Then
+	require.Error(t, failedErr)
+	assert.NoDirExists(t, filepath.Join(dir, "failed"))
+}
+
+// PRD-COMMENT: FR3.15 - Response Validation: Writing Expected Responses from Live Responses
+// Corresponds to: WriteExpectedResponses and the "< file" bodies of .hresp files.
+// This test verifies that snapshots of bodies with lines the .hresp syntax reads as comments, variables
+// or separators, e.g. Markdown headings or YAML documents, validate the responses they were written from.
+func RunValidateResponses_WriteExpectedRoundTrip(t *testing.T) {
+	t.Helper()
+	// Given
+	response := func(contentType, body string) *rc.Response {
+		return &rc.Response{StatusCode: http.StatusOK, Status: "200 OK", Proto: "HTTP/1.1",
+			Headers: http.Header{"Content-Type": {contentType}}, BodyString: body}
+	}
+	responses := []*rc.Response{
+		response("text/markdown", "# Title\ntext\n"),
+		response("application/yaml", "a: 1\n---\n###\nb: 2\n"),
+		response("text/plain", "@name = value\n{{not a variable}}\n< not a file\n"),
+		response("application/json", `{"id": "123e4567-e89b-12d3-a456-426614174000", "tags": ["#1"]}`),
+		response("text/plain", "plain text\n"),
+	}
+	dir := t.TempDir()
+	hrespFile := filepath.Join(dir, "docs.hresp")
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	writeErr := client.WriteExpectedResponses(hrespFile, responses...)
+	written, readErr := os.ReadFile(hrespFile)
+
+	// Then
+	require.NoError(t, writeErr)
+	require.NoError(t, readErr)
+	assert.Contains(t, string(written), "\n< ./docs.1.body\n")
+	assert.Contains(t, string(written), "\n< ./docs.3.body\n")
+	assert.Contains(t, string(written), "plain text\n", "other bodies are written inline")
+	assert.FileExists(t, filepath.Join(dir, "docs.2.body"))
+	assert.NoFileExists(t, filepath.Join(dir, "docs.5.body"))
+	assert.NoError(t, client.ValidateResponses(hrespFile, responses...))
+
+	changed := append([]*rc.Response{response("text/markdown", "# Other title\ntext\n")}, responses[1:]...)
+	assert.Error(t, client.ValidateResponses(hrespFile, changed...), "bodies read from files are compared")
+}
@@ -2,6 +2,8 @@ package test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	rc "github.com/bmcszk/go-restclient"
@@ -235,3 +237,59 @@ func validateStatusCodeResult(t *testing.T, expectedErrCount int, expectedErrTex
 		assertMultierrorContains(t, err, expectedErrCount, expectedErrTexts)
 	}
 }
+
+// PRD-COMMENT: FR3.18 - Response Validation: Status Alternatives
+// Corresponds to: Status lines of expected responses listing acceptable codes or status classes,
+// e.g. "HTTP/1.1 200|201|204" or "HTTP/1.1 2xx".
+// This test verifies that any matching status code is accepted and others are rejected.
+func RunValidateResponses_StatusAlternatives(t *testing.T) {
+	t.Helper()
+	tests := []struct {
+		name             string
+		statusLine       string
+		actualCode       int
+		expectedErrTexts []string
+	}{
+		{name: "one of several codes", statusLine: "HTTP/1.1 200|201|204", actualCode: 201},
+		{name: "status class", statusLine: "HTTP/1.1 2xx", actualCode: 204},
+		{name: "class and code with status text", statusLine: "HTTP/1.1 2XX|304 Fine", actualCode: 304},
+		{
+			name:             "code outside the alternatives",
+			statusLine:       "HTTP/1.1 200|201",
+			actualCode:       202,
+			expectedErrTexts: []string{"status code mismatch: expected 200|201, got 202"},
+		},
+		{
+			name:             "code outside the class",
+			statusLine:       "HTTP/1.1 2xx",
+			actualCode:       404,
+			expectedErrTexts: []string{"status code mismatch: expected 2xx, got 404"},
+		},
+		{
+			name:             "invalid alternative",
+			statusLine:       "HTTP/1.1 200|abc",
+			actualCode:       200,
+			expectedErrTexts: []string{"invalid status 'abc'", "mismatch in number of responses"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given
+			hrespPath := filepath.Join(t.TempDir(), "expected.hresp")
+			require.NoError(t, os.WriteFile(hrespPath, []byte(tt.statusLine+"\n"), 0644))
+			actual := &rc.Response{StatusCode: tt.actualCode, Status: fmt.Sprintf("%d Some Text", tt.actualCode)}
+			client, _ := rc.NewClient()
+
+			// When
+			err := client.ValidateResponses(hrespPath, actual)
+
+			// Then
+			if len(tt.expectedErrTexts) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertMultierrorContains(t, err, len(tt.expectedErrTexts), tt.expectedErrTexts)
+			}
+		})
+	}
+}
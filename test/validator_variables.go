@@ -0,0 +1,60 @@
+package test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// PRD-COMMENT: FR3.12 - Response Validation: Variables in Expected Responses
+// Corresponds to: {{variable}} placeholders in .hresp files resolved like those of .http files: programmatic
+// variables (WithVars), @name = value definitions, the selected environment of the http-client.env.json files
+// next to the .hresp file, OS environment variables and {{$dotenv}} from the .env file next to it.
+// This test verifies that an expected response built from these sources matches, that @name definitions take
+// precedence over the environment, and that a mismatching resolved value is reported.
+func RunValidateResponses_WithVariables(t *testing.T) {
+	t.Helper()
+	// Given
+	dir := t.TempDir()
+	env := `{"dev": {"test_server_url": "http://localhost:8080", "expected_user_id": "42", "role": "viewer"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "http-client.env.json"), []byte(env), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("REGION=eu-west-1\n"), 0644))
+	hrespFile := filepath.Join(dir, "user.hresp")
+	hresp := "@role = admin\n\n" +
+		"HTTP/1.1 200 OK\nLocation: {{test_server_url}}/users/{{expected_user_id}}\n\n" +
+		`{"id": {{expected_user_id}}, "role": "{{role}}", "region": "{{$dotenv REGION}}", "tenant": "{{tenant}}"}` +
+		"\n"
+	require.NoError(t, os.WriteFile(hrespFile, []byte(hresp), 0644))
+
+	actual := func(body string) *rc.Response {
+		return &rc.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Headers:    http.Header{"Location": {"http://localhost:8080/users/42"}},
+			Body:       []byte(body),
+			BodyString: body,
+		}
+	}
+	client, err := rc.NewClient(rc.WithEnvironment("dev"), rc.WithVars(map[string]any{"tenant": "acme"}))
+	require.NoError(t, err)
+
+	// When
+	validationErr := client.ValidateResponses(hrespFile,
+		actual(`{"id": 42, "role": "admin", "region": "eu-west-1", "tenant": "acme"}`))
+
+	// Then
+	assert.NoError(t, validationErr)
+
+	// When
+	validationErr = client.ValidateResponses(hrespFile,
+		actual(`{"id": 7, "role": "admin", "region": "eu-west-1", "tenant": "acme"}`))
+
+	// Then
+	require.Error(t, validationErr)
+	assert.Contains(t, validationErr.Error(), `-{"id":42,`, "the expected id comes from the environment")
+}
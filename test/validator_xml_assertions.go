@@ -0,0 +1,79 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	rc "github.com/bmcszk/go-restclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const soapOrderResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <o:GetOrderResponse xmlns:o="urn:example:orders">
+      <o:order status="shipped">
+        <o:id>42</o:id>
+        <o:item sku="A-1">Widget</o:item>
+        <o:item sku="B-2">Gadget</o:item>
+      </o:order>
+    </o:GetOrderResponse>
+  </soap:Body>
+</soap:Envelope>`
+
+// PRD-COMMENT: FR3.10 - Response Validation: XPath Assertions
+// Corresponds to: '?? xml <xpath> [==|!=|contains <value>]' assertion lines in .hresp files, with
+// '# xmlns <prefix>=<uri>' namespace bindings, evaluated on XML response bodies; without an expected
+// body they replace the whole-document comparison.
+// This test verifies passing and failing assertions on a SOAP response, including prefixes bound to
+// other prefixes than the document's, attributes, predicates and an unbound prefix.
+func RunValidateResponses_XMLAssertions(t *testing.T) {
+	t.Helper()
+	// Given
+	dir := t.TempDir()
+	actual := &rc.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Body:       []byte(soapOrderResponse),
+		BodyString: soapOrderResponse,
+	}
+	passing := filepath.Join(dir, "passing.hresp")
+	require.NoError(t, os.WriteFile(passing, []byte(`HTTP/1.1 200 OK
+# xmlns s=http://schemas.xmlsoap.org/soap/envelope/
+# xmlns ns=urn:example:orders
+?? xml //ns:order/ns:id == "42"
+?? xml /s:Envelope/s:Body/ns:GetOrderResponse/ns:order/@status == 'shipped'
+?? xml //ns:item[@sku='B-2'] == "Gadget"
+?? xml //ns:order/ns:item[1]/text() == Widget
+?? xml //ns:order[ns:id='42']/ns:item contains dget
+?? xml //ns:order/ns:id != "41"
+?? xml //s:Body
+`), 0644))
+	failing := filepath.Join(dir, "failing.hresp")
+	require.NoError(t, os.WriteFile(failing, []byte(`HTTP/1.1 200 OK
+# xmlns ns=urn:example:orders
+?? xml //ns:order/ns:id == "43"
+?? xml //order/id
+?? xml //x:order
+`), 0644))
+	client, err := rc.NewClient()
+	require.NoError(t, err)
+
+	// When
+	passingErr := client.ValidateResponses(passing, actual)
+	failingErr := client.ValidateResponses(failing, actual)
+
+	// Then
+	assert.NoError(t, passingErr)
+	require.Error(t, failingErr)
+	assert.Contains(t, failingErr.Error(), `assertion 'xml //ns:order/ns:id == "43"' failed: got "42"`)
+	assert.Contains(t, failingErr.Error(), `assertion 'xml //order/id' failed: got no matching nodes`)
+	assert.Contains(t, failingErr.Error(), "unbound namespace prefix 'x'")
+	assert.NotContains(t, failingErr.Error(), "body mismatch", "assertions replace the body comparison")
+
+	invalid := filepath.Join(dir, "invalid.hresp")
+	require.NoError(t, os.WriteFile(invalid, []byte("HTTP/1.1 200 OK\n?? xml order/id\n"), 0644))
+	assert.ErrorContains(t, client.ValidateResponses(invalid, actual), "must start with / or //")
+}
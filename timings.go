@@ -0,0 +1,96 @@
+package restclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings breaks the duration of a request down into the phases of its final round trip, measured
+// with net/http/httptrace. Phases that did not happen are zero: DNS lookup, connect and TLS handshake
+// on a reused connection or for an IP address, and all phases for a response served by WithHTTPCache.
+type Timings struct {
+	DNSLookup       time.Duration // resolving the host name
+	TCPConnect      time.Duration // establishing the TCP connection, including a proxy's
+	TLSHandshake    time.Duration // negotiating TLS
+	TimeToFirstByte time.Duration // from obtaining a connection until the first byte of the response
+	Total           time.Duration // until the response headers arrived, with redirects; same as Response.Duration
+	ReusedConn      bool          // the request was sent on a kept-alive connection
+}
+
+// plus sums the phases of two requests, e.g. of the pages of a @paginate request
+func (t Timings) plus(other Timings) Timings {
+	return Timings{
+		DNSLookup:       t.DNSLookup + other.DNSLookup,
+		TCPConnect:      t.TCPConnect + other.TCPConnect,
+		TLSHandshake:    t.TLSHandshake + other.TLSHandshake,
+		TimeToFirstByte: t.TimeToFirstByte + other.TimeToFirstByte,
+		Total:           t.Total + other.Total,
+		ReusedConn:      t.ReusedConn && other.ReusedConn,
+	}
+}
+
+// timingsTrace records the phases of the round trips of a request; trace hooks may run concurrently
+type timingsTrace struct {
+	mu                                        sync.Mutex
+	timings                                   Timings
+	getConn, dnsStart, connectStart, tlsStart time.Time
+}
+
+// withTimings traces the phases of httpReq, in addition to any trace already attached to its context
+func withTimings(httpReq *http.Request) (*http.Request, *timingsTrace) {
+	t := &timingsTrace{}
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.record(func() {
+				t.timings = Timings{} // a redirect starts a new round trip
+				t.getConn = time.Now()
+			})
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.record(func() { t.timings.ReusedConn = info.Reused })
+		},
+		DNSStart: func(httptrace.DNSStartInfo) { t.record(func() { t.dnsStart = time.Now() }) },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.record(func() { t.timings.DNSLookup = time.Since(t.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			t.record(func() {
+				if t.timings.TCPConnect == 0 {
+					t.connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				t.record(func() { t.timings.TCPConnect = time.Since(t.connectStart) })
+			}
+		},
+		TLSHandshakeStart: func() { t.record(func() { t.tlsStart = time.Now() }) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(func() { t.timings.TLSHandshake = time.Since(t.tlsStart) })
+		},
+		GotFirstResponseByte: func() {
+			t.record(func() { t.timings.TimeToFirstByte = time.Since(t.getConn) })
+		},
+	}
+	return httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), trace)), t
+}
+
+// record updates the trace under its lock
+func (t *timingsTrace) record(update func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	update()
+}
+
+// result returns the timings of the final round trip, with the total duration of the request
+func (t *timingsTrace) result(total time.Duration) Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := t.timings
+	timings.Total = total
+	return timings
+}
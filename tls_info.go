@@ -0,0 +1,75 @@
+package restclient
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+)
+
+// TLSInfo describes the negotiated TLS connection a response was received over.
+type TLSInfo struct {
+	Version            string // e.g., "TLS 1.3"
+	VersionID          uint16 // e.g., tls.VersionTLS13, for numeric comparisons
+	CipherSuite        string // e.g., "TLS_AES_128_GCM_SHA256"
+	NegotiatedProtocol string // ALPN protocol, e.g., "h2" or "http/1.1"; empty if none was negotiated
+	ServerName         string // SNI server name sent by the client
+	DidResume          bool   // True if the session was resumed
+	// PeerCertificates summarizes the certificate chain presented by the server, leaf first.
+	PeerCertificates []CertificateInfo
+}
+
+// CertificateInfo is a summary of an X.509 certificate in the server's chain.
+type CertificateInfo struct {
+	Subject           string // Distinguished name, e.g., "CN=api.example.com,O=Example"
+	Issuer            string // Distinguished name of the issuer
+	SerialNumber      string // Decimal serial number
+	DNSNames          []string
+	NotBefore         time.Time
+	NotAfter          time.Time
+	IsCA              bool
+	SHA256Fingerprint string // Hex-encoded SHA-256 of the DER certificate
+}
+
+// Leaf returns the server's leaf certificate summary, or nil if none was presented.
+func (t *TLSInfo) Leaf() *CertificateInfo {
+	if t == nil || len(t.PeerCertificates) == 0 {
+		return nil
+	}
+	return &t.PeerCertificates[0]
+}
+
+// newTLSInfo builds a TLSInfo from the connection state of a response
+func newTLSInfo(state *tls.ConnectionState) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+	info := &TLSInfo{
+		Version:            getTLSVersionString(state.Version),
+		VersionID:          state.Version,
+		CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
+		NegotiatedProtocol: state.NegotiatedProtocol,
+		ServerName:         state.ServerName,
+		DidResume:          state.DidResume,
+	}
+	for _, cert := range state.PeerCertificates {
+		info.PeerCertificates = append(info.PeerCertificates, newCertificateInfo(cert))
+	}
+	return info
+}
+
+// newCertificateInfo summarizes a single certificate
+func newCertificateInfo(cert *x509.Certificate) CertificateInfo {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return CertificateInfo{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SerialNumber:      cert.SerialNumber.String(),
+		DNSNames:          cert.DNSNames,
+		NotBefore:         cert.NotBefore,
+		NotAfter:          cert.NotAfter,
+		IsCA:              cert.IsCA,
+		SHA256Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}
+}
@@ -0,0 +1,154 @@
+package restclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	tokenStoreDirPerm  = 0o700
+	tokenStoreFilePerm = 0o600 // tokens are secrets
+)
+
+// TokenStore keeps the OAuth2 tokens of a client, keyed by token URL, client ID and scope. The
+// default store keeps them in memory for the life of the client; NewFileTokenStore keeps them between
+// runs, so long suites and repeated runs do not ask the user to authorize again. Custom stores, e.g.
+// backed by a keychain, implement this interface; the client never calls a store concurrently.
+type TokenStore interface {
+	// Load returns the stored token, or nil without error when there is none
+	Load(key string) (*OAuth2Token, error)
+	// Save stores the token, replacing any previous one
+	Save(key string, token *OAuth2Token) error
+	// Delete removes the token; deleting a missing token is not an error
+	Delete(key string) error
+}
+
+// WithTokenStore sets where OAuth2 tokens are kept, see TokenStore
+func WithTokenStore(store TokenStore) ClientOption {
+	return func(c *Client) error {
+		if store == nil {
+			return errors.New("token store must not be nil")
+		}
+		c.oauth2Tokens.store = store
+		return nil
+	}
+}
+
+// memoryTokenStore keeps tokens in a map
+type memoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*OAuth2Token
+}
+
+// NewMemoryTokenStore returns a TokenStore keeping tokens in memory, which can be shared by clients
+func NewMemoryTokenStore() TokenStore {
+	return &memoryTokenStore{tokens: make(map[string]*OAuth2Token)}
+}
+
+// Load implements TokenStore
+func (s *memoryTokenStore) Load(key string) (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[key], nil
+}
+
+// Save implements TokenStore
+func (s *memoryTokenStore) Save(key string, token *OAuth2Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = token
+	return nil
+}
+
+// Delete implements TokenStore
+func (s *memoryTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
+
+// fileTokenStore keeps tokens in a JSON file, read and rewritten on every operation
+type fileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStore returns a TokenStore keeping tokens in a JSON file at path, readable by the
+// current user only. The file and its directory are created on the first save.
+func NewFileTokenStore(path string) TokenStore {
+	return &fileTokenStore{path: path}
+}
+
+// Load implements TokenStore
+func (s *fileTokenStore) Load(key string) (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return tokens[key], nil
+}
+
+// Save implements TokenStore
+func (s *fileTokenStore) Save(key string, token *OAuth2Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	tokens[key] = token
+	return s.write(tokens)
+}
+
+// Delete implements TokenStore
+func (s *fileTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[key]; !ok {
+		return nil
+	}
+	delete(tokens, key)
+	return s.write(tokens)
+}
+
+// read returns the tokens of the file; a missing file holds no tokens
+func (s *fileTokenStore) read() (map[string]*OAuth2Token, error) {
+	tokens := make(map[string]*OAuth2Token)
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token store %s: %w", s.path, err)
+	}
+	if err := json.Unmarshal(content, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse token store %s: %w", s.path, err)
+	}
+	return tokens, nil
+}
+
+// write replaces the file with the tokens
+func (s *fileTokenStore) write(tokens map[string]*OAuth2Token) error {
+	content, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), tokenStoreDirPerm); err != nil {
+		return fmt.Errorf("failed to create token store directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, content, tokenStoreFilePerm); err != nil {
+		return fmt.Errorf("failed to write token store %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+// Package toolkit analyses .http and .rest documents for editor integrations: request outlines,
+// variable definitions and references with their positions, and hovers showing resolved values.
+// It is designed to back a language server; positions follow the Language Server Protocol.
+package toolkit
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	rc "github.com/bmcszk/go-restclient"
+)
+
+// referenceFinder matches {{name}}, {{name | fallback}} and {{$system args}} placeholders
+var referenceFinder = regexp.MustCompile(`\{\{\s*([^{}|]*?)\s*(?:\|\s*([^{}]*?)\s*)?\}\}`)
+
+// Position is a zero-based line and character offset. As in the Language Server Protocol,
+// Character counts UTF-16 code units.
+type Position struct {
+	Line      int
+	Character int
+}
+
+// Range is a span of a document; End is exclusive.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// Contains reports whether pos lies within the range.
+func (r Range) Contains(pos Position) bool {
+	if pos.Line < r.Start.Line || pos.Line > r.End.Line {
+		return false
+	}
+	if pos.Line == r.Start.Line && pos.Character < r.Start.Character {
+		return false
+	}
+	return pos.Line != r.End.Line || pos.Character < r.End.Character
+}
+
+// RequestSymbol is an entry of the document outline.
+type RequestSymbol struct {
+	Name   string // @name of the request, or ""
+	Method string
+	URL    string // as written, before substitution
+	Range  Range  // from the first line of the request (including directives) to its last line
+}
+
+// VariableDefinition is an "@name = value" line.
+type VariableDefinition struct {
+	Name       string // without the '@'
+	Value      string // as written, before substitution
+	Range      Range  // the "@name" token
+	ValueRange Range
+}
+
+// VariableReference is a {{...}} placeholder.
+type VariableReference struct {
+	Name        string // variable name; for system variables the name with '$' and without arguments
+	Fallback    string
+	HasFallback bool
+	System      bool  // a system variable such as {{$uuid}}, generated at execution
+	Range       Range // the whole placeholder, braces included
+}
+
+// Document is the analysed content of a request file.
+type Document struct {
+	Path        string
+	Requests    []RequestSymbol
+	Definitions []VariableDefinition
+	References  []VariableReference
+
+	lines []string
+}
+
+// Parse analyses the content of the request file at path, typically an unsaved editor buffer.
+// Requests are read with the library parser, so the outline matches what ExecuteFile runs;
+// requests pulled in by imports are not part of the outline.
+func Parse(path string, content []byte) (*Document, error) {
+	parsed, err := rc.ParseRequests(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(strings.TrimPrefix(string(content), "\uFEFF"))
+	doc := &Document{Path: parsed.FilePath, lines: strings.Split(text, "\n")}
+	doc.Requests = doc.outline(parsed)
+	for lineIndex, line := range doc.lines {
+		if def, ok := parseDefinitionLine(lineIndex, line); ok {
+			doc.Definitions = append(doc.Definitions, def)
+		}
+		doc.References = append(doc.References, parseReferences(lineIndex, line)...)
+	}
+	return doc, nil
+}
+
+// Definition returns the definition of the named variable. As during execution, the last
+// definition in the document wins.
+func (d *Document) Definition(name string) (VariableDefinition, bool) {
+	for i := len(d.Definitions) - 1; i >= 0; i-- {
+		if d.Definitions[i].Name == name {
+			return d.Definitions[i], true
+		}
+	}
+	return VariableDefinition{}, false
+}
+
+// ReferencesTo returns all references to the named variable, in document order.
+func (d *Document) ReferencesTo(name string) []VariableReference {
+	var refs []VariableReference
+	for _, ref := range d.References {
+		if ref.Name == name {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// ReferenceAt returns the reference at pos, if any.
+func (d *Document) ReferenceAt(pos Position) (VariableReference, bool) {
+	for _, ref := range d.References {
+		if ref.Range.Contains(pos) {
+			return ref, true
+		}
+	}
+	return VariableReference{}, false
+}
+
+// DefinitionAt returns the definition whose "@name" token is at pos, if any.
+func (d *Document) DefinitionAt(pos Position) (VariableDefinition, bool) {
+	for _, def := range d.Definitions {
+		if def.Range.Contains(pos) {
+			return def, true
+		}
+	}
+	return VariableDefinition{}, false
+}
+
+// RequestAt returns the request whose block contains pos, if any.
+func (d *Document) RequestAt(pos Position) (RequestSymbol, bool) {
+	for _, req := range d.Requests {
+		if req.Range.Contains(pos) {
+			return req, true
+		}
+	}
+	return RequestSymbol{}, false
+}
+
+// outline builds the request symbols from the parsed requests of this document
+func (d *Document) outline(parsed *rc.ParsedFile) []RequestSymbol {
+	var own []*rc.Request
+	for _, req := range parsed.Requests {
+		if filepath.Clean(req.FilePath) == filepath.Clean(parsed.FilePath) && req.LineNumber > 0 {
+			own = append(own, req)
+		}
+	}
+
+	symbols := make([]RequestSymbol, 0, len(own))
+	for i, req := range own {
+		startLine := req.LineNumber - 1
+		nextStart := len(d.lines)
+		if i+1 < len(own) {
+			nextStart = own[i+1].LineNumber - 1
+		}
+		endLine := d.lastContentLine(startLine, nextStart)
+		symbols = append(symbols, RequestSymbol{
+			Name:   req.Name,
+			Method: req.Method,
+			URL:    req.RawURLString,
+			Range: Range{
+				Start: Position{Line: startLine},
+				End:   Position{Line: endLine, Character: utf16Len(d.lines[endLine])},
+			},
+		})
+	}
+	return symbols
+}
+
+// lastContentLine returns the last line before limit that is neither blank nor a separator
+func (d *Document) lastContentLine(start, limit int) int {
+	for line := limit - 1; line > start; line-- {
+		trimmed := strings.TrimSpace(d.lines[line])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "###") {
+			return line
+		}
+	}
+	return start
+}
+
+// parseDefinitionLine recognises "@name = value" lines, as the request parser does
+func parseDefinitionLine(lineIndex int, line string) (VariableDefinition, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "@") {
+		return VariableDefinition{}, false
+	}
+	eq := strings.IndexByte(line, '=')
+	if eq < 0 {
+		return VariableDefinition{}, false
+	}
+	at := strings.IndexByte(line, '@')
+	name := strings.TrimSpace(line[at+1 : eq])
+	if name == "" {
+		return VariableDefinition{}, false
+	}
+
+	valueStart := eq + 1 + (len(line[eq+1:]) - len(strings.TrimLeft(line[eq+1:], " \t")))
+	value := strings.TrimSpace(line[eq+1:])
+	return VariableDefinition{
+		Name:       name,
+		Value:      value,
+		Range:      lineRange(lineIndex, line, at, at+1+len(name)),
+		ValueRange: lineRange(lineIndex, line, valueStart, valueStart+len(value)),
+	}, true
+}
+
+// parseReferences finds the placeholders of a line
+func parseReferences(lineIndex int, line string) []VariableReference {
+	var refs []VariableReference
+	for _, m := range referenceFinder.FindAllStringSubmatchIndex(line, -1) {
+		name := line[m[2]:m[3]]
+		ref := VariableReference{Range: lineRange(lineIndex, line, m[0], m[1])}
+		if m[4] >= 0 {
+			ref.Fallback = line[m[4]:m[5]]
+			ref.HasFallback = true
+		}
+		if strings.HasPrefix(name, "$") {
+			ref.System = true
+			if fields := strings.Fields(name); len(fields) > 0 {
+				name = fields[0]
+			}
+		}
+		ref.Name = name
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// lineRange converts a byte span of a line into a Range
+func lineRange(lineIndex int, line string, startByte, endByte int) Range {
+	return Range{
+		Start: Position{Line: lineIndex, Character: utf16Len(line[:startByte])},
+		End:   Position{Line: lineIndex, Character: utf16Len(line[:endByte])},
+	}
+}
+
+// utf16Len returns the length of s in UTF-16 code units
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2 // surrogate pair
+		} else {
+			n++
+		}
+	}
+	return n
+}
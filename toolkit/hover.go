@@ -0,0 +1,80 @@
+package toolkit
+
+import "strings"
+
+// maxHoverDepth bounds nested resolution, so circular definitions still produce a hover
+const maxHoverDepth = 10
+
+// HoverSource tells where a hovered value comes from.
+type HoverSource string
+
+// Hover sources
+const (
+	SourceProvided   HoverSource = "provided"   // the vars passed to Hover, e.g. programmatic or environment variables
+	SourceFile       HoverSource = "file"       // an "@name = value" definition in the document
+	SourceFallback   HoverSource = "fallback"   // the {{name | fallback}} default
+	SourceSystem     HoverSource = "system"     // a system variable, generated when the request is executed
+	SourceUnresolved HoverSource = "unresolved" // no value: the placeholder is substituted with ""
+)
+
+// Hover is the resolved value of the variable at a position.
+type Hover struct {
+	Name   string
+	Value  string // fully substituted value; empty for system and unresolved variables
+	Source HoverSource
+	Range  Range // the hovered reference or definition name
+}
+
+// Hover resolves the variable referenced or defined at pos. vars holds values from outside the
+// document (programmatic variables, the selected environment) and, as during execution, takes
+// precedence over file definitions. References inside values are substituted recursively.
+func (d *Document) Hover(pos Position, vars map[string]string) (Hover, bool) {
+	if ref, ok := d.ReferenceAt(pos); ok {
+		hover := Hover{Name: ref.Name, Range: ref.Range}
+		switch value, source := d.resolve(ref.Name, vars, 0); {
+		case ref.System:
+			hover.Source = SourceSystem
+		case source == SourceUnresolved && ref.HasFallback:
+			hover.Value, hover.Source = ref.Fallback, SourceFallback
+		default:
+			hover.Value, hover.Source = value, source
+		}
+		return hover, true
+	}
+
+	if def, ok := d.DefinitionAt(pos); ok {
+		value, source := d.resolve(def.Name, vars, 0)
+		return Hover{Name: def.Name, Value: value, Source: source, Range: def.Range}, true
+	}
+	return Hover{}, false
+}
+
+// resolve returns the substituted value of the named variable and its source
+func (d *Document) resolve(name string, vars map[string]string, depth int) (string, HoverSource) {
+	if value, ok := vars[name]; ok {
+		return d.expand(value, vars, depth+1), SourceProvided
+	}
+	if def, ok := d.Definition(name); ok {
+		return d.expand(def.Value, vars, depth+1), SourceFile
+	}
+	return "", SourceUnresolved
+}
+
+// expand substitutes the non-system references of text; system variables are kept as written
+func (d *Document) expand(text string, vars map[string]string, depth int) string {
+	if depth > maxHoverDepth {
+		return text
+	}
+	return referenceFinder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		m := referenceFinder.FindStringSubmatch(placeholder)
+		name := m[1]
+		if strings.HasPrefix(name, "$") {
+			return placeholder
+		}
+		value, source := d.resolve(name, vars, depth)
+		if source == SourceUnresolved {
+			return m[2]
+		}
+		return value
+	})
+}
@@ -0,0 +1,126 @@
+package restclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// InformationalResponse is an interim 1xx response received before the final one, e.g.
+// 100 Continue for a request with "Expect: 100-continue" or 103 Early Hints.
+type InformationalResponse struct {
+	StatusCode int
+	Headers    http.Header
+}
+
+// informationalCapture collects the 1xx responses of a request
+type informationalCapture struct {
+	responses []InformationalResponse
+}
+
+// withInformationalCapture traces the 1xx responses received for httpReq, in addition to any trace
+// already attached to its context
+func withInformationalCapture(httpReq *http.Request) (*http.Request, *informationalCapture) {
+	capture := &informationalCapture{}
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			capture.responses = append(capture.responses,
+				InformationalResponse{StatusCode: code, Headers: http.Header(header).Clone()})
+			return nil
+		},
+	}
+	return httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), trace)), capture
+}
+
+// receivedTrailers returns the trailers of httpResp that were sent, or nil without any. Trailers
+// announced in the Trailer header but not sent are left out.
+func receivedTrailers(httpResp *http.Response) http.Header {
+	var trailers http.Header
+	for name, values := range httpResp.Trailer {
+		if len(values) == 0 {
+			continue
+		}
+		if trailers == nil {
+			trailers = make(http.Header)
+		}
+		trailers[name] = append([]string(nil), values...)
+	}
+	return trailers
+}
+
+// parseTrailerDirective parses "# trailer <Name>: <value>"
+func parseTrailerDirective(value string, resp *ExpectedResponse) error {
+	name, expected, found := strings.Cut(value, ":")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return fmt.Errorf("expected <Name>: <value>, got '%s'", value)
+	}
+	if resp.Trailers == nil {
+		resp.Trailers = make(http.Header)
+	}
+	resp.Trailers.Add(name, strings.TrimSpace(expected))
+	return nil
+}
+
+// parseInformationalDirective parses "# informational <1xx status code>"
+func parseInformationalDirective(value string, resp *ExpectedResponse) error {
+	code, err := strconv.Atoi(value)
+	if err != nil || code < 100 || code > 199 {
+		return fmt.Errorf("expected a 1xx status code, got '%s'", value)
+	}
+	resp.Informational = append(resp.Informational, code)
+	return nil
+}
+
+// validateTrailersAndInformational checks the "# trailer" and "# informational" assertions
+func (*Client) validateTrailersAndInformational(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	for _, name := range sortedKeys(expected.Trailers) {
+		actualValues := actual.Trailers.Values(name)
+		for _, value := range expected.Trailers[name] {
+			present, err := isHeaderValuePresent(value, actualValues, newDateReference(actual))
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("validation for response #%d ('%s'): trailer '%s': %w",
+					responseIndex, responseFilePath, name, err))
+				continue
+			}
+			if !present {
+				errs = multierror.Append(errs, fmt.Errorf(
+					"validation for response #%d ('%s'): expected value '%s' for trailer '%s' not found "+
+						"in actual values %v", responseIndex, responseFilePath, value, name, actualValues))
+			}
+		}
+	}
+	for _, code := range expected.Informational {
+		if !hasInformationalResponse(actual.Informational, code) {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"validation for response #%d ('%s'): expected informational response %d not received, got %v",
+				responseIndex, responseFilePath, code, informationalCodes(actual.Informational)))
+		}
+	}
+	return errs
+}
+
+// hasInformationalResponse reports whether a 1xx response with code was received
+func hasInformationalResponse(responses []InformationalResponse, code int) bool {
+	for _, resp := range responses {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// informationalCodes lists the status codes of 1xx responses for error messages
+func informationalCodes(responses []InformationalResponse) []int {
+	codes := make([]int, 0, len(responses))
+	for _, resp := range responses {
+		codes = append(codes, resp.StatusCode)
+	}
+	return codes
+}
@@ -0,0 +1,99 @@
+package restclient
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// urlComponent identifies the part of a request URL a placeholder is substituted into
+type urlComponent int
+
+const (
+	urlComponentPrefix   urlComponent = iota // scheme, host and port (or a base URL variable): never encoded
+	urlComponentPath                         // a path segment, encoded with url.PathEscape
+	urlComponentQuery                        // a query parameter name or value, encoded with url.QueryEscape
+	urlComponentFragment                     // the fragment, encoded with url.PathEscape
+)
+
+var urlPlaceholderFinder = regexp.MustCompile(`{{\s*(.*?)\s*}}`)
+
+// encodesURLVariables reports whether values substituted into request URLs are percent-encoded:
+// as set by WithURLVariableEncoding, otherwise on in strict mode.
+func (c *Client) encodesURLVariables() bool {
+	if c.urlVariableEncoding != nil {
+		return *c.urlVariableEncoding
+	}
+	return c.strictMode
+}
+
+// substituteEncodedURL resolves each placeholder of rawURL separately with resolve and percent-encodes
+// the value for the URL component the placeholder appears in. Components are determined from the
+// template itself, so characters such as '/', '?' or '#' inside a value never change the URL structure.
+func substituteEncodedURL(rawURL string, resolve func(string) string) string {
+	matches := urlPlaceholderFinder.FindAllStringIndex(rawURL, -1)
+	if len(matches) == 0 {
+		return rawURL
+	}
+
+	// Mask placeholders so URL delimiters are only looked up in the literal parts of the template
+	masked := []byte(rawURL)
+	for _, m := range matches {
+		for i := m[0]; i < m[1]; i++ {
+			masked[i] = 'x'
+		}
+	}
+	template := string(masked)
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		sb.WriteString(rawURL[last:m[0]])
+		value := resolve(rawURL[m[0]:m[1]])
+		sb.WriteString(encodeURLComponent(value, urlComponentAt(template, m[0])))
+		last = m[1]
+	}
+	sb.WriteString(rawURL[last:])
+	return sb.String()
+}
+
+// urlComponentAt returns the component of the masked URL template that offset falls into
+func urlComponentAt(template string, offset int) urlComponent {
+	before := template[:offset]
+	if strings.Contains(before, "#") {
+		return urlComponentFragment
+	}
+	if strings.Contains(before, "?") {
+		return urlComponentQuery
+	}
+	if pathStart := urlPathStart(template); pathStart >= 0 && offset > pathStart {
+		return urlComponentPath
+	}
+	return urlComponentPrefix
+}
+
+// urlPathStart returns the index of the '/' starting the path of the template, or -1 if it has none.
+// Without a scheme the first '/' starts the path, so "{{baseUrl}}/users" keeps {{baseUrl}} as a prefix.
+func urlPathStart(template string) int {
+	if schemeEnd := strings.Index(template, "://"); schemeEnd >= 0 {
+		hostStart := schemeEnd + len("://")
+		slash := strings.IndexByte(template[hostStart:], '/')
+		if slash < 0 {
+			return -1
+		}
+		return hostStart + slash
+	}
+	return strings.IndexByte(template, '/')
+}
+
+// encodeURLComponent percent-encodes value for the given URL component
+func encodeURLComponent(value string, component urlComponent) string {
+	switch component {
+	case urlComponentPath, urlComponentFragment:
+		return url.PathEscape(value)
+	case urlComponentQuery:
+		return url.QueryEscape(value)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,116 @@
+package restclient
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// Percent-encode sets of the WHATWG URL Standard (in addition to C0 controls and non-ASCII)
+const (
+	fragmentEncodeSet = " \"<>`"
+	queryEncodeSet    = " \"#<>"
+	pathEncodeSet     = " \"#<>?`{}"
+)
+
+// normalizeRequestURL makes a substituted URL acceptable to url.Parse the way a browser would
+// (WHATWG URL rules): an internationalized host is converted to punycode, and spaces, quotes,
+// non-ASCII characters and other code points not allowed in the path, query or fragment are
+// percent-encoded as UTF-8. A '%' not followed by two hex digits is encoded as "%25". Characters
+// that are already valid, including existing percent-escapes, are left unchanged.
+func normalizeRequestURL(rawURL string) string {
+	prefix, rest := "", rawURL
+	if schemeEnd := strings.Index(rawURL, "://"); schemeEnd >= 0 {
+		authorityStart := schemeEnd + len("://")
+		authorityEnd := len(rawURL)
+		if i := strings.IndexAny(rawURL[authorityStart:], "/?#"); i >= 0 {
+			authorityEnd = authorityStart + i
+		}
+		prefix = rawURL[:authorityStart] + normalizeAuthority(rawURL[authorityStart:authorityEnd])
+		rest = rawURL[authorityEnd:]
+	}
+
+	suffix := ""
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		rest, suffix = rest[:i], "#"+percentEncodeURLPart(rest[i+1:], fragmentEncodeSet)
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		rest, suffix = rest[:i], "?"+percentEncodeURLPart(rest[i+1:], queryEncodeSet)+suffix
+	}
+	return prefix + percentEncodeURLPart(rest, pathEncodeSet) + suffix
+}
+
+// normalizeAuthority converts the host of "[userinfo@]host[:port]" to its ASCII form
+func normalizeAuthority(authority string) string {
+	userinfo := ""
+	if at := strings.LastIndexByte(authority, '@'); at >= 0 {
+		userinfo, authority = authority[:at+1], authority[at+1:]
+	}
+	if strings.HasPrefix(authority, "[") { // IPv6 literal
+		return userinfo + authority
+	}
+	host, port := authority, ""
+	if colon := strings.LastIndexByte(authority, ':'); colon >= 0 {
+		host, port = authority[:colon], authority[colon:]
+	}
+	return userinfo + hostToASCII(host) + port
+}
+
+// hostToASCII converts an internationalized domain name to its ASCII (punycode) form with the IDNA
+// lookup profile, e.g. "Bücher.example" becomes "xn--bcher-kva.example". ASCII hosts, and hosts IDNA
+// rejects, are returned unchanged.
+func hostToASCII(host string) string {
+	if isASCII(host) {
+		return host
+	}
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return host
+	}
+	return ascii
+}
+
+// percentEncodeURLPart percent-encodes C0 controls, non-ASCII characters, the characters of
+// encodeSet and '%' signs that do not start a valid escape
+func percentEncodeURLPart(part, encodeSet string) string {
+	var sb strings.Builder
+	for i := 0; i < len(part); i++ {
+		c := part[i]
+		switch {
+		case c == '%' && !isPercentEscape(part[i:]):
+			sb.WriteString("%25")
+		case c < 0x20 || c == 0x7F || c >= utf8.RuneSelf || strings.IndexByte(encodeSet, c) >= 0:
+			sb.WriteString(percentEncodeByte(c))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// isPercentEscape reports whether s starts with '%' followed by two hex digits
+func isPercentEscape(s string) bool {
+	return len(s) >= 3 && isHexDigit(s[1]) && isHexDigit(s[2])
+}
+
+// isHexDigit reports whether c is a hexadecimal digit
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+// percentEncodeByte returns the "%XX" escape of c
+func percentEncodeByte(c byte) string {
+	const hexDigits = "0123456789ABCDEF"
+	return string([]byte{'%', hexDigits[c>>4], hexDigits[c&0x0F]})
+}
+
+// isASCII reports whether s contains only ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
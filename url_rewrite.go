@@ -0,0 +1,49 @@
+package restclient
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// urlRewrite is a rule registered with WithURLRewrite
+type urlRewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// WithURLRewrite rewrites the URLs of requests after variable substitution and BaseURL resolution,
+// replacing the matches of the regular expression pattern with replacement, in which $1 or ${name}
+// stand for submatches. Suites hard-coded to production hosts can so be pointed at staging or a local
+// mock without editing them, e.g.
+//
+//	WithURLRewrite(`^https://api\.example\.com`, "http://localhost:8080")
+//
+// Rules apply in the order they were registered, each to the result of the previous one. The rewritten
+// URL is sent and reported in Response.FinalURL, while Response.Request.URL keeps the authored target.
+func WithURLRewrite(pattern, replacement string) ClientOption {
+	return func(c *Client) error {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid URL rewrite pattern %q: %w", pattern, err)
+		}
+		c.urlRewrites = append(c.urlRewrites, urlRewrite{pattern: re, replacement: replacement})
+		return nil
+	}
+}
+
+// rewriteURL applies the client's URL rewrite rules to u, returning u itself without any rule
+func (c *Client) rewriteURL(u *url.URL) (*url.URL, error) {
+	if len(c.urlRewrites) == 0 || u == nil {
+		return u, nil
+	}
+	rewritten := u.String()
+	for _, rule := range c.urlRewrites {
+		rewritten = rule.pattern.ReplaceAllString(rewritten, rule.replacement)
+	}
+	parsed, err := url.Parse(rewritten)
+	if err != nil {
+		return nil, fmt.Errorf("rewritten URL %q is invalid: %w", rewritten, err)
+	}
+	return parsed, nil
+}
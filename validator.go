@@ -1,6 +1,7 @@
 package restclient
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -26,6 +27,7 @@ var ( //nolint:gochecknoglobals
 	// Pre-compiled regex patterns for JSON placeholder normalization
 	// Since we replace with numbers and restore later, quotes don't matter
 	jsonAnyGuidPlaceholderPattern      = regexp.MustCompile(`\{\{\$anyGuid\}\}`)
+	jsonRegexpPlaceholderPattern       = regexp.MustCompile(`\{\{\$regexp\s+(?s).*?\}\}`)
 	jsonAnyTimestampPlaceholderPattern = regexp.MustCompile(`\{\{\$anyTimestamp\}\}`)
 	jsonAnyDatetimePlaceholderPattern  = regexp.MustCompile(`\{\{\$anyDatetime.*?\}\}`)
 	jsonAnyPlaceholderPattern          = regexp.MustCompile(`\{\{\$any(?:\s+[^}]*)?\}\}`)
@@ -50,14 +52,21 @@ const anyRegexPattern = `(?s).*?`       // Matches any char (incl newline), non-
 //
 // As a method on the `Client`, it uses `c.programmaticVars` for programmatic variables and the client instance `c`
 // itself for resolving system variables (e.g., {{$uuid}}) within the .hresp content.
-// Variables can also be defined in the .hresp file using `@name = value` syntax.
+// Variables can also be defined in the .hresp file using `@name = value` syntax, and come from the selected
+// environment (see WithEnvironment) of the http-client.env.json files and the .env file next to the .hresp file.
 // The precedence for variable resolution is detailed in `hresp_vars.go:resolveAndSubstitute`.
 //
 // It returns a consolidated error (multierror) if any discrepancies are found (e.g., status mismatch,
 // header mismatch, body mismatch, or count mismatch between actual and expected responses), or nil
 // if all validations pass. Errors during file reading, @define extraction, variable substitution, or
 // .hresp parsing are also returned.
+//
+// With the environment variable UPDATE_HRESP set to a true value such as "1", it writes the actual responses
+// to the file with WriteExpectedResponses instead of comparing them, to create or refresh expected responses.
 func (c *Client) ValidateResponses(responseFilePath string, actualResponses ...*Response) error {
+	if updateExpectedEnabled() {
+		return c.WriteExpectedResponses(responseFilePath, actualResponses...)
+	}
 	expectedResponses, errs, parseErr := c.loadAndParseExpectedResponses(responseFilePath)
 
 	// If there was a critical error (file not found, etc.), return immediately
@@ -84,12 +93,20 @@ func (c *Client) loadAndParseExpectedResponses(
 		return nil, nil, fmt.Errorf("failed to read expected response file %s: %w", responseFilePath, err)
 	}
 
-	fileVars, contentWithoutDefines, err := extractHrespDefines(string(hrespFileContent))
+	fileVars, contentWithoutDefines, err := extractHrespDefines(string(normalizeLineEndings(hrespFileContent)))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to extract @defines from %s: %w", responseFilePath, err)
 	}
 
-	substitutedContent := resolveAndSubstitute(contentWithoutDefines, fileVars, c)
+	envVars, dotEnvVars, err := c.loadHrespEnvironment(responseFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load environment for %s: %w", responseFilePath, err)
+	}
+	for name, value := range fileVars {
+		envVars[name] = value // @name = value definitions take precedence over the environment
+	}
+
+	substitutedContent := resolveAndSubstitute(contentWithoutDefines, envVars, dotEnvVars, c)
 
 	expectedResponses, parseErr := parseExpectedResponses(strings.NewReader(substitutedContent), responseFilePath)
 	if parseErr != nil {
@@ -98,6 +115,9 @@ func (c *Client) loadAndParseExpectedResponses(
 			responseFilePath, parseErr))
 		return nil, errs, parseErr
 	}
+	if err := loadExpectedBodyFiles(responseFilePath, expectedResponses); err != nil {
+		return nil, nil, err
+	}
 
 	return expectedResponses, nil, nil
 }
@@ -144,7 +164,12 @@ func (c *Client) validateResponsePairs(responseFilePath string, actualResponses
 			continue
 		}
 
-		errs = c.validateSingleResponse(responseFilePath, i+1, actual, expected, errs)
+		responseErrs := c.validateEventually(responseFilePath, i+1, actual, expected)
+		if responseErrs != nil {
+			for _, responseErr := range responseErrs.Errors {
+				errs = multierror.Append(errs, newRequestError(actual.Request, i, PhaseValidate, responseErr))
+			}
+		}
 	}
 
 	return errs
@@ -152,15 +177,31 @@ func (c *Client) validateResponsePairs(responseFilePath string, actualResponses
 
 func (c *Client) validateSingleResponse(responseFilePath string, responseIndex int,
 	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if err := actual.BufferBody(); err != nil {
+		errs = multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): %w", responseIndex, responseFilePath, err))
+	}
 	errs = c.validateStatusCode(responseFilePath, responseIndex, actual, expected, errs)
 	errs = c.validateStatusString(responseFilePath, responseIndex, actual, expected, errs)
 	errs = c.validateHeaders(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validateRedirects(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validateCacheStatus(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validateTrailersAndInformational(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validateNDJSONLines(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validateBudgets(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validatePlugins(responseFilePath, responseIndex, actual, expected, errs)
+	errs = c.validateXMLAssertions(responseFilePath, responseIndex, actual, expected, errs)
 	errs = c.validateBody(responseFilePath, responseIndex, actual, expected, errs)
 	return errs
 }
 
 func (*Client) validateStatusCode(responseFilePath string, responseIndex int,
 	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if len(expected.StatusCodes) > 0 && !statusCodeAccepted(actual.StatusCode, expected.StatusCodes) {
+		return multierror.Append(errs, fmt.Errorf(
+			"validation for response #%d ('%s'): status code mismatch: expected %s, got %d",
+			responseIndex, responseFilePath, strings.Join(expected.StatusCodes, "|"), actual.StatusCode))
+	}
 	if expected.StatusCode != nil && (actual.StatusCode != *expected.StatusCode) {
 		errs = multierror.Append(errs, fmt.Errorf(
 			"validation for response #%d ('%s'): status code mismatch: expected %d, got %d",
@@ -171,7 +212,8 @@ func (*Client) validateStatusCode(responseFilePath string, responseIndex int,
 
 func (*Client) validateStatusString(responseFilePath string, responseIndex int,
 	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
-	if expected.Status != nil && *expected.Status != "" && (actual.Status != *expected.Status) {
+	if expected.Status != nil && *expected.Status != "" && len(expected.StatusCodes) == 0 &&
+		actual.Status != *expected.Status {
 		errs = multierror.Append(errs, fmt.Errorf(
 			"validation for response #%d ('%s'): status string mismatch: expected '%s', got '%s'",
 			responseIndex, responseFilePath, *expected.Status, actual.Status))
@@ -194,16 +236,23 @@ func (c *Client) validateHeaders(responseFilePath string, responseIndex int,
 			continue
 		}
 
-		errs = c.validateHeaderValues(responseFilePath, responseIndex, key, expectedValues, actualValues, errs)
+		errs = c.validateHeaderValues(responseFilePath, responseIndex, key, expectedValues, actualValues,
+			newDateReference(actual), errs)
 	}
 
 	return errs
 }
 
 func (*Client) validateHeaderValues(responseFilePath string, responseIndex int, key string,
-	expectedValues, actualValues []string, errs *multierror.Error) *multierror.Error {
+	expectedValues, actualValues []string, ref dateReference, errs *multierror.Error) *multierror.Error {
 	for _, ev := range expectedValues {
-		if !isHeaderValuePresent(ev, actualValues) {
+		present, err := isHeaderValuePresent(ev, headerValueElements(key, actualValues), ref)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("validation for response #%d ('%s'): header '%s': %w",
+				responseIndex, responseFilePath, key, err))
+			continue
+		}
+		if !present {
 			errs = multierror.Append(errs, fmt.Errorf(
 				"validation for response #%d ('%s'): expected value '%s' for "+
 					"header '%s' not found in actual values %v",
@@ -213,20 +262,39 @@ func (*Client) validateHeaderValues(responseFilePath string, responseIndex int,
 	return errs
 }
 
-// isHeaderValuePresent checks if an expected header value is present in the actual values.
-func isHeaderValuePresent(expectedValue string, actualValues []string) bool {
-	for _, av := range actualValues {
-		if av == expectedValue {
-			return true
-		}
-	}
-	return false
-}
-
-func (*Client) validateBody(responseFilePath string, responseIndex int,
+func (c *Client) validateBody(responseFilePath string, responseIndex int,
 	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
 	if expected.Body != nil {
-		bodyErr := compareBodies(responseFilePath, responseIndex, *expected.Body, actual.BodyString)
+		// Compare in UTF-8: bodies declared as e.g. ISO-8859-1 or UTF-16 are decoded first
+		actualBody, decodeErr := actual.Text()
+		if actual.Request != nil && actual.Request.SOAP != nil && decodeErr == nil {
+			actualBody, decodeErr = actual.SOAPBody()
+		}
+		if decodeErr != nil {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"validation for response #%d ('%s'): %w", responseIndex, responseFilePath, decodeErr))
+		}
+		if validator, ok := lookupBodyValidator(actual.ContentType()); ok {
+			if bodyErr := validator.ValidateBody(*expected.Body, actualBody); bodyErr != nil {
+				errs = multierror.Append(errs, fmt.Errorf("validation for response #%d ('%s'): body mismatch: %w",
+					responseIndex, responseFilePath, bodyErr))
+			}
+			return errs
+		}
+		if isNDJSONContentType(actual.Header("Content-Type")) {
+			if ndjsonErrs := compareNDJSONBodies(responseFilePath, responseIndex, *expected.Body, actualBody,
+				newDateReference(actual)); ndjsonErrs != nil {
+				errs = multierror.Append(errs, ndjsonErrs.Errors...)
+			}
+			return errs
+		}
+		if alignment := c.newJSONAlignment(expected, actual); alignment != nil {
+			if aligned, ok := alignment.alignBody(*expected.Body, actualBody); ok {
+				actualBody = aligned
+			}
+		}
+		bodyErr := compareBodies(responseFilePath, responseIndex, *expected.Body, actualBody,
+			newDateReference(actual))
 		if bodyErr != nil {
 			errs = multierror.Append(errs, bodyErr)
 		}
@@ -250,9 +318,11 @@ type placeholderInfo struct {
 }
 
 // buildRegexFromExpectedBody constructs a complete regular expression string
-// from an expected body string containing placeholders.
-func buildRegexFromExpectedBody(normalizedExpectedBody string) string {
+// from an expected body string containing placeholders. Validation-time datetime placeholders
+// become named capture groups and are returned as date checks to evaluate after a match.
+func buildRegexFromExpectedBody(normalizedExpectedBody string) (string, []dateCheck) {
 	var finalRegexPattern strings.Builder
+	var dateChecks []dateCheck
 	_, _ = finalRegexPattern.WriteString("^")
 
 	remainingExpectedBody := normalizedExpectedBody
@@ -267,12 +337,21 @@ func buildRegexFromExpectedBody(normalizedExpectedBody string) string {
 		}
 
 		appendLiteralPart(&finalRegexPattern, remainingExpectedBody, earliestMatchIndices)
-		appendPlaceholderPattern(&finalRegexPattern, remainingExpectedBody, earliestMatchIndices, bestPlaceholder)
+		if isDateCheckPlaceholder(bestPlaceholder) {
+			dateChecks = append(dateChecks, dateCheck{
+				placeholder: remainingExpectedBody[earliestMatchIndices[0]:earliestMatchIndices[1]],
+				kind:        bestPlaceholder.name,
+				arg:         extractPlaceholderArgument(remainingExpectedBody, earliestMatchIndices, bestPlaceholder),
+			})
+			_, _ = finalRegexPattern.WriteString(dateCheckGroupPattern(len(dateChecks) - 1))
+		} else {
+			appendPlaceholderPattern(&finalRegexPattern, remainingExpectedBody, earliestMatchIndices, bestPlaceholder)
+		}
 		remainingExpectedBody = remainingExpectedBody[earliestMatchIndices[1]:]
 	}
 
 	_, _ = finalRegexPattern.WriteString("$")
-	return finalRegexPattern.String()
+	return finalRegexPattern.String(), dateChecks
 }
 
 // getKnownPlaceholders returns all known placeholder definitions.
@@ -284,6 +363,14 @@ func getKnownPlaceholders() []placeholderInfo {
 		{name: "anyDatetimeWithArg", finder: anyDatetimePlaceholderFinder, hasArgument: true},
 		{name: "anyDatetimeNoArg", finder: anyDatetimeNoArgFinder, pattern: nonMatchingRegexPattern},
 		{name: "any", finder: anyPlaceholderFinder, pattern: anyRegexPattern},
+		{name: "anyOf", finder: anyOfPlaceholderFinder, hasArgument: true},
+		{name: "anyNumber", finder: anyNumberPlaceholderFinder, pattern: jsonNumberRegexPattern},
+		{name: "anyBool", finder: anyBoolPlaceholderFinder, pattern: jsonBoolRegexPattern},
+		{name: "anyString", finder: anyStringPlaceholderFinder, pattern: jsonStringRegexPattern},
+		{name: "anyArray", finder: anyArrayPlaceholderFinder, pattern: jsonArrayRegexPattern},
+		{name: "dateWithin", finder: dateWithinPlaceholderFinder, hasArgument: true},
+		{name: "dateAfter", finder: dateAfterPlaceholderFinder, hasArgument: true},
+		{name: "dateBefore", finder: dateBeforePlaceholderFinder, hasArgument: true},
 	}
 }
 
@@ -339,6 +426,8 @@ func getPlaceholderPattern(placeholder placeholderInfo, arg string) string {
 		return processRegexpPlaceholder(arg)
 	case "anyDatetimeWithArg":
 		return processDatetimePlaceholder(arg)
+	case "anyOf":
+		return processAnyOfPlaceholder(arg)
 	default:
 		return placeholder.pattern
 	}
@@ -418,13 +507,16 @@ func normalizeJSON(jsonStr string) (string, error) {
 		return "", fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
-	// Re-serialize with consistent formatting (no extra whitespace)
-	normalized, err := json.Marshal(jsonData)
-	if err != nil {
+	// Re-serialize with consistent formatting (no extra whitespace). HTML characters are kept as is,
+	// so {{$regexp}} patterns of string values see them as written.
+	var normalized bytes.Buffer
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(jsonData); err != nil {
 		return "", fmt.Errorf("failed to serialize JSON: %w", err)
 	}
 
-	return string(normalized), nil
+	return strings.TrimSuffix(normalized.String(), "\n"), nil
 }
 
 // replacePlaceholdersWithTempValues replaces JSON placeholders with temporary valid JSON values
@@ -435,10 +527,15 @@ func replacePlaceholdersWithTempValues(jsonStr string) (string, map[int]string)
 	placeholderMap := make(map[int]string)
 
 	// Replace all placeholder patterns with unique random number keys using pre-compiled regex patterns
+	// {{$regexp}} goes first, as its pattern may contain text looking like another placeholder
+	result = replacePatternPlaceholders(result, jsonRegexpPlaceholderPattern, placeholderMap)
+	result = replacePatternPlaceholders(result, jsonDateCheckPlaceholderPattern, placeholderMap)
 	result = replacePatternPlaceholders(result, jsonAnyGuidPlaceholderPattern, placeholderMap)
 	result = replacePatternPlaceholders(result, jsonAnyTimestampPlaceholderPattern, placeholderMap)
 	result = replacePatternPlaceholders(result, jsonAnyDatetimePlaceholderPattern, placeholderMap)
 	result = replacePatternPlaceholders(result, jsonAnyPlaceholderPattern, placeholderMap)
+	result = replacePatternPlaceholders(result, jsonAnyOfPlaceholderPattern, placeholderMap)
+	result = replacePatternPlaceholders(result, jsonAnyTypePlaceholderPattern, placeholderMap)
 
 	return result, placeholderMap
 }
@@ -484,13 +581,14 @@ func restorePlaceholdersInNormalizedJSON(normalizedJSON string, placeholderMap m
 
 // compareJSONWithPlaceholders compares JSON bodies that contain placeholders.
 // It attempts to normalize JSON structure while preserving placeholders, but falls back to original behavior if needed.
-func compareJSONWithPlaceholders(responseFilePath string, responseIndex int, expectedBody, actualBody string) error {
+func compareJSONWithPlaceholders(responseFilePath string, responseIndex int, expectedBody, actualBody string,
+	ref dateReference) error {
 	// First, try the JSON-aware placeholder handling
 	// Normalize the actual JSON first
 	normalizedActual, err := normalizeJSON(actualBody)
 	if err != nil {
 		// If actual body isn't valid JSON, fall back to original placeholder handling
-		return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody)
+		return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody, ref)
 	}
 
 	// For JSON with placeholders, we need to:
@@ -506,39 +604,51 @@ func compareJSONWithPlaceholders(responseFilePath string, responseIndex int, exp
 	if err != nil {
 		// If we can't normalize the temporary JSON (e.g., malformed JSON with placeholders),
 		// fall back to original behavior
-		return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody)
+		return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody, ref)
 	}
 
 	// Restore placeholders in the normalized JSON
 	normalizedExpectedWithPlaceholders := restorePlaceholdersInNormalizedJSON(normalizedTemp, placeholderMap)
 
 	// Build regex pattern from the normalized JSON with placeholders
-	regexPatternString := buildRegexFromExpectedBody(normalizedExpectedWithPlaceholders)
+	regexPatternString, dateChecks := buildRegexFromExpectedBody(normalizedExpectedWithPlaceholders)
 
 	compiledRegex, err := regexp.Compile(regexPatternString)
 	if err != nil {
 		// If regex compilation fails, fall back to original behavior
-		return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody)
+		return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody, ref)
 	}
 
 	// Match the normalized actual JSON against the regex pattern
 	if compiledRegex.MatchString(normalizedActual) {
-		return nil // Success!
+		return wrapDateCheckError(responseFilePath, responseIndex,
+			verifyDateChecks(compiledRegex, normalizedActual, dateChecks, ref))
 	}
 
 	// If JSON-aware placeholder matching failed, fall back to original behavior
-	return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody)
+	return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody, ref)
 }
 
 // compareJSONBodies compares two JSON bodies with whitespace-agnostic comparison.
 // It processes placeholders in the expected body, then normalizes both JSON strings and compares them.
-func compareJSONBodies(responseFilePath string, responseIndex int, expectedBody, actualBody string) error {
+func compareJSONBodies(responseFilePath string, responseIndex int, expectedBody, actualBody string,
+	ref dateReference) error {
 	// First, check if the expected body contains placeholders
 	normalizedExpectedBody := strings.TrimSpace(strings.ReplaceAll(expectedBody, "\\r\\n", "\\n"))
 
 	if strings.Contains(normalizedExpectedBody, "{{$") {
 		// Use placeholder-based comparison for JSON with placeholders
-		return compareJSONWithPlaceholders(responseFilePath, responseIndex, expectedBody, actualBody)
+		if err := compareJSONWithPlaceholders(responseFilePath, responseIndex, expectedBody, actualBody,
+			ref); err != nil {
+			return err
+		}
+		// The placeholders were matched in the whole document, where a pattern such as that of
+		// {{$anyArray}} can span several values; each value must also match on its own
+		if report := jsonMismatchReport(expectedBody, actualBody); report != "" {
+			return fmt.Errorf("validation for response #%d ('%s'): body mismatch:\n%s",
+				responseIndex, responseFilePath, report)
+		}
+		return nil
 	}
 
 	// No placeholders, use direct JSON normalization and comparison
@@ -564,8 +674,8 @@ func compareJSONBodies(responseFilePath string, responseIndex int, expectedBody,
 			Context:  3,
 		}
 		diffText, _ := difflib.GetUnifiedDiffString(diff)
-		return fmt.Errorf("validation for response #%d ('%s'): JSON content mismatch:\\n%s",
-			responseIndex, responseFilePath, diffText)
+		return fmt.Errorf("validation for response #%d ('%s'): JSON content mismatch:\n%s%s",
+			responseIndex, responseFilePath, jsonMismatchReport(expectedBody, actualBody), diffText)
 	}
 
 	return nil
@@ -574,7 +684,8 @@ func compareJSONBodies(responseFilePath string, responseIndex int, expectedBody,
 // compareBodiesOriginal compares the expected body string with the actual body string,
 // supporting placeholders like {{$regexp pattern}}, {{$anyGuid}}, {{$anyTimestamp}}, and {{$anyDatetime format}}.
 // This is the original placeholder logic without JSON-specific handling.
-func compareBodiesOriginal(responseFilePath string, responseIndex int, expectedBody, actualBody string) error {
+func compareBodiesOriginal(responseFilePath string, responseIndex int, expectedBody, actualBody string,
+	ref dateReference) error {
 	normalizedExpectedBody := strings.TrimSpace(strings.ReplaceAll(expectedBody, "\\r\\n", "\\n"))
 	normalizedActualBody := strings.TrimSpace(strings.ReplaceAll(actualBody, "\\r\\n", "\\n"))
 
@@ -590,14 +701,15 @@ func compareBodiesOriginal(responseFilePath string, responseIndex int, expectedB
 				Context:  3,
 			}
 			diffText, _ := difflib.GetUnifiedDiffString(diff)
-			return fmt.Errorf("validation for response #%d ('%s'): body mismatch:\\n%s",
-				responseIndex, responseFilePath, diffText)
+			return fmt.Errorf("validation for response #%d ('%s'): body mismatch:\n%s%s",
+				responseIndex, responseFilePath, bodyMismatchReport(normalizedExpectedBody, normalizedActualBody),
+				diffText)
 		}
 		return nil
 	}
 
 	// Placeholder-based comparison
-	regexPatternString := buildRegexFromExpectedBody(normalizedExpectedBody)
+	regexPatternString, dateChecks := buildRegexFromExpectedBody(normalizedExpectedBody)
 
 	compiledRegex, err := regexp.Compile(regexPatternString)
 	if err != nil {
@@ -617,28 +729,39 @@ func compareBodiesOriginal(responseFilePath string, responseIndex int, expectedB
 		diffText, _ := difflib.GetUnifiedDiffString(diff)
 		return fmt.Errorf(
 			"validation for response #%d ('%s'): body mismatch "+
-				"(regexp/placeholder evaluation failed):\\n%s\\nCompiled Regex: %s",
-			responseIndex, responseFilePath, diffText, regexPatternString)
+				"(regexp/placeholder evaluation failed):\n%s%s\nCompiled Regex: %s",
+			responseIndex, responseFilePath, bodyMismatchReport(normalizedExpectedBody, normalizedActualBody),
+			diffText, regexPatternString)
 	}
 
-	return nil
+	return wrapDateCheckError(responseFilePath, responseIndex,
+		verifyDateChecks(compiledRegex, normalizedActualBody, dateChecks, ref))
+}
+
+// wrapDateCheckError adds the response context to a failed datetime placeholder check
+func wrapDateCheckError(responseFilePath string, responseIndex int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("validation for response #%d ('%s'): body mismatch: %w", responseIndex, responseFilePath, err)
 }
 
 // compareBodies compares the expected body string with the actual body string,
 // supporting placeholders like {{$regexp pattern}}, {{$anyGuid}}, {{$anyTimestamp}}, and {{$anyDatetime format}}.
 // For JSON content, it performs whitespace-agnostic comparison by normalizing JSON formatting.
-func compareBodies(responseFilePath string, responseIndex int, expectedBody, actualBody string) error {
+func compareBodies(responseFilePath string, responseIndex int, expectedBody, actualBody string,
+	ref dateReference) error {
 	// Check if both bodies are JSON content - if so, use JSON-specific comparison
 	// For expected body, use placeholder-aware JSON detection
 	expectedIsJSON := isJSONContentWithPlaceholders(expectedBody)
 	actualIsJSON := isJSONContent(actualBody)
 
 	if expectedIsJSON && actualIsJSON {
-		return compareJSONBodies(responseFilePath, responseIndex, expectedBody, actualBody)
+		return compareJSONBodies(responseFilePath, responseIndex, expectedBody, actualBody, ref)
 	}
 
 	// For non-JSON content, use the original placeholder logic
-	return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody)
+	return compareBodiesOriginal(responseFilePath, responseIndex, expectedBody, actualBody, ref)
 }
 
 // countNonNilActuals counts non-nil responses in a slice.
@@ -0,0 +1,33 @@
+package restclient
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// {{$anyOf "PENDING" "QUEUED"}} captures the values; they contain no braces
+	anyOfPlaceholderFinder = regexp.MustCompile(`\{\{\$anyOf\s+([^{}]*?)\s*\}\}`)
+	// anyOfValueFinder matches a "double quoted" value, in which \" is a quote, or an unquoted one
+	anyOfValueFinder = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|(\S+)`)
+
+	// For JSON placeholder normalization
+	jsonAnyOfPlaceholderPattern = regexp.MustCompile(`\{\{\$anyOf\s+[^{}]*?\s*\}\}`)
+)
+
+// processAnyOfPlaceholder turns the values of an {{$anyOf}} placeholder into an alternation of
+// literals. Quoted values may contain spaces; without values the placeholder matches nothing.
+func processAnyOfPlaceholder(args string) string {
+	var alternatives []string
+	for _, match := range anyOfValueFinder.FindAllStringSubmatch(args, -1) {
+		value := match[2]
+		if strings.HasPrefix(match[0], `"`) {
+			value = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(match[1])
+		}
+		alternatives = append(alternatives, regexp.QuoteMeta(value))
+	}
+	if len(alternatives) == 0 {
+		return nonMatchingRegexPattern
+	}
+	return "(?:" + strings.Join(alternatives, "|") + ")"
+}
@@ -0,0 +1,23 @@
+package restclient
+
+import "regexp"
+
+var (
+	anyNumberPlaceholderFinder = regexp.MustCompile(`\{\{\$anyNumber\}\}`)
+	anyBoolPlaceholderFinder   = regexp.MustCompile(`\{\{\$anyBool\}\}`)
+	// {{$anyString}} may be written bare or within quotes; the quotes are part of the match
+	anyStringPlaceholderFinder = regexp.MustCompile(`"\{\{\$anyString\}\}"|\{\{\$anyString\}\}`)
+	anyArrayPlaceholderFinder  = regexp.MustCompile(`\{\{\$anyArray\}\}`)
+
+	// For JSON placeholder normalization
+	jsonAnyTypePlaceholderPattern = regexp.MustCompile(`"\{\{\$anyString\}\}"|\{\{\$any(?:Number|Bool|String|Array)\}\}`)
+)
+
+// Patterns of the JSON values matched by {{$anyNumber}}, {{$anyBool}}, {{$anyString}} and {{$anyArray}}.
+// Bodies are compared in their normalized form, so arrays contain no insignificant whitespace.
+const (
+	jsonNumberRegexPattern = `-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?`
+	jsonBoolRegexPattern   = `(?:true|false)`
+	jsonStringRegexPattern = `"(?:[^"\\]|\\.)*"`
+	jsonArrayRegexPattern  = `\[(?s:.*?)\]`
+)
@@ -0,0 +1,200 @@
+package restclient
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// Arguments may be a nested reference such as {{requestStart}}, so the argument alternation
+	// accepts one level of {{...}} before the closing braces.
+	dateWithinPlaceholderFinder = regexp.MustCompile(`\{\{\$dateWithin\s+([^{}]+?)\s*\}\}`)
+	dateAfterPlaceholderFinder  = regexp.MustCompile(`\{\{\$dateAfter\s+(\{\{[^{}]*\}\}|[^{}]+?)\s*\}\}`)
+	dateBeforePlaceholderFinder = regexp.MustCompile(`\{\{\$dateBefore\s+(\{\{[^{}]*\}\}|[^{}]+?)\s*\}\}`)
+
+	// For JSON placeholder normalization
+	jsonDateCheckPlaceholderPattern = regexp.MustCompile(
+		`\{\{\$date(?:Within|After|Before)\s+(?:\{\{[^{}]*\}\}|[^{}])+?\s*\}\}`)
+)
+
+// dateCheckGroupPrefix names the regex capture groups holding datetimes to check after matching
+const dateCheckGroupPrefix = "dateCheck"
+
+// Supported keywords for {{$dateAfter}} / {{$dateBefore}} references
+const (
+	dateRefRequestStart = "requestStart"
+	dateRefRequestEnd   = "requestEnd"
+	dateRefNow          = "now"
+)
+
+// actualDatetimeLayouts are tried in order when parsing a datetime found in the actual body
+var actualDatetimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC850,
+	time.ANSIC,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// dateReference is the time window of the request a response belongs to.
+// Validation-time datetime placeholders are evaluated against it.
+type dateReference struct {
+	start time.Time
+	end   time.Time
+}
+
+// newDateReference builds the reference window from a response. Responses without a recorded
+// start time (e.g. constructed programmatically) use the validation time.
+func newDateReference(resp *Response) dateReference {
+	if resp == nil || resp.StartTime.IsZero() {
+		now := time.Now()
+		return dateReference{start: now, end: now}
+	}
+	return dateReference{start: resp.StartTime, end: resp.StartTime.Add(resp.Duration)}
+}
+
+// dateCheck is a validation-time datetime placeholder found in an expected body
+type dateCheck struct {
+	placeholder string // e.g. "{{$dateWithin 5s}}", for error messages
+	kind        string // "dateWithin", "dateAfter" or "dateBefore"
+	arg         string
+}
+
+// isDateCheckPlaceholder reports whether a placeholder needs a post-match datetime check
+func isDateCheckPlaceholder(placeholder placeholderInfo) bool {
+	switch placeholder.name {
+	case "dateWithin", "dateAfter", "dateBefore":
+		return true
+	default:
+		return false
+	}
+}
+
+// dateCheckGroupPattern returns the named capture group matching a datetime for check number index
+func dateCheckGroupPattern(index int) string {
+	return fmt.Sprintf("(?P<%s%d>%s)", dateCheckGroupPrefix, index, genericDatetimeRegexPattern)
+}
+
+// verifyDateChecks evaluates the date checks against the values captured by a successful match
+func verifyDateChecks(compiledRegex *regexp.Regexp, actual string, checks []dateCheck, ref dateReference) error {
+	if len(checks) == 0 {
+		return nil
+	}
+	submatches := compiledRegex.FindStringSubmatch(actual)
+	if submatches == nil {
+		return nil
+	}
+
+	for i, check := range checks {
+		groupIndex := compiledRegex.SubexpIndex(dateCheckGroupPrefix + strconv.Itoa(i))
+		if groupIndex < 0 || groupIndex >= len(submatches) {
+			continue
+		}
+		if err := check.verify(strings.TrimSpace(submatches[groupIndex]), ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verify checks a single captured datetime value
+func (check dateCheck) verify(value string, ref dateReference) error {
+	actualTime, err := parseActualDatetime(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", check.placeholder, err)
+	}
+
+	switch check.kind {
+	case "dateWithin":
+		return check.verifyWithin(actualTime, value, ref)
+	case "dateAfter":
+		refTime, err := resolveDateReferenceArg(check.arg, ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", check.placeholder, err)
+		}
+		if actualTime.Before(truncateToPrecisionOf(refTime, actualTime)) {
+			return fmt.Errorf("%s: datetime %q is not after %s", check.placeholder, value, refTime.Format(time.RFC3339Nano))
+		}
+	case "dateBefore":
+		refTime, err := resolveDateReferenceArg(check.arg, ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", check.placeholder, err)
+		}
+		if actualTime.After(refTime) {
+			return fmt.Errorf("%s: datetime %q is not before %s", check.placeholder, value, refTime.Format(time.RFC3339Nano))
+		}
+	}
+	return nil
+}
+
+// verifyWithin checks that actualTime lies within the tolerance of the request window
+func (check dateCheck) verifyWithin(actualTime time.Time, value string, ref dateReference) error {
+	tolerance, err := time.ParseDuration(strings.Trim(check.arg, `"`))
+	if err != nil || tolerance < 0 {
+		return fmt.Errorf("%s: invalid tolerance %q, expected a duration like 5s or 1m", check.placeholder, check.arg)
+	}
+	earliest := ref.start.Add(-tolerance)
+	latest := ref.end.Add(tolerance)
+	if actualTime.Before(truncateToPrecisionOf(earliest, actualTime)) || actualTime.After(latest) {
+		return fmt.Errorf("%s: datetime %q is not within %s of the request time %s",
+			check.placeholder, value, tolerance, ref.start.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+// resolveDateReferenceArg resolves the argument of {{$dateAfter}} / {{$dateBefore}}:
+// a keyword (requestStart, requestEnd, now), optionally wrapped in {{...}}, or an absolute datetime.
+func resolveDateReferenceArg(arg string, ref dateReference) (time.Time, error) {
+	arg = strings.TrimSpace(arg)
+	if strings.HasPrefix(arg, "{{") && strings.HasSuffix(arg, "}}") {
+		arg = strings.TrimSpace(arg[2 : len(arg)-2])
+	}
+	arg = strings.Trim(arg, `"`)
+
+	switch arg {
+	case dateRefRequestStart:
+		return ref.start, nil
+	case dateRefRequestEnd:
+		return ref.end, nil
+	case dateRefNow:
+		return time.Now(), nil
+	}
+
+	refTime, err := parseActualDatetime(arg)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid reference time %q", arg)
+	}
+	return refTime, nil
+}
+
+// parseActualDatetime parses a datetime in one of the common layouts or as a Unix timestamp
+// (seconds, or milliseconds when it has 13 or more digits).
+func parseActualDatetime(value string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if len(value) >= 13 {
+			return time.UnixMilli(unix), nil
+		}
+		return time.Unix(unix, 0), nil
+	}
+	for _, layout := range actualDatetimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a datetime", value)
+}
+
+// truncateToPrecisionOf drops sub-second precision from ref when actual has none, so a
+// second-precision server timestamp is not rejected for being a fraction of a second early.
+func truncateToPrecisionOf(ref, actual time.Time) time.Time {
+	if actual.Nanosecond() == 0 {
+		return ref.Truncate(time.Second)
+	}
+	return ref
+}
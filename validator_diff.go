@@ -0,0 +1,213 @@
+package restclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Limits of the mismatch reports of body mismatch errors
+const (
+	maxReportedMismatches = 20 // mismatched values or lines listed
+	maxReportedValueLen   = 80 // characters of a value shown, longer ones are cut
+)
+
+// bodyMismatchReport lists the differences of a mismatching body: the JSON Pointers of the differing
+// values for JSON bodies, else the differing lines. It returns "" when no single value or line can be
+// blamed, e.g. when the bodies have a different number of lines; the unified diff then tells more.
+func bodyMismatchReport(expectedBody, actualBody string) string {
+	if isJSONContentWithPlaceholders(expectedBody) && isJSONContent(actualBody) {
+		if report := jsonMismatchReport(expectedBody, actualBody); report != "" {
+			return report
+		}
+	}
+	return lineMismatchReport(expectedBody, actualBody)
+}
+
+// jsonMismatchReport lists the JSON Pointers of the values of actualBody that differ from those of
+// expectedBody, which may contain placeholders, e.g. "/items/1/qty: expected 2, got 3"
+func jsonMismatchReport(expectedBody, actualBody string) string {
+	tempExpected, placeholders := replacePlaceholdersWithTempValues(expectedBody)
+	expected, err := decodeJSONValue(tempExpected)
+	if err != nil {
+		return ""
+	}
+	actual, err := decodeJSONValue(actualBody)
+	if err != nil {
+		return ""
+	}
+	var mismatches []string
+	collectJSONMismatches("", expected, actual, placeholders, &mismatches)
+	return formatMismatchReport("JSON values", mismatches)
+}
+
+// collectJSONMismatches appends the differences between the values at pointer, recursing into objects
+// and arrays of the same kind
+func collectJSONMismatches(pointer string, expected, actual any, placeholders map[int]string, out *[]string) {
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, key := range sortedJSONKeys(exp) {
+			child := pointer + "/" + escapeJSONPointer(key)
+			if value, found := act[key]; found {
+				collectJSONMismatches(child, exp[key], value, placeholders, out)
+			} else {
+				*out = append(*out, fmt.Sprintf("%s: expected %s, missing", child, formatJSONValue(exp[key], placeholders)))
+			}
+		}
+		for _, key := range sortedJSONKeys(act) {
+			if _, found := exp[key]; !found {
+				*out = append(*out, fmt.Sprintf("%s/%s: unexpected %s", pointer, escapeJSONPointer(key),
+					formatJSONValue(act[key], nil)))
+			}
+		}
+		return
+	case []any:
+		act, ok := actual.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(exp) || i < len(act); i++ {
+			child := pointer + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(act):
+				*out = append(*out, fmt.Sprintf("%s: expected %s, missing", child, formatJSONValue(exp[i], placeholders)))
+			case i >= len(exp):
+				*out = append(*out, fmt.Sprintf("%s: unexpected %s", child, formatJSONValue(act[i], nil)))
+			default:
+				collectJSONMismatches(child, exp[i], act[i], placeholders, out)
+			}
+		}
+		return
+	}
+	if !jsonValueMatches(expected, actual, placeholders) {
+		if pointer == "" {
+			pointer = "(root)"
+		}
+		*out = append(*out, fmt.Sprintf("%s: expected %s, got %s", pointer,
+			formatJSONValue(expected, placeholders), formatJSONValue(actual, nil)))
+	}
+}
+
+// jsonValueMatches compares an expected value, which may be or contain a placeholder, with an actual one
+func jsonValueMatches(expected, actual any, placeholders map[int]string) bool {
+	expectedText := restorePlaceholdersInNormalizedJSON(encodeJSONValue(expected), placeholders)
+	actualText := encodeJSONValue(actual)
+	if !strings.Contains(expectedText, "{{$") {
+		expNumber, expIsNumber := expected.(json.Number)
+		actNumber, actIsNumber := actual.(json.Number)
+		if expIsNumber && actIsNumber {
+			expFloat, expErr := expNumber.Float64()
+			actFloat, actErr := actNumber.Float64()
+			return expErr == nil && actErr == nil && expFloat == actFloat
+		}
+		return expectedText == actualText
+	}
+	return matchesPlaceholderText(expectedText, actualText)
+}
+
+// lineMismatchReport lists the lines of actualBody that differ from those of expectedBody, which may
+// contain placeholders, when both have the same number of lines
+func lineMismatchReport(expectedBody, actualBody string) string {
+	expectedLines := strings.Split(strings.TrimSpace(expectedBody), "\n")
+	actualLines := strings.Split(strings.TrimSpace(actualBody), "\n")
+	if len(expectedLines) != len(actualLines) {
+		return ""
+	}
+	var mismatches []string
+	for i, expected := range expectedLines {
+		expected = strings.TrimRight(expected, "\r")
+		actual := strings.TrimRight(actualLines[i], "\r")
+		matches := expected == actual
+		if !matches && strings.Contains(expected, "{{$") {
+			matches = matchesPlaceholderText(expected, actual)
+		}
+		if !matches {
+			mismatches = append(mismatches, fmt.Sprintf("line %d: expected %s, got %s", i+1,
+				strconv.Quote(truncateReportedValue(expected)), strconv.Quote(truncateReportedValue(actual))))
+		}
+	}
+	return formatMismatchReport("lines", mismatches)
+}
+
+// matchesPlaceholderText matches text against expected text containing placeholders
+func matchesPlaceholderText(expected, actual string) bool {
+	pattern, _ := buildRegexFromExpectedBody(expected)
+	compiled, err := regexp.Compile(pattern)
+	return err == nil && compiled.MatchString(actual)
+}
+
+// formatMismatchReport formats the listed mismatches, one per line, "" without any
+func formatMismatchReport(kind string, mismatches []string) string {
+	if len(mismatches) == 0 {
+		return ""
+	}
+	var report strings.Builder
+	fmt.Fprintf(&report, "mismatched %s (%d):\n", kind, len(mismatches))
+	for i, mismatch := range mismatches {
+		if i == maxReportedMismatches {
+			fmt.Fprintf(&report, "  ... and %d more\n", len(mismatches)-i)
+			break
+		}
+		report.WriteString("  " + mismatch + "\n")
+	}
+	return report.String()
+}
+
+// decodeJSONValue decodes a JSON document, keeping numbers as written
+func decodeJSONValue(body string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// encodeJSONValue encodes a decoded JSON value compactly, without escaping HTML characters
+func encodeJSONValue(value any) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// formatJSONValue formats a value for a mismatch report, with its placeholders restored
+func formatJSONValue(value any, placeholders map[int]string) string {
+	return truncateReportedValue(restorePlaceholdersInNormalizedJSON(encodeJSONValue(value), placeholders))
+}
+
+// truncateReportedValue cuts values longer than maxReportedValueLen characters
+func truncateReportedValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= maxReportedValueLen {
+		return value
+	}
+	return string(runes[:maxReportedValueLen]) + "..."
+}
+
+// escapeJSONPointer escapes an object key as a JSON Pointer (RFC 6901) reference token
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// sortedJSONKeys returns the keys of a JSON object in order, for stable reports
+func sortedJSONKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
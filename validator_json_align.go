@@ -0,0 +1,161 @@
+package restclient
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// WithUnorderedArrays makes ValidateResponses accept the elements of JSON arrays in any order, e.g. for
+// collections an API returns in nondeterministic order. The "# unordered" directive of .hresp files
+// selects single arrays instead.
+func WithUnorderedArrays() ClientOption {
+	return func(c *Client) error {
+		c.unorderedArrays = true
+		return nil
+	}
+}
+
+// jsonAlignment adjusts an actual JSON body to the expected one before the two are compared, for
+// the "subset" match mode and arrays compared regardless of the order of their elements
+type jsonAlignment struct {
+	subset       bool           // drop the fields of actual objects that the expected body leaves out
+	unordered    []string       // paths of arrays whose elements may come in any order, "$" for all
+	placeholders map[int]string // placeholders of the expected body by their temporary values
+	ref          dateReference
+}
+
+// newJSONAlignment returns the alignment of a response's body, or nil when it is compared as is
+func (c *Client) newJSONAlignment(expected *ExpectedResponse, actual *Response) *jsonAlignment {
+	alignment := &jsonAlignment{
+		subset:    c.bodyMatchMode(expected) == MatchSubset,
+		unordered: expected.Unordered,
+		ref:       newDateReference(actual),
+	}
+	if c.unorderedArrays {
+		alignment.unordered = []string{jsonRootPath}
+	}
+	if !alignment.subset && len(alignment.unordered) == 0 {
+		return nil
+	}
+	return alignment
+}
+
+// jsonRootPath is the path of the whole body; arrays below a path are unordered with it
+const jsonRootPath = "$"
+
+// alignBody returns the actual body aligned to the expected one, reporting false when either body
+// is not JSON. Placeholders of the expected body keep the whole actual value they stand for.
+func (a *jsonAlignment) alignBody(expectedBody, actualBody string) (string, bool) {
+	tempExpected, placeholders := replacePlaceholdersWithTempValues(expectedBody)
+	a.placeholders = placeholders
+	var expected, actual any
+	if json.Unmarshal([]byte(tempExpected), &expected) != nil || json.Unmarshal([]byte(actualBody), &actual) != nil {
+		return "", false
+	}
+	aligned, err := json.Marshal(a.align(jsonRootPath, expected, actual))
+	if err != nil {
+		return "", false
+	}
+	return string(aligned), true
+}
+
+// align aligns the actual value at path with the expected one, down through nested objects and arrays
+func (a *jsonAlignment) align(path string, expected, actual any) any {
+	switch expectedValue := expected.(type) {
+	case map[string]any:
+		actualObject, ok := actual.(map[string]any)
+		if !ok {
+			return actual
+		}
+		aligned := make(map[string]any, len(actualObject))
+		for key, actualField := range actualObject {
+			if expectedField, found := expectedValue[key]; found {
+				aligned[key] = a.align(path+"."+key, expectedField, actualField)
+			} else if !a.subset {
+				aligned[key] = actualField
+			}
+		}
+		return aligned
+	case []any:
+		actualArray, ok := actual.([]any)
+		if !ok {
+			return actual
+		}
+		if a.isUnordered(path) {
+			actualArray = a.reorder(path, expectedValue, actualArray)
+		}
+		aligned := make([]any, len(actualArray))
+		for i, element := range actualArray {
+			aligned[i] = element
+			if i < len(expectedValue) {
+				aligned[i] = a.align(path+"[*]", expectedValue[i], element)
+			}
+		}
+		return aligned
+	default:
+		return actual
+	}
+}
+
+// isUnordered reports whether the array at path, or an array containing it, is unordered
+func (a *jsonAlignment) isUnordered(path string) bool {
+	for _, unordered := range a.unordered {
+		if path == unordered || strings.HasPrefix(path, unordered+".") || strings.HasPrefix(path, unordered+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// reorder orders the actual elements like the expected elements they match, in the expected order;
+// actual elements matching none follow in their original order
+func (a *jsonAlignment) reorder(path string, expected, actual []any) []any {
+	used := make([]bool, len(actual))
+	reordered := make([]any, 0, len(actual))
+	for _, expectedElement := range expected {
+		for i, actualElement := range actual {
+			if !used[i] && a.matches(path+"[*]", expectedElement, actualElement) {
+				used[i] = true
+				reordered = append(reordered, actualElement)
+				break
+			}
+		}
+	}
+	for i, actualElement := range actual {
+		if !used[i] {
+			reordered = append(reordered, actualElement)
+		}
+	}
+	return reordered
+}
+
+// matches reports whether an actual array element matches an expected one, placeholders included
+func (a *jsonAlignment) matches(path string, expected, actual any) bool {
+	expectedJSON, err := json.Marshal(expected)
+	if err != nil {
+		return false
+	}
+	actualJSON, err := json.Marshal(a.align(path, expected, actual))
+	if err != nil {
+		return false
+	}
+	expectedBody := restorePlaceholdersInNormalizedJSON(string(expectedJSON), a.placeholders)
+	return compareBodies("", 0, expectedBody, string(actualJSON), a.ref) == nil
+}
+
+// parseUnorderedDirective parses "# unordered [path...]", e.g. "$.items $.items[*].tags"; without
+// paths, every array of the body is unordered
+func parseUnorderedDirective(value string, resp *ExpectedResponse) error {
+	paths := strings.Fields(value)
+	if len(paths) == 0 {
+		paths = []string{jsonRootPath}
+	}
+	for _, path := range paths {
+		if path != jsonRootPath && !strings.HasPrefix(path, jsonRootPath+".") {
+			return errors.New("expected JSON paths like $.items or $.items[*].tags, got '" + path + "'")
+		}
+	}
+	resp.Unordered = append(resp.Unordered, paths...)
+	return nil
+}
@@ -0,0 +1,48 @@
+package restclient
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MatchMode selects how ValidateResponses compares an expected JSON body with the actual one
+type MatchMode string
+
+// Match modes of WithMatchMode and the "# match-mode" directive of .hresp files
+const (
+	MatchExact  MatchMode = "exact"  // the bodies must be equal; the default
+	MatchSubset MatchMode = "subset" // fields of actual objects that the expected body leaves out are ignored
+)
+
+// WithMatchMode sets how ValidateResponses compares JSON bodies of expected responses without a
+// "# match-mode" directive. With MatchSubset, responses may gain fields without breaking .hresp files.
+func WithMatchMode(mode MatchMode) ClientOption {
+	return func(c *Client) error {
+		if mode != MatchExact && mode != MatchSubset {
+			return fmt.Errorf("unknown match mode %q, expected %q or %q", mode, MatchExact, MatchSubset)
+		}
+		c.matchMode = mode
+		return nil
+	}
+}
+
+// parseMatchModeDirective parses "# match-mode exact|subset"
+func parseMatchModeDirective(value string, resp *ExpectedResponse) error {
+	mode := MatchMode(strings.ToLower(value))
+	if mode != MatchExact && mode != MatchSubset {
+		return fmt.Errorf("expected %s or %s, got '%s'", MatchExact, MatchSubset, value)
+	}
+	resp.MatchMode = mode
+	return nil
+}
+
+// bodyMatchMode returns the match mode of an expected response: its directive, or the client's
+func (c *Client) bodyMatchMode(expected *ExpectedResponse) MatchMode {
+	if expected.MatchMode != "" {
+		return expected.MatchMode
+	}
+	if c.matchMode != "" {
+		return c.matchMode
+	}
+	return MatchExact
+}
@@ -33,6 +33,10 @@ func TestValidateResponses_StatusCode(t *testing.T) {
 	test.RunValidateResponses_StatusCode(t)
 }
 
+func TestValidateResponses_StatusAlternatives(t *testing.T) {
+	test.RunValidateResponses_StatusAlternatives(t)
+}
+
 // Header validation tests
 func TestValidateResponses_Headers(t *testing.T) {
 	test.RunValidateResponses_Headers(t)
@@ -42,6 +46,10 @@ func TestValidateResponses_HeadersContain(t *testing.T) {
 	test.RunValidateResponses_HeadersContain(t)
 }
 
+func TestValidateResponses_HeaderMatchers(t *testing.T) {
+	test.RunValidateResponses_HeaderMatchers(t)
+}
+
 // Body validation tests
 func TestValidateResponses_Body_ExactMatch(t *testing.T) {
 	test.RunValidateResponses_Body_ExactMatch(t)
@@ -76,6 +84,22 @@ func TestValidateResponses_BodyAnyPlaceholder(t *testing.T) {
 	test.RunValidateResponses_BodyAnyPlaceholder(t)
 }
 
+func TestValidateResponses_BodyDateTolerancePlaceholders(t *testing.T) {
+	test.RunValidateResponses_BodyDateTolerancePlaceholders(t)
+}
+
+func TestValidateResponses_BodyAnyOfPlaceholder(t *testing.T) {
+	test.RunValidateResponses_BodyAnyOfPlaceholder(t)
+}
+
+func TestValidateResponses_BodyAnyTypePlaceholders(t *testing.T) {
+	test.RunValidateResponses_BodyAnyTypePlaceholders(t)
+}
+
+func TestValidateResponses_BodyRegexpPlaceholderInJSON(t *testing.T) {
+	test.RunValidateResponses_BodyRegexpPlaceholderInJSON(t)
+}
+
 // JSON validation tests
 func TestValidateResponses_JSON_WhitespaceComparison(t *testing.T) {
 	test.RunValidateResponses_JSON_WhitespaceComparison(t)
@@ -88,3 +112,38 @@ func TestValidateResponses_JSON_WithPlaceholders(t *testing.T) {
 func TestValidateResponses_JSON_WithPlaceholdersInBody(t *testing.T) {
 	test.RunValidateResponses_JSON_WithPlaceholdersInBody(t)
 }
+
+// Eventual consistency tests
+func TestValidateResponses_WithEventualConsistency(t *testing.T) {
+	test.RunValidateResponses_WithEventualConsistency(t)
+}
+
+// XML assertion tests
+func TestValidateResponses_XMLAssertions(t *testing.T) {
+	test.RunValidateResponses_XMLAssertions(t)
+}
+
+// Variable substitution tests
+func TestValidateResponses_WithVariables(t *testing.T) {
+	test.RunValidateResponses_WithVariables(t)
+}
+
+func TestValidateResponses_SubsetMatchMode(t *testing.T) {
+	test.RunValidateResponses_SubsetMatchMode(t)
+}
+
+func TestValidateResponses_UnorderedArrays(t *testing.T) {
+	test.RunValidateResponses_UnorderedArrays(t)
+}
+
+func TestValidateResponses_WriteExpected(t *testing.T) {
+	test.RunValidateResponses_WriteExpected(t)
+}
+
+func TestValidateResponses_WriteExpectedRoundTrip(t *testing.T) {
+	test.RunValidateResponses_WriteExpectedRoundTrip(t)
+}
+
+func TestValidateResponses_MismatchReport(t *testing.T) {
+	test.RunValidateResponses_MismatchReport(t)
+}
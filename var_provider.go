@@ -0,0 +1,36 @@
+package restclient
+
+import (
+	"errors"
+	"os"
+)
+
+// VarProvider looks up the value of a variable on demand, e.g. in a database or a secrets vault. It
+// returns false when it does not know the variable.
+type VarProvider func(name string) (string, bool)
+
+// WithVarProvider registers a provider consulted for variables that no programmatic, file, environment
+// or global variable defines, before OS environment and .env variables. Providers are consulted in the
+// order they were registered, only for the variables a file actually references, and once per
+// reference: a provider backed by a slow service should cache its values itself.
+func WithVarProvider(provider VarProvider) ClientOption {
+	return func(c *Client) error {
+		if provider == nil {
+			return errors.New("variable provider must not be nil")
+		}
+		c.varProviders = append(c.varProviders, provider)
+		return nil
+	}
+}
+
+// lookupVariable resolves a variable from the registered providers, then from the OS environment
+func (c *Client) lookupVariable(name string) (string, bool) {
+	if c != nil {
+		for _, provider := range c.varProviders {
+			if value, ok := provider(name); ok {
+				return value, true
+			}
+		}
+	}
+	return os.LookupEnv(name)
+}
@@ -0,0 +1,90 @@
+package restclient
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// reVariableReference matches a {{name}} or {{name | fallback}} reference to a regular variable
+var reVariableReference = regexp.MustCompile(`{{\s*([^\s${}|][^{}|]*?)\s*(?:\|[^{}]*)?}}`)
+
+// detectVariableCycles reports file-scoped variables that reference each other in a cycle
+// (e.g. `@a = {{b}}` and `@b = {{a}}`), which would otherwise resolve to leftover placeholders.
+// Variables overridden by programmatic variables are skipped, since their definitions are never used.
+func detectVariableCycles(parsedFile *ParsedFile, programmaticVars map[string]any) error {
+	graph := make(map[string][]string, len(parsedFile.FileVariables))
+	for nameWithAt, value := range parsedFile.FileVariables {
+		name := strings.TrimPrefix(nameWithAt, "@")
+		if _, overridden := programmaticVars[name]; overridden {
+			continue
+		}
+		for _, match := range reVariableReference.FindAllStringSubmatch(value, -1) {
+			ref := match[1]
+			_, defined := parsedFile.FileVariables["@"+ref]
+			_, refOverridden := programmaticVars[ref]
+			if defined && !refOverridden {
+				graph[name] = append(graph[name], ref)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names) // deterministic error messages
+
+	finder := &cycleFinder{graph: graph, state: make(map[string]int)}
+	for _, name := range names {
+		if cycle := finder.visit(name, nil); cycle != nil {
+			return fmt.Errorf("circular variable reference: %s", describeVariableCycle(cycle, parsedFile))
+		}
+	}
+	return nil
+}
+
+// cycleFinder runs a depth-first search for cycles in the variable reference graph
+type cycleFinder struct {
+	graph map[string][]string
+	state map[string]int // 0 = unvisited, 1 = on the current path, 2 = done
+}
+
+// visit returns the names forming a cycle reachable from name (first name repeated at the end), or nil
+func (f *cycleFinder) visit(name string, path []string) []string {
+	switch f.state[name] {
+	case 1:
+		for i, onPath := range path {
+			if onPath == name {
+				return append(append([]string{}, path[i:]...), name)
+			}
+		}
+	case 2:
+		return nil
+	}
+
+	f.state[name] = 1
+	path = append(path, name)
+	for _, ref := range f.graph[name] {
+		if cycle := f.visit(ref, path); cycle != nil {
+			return cycle
+		}
+	}
+	f.state[name] = 2
+	return nil
+}
+
+// describeVariableCycle renders a cycle as "@a (line 1) -> @b (line 2) -> @a"
+func describeVariableCycle(cycle []string, parsedFile *ParsedFile) string {
+	parts := make([]string, 0, len(cycle))
+	for i, name := range cycle {
+		line, known := parsedFile.variableLines["@"+name]
+		if i == len(cycle)-1 || !known {
+			parts = append(parts, "@"+name)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("@%s (line %d)", name, line))
+	}
+	return strings.Join(parts, " -> ")
+}
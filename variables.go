@@ -6,7 +6,6 @@ import (
 	"log/slog"
 	"math/rand"
 	"net/url"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -27,9 +26,6 @@ var (
 	reRandomDotAlphanumeric = regexp.MustCompile(`{{\$random\.alphanumeric(?:\s+(\d+))?}}`)
 	reRandomString          = regexp.MustCompile(`{{\$randomString(?:\s+(\d+))?}}`)
 	reRandomPassword        = regexp.MustCompile(`{{\$randomPassword(?:\s+(\d+))?}}`)
-	reDotEnv                = regexp.MustCompile(`{{\s*\$dotenv\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*}}`)
-	reProcessEnv            = regexp.MustCompile(`{{\s*\$processEnv\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*}}`)
-	reProcessEnvIndirect    = regexp.MustCompile(`{{\s*\$processEnv\s+%([a-zA-Z_][a-zA-Z0-9_]*)\s*}}`)
 	reDateTime = regexp.MustCompile(
 		`{{\s*\$datetime(?:\s+("([^"]+)"|[^}\s]+))?(?:\s+("([^"]+)"|[^}\s]+))?\s*}}`)
 	reAadToken              = regexp.MustCompile(`{{\s*\$aadToken(?:\s+("([^"]+)"|[^}\s]+))*\s*}}`)
@@ -91,7 +87,8 @@ var randomWords = []string{"apple", "banana", "cherry", "date", "elderberry", "f
 // resolveVariablesInText is the primary substitution engine for non-system and request-scoped system variables.
 // It iterates through placeholders like `{{varName | fallback}}` and resolves them based on a defined precedence.
 // Dynamic system variables (like {{$dotenv NAME}}) are left untouched for substituteDynamicSystemVariables.
-// Precedence: 1. Client programmatic 2. File-defined 3. Environment 4. Global 5. OS Env 6. .env file 7. Fallback
+// Precedence: 1. Client programmatic 2. File-defined 3. Global (captured) 4. Environment
+// 5. Variable providers and OS Env (osEnvGetter) 6. .env file 7. Fallback
 func resolveVariablesInText(
 	text string,
 	clientProgrammaticVars map[string]any,
@@ -102,7 +99,9 @@ func resolveVariablesInText(
 	osEnvGetter func(string) (string, bool),
 	dotEnvVars map[string]string,
 ) string {
-	const maxIterations = 10 // Safety break for circular dependencies
+	// Safety break for deeply nested references; circular file variables are rejected at parse time
+	// by detectVariableCycles
+	const maxIterations = 10
 	currentText := text
 
 	for i := 0; i < maxIterations; i++ {
@@ -144,6 +143,11 @@ type variableResolverContext struct {
 // resolveVariablePlaceholder resolves a single variable placeholder.
 func resolveVariablePlaceholder(match string, ctx variableResolverContext) string {
 	directive := strings.TrimSpace(match[2 : len(match)-2])
+	if value, isExpression := resolveExpressionPlaceholder(match, directive, func(name string) string {
+		return resolveRegularVariable(name, ctx)
+	}); isExpression {
+		return value
+	}
 	varName, fallbackValue, hasFallback := parseVariableDirective(directive)
 
 	// Handle system variables first
@@ -212,17 +216,17 @@ func resolveHighPriorityVariables(varName string, ctx variableResolverContext) s
 
 // resolveLowPriorityVariables resolves from environment and system variables.
 func resolveLowPriorityVariables(varName string, ctx variableResolverContext) string {
-	// 3. Environment-specific variables
-	if resolved := resolveFromMap(varName, ctx.environmentVars); resolved != "" {
+	// 3. Global variables, captured during the run, so they replace stale values of the environment
+	if resolved := resolveFromMap(varName, ctx.globalVars); resolved != "" {
 		return resolved
 	}
 
-	// 4. Global variables
-	if resolved := resolveFromMap(varName, ctx.globalVars); resolved != "" {
+	// 4. Environment-specific variables
+	if resolved := resolveFromMap(varName, ctx.environmentVars); resolved != "" {
 		return resolved
 	}
 
-	// 5. OS environment variables
+	// 5. Variable providers (see WithVarProvider), then OS environment variables
 	if ctx.osEnvGetter != nil {
 		if val, ok := ctx.osEnvGetter(varName); ok {
 			return val
@@ -378,6 +382,9 @@ func matchesDynamicPattern(value string) bool {
 		reRandomUrl, reRandomDomainName, reRandomUserAgent, reRandomMacAddress,
 		reRandomUrlDot, reRandomDomainNameDot, reRandomUserAgentDot, reRandomMacAddressDot,
 	}
+	dynamicRegexes = append(dynamicRegexes, identifierRegexes...)
+	dynamicRegexes = append(dynamicRegexes, loremRegexes...)
+	dynamicRegexes = append(dynamicRegexes, geoRegexes...)
 
 	for _, re := range dynamicRegexes {
 		if re.MatchString(value) {
@@ -427,6 +434,7 @@ func substituteRequestVariables(
 	programmaticVars map[string]any,
 	currentDotEnvVars map[string]string,
 	clientBaseURL string,
+	encodeURLValues bool,
 ) (*url.URL, error) {
 	fileScopedVars, envVarsFromFile, globalVarsFromFile := initializeVariableMaps(parsedFile)
 	mergeRequestActiveVariables(rcRequest, fileScopedVars)
@@ -438,13 +446,41 @@ func substituteRequestVariables(
 	}
 	
 	finalParsedURL, err := processURLSubstitution(rcRequest, varMaps,
-		requestScopedSystemVars, osEnvGetter, programmaticVars, currentDotEnvVars, clientBaseURL)
+		requestScopedSystemVars, osEnvGetter, programmaticVars, currentDotEnvVars, clientBaseURL, encodeURLValues)
 	if err != nil {
 		return nil, err
 	}
 	
 	processHeaderSubstitution(rcRequest, varMaps,
 		requestScopedSystemVars, osEnvGetter, programmaticVars, currentDotEnvVars)
+	if rcRequest.Proxy != "" {
+		resolvedProxy := resolveVariablesInText(rcRequest.Proxy, programmaticVars, varMaps.fileScopedVars,
+			varMaps.envVarsFromFile, varMaps.globalVarsFromFile, requestScopedSystemVars,
+			osEnvGetter, currentDotEnvVars)
+		rcRequest.Proxy = substituteDynamicSystemVariables(resolvedProxy, currentDotEnvVars, programmaticVars)
+	}
+	if rcRequest.OutputFile != "" {
+		resolvedPath := resolveVariablesInText(rcRequest.OutputFile, programmaticVars, varMaps.fileScopedVars,
+			varMaps.envVarsFromFile, varMaps.globalVarsFromFile, requestScopedSystemVars,
+			osEnvGetter, currentDotEnvVars)
+		rcRequest.OutputFile = substituteDynamicSystemVariables(resolvedPath, currentDotEnvVars, programmaticVars)
+	}
+	if rcRequest.WaitFor != nil {
+		resolvedURL := resolveVariablesInText(rcRequest.WaitFor.URL, programmaticVars, varMaps.fileScopedVars,
+			varMaps.envVarsFromFile, varMaps.globalVarsFromFile, requestScopedSystemVars,
+			osEnvGetter, currentDotEnvVars)
+		waitFor := *rcRequest.WaitFor
+		waitFor.URL = substituteDynamicSystemVariables(resolvedURL, currentDotEnvVars, programmaticVars)
+		rcRequest.WaitFor = &waitFor
+	}
+	if rcRequest.SOAP != nil && rcRequest.SOAP.Action != "" {
+		resolvedAction := resolveVariablesInText(rcRequest.SOAP.Action, programmaticVars, varMaps.fileScopedVars,
+			varMaps.envVarsFromFile, varMaps.globalVarsFromFile, requestScopedSystemVars,
+			osEnvGetter, currentDotEnvVars)
+		soap := *rcRequest.SOAP
+		soap.Action = substituteDynamicSystemVariables(resolvedAction, currentDotEnvVars, programmaticVars)
+		rcRequest.SOAP = &soap
+	}
 	
 	return finalParsedURL, nil
 }
@@ -477,20 +513,32 @@ func mergeRequestActiveVariables(rcRequest *Request, fileScopedVars map[string]s
 	}
 }
 
-// processURLSubstitution handles URL variable substitution and parsing
+// processURLSubstitution handles URL variable substitution and parsing.
+// With encodeURLValues, each substituted value is percent-encoded for the URL component it lands in.
 func processURLSubstitution(rcRequest *Request, varMaps variableMaps,
 	requestScopedSystemVars map[string]string, osEnvGetter func(string) (string, bool),
-	programmaticVars map[string]any, currentDotEnvVars map[string]string, clientBaseURL string) (*url.URL, error) {
-	substitutedRawURL := resolveVariablesInText(
-		rcRequest.RawURLString, programmaticVars, varMaps.fileScopedVars, varMaps.envVarsFromFile, 
-		varMaps.globalVarsFromFile, requestScopedSystemVars, osEnvGetter, currentDotEnvVars)
-	substitutedRawURL = substituteDynamicSystemVariables(substitutedRawURL, currentDotEnvVars, programmaticVars)
+	programmaticVars map[string]any, currentDotEnvVars map[string]string, clientBaseURL string,
+	encodeURLValues bool) (*url.URL, error) {
+	resolve := func(text string) string {
+		resolved := resolveVariablesInText(
+			text, programmaticVars, varMaps.fileScopedVars, varMaps.envVarsFromFile,
+			varMaps.globalVarsFromFile, requestScopedSystemVars, osEnvGetter, currentDotEnvVars)
+		return substituteDynamicSystemVariables(resolved, currentDotEnvVars, programmaticVars)
+	}
+
+	var substitutedRawURL string
+	if encodeURLValues {
+		substitutedRawURL = substituteEncodedURL(rcRequest.RawURLString, resolve)
+	} else {
+		substitutedRawURL = resolve(rcRequest.RawURLString)
+	}
 
 	if strings.TrimSpace(substitutedRawURL) == "" {
 		return nil, fmt.Errorf("URL is empty after variable substitution (original: %s)", rcRequest.RawURLString)
 	}
 
 	substitutedRawURL = _applyBaseURLIfNeeded(substitutedRawURL, clientBaseURL)
+	substitutedRawURL = normalizeRequestURL(substitutedRawURL)
 
 	finalParsedURL, parseErr := url.Parse(substitutedRawURL)
 	if parseErr != nil {
@@ -498,6 +546,10 @@ func processURLSubstitution(rcRequest *Request, varMaps variableMaps,
 			"failed to parse URL after variable substitution: %s (original: %s): %w",
 			substitutedRawURL, rcRequest.RawURLString, parseErr)
 	}
+	if len(rcRequest.QueryParams) > 0 {
+		mergeQueryParams(finalParsedURL, rcRequest.QueryParams, resolve)
+		rcRequest.QueryParams = nil
+	}
 	
 	return finalParsedURL, nil
 }
@@ -709,151 +761,10 @@ func substituteDynamicSystemVariables(
 	text = substituteProcessEnvVariables(text)
 	text = substituteProcessEnvIndirect(text, programmaticVars)
 	text = _substituteDateTimeVariables(text)
+	text = substituteRegisteredSystemVariables(text)
 	return text
 }
 
-// substituteSystemEnvVariables handles {{$env.VAR_NAME}} placeholders
-func substituteSystemEnvVariables(text string) string {
-	reSystemEnvVar := regexp.MustCompile(`{{\$env\.([A-Za-z_][A-Za-z0-9_]*?)}}`)
-	return reSystemEnvVar.ReplaceAllStringFunc(text, func(match string) string {
-		parts := reSystemEnvVar.FindStringSubmatch(match)
-		if len(parts) == 2 {
-			return os.Getenv(parts[1])
-		}
-		slog.Warn("Failed to parse $env.VAR_NAME, returning original match", "match", match, "parts_len", len(parts))
-		return match
-	})
-}
-
-// substituteDotEnvVariables handles {{$dotenv VAR}} placeholders
-func substituteDotEnvVariables(text string, activeDotEnvVars map[string]string) string {
-	text = reDotEnv.ReplaceAllStringFunc(text, dotEnvReplacer(activeDotEnvVars))
-	text = substituteDotEnvEncoded(text, activeDotEnvVars)
-	return text
-}
-
-// dotEnvReplacer returns a replacement function for dotenv variables
-func dotEnvReplacer(activeDotEnvVars map[string]string) func(string) string {
-	return func(match string) string {
-		parts := reDotEnv.FindStringSubmatch(match)
-		if len(parts) == 2 {
-			varName := parts[1]
-			if val, ok := activeDotEnvVars[varName]; ok {
-				return val
-			}
-			return ""
-		}
-		slog.Warn("Failed to parse $dotenv, returning original match", "match", match, "parts_len", len(parts))
-		return match
-	}
-}
-
-// substituteDotEnvEncoded handles URL-encoded dotenv variables
-func substituteDotEnvEncoded(text string, activeDotEnvVars map[string]string) string {
-	reDotEnvEncoded := regexp.MustCompile(`%7B%7B\$dotenv\s+([a-zA-Z_][a-zA-Z0-9_]*)%7D%7D`)
-	return reDotEnvEncoded.ReplaceAllStringFunc(text, func(match string) string {
-		parts := reDotEnvEncoded.FindStringSubmatch(match)
-		if len(parts) == 2 {
-			varName := parts[1]
-			if val, ok := activeDotEnvVars[varName]; ok {
-				return val
-			}
-			return ""
-		}
-		slog.Warn("Failed to parse URL-encoded $dotenv, returning original match",
-			"match", match, "parts_len", len(parts))
-		return match
-	})
-}
-
-// substituteProcessEnvVariables handles {{$processEnv VAR}} placeholders
-func substituteProcessEnvVariables(text string) string {
-	text = reProcessEnv.ReplaceAllStringFunc(text, processEnvReplacer())
-	text = substituteProcessEnvEncoded(text)
-	return text
-}
-
-// substituteProcessEnvIndirect handles {{$processEnv %VAR}} placeholders
-func substituteProcessEnvIndirect(text string, programmaticVars map[string]any) string {
-	return reProcessEnvIndirect.ReplaceAllStringFunc(text, func(match string) string {
-		return processIndirectEnvMatch(match, programmaticVars)
-	})
-}
-
-// processIndirectEnvMatch processes a single indirect environment variable match
-func processIndirectEnvMatch(match string, programmaticVars map[string]any) string {
-	parts := reProcessEnvIndirect.FindStringSubmatch(match)
-	if len(parts) != 2 {
-		slog.Warn("Failed to parse $processEnv indirect, returning original match", 
-			"match", match, "parts_len", len(parts))
-		return match
-	}
-
-	varName := parts[1]
-	envVarName := resolveIndirectVarName(varName, programmaticVars)
-	if envVarName == "" {
-		return match // Variable not found, return original match
-	}
-
-	if envVal, ok := os.LookupEnv(envVarName); ok {
-		return envVal
-	}
-	return "" // Environment variable doesn't exist, return empty string
-}
-
-// resolveIndirectVarName resolves the variable name from programmaticVars
-func resolveIndirectVarName(varName string, programmaticVars map[string]any) string {
-	if programmaticVars == nil {
-		return ""
-	}
-	
-	val, ok := programmaticVars[varName]
-	if !ok {
-		return ""
-	}
-	
-	envVarName, ok := val.(string)
-	if !ok {
-		return ""
-	}
-	
-	return envVarName
-}
-
-// processEnvReplacer returns a replacement function for process env variables
-func processEnvReplacer() func(string) string {
-	return func(match string) string {
-		parts := reProcessEnv.FindStringSubmatch(match)
-		if len(parts) == 2 {
-			varName := parts[1]
-			if val, ok := os.LookupEnv(varName); ok {
-				return val
-			}
-			return match
-		}
-		slog.Warn("Failed to parse $processEnv, returning original match", "match", match, "parts_len", len(parts))
-		return match
-	}
-}
-
-// substituteProcessEnvEncoded handles URL-encoded process env variables
-func substituteProcessEnvEncoded(text string) string {
-	reProcessEnvEncoded := regexp.MustCompile(`%7B%7B\$processEnv\s+([A-Za-z_][A-Za-z0-9_]*)%7D%7D`)
-	return reProcessEnvEncoded.ReplaceAllStringFunc(text, func(match string) string {
-		parts := reProcessEnvEncoded.FindStringSubmatch(match)
-		if len(parts) == 2 {
-			varName := parts[1]
-			if val, ok := os.LookupEnv(varName); ok {
-				return val
-			}
-			return match
-		}
-		slog.Warn("Failed to parse URL-encoded $processEnv, returning original match",
-			"match", match, "parts_len", len(parts))
-		return match
-	})
-}
-
 // substituteRandomVariables handles the substitution of $random.* variables.
 func substituteRandomVariables(text string, programmaticVars map[string]any) string {
 	// Integer types
@@ -931,6 +842,9 @@ func substituteRandomVariables(text string, programmaticVars map[string]any) str
 		text = strings.ReplaceAll(text, "{{$randomWord}}", randomWords[rand.Intn(len(randomWords))])
 	}
 
+	// Compact identifiers
+	text = substituteIdentifierVariables(text)
+
 	// Person/Identity data (faker variables)
 	text = substituteFakerVariables(text)
 
@@ -0,0 +1,122 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Defaults of the @wait-for directive options
+const (
+	defaultWaitForEvery   = 500 * time.Millisecond
+	defaultWaitForTimeout = 30 * time.Second
+)
+
+// WaitFor configures a request with a "# @wait-for" directive, which holds the request back until a
+// readiness URL answers 2xx, e.g. a health check of a service started alongside the suite.
+type WaitFor struct {
+	URL     string        // readiness URL; may contain variables
+	Every   time.Duration // every=<duration>: delay between probes
+	Timeout time.Duration // timeout=<duration>: give up when the URL is not ready in time
+}
+
+// parseWaitForDirective parses the arguments of `@wait-for {{host}}/healthz timeout=30s every=1s`
+func parseWaitForDirective(args string) (*WaitFor, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return nil, errors.New("missing readiness URL")
+	}
+	waitFor := &WaitFor{URL: fields[0], Every: defaultWaitForEvery, Timeout: defaultWaitForTimeout}
+	for _, option := range fields[1:] {
+		name, value, _ := strings.Cut(option, "=")
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("%s must be a positive duration like 30s, got '%s'", name, value)
+		}
+		switch name {
+		case "every":
+			waitFor.Every = duration
+		case "timeout":
+			waitFor.Timeout = duration
+		default:
+			return nil, fmt.Errorf("unknown option '%s'", option)
+		}
+	}
+	return waitFor, nil
+}
+
+// String renders the directive arguments, e.g. `http://localhost/healthz every=500ms timeout=30s`
+func (w *WaitFor) String() string {
+	return fmt.Sprintf("%s every=%s timeout=%s", w.URL, w.Every, w.Timeout)
+}
+
+// handleWaitForDirective processes "@wait-for <url> [every=<d>] [timeout=<d>]" directives
+func (p *requestParserState) handleWaitForDirective(commentContent string) bool {
+	if commentContent != "@wait-for" && !strings.HasPrefix(commentContent, "@wait-for ") {
+		return false
+	}
+	p.ensureCurrentRequest()
+	waitFor, err := parseWaitForDirective(strings.TrimPrefix(commentContent, "@wait-for"))
+	if err != nil {
+		slog.Warn("Invalid @wait-for directive",
+			"error", err,
+			"lineNumber", p.lineNumber,
+			"filePath", p.filePath)
+		return true
+	}
+	p.currentRequest.WaitFor = waitFor
+	return true
+}
+
+// waitUntilReady sends GET requests to the readiness URL of the @wait-for directive, every
+// WaitFor.Every, until one is answered with 2xx. It fails when the timeout elapses first.
+func (c *Client) waitUntilReady(ctx context.Context, waitFor *WaitFor) error {
+	target, err := url.Parse(waitFor.URL)
+	if err == nil {
+		target, err = c.rewriteURL(target)
+	}
+	if err != nil {
+		return fmt.Errorf("@wait-for: invalid URL '%s': %w", waitFor.URL, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, waitFor.Timeout)
+	defer cancel()
+
+	probeClient := *c.httpClient
+	probeClient.Jar = nil
+	var lastOutcome string
+	for attempt := 1; ; attempt++ {
+		lastOutcome, err = probeReadiness(ctx, &probeClient, target.String())
+		if err == nil {
+			return nil
+		}
+		if err := sleepContext(ctx, waitFor.Every); err != nil {
+			return fmt.Errorf("@wait-for %s: not ready after %d attempts within %s, last: %s",
+				target, attempt, waitFor.Timeout, lastOutcome)
+		}
+	}
+}
+
+// probeReadiness sends one readiness probe, returning an error and a description of the outcome
+// unless the URL answered 2xx
+func probeReadiness(ctx context.Context, client *http.Client, target string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err.Error(), err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err.Error(), err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.Status, fmt.Errorf("readiness probe answered %s", resp.Status)
+	}
+	return resp.Status, nil
+}
@@ -0,0 +1,68 @@
+package restclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httputil"
+)
+
+// wireCaptureKey is the context key under which the per-request wireCapture is stored
+type wireCaptureKey struct{}
+
+// wireCapture holds the serialized request and response of the final round trip of a request.
+// With redirects, each hop overwrites the previous one so the dumps match the returned Response.
+type wireCapture struct {
+	request  []byte
+	response []byte
+}
+
+// wireCaptureTransport wraps an http.RoundTripper and records the request and response of each
+// round trip into the wireCapture found in the request context. Because it sits below
+// http.Client, the request dump includes headers added by the client itself, e.g. cookies
+// from the jar.
+type wireCaptureTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *wireCaptureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	capture, ok := req.Context().Value(wireCaptureKey{}).(*wireCapture)
+	if !ok {
+		return base.RoundTrip(req)
+	}
+
+	// DumpRequestOut restores req.Body after reading it, so the request can still be sent.
+	if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+		capture.request = dump
+		capture.response = nil
+	}
+	resp, err := base.RoundTrip(req)
+	if resp != nil {
+		if dump, dumpErr := httputil.DumpResponse(resp, true); dumpErr == nil {
+			capture.response = dump
+		}
+	}
+	return resp, err
+}
+
+// withWireCapture attaches a fresh wireCapture to the request when wire capture is enabled
+func (c *Client) withWireCapture(httpReq *http.Request) (*http.Request, *wireCapture) {
+	if !c.wireCapture {
+		return httpReq, nil
+	}
+	capture := &wireCapture{}
+	return httpReq.WithContext(context.WithValue(httpReq.Context(), wireCaptureKey{}, capture)), capture
+}
+
+// applyWireCapture copies the captured dumps onto the response
+func applyWireCapture(resp *Response, capture *wireCapture) {
+	if capture == nil {
+		return
+	}
+	resp.RawRequestDump = capture.request
+	resp.RawResponseDump = capture.response
+}
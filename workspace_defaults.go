@@ -0,0 +1,156 @@
+package restclient
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// workspaceDefaultsFileName is the file whose defaults every request file in its directory and below
+// inherits
+const workspaceDefaultsFileName = "rest-client.defaults.http"
+
+// workspaceDefaults are the settings of a rest-client.defaults.http file, e.g.
+//
+//	# @base-url https://staging.example.com
+//	# @timeout 5000
+//	# @retry 2 delay=500ms
+//	User-Agent: e2e-suite
+//	X-Team: {{team}}
+type workspaceDefaults struct {
+	baseURL string
+	timeout time.Duration
+	retry   *RetryPolicy
+	headers http.Header
+}
+
+// findWorkspaceDefaults loads the rest-client.defaults.http file nearest to a request file, searching
+// its directory and then the parent directories up to the repository root (a directory holding .git)
+// or the filesystem root. It returns nil when there is none.
+func findWorkspaceDefaults(requestFilePath string) (*workspaceDefaults, error) {
+	absPath, err := filepath.Abs(requestFilePath)
+	if err != nil {
+		return nil, err
+	}
+	for dir := filepath.Dir(absPath); ; {
+		candidate := filepath.Join(dir, workspaceDefaultsFileName)
+		content, err := os.ReadFile(candidate)
+		if err == nil {
+			return parseWorkspaceDefaults(candidate, content)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read workspace defaults: %w", err)
+		}
+		parent := filepath.Dir(dir)
+		if _, gitErr := os.Stat(filepath.Join(dir, ".git")); gitErr == nil || parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// parseWorkspaceDefaults parses the "# @directive" and "Name: value" header lines of a defaults file;
+// other comments and blank lines are ignored
+func parseWorkspaceDefaults(path string, content []byte) (*workspaceDefaults, error) {
+	defaults := &workspaceDefaults{headers: make(http.Header)}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		var err error
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+			comment := strings.TrimSpace(strings.TrimLeft(line, "#/"))
+			if strings.HasPrefix(comment, "@") {
+				err = defaults.setDirective(comment)
+			}
+		default:
+			name, value, isHeader := strings.Cut(line, ":")
+			if !isHeader || strings.TrimSpace(name) == "" {
+				err = fmt.Errorf("expected a '# @directive' or a 'Name: value' header, got '%s'", line)
+				break
+			}
+			defaults.headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace defaults %s line %d: %w", path, lineNumber, err)
+		}
+	}
+	return defaults, scanner.Err()
+}
+
+// setDirective applies a "@base-url", "@timeout", "@retry" or "@retry-delay" directive of a defaults file
+func (d *workspaceDefaults) setDirective(directive string) error {
+	name, args, _ := strings.Cut(directive, " ")
+	args = strings.TrimSpace(args)
+	switch name {
+	case "@base-url":
+		if args == "" {
+			return errors.New("missing URL of @base-url")
+		}
+		d.baseURL = args
+	case "@timeout":
+		timeout, err := parseTimeout(args)
+		if err != nil {
+			return fmt.Errorf("invalid @timeout: %w", err)
+		}
+		d.timeout = timeout
+	case "@retry":
+		policy, err := parseRetryDirective(args)
+		if err != nil {
+			return fmt.Errorf("invalid @retry: %w", err)
+		}
+		if d.retry != nil && !strings.Contains(args, "delay=") {
+			policy.Delay = d.retry.Delay
+		}
+		d.retry = policy
+	case "@retry-delay":
+		delay, err := parseRetryDelay(args)
+		if err != nil {
+			return fmt.Errorf("invalid @retry-delay: %w", err)
+		}
+		d.retry = withRetryDelay(d.retry, delay)
+	default:
+		return fmt.Errorf("unknown directive '%s'", name)
+	}
+	return nil
+}
+
+// withWorkspaceDefaults fills in the timeout, retry policy and headers the requests of a file do not
+// set themselves from the nearest defaults file, and returns the client to run them with: a copy using
+// the defaults' base URL when the client has none
+func (c *Client) withWorkspaceDefaults(requestFilePath string, requests []*Request) (*Client, error) {
+	defaults, err := findWorkspaceDefaults(requestFilePath)
+	if err != nil || defaults == nil {
+		return c, err
+	}
+	for _, req := range requests {
+		if req.Timeout <= 0 {
+			req.Timeout = defaults.timeout
+		}
+		if req.Retry == nil {
+			req.Retry = defaults.retry
+		}
+		for name, values := range defaults.headers {
+			if len(req.Headers.Values(name)) > 0 || len(c.DefaultHeaders.Values(name)) > 0 {
+				continue
+			}
+			if req.Headers == nil {
+				req.Headers = make(http.Header)
+			}
+			req.Headers[name] = append([]string(nil), values...)
+		}
+	}
+	if c.BaseURL != "" || defaults.baseURL == "" {
+		return c, nil
+	}
+	inherited := *c
+	inherited.BaseURL = defaults.baseURL
+	return &inherited, nil
+}
@@ -0,0 +1,167 @@
+package restclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// assertionPrefix starts an assertion line of an .hresp expectation, e.g. `?? xml //ns:id == "42"`
+const assertionPrefix = "??"
+
+// XML assertion operators; an assertion without operator checks that the XPath selects something
+const (
+	XMLOpExists   = "exists"
+	XMLOpEquals   = "=="
+	XMLOpNotEqual = "!="
+	XMLOpContains = "contains"
+)
+
+// XMLAssertion is a `?? xml <xpath> [<op> <value>]` line of an .hresp expectation, checked against
+// the string values of the nodes the XPath selects in the response body.
+type XMLAssertion struct {
+	XPath    string
+	Operator string // XMLOpExists, XMLOpEquals, XMLOpNotEqual or XMLOpContains
+	Value    string
+}
+
+// String renders the assertion as written in .hresp files
+func (a XMLAssertion) String() string {
+	if a.Operator == XMLOpExists {
+		return "xml " + a.XPath
+	}
+	return fmt.Sprintf("xml %s %s %q", a.XPath, a.Operator, a.Value)
+}
+
+// processAssertionLine handles a `??` assertion line in the status/header section.
+// It reports whether the line was consumed.
+func (s *responseParserState) processAssertionLine(trimmedLine string) (bool, error) {
+	if s.parsingBody || !strings.HasPrefix(trimmedLine, assertionPrefix) {
+		return false, nil
+	}
+	s.processedAnyLine = true
+	assertion, err := parseXMLAssertion(strings.TrimSpace(strings.TrimPrefix(trimmedLine, assertionPrefix)))
+	if err != nil {
+		return true, fmt.Errorf("line %d: invalid assertion '%s': %w", s.lineNumber, trimmedLine, err)
+	}
+	s.currentExpectedResponse.XMLAssertions = append(s.currentExpectedResponse.XMLAssertions, assertion)
+	return true, nil
+}
+
+// parseXMLAssertion parses the part of an assertion line after "??", e.g. `xml //ns:id == "42"`
+func parseXMLAssertion(text string) (XMLAssertion, error) {
+	kind, rest, _ := strings.Cut(text, " ")
+	if kind != "xml" {
+		return XMLAssertion{}, fmt.Errorf("unknown assertion type '%s', expected xml", kind)
+	}
+	rest = strings.TrimSpace(rest)
+	assertion := XMLAssertion{XPath: rest, Operator: XMLOpExists}
+	for _, operator := range []string{XMLOpEquals, XMLOpNotEqual, XMLOpContains} {
+		path, value, found := strings.Cut(rest, " "+operator+" ")
+		if !found {
+			continue
+		}
+		assertion.XPath, assertion.Operator = strings.TrimSpace(path), operator
+		assertion.Value = unquoteAssertionValue(strings.TrimSpace(value))
+		break
+	}
+	if _, err := parseXPath(assertion.XPath); err != nil {
+		return XMLAssertion{}, err
+	}
+	return assertion, nil
+}
+
+// unquoteAssertionValue strips matching single or double quotes around a value
+func unquoteAssertionValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// parseXMLNamespaceDirective parses "# xmlns <prefix>=<uri>", binding a prefix for the XPath
+// expressions of the response's XML assertions
+func parseXMLNamespaceDirective(value string, resp *ExpectedResponse) error {
+	prefix, uri, ok := strings.Cut(value, "=")
+	prefix, uri = strings.TrimSpace(prefix), strings.TrimSpace(uri)
+	if !ok || prefix == "" || uri == "" || strings.ContainsAny(prefix, " :") {
+		return fmt.Errorf("expected <prefix>=<namespace URI>, got '%s'", value)
+	}
+	if resp.XMLNamespaces == nil {
+		resp.XMLNamespaces = make(map[string]string)
+	}
+	resp.XMLNamespaces[prefix] = unquoteAssertionValue(uri)
+	return nil
+}
+
+// validateXMLAssertions evaluates the `?? xml` assertions of the expectation on the response body
+func (*Client) validateXMLAssertions(responseFilePath string, responseIndex int,
+	actual *Response, expected *ExpectedResponse, errs *multierror.Error) *multierror.Error {
+	if len(expected.XMLAssertions) == 0 {
+		return errs
+	}
+	fail := func(format string, args ...any) {
+		errs = multierror.Append(errs, fmt.Errorf("validation for response #%d ('%s'): "+format,
+			append([]any{responseIndex, responseFilePath}, args...)...))
+	}
+	body, err := actual.Text()
+	if err != nil {
+		fail("%w", err)
+		return errs
+	}
+	document, err := parseXMLDocument([]byte(body))
+	if err != nil {
+		fail("XML assertions: %w", err)
+		return errs
+	}
+	for _, assertion := range expected.XMLAssertions {
+		values, err := evaluateXPath(document, assertion.XPath, expected.XMLNamespaces)
+		if err != nil {
+			fail("assertion '%s': %w", assertion, err)
+			continue
+		}
+		if !xmlAssertionHolds(assertion, values) {
+			fail("assertion '%s' failed: got %s", assertion, describeXPathValues(values))
+		}
+	}
+	return errs
+}
+
+// xmlAssertionHolds compares the selected values, trimmed of surrounding whitespace, following
+// XPath node-set semantics: == and contains hold if any value matches, != if none equals the value
+func xmlAssertionHolds(assertion XMLAssertion, values []string) bool {
+	if assertion.Operator == XMLOpExists {
+		return len(values) > 0
+	}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		switch assertion.Operator {
+		case XMLOpEquals:
+			if value == assertion.Value {
+				return true
+			}
+		case XMLOpNotEqual:
+			if value == assertion.Value {
+				return false
+			}
+		case XMLOpContains:
+			if strings.Contains(value, assertion.Value) {
+				return true
+			}
+		}
+	}
+	return assertion.Operator == XMLOpNotEqual && len(values) > 0
+}
+
+// describeXPathValues renders the selected values for an error message
+func describeXPathValues(values []string) string {
+	if len(values) == 0 {
+		return "no matching nodes"
+	}
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", strings.TrimSpace(value))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,387 @@
+package restclient
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is an element of a parsed XML document; the document itself is a nameless root node
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlNode
+	texts    []string // character data directly inside the element
+	content  strings.Builder
+}
+
+// parseXMLDocument parses an XML body into a tree of elements with resolved namespaces
+func parseXMLDocument(body []byte) (*xmlNode, error) {
+	decoder := newXMLDecoder(string(body))
+	document := &xmlNode{}
+	stack := []*xmlNode{document}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("body is not well-formed XML: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name, attrs: t.Attr}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			stack[len(stack)-1].texts = append(stack[len(stack)-1].texts, string(t))
+			for _, node := range stack {
+				node.content.Write(t)
+			}
+		}
+	}
+	if len(document.children) == 0 {
+		return nil, errors.New("body is not XML: no root element")
+	}
+	return document, nil
+}
+
+// xpathName is a possibly prefixed name test of an XPath step; local "*" matches any name
+type xpathName struct {
+	prefix string
+	local  string
+}
+
+// xpathPredicate is a `[n]`, `[@attr]`, `[@attr='v']` or `[child='v']` filter of a step
+type xpathPredicate struct {
+	position  int // 1-based; 0 when the predicate tests a name
+	attribute bool
+	name      xpathName
+	value     string
+	hasValue  bool
+}
+
+// xpathStep is one location step of an XPath expression
+type xpathStep struct {
+	descendant bool // preceded by "//"
+	attribute  bool // @name, only as the last step
+	text       bool // text(), only as the last step
+	name       xpathName
+	predicates []xpathPredicate
+}
+
+// evaluateXPath evaluates a subset of XPath 1.0 on a document and returns the string values of the
+// selected nodes: absolute paths of child (/) and descendant (//) steps with prefixed or * name tests,
+// a final @attribute or text() step, and [n], [@attr], [@attr='v'] and [child='v'] predicates.
+// Prefixes are resolved with namespaces; unprefixed names match elements without a namespace.
+func evaluateXPath(document *xmlNode, path string, namespaces map[string]string) ([]string, error) {
+	steps, err := parseXPath(path)
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(name xpathName) (string, error) {
+		if name.prefix == "" {
+			return "", nil
+		}
+		uri, ok := namespaces[name.prefix]
+		if !ok {
+			return "", fmt.Errorf("XPath %s: unbound namespace prefix '%s'", path, name.prefix)
+		}
+		return uri, nil
+	}
+
+	nodes := []*xmlNode{document}
+	for i, step := range steps {
+		if step.descendant {
+			nodes = descendantsOrSelf(nodes)
+		}
+		if step.attribute || step.text {
+			if i != len(steps)-1 {
+				return nil, fmt.Errorf("XPath %s: attribute and text() steps must come last", path)
+			}
+			return selectValues(nodes, step, resolve)
+		}
+		space, err := resolve(step.name)
+		if err != nil {
+			return nil, err
+		}
+		var selected []*xmlNode
+		for _, node := range nodes {
+			var matching []*xmlNode
+			for _, child := range node.children {
+				if nameMatches(child.name, step.name, space) {
+					matching = append(matching, child)
+				}
+			}
+			for _, predicate := range step.predicates {
+				if matching, err = filterXPathPredicate(matching, predicate, resolve); err != nil {
+					return nil, err
+				}
+			}
+			selected = append(selected, matching...)
+		}
+		nodes = selected
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, node.content.String())
+	}
+	return values, nil
+}
+
+// selectValues returns the attribute values or text nodes selected by a final step
+func selectValues(nodes []*xmlNode, step xpathStep, resolve func(xpathName) (string, error)) ([]string, error) {
+	var values []string
+	if step.text {
+		for _, node := range nodes {
+			for _, text := range node.texts {
+				if strings.TrimSpace(text) != "" {
+					values = append(values, text)
+				}
+			}
+		}
+		return values, nil
+	}
+	space, err := resolve(step.name)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		if value, ok := attributeValue(node, step.name, space); ok {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// filterXPathPredicate keeps the nodes satisfying a predicate
+func filterXPathPredicate(nodes []*xmlNode, predicate xpathPredicate,
+	resolve func(xpathName) (string, error)) ([]*xmlNode, error) {
+	if predicate.position > 0 {
+		if predicate.position > len(nodes) {
+			return nil, nil
+		}
+		return nodes[predicate.position-1 : predicate.position], nil
+	}
+	space, err := resolve(predicate.name)
+	if err != nil {
+		return nil, err
+	}
+	var kept []*xmlNode
+	for _, node := range nodes {
+		if predicate.attribute {
+			value, ok := attributeValue(node, predicate.name, space)
+			if ok && (!predicate.hasValue || value == predicate.value) {
+				kept = append(kept, node)
+			}
+			continue
+		}
+		for _, child := range node.children {
+			if nameMatches(child.name, predicate.name, space) &&
+				(!predicate.hasValue || strings.TrimSpace(child.content.String()) == predicate.value) {
+				kept = append(kept, node)
+				break
+			}
+		}
+	}
+	return kept, nil
+}
+
+// nameMatches reports whether an element or attribute name passes a name test
+func nameMatches(name xml.Name, test xpathName, space string) bool {
+	return name.Space == space && (test.local == "*" || name.Local == test.local)
+}
+
+// attributeValue returns the value of a node's attribute, ignoring namespace declarations
+func attributeValue(node *xmlNode, name xpathName, space string) (string, bool) {
+	for _, attr := range node.attrs {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		if nameMatches(attr.Name, name, space) {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// descendantsOrSelf returns the nodes and all their descendants in document order, without duplicates
+func descendantsOrSelf(nodes []*xmlNode) []*xmlNode {
+	seen := make(map[*xmlNode]bool)
+	var result []*xmlNode
+	var walk func(node *xmlNode)
+	walk = func(node *xmlNode) {
+		if seen[node] {
+			return
+		}
+		seen[node] = true
+		result = append(result, node)
+		for _, child := range node.children {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return result
+}
+
+// parseXPath splits an absolute XPath expression into location steps
+func parseXPath(path string) ([]xpathStep, error) {
+	rest := strings.TrimSpace(path)
+	if !strings.HasPrefix(rest, "/") {
+		return nil, fmt.Errorf("invalid XPath %q: must start with / or //", path)
+	}
+	var steps []xpathStep
+	for rest != "" {
+		var step xpathStep
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			step.descendant, rest = true, rest[2:]
+		case strings.HasPrefix(rest, "/"):
+			rest = rest[1:]
+		default:
+			return nil, fmt.Errorf("invalid XPath %q: expected '/' before %q", path, rest)
+		}
+		end := xpathStepEnd(rest)
+		if err := parseXPathStep(rest[:end], &step); err != nil {
+			return nil, fmt.Errorf("invalid XPath %q: %w", path, err)
+		}
+		steps = append(steps, step)
+		rest = rest[end:]
+	}
+	return steps, nil
+}
+
+// xpathStepEnd returns the length of the step at the start of rest, which ends at a '/' outside
+// predicates and quotes
+func xpathStepEnd(rest string) int {
+	depth, quote := 0, byte(0)
+	for i := 0; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '/' && depth == 0:
+			return i
+		}
+	}
+	return len(rest)
+}
+
+// parseXPathStep parses a step like `ns:order`, `*`, `@id`, `text()` or `item[@type='book'][2]`
+func parseXPathStep(text string, step *xpathStep) error {
+	nameEnd := strings.IndexByte(text, '[')
+	if nameEnd < 0 {
+		nameEnd = len(text)
+	}
+	nameText := text[:nameEnd]
+	switch {
+	case nameText == "text()":
+		step.text = true
+	case strings.HasPrefix(nameText, "@"):
+		step.attribute = true
+		nameText = nameText[1:]
+	}
+	if !step.text {
+		name, err := parseXPathName(nameText)
+		if err != nil {
+			return err
+		}
+		step.name = name
+	}
+
+	rest := text[nameEnd:]
+	for rest != "" {
+		end := xpathPredicateEnd(rest)
+		if end < 0 {
+			return fmt.Errorf("unterminated predicate in %q", text)
+		}
+		predicate, err := parseXPathPredicate(strings.TrimSpace(rest[1:end]))
+		if err != nil {
+			return err
+		}
+		step.predicates = append(step.predicates, predicate)
+		rest = rest[end+1:]
+	}
+	if (step.attribute || step.text) && len(step.predicates) > 0 {
+		return fmt.Errorf("predicates are not supported on %q", nameText)
+	}
+	return nil
+}
+
+// xpathPredicateEnd returns the index of the ']' closing the predicate that rest starts with, or -1
+func xpathPredicateEnd(rest string) int {
+	if !strings.HasPrefix(rest, "[") {
+		return -1
+	}
+	quote := byte(0)
+	for i := 1; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseXPathPredicate parses the inside of a predicate
+func parseXPathPredicate(text string) (xpathPredicate, error) {
+	if position, err := strconv.Atoi(text); err == nil {
+		if position < 1 {
+			return xpathPredicate{}, fmt.Errorf("position %d out of range, positions start at 1", position)
+		}
+		return xpathPredicate{position: position}, nil
+	}
+	var predicate xpathPredicate
+	nameText, value, hasValue := strings.Cut(text, "=")
+	nameText = strings.TrimSpace(nameText)
+	if strings.HasPrefix(nameText, "@") {
+		predicate.attribute, nameText = true, nameText[1:]
+	}
+	name, err := parseXPathName(nameText)
+	if err != nil {
+		return xpathPredicate{}, err
+	}
+	predicate.name = name
+	if hasValue {
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return xpathPredicate{}, fmt.Errorf("predicate value %s must be quoted", value)
+		}
+		predicate.value, predicate.hasValue = value[1:len(value)-1], true
+	}
+	return predicate, nil
+}
+
+// parseXPathName parses a name test: `local`, `prefix:local` or `*`
+func parseXPathName(text string) (xpathName, error) {
+	prefix, local, prefixed := strings.Cut(text, ":")
+	if !prefixed {
+		prefix, local = "", text
+	}
+	if local == "" || (prefixed && prefix == "") || strings.ContainsAny(text, " ()@'\"") {
+		return xpathName{}, fmt.Errorf("invalid name test %q", text)
+	}
+	return xpathName{prefix: prefix, local: local}, nil
+}